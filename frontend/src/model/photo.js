@@ -60,6 +60,7 @@ export const MediaAnimated = "animated";
 export const MediaLive = "live";
 export const MediaVideo = "video";
 export const MediaVector = "vector";
+export const MediaDocument = "document";
 export const MediaSidecar = "sidecar";
 export const YearUnknown = -1;
 export const MonthUnknown = -1;
@@ -582,22 +583,26 @@ export class Photo extends RestModel {
       this.videoFile(),
       config.contentUri,
       config.previewToken,
-      size
+      size,
+      this.Type
     );
   }
 
-  generateThumbnailUrl = memoizeOne((mainFileHash, videoFile, contentUri, previewToken, size) => {
+  generateThumbnailUrl = memoizeOne((mainFileHash, videoFile, contentUri, previewToken, size, type) => {
     let hash = mainFileHash;
 
+    // Documents are filled from the top left corner instead of the center.
+    const query = type === MediaDocument ? "?crop=left" : "";
+
     if (!hash) {
       if (videoFile && videoFile.Hash) {
-        return `${contentUri}/t/${videoFile.Hash}/${previewToken}/${size}`;
+        return `${contentUri}/t/${videoFile.Hash}/${previewToken}/${size}${query}`;
       }
 
       return `${contentUri}/svg/photo`;
     }
 
-    return `${contentUri}/t/${hash}/${previewToken}/${size}`;
+    return `${contentUri}/t/${hash}/${previewToken}/${size}${query}`;
   });
 
   getDownloadUrl() {
@@ -75,6 +75,13 @@ describe("model/photo", () => {
     assert.equal(result3, "/api/v1/svg/photo");
   });
 
+  it("should get document thumbnail url", () => {
+    const values = { ID: 5, Title: "Invoice", Type: "document", Hash: 345982 };
+    const photo = new Photo(values);
+    const result = photo.thumbnailUrl("tile500");
+    assert.equal(result, "/api/v1/t/345982/public/tile500?crop=left");
+  });
+
   it("should get classes", () => {
     const values2 = {
       ID: 10,
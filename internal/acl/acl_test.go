@@ -10,6 +10,12 @@ func TestACL_Allow(t *testing.T) {
 	t.Run("ResourcePhotosRoleAdminActionModify", func(t *testing.T) {
 		assert.True(t, Resources.Allow(ResourcePhotos, RoleAdmin, ActionUpdate))
 	})
+	t.Run("ResourcePhotosRoleAdminActionExport", func(t *testing.T) {
+		assert.True(t, Resources.Allow(ResourcePhotos, RoleAdmin, ActionExport))
+	})
+	t.Run("ResourcePhotosRoleVisitorActionExport", func(t *testing.T) {
+		assert.False(t, Resources.Allow(ResourcePhotos, RoleVisitor, ActionExport))
+	})
 	t.Run("ResourceDefaultRoleAdminActionDefault", func(t *testing.T) {
 		assert.True(t, Resources.Allow(ResourceDefault, RoleAdmin, FullAccess))
 	})
@@ -16,6 +16,7 @@ const (
 	ActionCreate    Permission = "create"
 	ActionUpdate    Permission = "update"
 	ActionDownload  Permission = "download"
+	ActionExport    Permission = "export"
 	ActionShare     Permission = "share"
 	ActionDelete    Permission = "delete"
 	ActionRate      Permission = "rate"
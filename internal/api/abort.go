@@ -3,9 +3,11 @@ package api
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/pkg/clean"
 )
@@ -70,6 +72,44 @@ func AbortBadRequest(c *gin.Context) {
 	Abort(c, http.StatusBadRequest, i18n.ErrBadRequest)
 }
 
+// ValidationResponse represents an error response with the form fields that failed validation.
+type ValidationResponse struct {
+	i18n.Response
+	Fields form.ValidationErrors `json:"fields"`
+}
+
+// AbortInvalidValues aborts with status code 422 and the form fields that failed validation.
+func AbortInvalidValues(c *gin.Context, errs form.ValidationErrors) {
+	resp := ValidationResponse{
+		Response: i18n.NewResponse(http.StatusUnprocessableEntity, i18n.ErrInvalidValues),
+		Fields:   errs,
+	}
+
+	log.Debugf("api-v1: abort %s with code %d (%s)", clean.Log(c.FullPath()), resp.Code, clean.Log(errs.Error()))
+
+	c.AbortWithStatusJSON(resp.Code, resp)
+}
+
+// RemovedResponse represents an error response for entities that have been removed.
+type RemovedResponse struct {
+	i18n.Response
+	UID       string    `json:"uid"`
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+// AbortRemoved aborts with status code 410 so that clients can distinguish removed from unknown entities.
+func AbortRemoved(c *gin.Context, uid string, deletedAt time.Time) {
+	resp := RemovedResponse{
+		Response:  i18n.NewResponse(http.StatusGone, i18n.ErrEntityRemoved),
+		UID:       uid,
+		DeletedAt: deletedAt,
+	}
+
+	log.Debugf("api-v1: abort %s with code %d (%s)", clean.Log(c.FullPath()), resp.Code, clean.Log(uid))
+
+	c.AbortWithStatusJSON(resp.Code, resp)
+}
+
 func AbortFeatureDisabled(c *gin.Context) {
 	Abort(c, http.StatusForbidden, i18n.ErrFeatureDisabled)
 }
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Supported orphan file cleanup actions.
+const (
+	OrphansRemove = "remove"
+	OrphansAttach = "attach"
+)
+
+// GetOrphanFiles returns the files that are not linked to an existing photo as JSON.
+// The total number of orphaned files is returned in the X-Total header.
+//
+// GET /api/v1/admin/orphans
+// Params:
+// - count (int) maximum number of results, default 100
+// - offset (int) result offset
+func GetOrphanFiles(router *gin.RouterGroup) {
+	router.GET("/admin/orphans", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceFiles, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+
+		if limit <= 0 || limit > 1000 {
+			limit = 100
+		}
+
+		files, total, err := query.OrphanFilesRange(limit, offset)
+
+		if err != nil {
+			log.Errorf("orphans: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		AddCountHeader(c, len(files))
+		AddLimitHeader(c, limit)
+		AddOffsetHeader(c, offset)
+		AddTotalHeader(c, total)
+
+		c.JSON(http.StatusOK, files)
+	})
+}
+
+// CleanupOrphanFiles removes orphaned files from the index or re-attaches them to a photo.
+// The action is performed on the selected files, or on all orphaned files if "all" is true.
+// Files in storage are not deleted, so removed index entries are recreated when the files
+// are indexed again.
+//
+// POST /api/v1/admin/orphans
+// Request Body:
+// - action (string) "remove" or "attach"
+// - files ([]string) file UIDs, required unless all is true
+// - all (bool) perform the action on all orphaned files
+// - photo (string) optional UID of the photo to attach the files to, default is the photo UID stored in each file
+func CleanupOrphanFiles(router *gin.RouterGroup) {
+	router.POST("/admin/orphans", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceFiles, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.OrphanFiles
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		} else if f.Action != OrphansRemove && f.Action != OrphansAttach {
+			AbortBadRequest(c)
+			return
+		} else if len(f.Files) == 0 && !f.All {
+			// Files must be selected explicitly, or all files must be selected.
+			AbortBadRequest(c)
+			return
+		}
+
+		// Find the photo to attach the files to, if specified.
+		var target *entity.Photo
+
+		if f.Action == OrphansAttach && f.Photo != "" {
+			if target = entity.FindPhoto(entity.Photo{PhotoUID: clean.UID(f.Photo)}); target == nil {
+				AbortEntityNotFound(c)
+				return
+			}
+		}
+
+		// Find orphaned files.
+		selected := make(map[string]bool, len(f.Files))
+
+		for _, uid := range f.Files {
+			selected[clean.UID(uid)] = true
+		}
+
+		files, err := query.OrphanFiles()
+
+		if err != nil {
+			log.Errorf("orphans: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		var orphans entity.Files
+
+		for _, file := range files {
+			if f.All || selected[file.FileUID] {
+				orphans = append(orphans, file)
+			}
+		}
+
+		var removed, attached, skipped int
+
+		for i := range orphans {
+			file := &orphans[i]
+
+			switch f.Action {
+			case OrphansRemove:
+				if err := file.DeletePermanently(); err != nil {
+					log.Errorf("orphans: %s while removing %s", err, clean.Log(file.FileName))
+					skipped++
+				} else {
+					removed++
+				}
+			case OrphansAttach:
+				photo := target
+
+				if photo == nil {
+					photo = entity.FindPhoto(entity.Photo{PhotoUID: file.PhotoUID})
+				}
+
+				if photo == nil {
+					log.Warnf("orphans: found no photo to attach %s to", clean.Log(file.FileName))
+					skipped++
+				} else if err := file.Attach(photo); err != nil {
+					log.Errorf("orphans: %s while attaching %s", err, clean.Log(file.FileName))
+					skipped++
+				} else {
+					attached++
+
+					// Set a new primary file if the photo doesn't have one.
+					if _, err := photo.PrimaryFile(); err != nil {
+						if err := query.SetPhotoPrimary(photo.PhotoUID, ""); err != nil {
+							log.Warnf("orphans: %s while setting primary file of %s", err, photo.String())
+						}
+					}
+
+					PublishPhotoEvent(EntityUpdated, photo.PhotoUID, c)
+				}
+			}
+		}
+
+		if removed > 0 || attached > 0 {
+			log.Infof("orphans: removed %s, attached %s", english.Plural(removed, "file", "files"), english.Plural(attached, "file", "files"))
+
+			if err := entity.UpdateCounts(); err != nil {
+				log.Warnf("orphans: %s (update counts)", err)
+			}
+
+			UpdateClientConfig()
+		}
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgChangesSaved), "removed": removed, "attached": attached, "skipped": skipped})
+	})
+}
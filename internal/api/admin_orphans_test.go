@@ -0,0 +1,175 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func TestGetOrphanFiles(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetOrphanFiles(router)
+
+		orphan := &entity.File{
+			PhotoID:  9999990,
+			PhotoUID: rnd.GenerateUID(entity.PhotoUID),
+			FileRoot: entity.RootOriginals,
+			FileName: "orphans/" + rnd.GenerateUID('h') + ".jpg",
+			FileHash: rnd.GenerateUID('h'),
+			FileType: "jpg",
+		}
+
+		if err := orphan.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _ = orphan.DeletePermanently() }()
+
+		r := PerformRequest(app, "GET", "/api/v1/admin/orphans?count=1000")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		found := false
+
+		for _, uid := range gjson.Get(r.Body.String(), "#.UID").Array() {
+			if uid.String() == orphan.FileUID {
+				found = true
+			}
+		}
+
+		assert.True(t, found)
+
+		total := r.Header().Get("X-Total")
+		assert.NotEmpty(t, total)
+		assert.Equal(t, total, r.Header().Get("X-Count"))
+
+		// The total number of orphaned files does not depend on the limit.
+		r = PerformRequest(app, "GET", "/api/v1/admin/orphans?count=1&offset=0")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "1", r.Header().Get("X-Count"))
+		assert.Equal(t, total, r.Header().Get("X-Total"))
+	})
+}
+
+func TestCleanupOrphanFiles(t *testing.T) {
+	t.Run("Attach", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CleanupOrphanFiles(router)
+
+		photo := &entity.Photo{PhotoTitle: "Orphans"}
+
+		if err := photo.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _, _ = photo.DeletePermanently() }()
+
+		orphan := &entity.File{
+			PhotoID:  9999991,
+			PhotoUID: photo.PhotoUID,
+			FileRoot: entity.RootOriginals,
+			FileName: "orphans/" + photo.PhotoUID + ".jpg",
+			FileHash: rnd.GenerateUID('h'),
+			FileType: "jpg",
+		}
+
+		if err := orphan.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _ = orphan.DeletePermanently() }()
+
+		body := fmt.Sprintf(`{"action": "attach", "files": ["%s"]}`, orphan.FileUID)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/admin/orphans", body)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "attached").Int())
+
+		if file, err := photo.PrimaryFile(); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, orphan.FileUID, file.FileUID)
+			assert.Equal(t, photo.ID, file.PhotoID)
+		}
+	})
+	t.Run("Remove", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CleanupOrphanFiles(router)
+
+		orphan := &entity.File{
+			PhotoID:  9999992,
+			PhotoUID: rnd.GenerateUID(entity.PhotoUID),
+			FileRoot: entity.RootOriginals,
+			FileName: "orphans/" + rnd.GenerateUID('h') + ".jpg",
+			FileHash: rnd.GenerateUID('h'),
+			FileType: "jpg",
+		}
+
+		if err := orphan.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		body := fmt.Sprintf(`{"action": "remove", "files": ["%s"]}`, orphan.FileUID)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/admin/orphans", body)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "removed").Int())
+
+		var count int
+
+		if err := entity.UnscopedDb().Model(entity.File{}).Where("file_uid = ?", orphan.FileUID).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, count)
+	})
+	t.Run("PhotoNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CleanupOrphanFiles(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/admin/orphans", `{"action": "attach", "photo": "ps6sg6be2lvl0yxx", "all": true}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("NoFiles", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CleanupOrphanFiles(router)
+
+		orphan := &entity.File{
+			PhotoID:  9999993,
+			PhotoUID: rnd.GenerateUID(entity.PhotoUID),
+			FileRoot: entity.RootOriginals,
+			FileName: "orphans/" + rnd.GenerateUID('h') + ".jpg",
+			FileHash: rnd.GenerateUID('h'),
+			FileType: "jpg",
+		}
+
+		if err := orphan.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _ = orphan.DeletePermanently() }()
+
+		// Orphaned files are not removed unless they are selected or "all" is true.
+		for _, body := range []string{`{"action": "remove"}`, `{"action": "remove", "files": []}`, `{"action": "attach", "all": false}`} {
+			r := PerformRequestWithBody(app, "POST", "/api/v1/admin/orphans", body)
+			assert.Equal(t, http.StatusBadRequest, r.Code, body)
+		}
+
+		var count int
+
+		if err := entity.UnscopedDb().Model(entity.File{}).Where("file_uid = ?", orphan.FileUID).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, count)
+	})
+	t.Run("InvalidAction", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CleanupOrphanFiles(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/admin/orphans", `{"action": "purge"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
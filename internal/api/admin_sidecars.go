@@ -0,0 +1,77 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+// RebuildSidecars rewrites the YAML sidecar files of all photos taken within a date range,
+// e.g. after a schema change. The files are written in the background and progress is reported
+// with "sidecars.updating" and "sidecars.completed" events.
+//
+// POST /api/v1/admin/sidecars/rebuild
+// Request Body:
+// - from (string) optional start date, e.g. "2020-01-01"
+// - to (string) optional end date, e.g. "2020-12-31"
+func RebuildSidecars(router *gin.RouterGroup) {
+	router.POST("/admin/sidecars/rebuild", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if !conf.BackupYaml() || conf.ReadOnly() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		var f form.RebuildSidecars
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		from, to, err := f.Range()
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		// Rewrite the files in the background, the progress is reported with events.
+		if err = get.Sidecars().Start(photoprism.SidecarsOptions{From: from, To: to}); err != nil {
+			log.Debugf("sidecars: %s (start)", err)
+			AbortBusy(c)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{"code": http.StatusAccepted})
+	})
+}
+
+// CancelRebuildSidecars stops rewriting YAML sidecar files.
+//
+// DELETE /api/v1/admin/sidecars/rebuild
+func CancelRebuildSidecars(router *gin.RouterGroup) {
+	router.DELETE("/admin/sidecars/rebuild", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		get.Sidecars().Cancel()
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK})
+	})
+}
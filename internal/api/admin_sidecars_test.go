@@ -0,0 +1,97 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestRebuildSidecars(t *testing.T) {
+	t.Run("Range", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		RebuildSidecars(router)
+
+		var fileNames []string
+
+		for _, day := range []int{3, 4} {
+			takenAt := time.Date(1975, 5, day, 12, 0, 0, 0, time.UTC)
+
+			photo := &entity.Photo{
+				PhotoTitle:   "Sidecars",
+				PhotoPath:    "sidecars",
+				TakenAt:      takenAt,
+				TakenAtLocal: takenAt,
+			}
+
+			if err := photo.Create(); err != nil {
+				t.Fatal(err)
+			}
+
+			photo.PhotoName = photo.PhotoUID
+
+			if err := photo.Update("PhotoName", photo.PhotoName); err != nil {
+				t.Fatal(err)
+			}
+
+			fileName := photo.YamlFileName(conf.OriginalsPath(), conf.SidecarPath())
+			fileNames = append(fileNames, fileName)
+
+			defer func() {
+				_, _ = photo.DeletePermanently()
+				_ = os.Remove(fileName)
+			}()
+		}
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/admin/sidecars/rebuild", `{"from": "1975-05-03", "to": "1975-05-03"}`)
+		assert.Equal(t, http.StatusAccepted, r.Code)
+		assert.Equal(t, int64(http.StatusAccepted), gjson.Get(r.Body.String(), "code").Int())
+
+		// Wait for the background worker to finish.
+		for i := 0; i < 100 && mutex.SidecarWorker.Running(); i++ {
+			time.Sleep(50 * time.Millisecond)
+		}
+
+		assert.True(t, fs.FileExists(fileNames[0]))
+		assert.False(t, fs.FileExists(fileNames[1]))
+	})
+	t.Run("Busy", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RebuildSidecars(router)
+
+		if err := mutex.SidecarWorker.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer mutex.SidecarWorker.Stop()
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/admin/sidecars/rebuild", `{"from": "1975-05-03", "to": "1975-05-03"}`)
+		assert.Equal(t, http.StatusTooManyRequests, r.Code)
+	})
+	t.Run("InvalidRange", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RebuildSidecars(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/admin/sidecars/rebuild", `{"from": "2020-01-02", "to": "2020-01-01"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RebuildSidecars(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/admin/sidecars/rebuild", `{"from": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestCancelRebuildSidecars(t *testing.T) {
+	app, router, _ := NewApiTest()
+	CancelRebuildSidecars(router)
+	r := PerformRequest(app, "DELETE", "/api/v1/admin/sidecars/rebuild")
+	assert.Equal(t, http.StatusOK, r.Code)
+}
@@ -17,12 +17,22 @@ const (
 	EntityDeleted EntityEvent = "deleted"
 )
 
-// PublishPhotoEvent publishes updated photo data after changes have been made.
-func PublishPhotoEvent(ev EntityEvent, uid string, c *gin.Context) {
+// Names of photo fields, as used in event payloads.
+const (
+	PhotoFavorite     = "Favorite"
+	PhotoPrivate      = "Private"
+	PhotoTakenAt      = "TakenAt"
+	PhotoTakenAtLocal = "TakenAtLocal"
+	PhotoTimeZone     = "TimeZone"
+)
+
+// PublishPhotoEvent publishes updated photo data after changes have been made. The names of the
+// changed fields, e.g. PhotoFavorite, may optionally be passed so that clients can update incrementally.
+func PublishPhotoEvent(ev EntityEvent, uid string, c *gin.Context, fields ...string) {
 	if result, _, err := search.Photos(form.SearchPhotos{UID: uid, Merged: true}); err != nil {
 		event.AuditErr([]string{ClientIP(c), "session %s", "%s photo %s", "%s"}, SessionID(c), string(ev), uid, err)
 	} else {
-		event.PublishEntities("photos", string(ev), result)
+		event.PublishEntities("photos", string(ev), result, fields...)
 	}
 }
 
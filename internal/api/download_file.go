@@ -28,6 +28,8 @@ func DownloadName(c *gin.Context) customize.DownloadName {
 		return customize.DownloadNameShare
 	case "original":
 		return customize.DownloadNameOriginal
+	case "path":
+		return customize.DownloadNamePath
 	default:
 		return get.Config().Settings().Download.Name
 	}
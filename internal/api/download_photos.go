@@ -0,0 +1,172 @@
+package api
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// DownloadPhotosMaxCount limits the number of pictures that can be downloaded as zip archive at once.
+const DownloadPhotosMaxCount = 10000
+
+// DownloadManifestName is the name of the zip archive entry that lists skipped pictures.
+const DownloadManifestName = "manifest.json"
+
+// DownloadManifest lists the pictures that could not be added to a download archive.
+type DownloadManifest struct {
+	Created time.Time             `json:"Created"`
+	Added   int                   `json:"Added"`
+	Missing []DownloadMissingFile `json:"Missing"`
+}
+
+// DownloadMissingFile represents a picture whose primary file could not be added to a download archive.
+type DownloadMissingFile struct {
+	PhotoUID string `json:"PhotoUID"`
+	FileName string `json:"FileName,omitempty"`
+	Error    string `json:"Error"`
+}
+
+// DownloadPhotos streams the files of the selected pictures as zip archive, based on the same
+// download settings and selection rules as ZipCreate. Pictures without downloadable files are
+// skipped and listed in a manifest.json entry of the archive.
+//
+// POST /api/v1/photos/download
+//
+// Request Body:
+//   - photos ([]string) photo UIDs to download
+func DownloadPhotos(router *gin.RouterGroup) {
+	router.POST("/photos/download", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionDownload)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if !conf.Settings().Features.Download {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		var f form.Selection
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		// Only pictures can be selected, e.g. no albums or labels.
+		uids := make([]string, 0, len(f.Photos))
+		done := make(map[string]bool, len(f.Photos))
+
+		for _, uid := range f.Photos {
+			if uid = clean.UID(uid); uid != "" && !done[uid] {
+				uids = append(uids, uid)
+				done[uid] = true
+			}
+		}
+
+		if len(uids) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		} else if len(uids) > DownloadPhotosMaxCount {
+			AbortBadRequest(c)
+			return
+		}
+
+		// Find files to download.
+		files, ok := zipSelectedFiles(c, form.Selection{Photos: uids})
+
+		if !ok {
+			return
+		}
+
+		start := time.Now()
+		zipFileName := fmt.Sprintf("photoprism-download-%s.zip", start.Format("20060102-150405"))
+
+		AddDownloadHeader(c, zipFileName)
+
+		c.Header("Content-Type", ContentTypeZip)
+		c.Status(http.StatusOK)
+
+		zipWriter := zip.NewWriter(c.Writer)
+
+		added, missing, err := addFilesToZip(zipWriter, files, DownloadName(c), conf.Settings().Download.Unique)
+
+		if err != nil {
+			log.Errorf("download: %s (add files)", err)
+		}
+
+		// List skipped pictures in the archive so that users know what is missing.
+		if manifest := newDownloadManifest(start, added, uids, files, missing); len(manifest.Missing) > 0 {
+			if err = addManifestToZip(zipWriter, manifest); err != nil {
+				log.Errorf("download: %s (add manifest)", err)
+			}
+		}
+
+		if err = zipWriter.Close(); err != nil {
+			log.Errorf("download: %s (close zip)", err)
+			return
+		}
+
+		log.Infof("download: created %s with %d files [%s]", clean.Log(zipFileName), added, time.Since(start))
+	})
+}
+
+// newDownloadManifest returns a manifest that lists the selected pictures for which
+// no files were found, as well as the files that are missing in storage.
+func newDownloadManifest(created time.Time, added int, uids []string, files, missing entity.Files) DownloadManifest {
+	manifest := DownloadManifest{Created: created.UTC(), Added: added}
+	found := make(map[string]bool, len(files))
+
+	for _, file := range files {
+		found[file.PhotoUID] = true
+	}
+
+	for _, file := range missing {
+		manifest.Missing = append(manifest.Missing, DownloadMissingFile{PhotoUID: file.PhotoUID, FileName: file.FileName, Error: "file missing"})
+	}
+
+	for _, uid := range uids {
+		if !found[uid] {
+			manifest.Missing = append(manifest.Missing, DownloadMissingFile{PhotoUID: uid, Error: "not found"})
+		}
+	}
+
+	return manifest
+}
+
+// addManifestToZip adds the download manifest to a zip archive.
+func addManifestToZip(zipWriter *zip.Writer, manifest DownloadManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	w, err := zipWriter.CreateHeader(&zip.FileHeader{
+		Name:     DownloadManifestName,
+		Method:   zip.Deflate,
+		Modified: manifest.Created,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+
+	return err
+}
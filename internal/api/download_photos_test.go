@@ -0,0 +1,123 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestDownloadPhotos(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DownloadPhotos(router)
+
+		f, err := query.FileByPhotoUID("pt9jtdre2lvl0y11")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+
+		if fs.FileExists(fileName) {
+			t.Skipf("%s already exists", fileName)
+		} else if err = os.MkdirAll(filepath.Dir(fileName), fs.ModeDir); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(fileName, []byte("download photos"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/download", `{"photos": ["pt9jtdre2lvl0y11", "pt9jtdre2lvl0yh7", "pt9jtdre2lvl0xxx", "pt9jtdre2lvl0y11"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, ContentTypeZip, r.Header().Get("Content-Type"))
+		assert.Contains(t, r.Header().Get("Content-Disposition"), "attachment; filename=photoprism-download-")
+
+		body := r.Body.Bytes()
+		zipReader, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !assert.Len(t, zipReader.File, 2) {
+			return
+		}
+
+		assert.Equal(t, DownloadManifestName, zipReader.File[1].Name)
+
+		// readEntry returns the contents of a zip archive entry.
+		readEntry := func(zf *zip.File) []byte {
+			rc, err := zf.Open()
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer rc.Close()
+
+			data, err := io.ReadAll(rc)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return data
+		}
+
+		assert.Equal(t, "download photos", string(readEntry(zipReader.File[0])))
+
+		var manifest DownloadManifest
+
+		if err = json.Unmarshal(readEntry(zipReader.File[1]), &manifest); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, manifest.Added)
+
+		if assert.Len(t, manifest.Missing, 2) {
+			assert.Equal(t, "pt9jtdre2lvl0yh7", manifest.Missing[0].PhotoUID)
+			assert.NotEmpty(t, manifest.Missing[0].FileName)
+			assert.Equal(t, "pt9jtdre2lvl0xxx", manifest.Missing[1].PhotoUID)
+		}
+	})
+	t.Run("NoItemsSelected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DownloadPhotos(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/download", `{"photos": []}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DownloadPhotos(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/download", `{"photos": ["pt9jtdre2lvl0xxx"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DownloadPhotos(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/download", `{"photos": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("Unauthorized", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		DownloadPhotos(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/download?t="+conf.DownloadToken(), `{"photos": ["pt9jtdre2lvl0y11"]}`)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"net/http"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// FilePath represents the resolved storage location of an indexed file.
+type FilePath struct {
+	FileUID  string `json:"FileUID"`
+	PhotoUID string `json:"PhotoUID"`
+	Root     string `json:"Root"`
+	Name     string `json:"Name"`
+	Path     string `json:"Path"`
+	Exists   bool   `json:"Exists"`
+}
+
+// GetFilePath returns the absolute storage path of a file for debugging, and whether it exists.
+// GET /api/v1/photos/:uid/files/:file_uid/path
+//
+// Parameters:
+//
+//	uid: string Photo UID as returned by the API
+//	file_uid: string File UID as returned by the API
+func GetFilePath(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/files/:file_uid/path", func(c *gin.Context) {
+		// Only admins may see file system paths.
+		s := Auth(c, acl.ResourceFiles, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		photoUid := clean.UID(c.Param("uid"))
+		fileUid := clean.UID(c.Param("file_uid"))
+
+		file, err := query.FileByUID(fileUid)
+
+		// Abort if the file was not found or belongs to a different picture.
+		if err != nil {
+			log.Errorf("files: %s (get path)", err)
+			AbortEntityNotFound(c)
+			return
+		} else if file.PhotoUID != photoUid {
+			log.Errorf("files: %s does not belong to %s (get path)", clean.Log(fileUid), clean.Log(photoUid))
+			AbortEntityNotFound(c)
+			return
+		}
+
+		fileName := photoprism.FileName(file.FileRoot, file.FileName)
+
+		if abs, err := filepath.Abs(fileName); err == nil {
+			fileName = abs
+		}
+
+		result := FilePath{
+			FileUID:  file.FileUID,
+			PhotoUID: file.PhotoUID,
+			Root:     file.FileRoot,
+			Name:     file.FileName,
+			Path:     fileName,
+			Exists:   fs.FileExists(fileName),
+		}
+
+		// Resolve symbolic links if the file exists.
+		if !result.Exists {
+			log.Debugf("files: %s does not exist", clean.Log(file.FileName))
+		} else if resolved, err := filepath.EvalSymlinks(fileName); err == nil {
+			result.Path = resolved
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func TestGetFilePath(t *testing.T) {
+	t.Run("Exists", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetFilePath(router)
+
+		photo := &entity.Photo{PhotoTitle: "File Path"}
+
+		if err := photo.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _, _ = photo.DeletePermanently() }()
+
+		fileName := "path/" + photo.PhotoUID + ".jpg"
+		filePath := filepath.Join(conf.OriginalsPath(), fileName)
+
+		if err := os.MkdirAll(filepath.Dir(filePath), fs.ModeDir); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(filePath, []byte("test"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _ = os.Remove(filePath) }()
+
+		file := &entity.File{
+			PhotoID:     photo.ID,
+			PhotoUID:    photo.PhotoUID,
+			FileRoot:    entity.RootOriginals,
+			FileName:    fileName,
+			FileHash:    rnd.GenerateUID('h'),
+			FileType:    "jpg",
+			FilePrimary: true,
+		}
+
+		if err := file.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		r := PerformRequest(app, "GET", "/api/v1/photos/"+photo.PhotoUID+"/files/"+file.FileUID+"/path")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		resolved, _ := filepath.EvalSymlinks(filePath)
+
+		assert.Equal(t, file.FileUID, gjson.Get(r.Body.String(), "FileUID").String())
+		assert.Equal(t, fileName, gjson.Get(r.Body.String(), "Name").String())
+		assert.Equal(t, resolved, gjson.Get(r.Body.String(), "Path").String())
+		assert.True(t, filepath.IsAbs(gjson.Get(r.Body.String(), "Path").String()))
+		assert.True(t, gjson.Get(r.Body.String(), "Exists").Bool())
+	})
+	t.Run("Missing", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetFilePath(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/files/ft8es39w45bnlqdw/path")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", gjson.Get(r.Body.String(), "PhotoUID").String())
+		assert.Contains(t, gjson.Get(r.Body.String(), "Path").String(), "2790/07/27900704_070228_D6D51B6C.jpg")
+		assert.False(t, gjson.Get(r.Body.String(), "Exists").Bool())
+	})
+	t.Run("OtherPhoto", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetFilePath(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh8/files/ft8es39w45bnlqdw/path")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetFilePath(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/files/fs6sg6bwhhbnlqdn/path")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
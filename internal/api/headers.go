@@ -2,13 +2,16 @@ package api
 
 import (
 	"fmt"
+	"mime"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/internal/thumb"
 )
 
 const (
@@ -20,6 +23,11 @@ func AddCountHeader(c *gin.Context, count int) {
 	c.Header("X-Count", strconv.Itoa(count))
 }
 
+// AddTotalHeader adds the total number of results, regardless of limit and offset, to the response.
+func AddTotalHeader(c *gin.Context, total int) {
+	c.Header("X-Total", strconv.Itoa(total))
+}
+
 // AddLimitHeader adds the max result count to the response.
 func AddLimitHeader(c *gin.Context, limit int) {
 	c.Header("X-Limit", strconv.Itoa(limit))
@@ -35,6 +43,61 @@ func AddDownloadHeader(c *gin.Context, fileName string) {
 	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
 }
 
+// AddDispositionHeader adds a header indicating whether the response should be displayed inline
+// or downloaded as attachment with the specified file name.
+func AddDispositionHeader(c *gin.Context, inline bool, fileName string) {
+	disposition := "attachment"
+
+	if inline {
+		disposition = "inline"
+	}
+
+	// Non-ASCII names are encoded as specified in RFC 2231.
+	if value := mime.FormatMediaType(disposition, map[string]string{"filename": fileName}); value != "" {
+		c.Header("Content-Disposition", value)
+	} else {
+		c.Header("Content-Disposition", disposition)
+	}
+}
+
+// AddETagHeader adds a strong entity tag based on the file hash to the response, and returns it.
+func AddETagHeader(c *gin.Context, fileHash string) string {
+	if fileHash == "" {
+		return ""
+	}
+
+	etag := fmt.Sprintf("%q", fileHash)
+
+	c.Header("ETag", etag)
+
+	return etag
+}
+
+// NotModified tests if the entity tag matches the If-None-Match request header,
+// so that the client can use its cached copy.
+func NotModified(c *gin.Context, etag string) bool {
+	if etag == "" {
+		return false
+	}
+
+	header := c.GetHeader("If-None-Match")
+
+	if header == "" {
+		return false
+	}
+
+	// Entity tags are compared using the weak comparison function, see RFC 7232.
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
 // AddSessionHeader adds a session id header to the response.
 func AddSessionHeader(c *gin.Context, id string) {
 	c.Header(session.Header, id)
@@ -64,3 +127,17 @@ func AddTokenHeaders(c *gin.Context, s *entity.Session) {
 		c.Header("X-Download-Token", s.DownloadToken)
 	}
 }
+
+// AddThumbPreloadHeaders adds preload hints for the configured thumbnail sizes to the response,
+// so that HTTP/2 clients can start fetching them before the photo details have been processed.
+func AddThumbPreloadHeaders(c *gin.Context, fileHash, previewToken string) {
+	if fileHash == "" || c.Request.ProtoMajor < 2 {
+		return
+	}
+
+	conf := get.Config()
+
+	for _, name := range conf.ThumbPreload() {
+		c.Writer.Header().Add("Link", fmt.Sprintf("<%s>; rel=preload; as=image", thumb.Url(fileHash, name, conf.ContentUri(), previewToken)))
+	}
+}
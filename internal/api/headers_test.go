@@ -0,0 +1,70 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotModified(t *testing.T) {
+	etag := `"2cad9168fa6acc5c5c2965ddf6ec465ca42fd818"`
+
+	tests := []struct {
+		header   string
+		expected bool
+	}{
+		{"", false},
+		{etag, true},
+		{"W/" + etag, true},
+		{`"foo", ` + etag, true},
+		{`"foo", "bar"`, false},
+		{"*", true},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("GET", "/", nil)
+
+		if tt.header != "" {
+			c.Request.Header.Set("If-None-Match", tt.header)
+		}
+
+		assert.Equal(t, tt.expected, NotModified(c, etag), tt.header)
+		assert.False(t, NotModified(c, ""), tt.header)
+	}
+}
+
+func TestAddETagHeader(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	assert.Equal(t, `"2cad9168fa6acc5c5c2965ddf6ec465ca42fd818"`, AddETagHeader(c, "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818"))
+	assert.Equal(t, `"2cad9168fa6acc5c5c2965ddf6ec465ca42fd818"`, w.Header().Get("ETag"))
+	assert.Equal(t, "", AddETagHeader(c, ""))
+}
+
+func TestAddDispositionHeader(t *testing.T) {
+	tests := []struct {
+		inline   bool
+		name     string
+		expected string
+	}{
+		{false, "bridge.jpg", "attachment; filename=bridge.jpg"},
+		{true, "bridge.jpg", "inline; filename=bridge.jpg"},
+		{false, "my photo.jpg", `attachment; filename="my photo.jpg"`},
+		{false, "Neckarbrücke.jpg", "attachment; filename*=utf-8''Neckarbr%C3%BCcke.jpg"},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		AddDispositionHeader(c, tt.inline, tt.name)
+
+		assert.Equal(t, tt.expected, w.Header().Get("Content-Disposition"), tt.name)
+	}
+}
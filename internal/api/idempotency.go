@@ -0,0 +1,157 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gc "github.com/patrickmn/go-cache"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// IdempotencyKeyHeader is the request header that clients can set to identify retries of the same request.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader is the response header that indicates the response has been returned before.
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// IdempotencyTTL specifies how long responses to requests with an idempotency key are remembered.
+var IdempotencyTTL = 10 * time.Minute
+
+// idempotencyCache contains the responses to requests with an idempotency key.
+var idempotencyCache = gc.New(IdempotencyTTL, IdempotencyTTL)
+
+// idempotencyMutex prevents concurrent requests with the same key from being handled more than once.
+var idempotencyMutex = sync.Mutex{}
+
+// idempotentResponse represents a remembered response, or a request in progress if it is pending.
+type idempotentResponse struct {
+	Hash    string
+	Pending bool
+	Status  int
+	Body    interface{}
+}
+
+// IdempotencyKey returns the cache key for the request based on the session, the entity UID, and the
+// Idempotency-Key header, or an empty string if the header is missing or invalid.
+func IdempotencyKey(c *gin.Context, s *entity.Session, uid string) string {
+	key := clean.Token(c.GetHeader(IdempotencyKeyHeader))
+
+	if key == "" || len(key) > 255 || s == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s:%s:%s", s.RefID, uid, key)
+}
+
+// idempotentRequestHash returns the hash of the request body, which remains readable afterwards.
+func idempotentRequestHash(c *gin.Context) (string, error) {
+	if c.Request == nil || c.Request.Body == nil {
+		return "", nil
+	}
+
+	data, err := io.ReadAll(c.Request.Body)
+
+	if err != nil {
+		return "", err
+	}
+
+	c.Request.Body = io.NopCloser(bytes.NewReader(data))
+
+	hash := sha1.Sum(data)
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// IdempotentReplay handles retries of requests with the same key and returns true if the request must not
+// be processed again. Otherwise, the key is marked as pending until SetIdempotentResponse or
+// IdempotentRelease is called:
+//
+//   - the previous response is returned if the request is a retry of a completed request,
+//   - status 409 is returned if the same request is still in progress,
+//   - status 422 is returned if the key has already been used for a different request.
+func IdempotentReplay(c *gin.Context, key string) bool {
+	if key == "" {
+		return false
+	}
+
+	hash, err := idempotentRequestHash(c)
+
+	if err != nil {
+		AbortBadRequest(c)
+		return true
+	}
+
+	idempotencyMutex.Lock()
+	defer idempotencyMutex.Unlock()
+
+	cached, found := idempotencyCache.Get(key)
+
+	if !found {
+		idempotencyCache.SetDefault(key, idempotentResponse{Hash: hash, Pending: true})
+		return false
+	}
+
+	resp, ok := cached.(idempotentResponse)
+
+	switch {
+	case !ok:
+		return false
+	case resp.Hash != hash:
+		log.Debugf("api: idempotency key %s has been used for a different request", clean.Log(key))
+		Abort(c, http.StatusUnprocessableEntity, i18n.ErrBadRequest)
+	case resp.Pending:
+		Abort(c, http.StatusConflict, i18n.ErrBusy)
+	default:
+		log.Debugf("api: returning previous response for idempotency key %s", clean.Log(key))
+		c.Header(IdempotentReplayedHeader, "true")
+		c.JSON(resp.Status, resp.Body)
+	}
+
+	return true
+}
+
+// SetIdempotentResponse remembers the response for the key, so that it can be returned if the request is retried.
+func SetIdempotentResponse(key string, status int, body interface{}) {
+	if key == "" {
+		return
+	}
+
+	idempotencyMutex.Lock()
+	defer idempotencyMutex.Unlock()
+
+	var hash string
+
+	if cached, found := idempotencyCache.Get(key); found {
+		if resp, ok := cached.(idempotentResponse); ok {
+			hash = resp.Hash
+		}
+	}
+
+	idempotencyCache.SetDefault(key, idempotentResponse{Hash: hash, Status: status, Body: body})
+}
+
+// IdempotentRelease removes the key if the request has not completed, so that it can be retried.
+func IdempotentRelease(key string) {
+	if key == "" {
+		return
+	}
+
+	idempotencyMutex.Lock()
+	defer idempotencyMutex.Unlock()
+
+	if cached, found := idempotencyCache.Get(key); !found {
+		return
+	} else if resp, ok := cached.(idempotentResponse); ok && resp.Pending {
+		idempotencyCache.Delete(key)
+	}
+}
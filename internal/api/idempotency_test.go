@@ -0,0 +1,92 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	s := &entity.Session{RefID: "sess34q3hael"}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("PUT", "/", nil)
+
+	assert.Equal(t, "", IdempotencyKey(c, s, "pt9jtdre2lvl0y13"))
+
+	c.Request.Header.Set(IdempotencyKeyHeader, "3f1c5a3e-b5a4-4bd1-9a8e-d2c3b4a5e6f7")
+
+	assert.Equal(t, "sess34q3hael:pt9jtdre2lvl0y13:3f1c5a3e-b5a4-4bd1-9a8e-d2c3b4a5e6f7", IdempotencyKey(c, s, "pt9jtdre2lvl0y13"))
+	assert.Equal(t, "", IdempotencyKey(c, nil, "pt9jtdre2lvl0y13"))
+
+	c.Request.Header.Set(IdempotencyKeyHeader, "<>")
+
+	assert.Equal(t, "", IdempotencyKey(c, s, "pt9jtdre2lvl0y13"))
+}
+
+func TestIdempotentReplay(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	assert.False(t, IdempotentReplay(c, ""))
+	assert.False(t, IdempotentReplay(c, "sess34q3hael:pt9jtdre2lvl0y13:replay-test"))
+
+	SetIdempotentResponse("", http.StatusOK, gin.H{"Title": "Empty"})
+	SetIdempotentResponse("sess34q3hael:pt9jtdre2lvl0y13:replay-test", http.StatusOK, gin.H{"Title": "Replay"})
+
+	assert.True(t, IdempotentReplay(c, "sess34q3hael:pt9jtdre2lvl0y13:replay-test"))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "true", w.Header().Get(IdempotentReplayedHeader))
+	assert.JSONEq(t, `{"Title": "Replay"}`, w.Body.String())
+}
+
+func TestIdempotentReplay_Pending(t *testing.T) {
+	key := "sess34q3hael:pt9jtdre2lvl0y13:pending-test"
+
+	request := func(body string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("PUT", "/", strings.NewReader(body))
+		return c, w
+	}
+
+	// The first request is marked as pending and must be processed.
+	c, _ := request(`{"Title": "Pending"}`)
+	assert.False(t, IdempotentReplay(c, key))
+
+	// The request body must remain readable.
+	var f struct{ Title string }
+	assert.NoError(t, c.BindJSON(&f))
+	assert.Equal(t, "Pending", f.Title)
+
+	// Retries are rejected while the request is in progress.
+	c, w := request(`{"Title": "Pending"}`)
+	assert.True(t, IdempotentReplay(c, key))
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// Requests with a different body are rejected.
+	c, w = request(`{"Title": "Other"}`)
+	assert.True(t, IdempotentReplay(c, key))
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	// Failed requests can be retried.
+	IdempotentRelease(key)
+
+	c, _ = request(`{"Title": "Pending"}`)
+	assert.False(t, IdempotentReplay(c, key))
+
+	SetIdempotentResponse(key, http.StatusOK, gin.H{"Title": "Pending"})
+	IdempotentRelease(key)
+
+	c, w = request(`{"Title": "Pending"}`)
+	assert.True(t, IdempotentReplay(c, key))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"Title": "Pending"}`, w.Body.String())
+}
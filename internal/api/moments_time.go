@@ -11,6 +11,12 @@ import (
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
+// MomentTime represents a monthly moment with a title in the language of the current session.
+type MomentTime struct {
+	query.Moment
+	Title string `json:"Title"`
+}
+
 // GetMomentsTime returns monthly albums as JSON.
 //
 // GET /api/v1/moments/time
@@ -31,6 +37,13 @@ func GetMomentsTime(router *gin.RouterGroup) {
 			return
 		}
 
-		c.JSON(http.StatusOK, result)
+		loc := s.Locale()
+		moments := make([]MomentTime, len(result))
+
+		for i, m := range result {
+			moments[i] = MomentTime{Moment: m, Title: m.LocaleTitle(loc)}
+		}
+
+		c.JSON(http.StatusOK, moments)
 	})
 }
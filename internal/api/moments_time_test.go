@@ -20,4 +20,17 @@ func TestGetMomentsTime(t *testing.T) {
 		assert.LessOrEqual(t, val.Int(), int64(2))
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("Title", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetMomentsTime(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/moments/time")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		for _, m := range gjson.Parse(r.Body.String()).Array() {
+			assert.NotEmpty(t, m.Get("Title").String())
+			assert.True(t, m.Get("Year").Exists())
+		}
+	})
 }
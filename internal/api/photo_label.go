@@ -16,11 +16,15 @@ import (
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
+// AddPhotoLabel adds a label to a photo, or updates the existing photo label if it has already been added.
+//
 // POST /api/v1/photos/:uid/label
 //
 // Parameters:
 //
 //	uid: string PhotoUID as returned by the API
+//
+// Request body: {"name": "beach", "priority": 10}
 func AddPhotoLabel(router *gin.RouterGroup) {
 	router.POST("/photos/:uid/label", func(c *gin.Context) {
 		s := Auth(c, acl.ResourcePhotos, acl.ActionUpdate)
@@ -41,6 +45,9 @@ func AddPhotoLabel(router *gin.RouterGroup) {
 		if err := c.BindJSON(&f); err != nil {
 			AbortBadRequest(c)
 			return
+		} else if clean.Name(f.LabelName) == "" {
+			AbortBadRequest(c)
+			return
 		}
 
 		labelEntity := entity.FirstOrCreateLabel(entity.NewLabel(f.LabelName, f.LabelPriority))
@@ -52,6 +59,7 @@ func AddPhotoLabel(router *gin.RouterGroup) {
 
 		if err := labelEntity.Restore(); err != nil {
 			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "could not restore label"})
+			return
 		}
 
 		photoLabel := entity.FirstOrCreatePhotoLabel(entity.NewPhotoLabel(m.ID, labelEntity.ID, f.Uncertainty, "manual"))
@@ -90,6 +98,9 @@ func AddPhotoLabel(router *gin.RouterGroup) {
 	})
 }
 
+// RemovePhotoLabel removes a manually added label from a photo. Labels from other sources are
+// suppressed instead, so that they are not added again when the photo is re-indexed.
+//
 // DELETE /api/v1/photos/:uid/label/:id
 //
 // Parameters:
@@ -4,7 +4,10 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
 )
@@ -39,6 +42,26 @@ func TestAddPhotoLabel(t *testing.T) {
 		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh8/label", `{"Name": 123, "Uncertainty": 10, "Priority": 2}`)
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
+	t.Run("name and priority", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddPhotoLabel(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh8/label", `{"name": "testAddLabels", "priority": 10}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "pt9jtdre2lvl0yh8", gjson.Get(r.Body.String(), "UID").String())
+		assert.Equal(t, "0", gjson.Get(r.Body.String(), `Labels.#(Label.Name=="TestAddLabels").Uncertainty`).String())
+		assert.Equal(t, "manual", gjson.Get(r.Body.String(), `Labels.#(Label.Name=="TestAddLabels").LabelSrc`).String())
+
+		// Adding the same label again must not create a duplicate.
+		r = PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh8/label", `{"name": "testaddlabels", "priority": 10}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), `Labels.#(Label.Name=="TestAddLabels")#|#`).Int())
+	})
+	t.Run("empty name", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddPhotoLabel(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh8/label", `{"name": " ", "priority": 10}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
 
 }
 
@@ -89,6 +112,52 @@ func TestRemovePhotoLabel(t *testing.T) {
 		r := PerformRequest(app, "DELETE", "/api/v1/photos/xx/label/")
 		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
+	t.Run("not existing photo with label id", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RemovePhotoLabel(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/photos/xx/label/1000001")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("manual label", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddPhotoLabel(router)
+		RemovePhotoLabel(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh8/label", `{"name": "testRemoveLabels", "priority": 10}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		labelId := gjson.Get(r.Body.String(), `Labels.#(Label.Name=="TestRemoveLabels").LabelID`).String()
+		assert.NotEmpty(t, labelId)
+		assert.Contains(t, gjson.Get(r.Body.String(), "Details.Keywords").String(), "testremovelabels")
+
+		r = PerformRequest(app, "DELETE", "/api/v1/photos/pt9jtdre2lvl0yh8/label/"+labelId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.False(t, gjson.Get(r.Body.String(), `Labels.#(LabelID==`+labelId+`)`).Exists())
+		assert.NotContains(t, gjson.Get(r.Body.String(), "Details.Keywords").String(), "testremovelabels")
+	})
+	t.Run("suppressed label", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RemovePhotoLabel(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/photos/pt9jtdre2lvl0yh7/label/1000001")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "100", gjson.Get(r.Body.String(), "Labels.#(LabelID==1000001).Uncertainty").String())
+
+		// Suppressed labels must not be added again when the photo is re-indexed.
+		p, err := query.PhotoByUID("pt9jtdre2lvl0yh7")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		label := entity.LabelFixtures.Get("flower")
+		p.AddLabels(classify.Labels{{Name: label.LabelName, Source: classify.SrcImage, Uncertainty: 10, Priority: label.LabelPriority}})
+
+		photoLabel, err := query.PhotoLabel(p.ID, 1000001)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 100, photoLabel.Uncertainty)
+	})
 }
 
 func TestUpdatePhotoLabel(t *testing.T) {
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/internal/viewer"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Rendition types.
+const (
+	RenditionThumb    = "thumb"
+	RenditionPreview  = "preview"
+	RenditionOriginal = "original"
+)
+
+// Rendition represents an available derivative of a photo, e.g. a thumbnail size.
+type Rendition struct {
+	Type   string `json:"Type"`
+	Name   string `json:"Name"`
+	Format string `json:"Format"`
+	Width  int    `json:"Width"`
+	Height int    `json:"Height"`
+	Url    string `json:"Url"`
+}
+
+// Renditions represents a list of photo renditions.
+type Renditions []Rendition
+
+// GetPhotoRenditions returns the available thumbnail sizes, preview, and original of a photo as JSON.
+//
+// GET /api/v1/photos/:uid/renditions
+//
+// Parameters:
+//
+//	uid: string PhotoUID as returned by the API
+func GetPhotoRenditions(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/renditions", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		p, err := query.PhotoPreloadByUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		var primary, original *entity.File
+
+		for i := range p.Files {
+			f := &p.Files[i]
+
+			if f.FilePrimary {
+				primary = f
+			} else if original == nil && !f.FileSidecar {
+				original = f
+			}
+		}
+
+		if primary == nil {
+			AbortEntityNotFound(c)
+			return
+		} else if original == nil {
+			original = primary
+		}
+
+		c.JSON(http.StatusOK, PhotoRenditions(primary, original, s.PreviewToken, s.DownloadToken))
+	})
+}
+
+// PhotoRenditions returns the renditions based on the primary and original file of a photo.
+func PhotoRenditions(primary, original *entity.File, previewToken, downloadToken string) (result Renditions) {
+	conf := get.Config()
+	contentUri := conf.ContentUri()
+	apiUri := conf.ApiUri()
+
+	for _, name := range thumb.Names {
+		size := thumb.Sizes[name]
+
+		// Skip sizes that cannot be rendered.
+		if size.Uncached() && !conf.ThumbUncached() {
+			continue
+		}
+
+		_, _, format := thumb.ResampleOptions(size.Options...)
+
+		t := thumb.New(primary.FileWidth, primary.FileHeight, primary.FileHash, size, contentUri, previewToken)
+
+		// Cropped thumbnails always have the exact size.
+		if !size.Fit {
+			t.W, t.H = size.Width, size.Height
+		}
+
+		result = append(result, Rendition{
+			Type:   RenditionThumb,
+			Name:   name.String(),
+			Format: format.String(),
+			Width:  t.W,
+			Height: t.H,
+			Url:    t.Src,
+		})
+	}
+
+	result = append(result, Rendition{
+		Type:   RenditionPreview,
+		Name:   primary.FileUID,
+		Format: primary.FileType,
+		Width:  primary.FileWidth,
+		Height: primary.FileHeight,
+		Url:    viewer.DownloadUrl(primary.FileHash, apiUri, downloadToken),
+	}, Rendition{
+		Type:   RenditionOriginal,
+		Name:   original.FileUID,
+		Format: original.FileType,
+		Width:  original.FileWidth,
+		Height: original.FileHeight,
+		Url:    viewer.DownloadUrl(original.FileHash, apiUri, downloadToken),
+	})
+
+	return result
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/thumb"
+)
+
+func TestGetPhotoRenditions(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoRenditions(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/renditions")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		var expected []string
+
+		for _, name := range thumb.Names {
+			if size := thumb.Sizes[name]; !size.Uncached() || get.Config().ThumbUncached() {
+				expected = append(expected, name.String())
+			}
+		}
+
+		thumbs := gjson.Get(r.Body.String(), `#(Type=="thumb")#.Name`).Array()
+
+		assert.Len(t, thumbs, len(expected))
+
+		for i, name := range thumbs {
+			assert.Equal(t, expected[i], name.String())
+		}
+
+		assert.Contains(t, gjson.Get(r.Body.String(), `#(Type=="preview").Url`).String(), "/api/v1/dl/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818")
+		assert.True(t, gjson.Get(r.Body.String(), `#(Type=="original").Url`).Exists())
+		assert.Equal(t, "jpg", gjson.Get(r.Body.String(), `#(Type=="thumb").Format`).String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoRenditions(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/xxx/renditions")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
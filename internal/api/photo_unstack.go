@@ -17,7 +17,15 @@ import (
 	"github.com/photoprism/photoprism/pkg/clean"
 )
 
-// PhotoUnstack removes a file from an existing photo stack.
+// PhotoUnstackResponse represents the photo stack from which a file was removed,
+// along with the new photo that has been created for the file.
+type PhotoUnstackResponse struct {
+	entity.Photo
+	Unstacked entity.Photo `json:"Unstacked"`
+}
+
+// PhotoUnstack removes a file from an existing photo stack and returns the stack
+// with the newly created photo in the "Unstacked" field.
 //
 // POST /api/v1/photos/:uid/files/:file_uid/unstack
 //
@@ -206,6 +214,17 @@ func PhotoUnstack(router *gin.RouterGroup) {
 			return
 		}
 
-		c.JSON(http.StatusOK, p)
+		unstacked, err := query.PhotoPreloadByUID(newPhoto.PhotoUID)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		// Update YAML sidecar files of both photos.
+		SavePhotoAsYaml(p)
+		SavePhotoAsYaml(unstacked)
+
+		c.JSON(http.StatusOK, PhotoUnstackResponse{Photo: p, Unstacked: unstacked})
 	})
 }
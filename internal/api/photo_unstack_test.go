@@ -2,9 +2,18 @@ package api
 
 import (
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 func TestPhotoUnstack(t *testing.T) {
@@ -33,4 +42,75 @@ func TestPhotoUnstack(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, r.Code)
 		// t.Logf("RESP: %s", r.Body.String())
 	})
+	t.Run("Success", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		PhotoUnstack(router)
+
+		dir := filepath.Join(conf.OriginalsPath(), "unstack-test")
+
+		if err := os.MkdirAll(dir, fs.ModeDir); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(dir)
+		defer os.RemoveAll(filepath.Join(conf.SidecarPath(), "unstack-test"))
+
+		// Index two unrelated pictures.
+		results := make(map[string]photoprism.IndexResult, 2)
+
+		for src, dest := range map[string]string{"beach_wood.jpg": "stack.jpg", "tree_white.jpg": "other.jpg"} {
+			if err := fs.Copy(filepath.Join(conf.ExamplesPath(), src), filepath.Join(dir, dest)); err != nil {
+				t.Fatal(err)
+			} else if results[dest] = get.Index().FileName(filepath.Join(dir, dest), photoprism.IndexOptionsSingle()); results[dest].Failed() {
+				t.Fatal(results[dest].Err)
+			}
+		}
+
+		stackFile, err := query.FileByUID(results["stack.jpg"].FileUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		otherFile, err := query.FileByUID(results["other.jpg"].FileUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		otherPhotoUID := otherFile.PhotoUID
+
+		defer func() {
+			for _, uid := range []string{stackFile.PhotoUID, otherPhotoUID} {
+				if p, err := query.PhotoByUID(uid); err == nil {
+					_, _ = p.DeletePermanently()
+				}
+			}
+		}()
+
+		// Incorrectly stack the other file.
+		if err = entity.UnscopedDb().Model(&entity.File{}).Where("id = ?", otherFile.ID).
+			Updates(map[string]interface{}{"PhotoID": stackFile.PhotoID, "PhotoUID": stackFile.PhotoUID, "FilePrimary": false}).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		r := PerformRequest(app, "POST", "/api/v1/photos/"+stackFile.PhotoUID+"/files/"+otherFile.FileUID+"/unstack")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		unstackedUID := gjson.Get(r.Body.String(), "Unstacked.UID").String()
+
+		assert.Equal(t, stackFile.PhotoUID, gjson.Get(r.Body.String(), "UID").String())
+		assert.NotEmpty(t, unstackedUID)
+		assert.NotEqual(t, stackFile.PhotoUID, unstackedUID)
+
+		if p, err := query.PhotoByUID(unstackedUID); err == nil {
+			defer func() { _, _ = p.DeletePermanently() }()
+		}
+
+		if f, err := query.FileByUID(otherFile.FileUID); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, unstackedUID, f.PhotoUID)
+		}
+	})
 }
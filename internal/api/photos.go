@@ -1,10 +1,14 @@
 package api
 
 import (
+	"errors"
 	"net/http"
+	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/ulule/deepcopier"
 
 	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/entity"
@@ -16,9 +20,10 @@ import (
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
-// SavePhotoAsYaml saves photo data as YAML file.
+// SavePhotoAsYaml saves photo data as YAML file, or schedules the update if a backup delay is configured.
 func SavePhotoAsYaml(p entity.Photo) {
 	c := get.Config()
 
@@ -27,20 +32,46 @@ func SavePhotoAsYaml(p entity.Photo) {
 		return
 	}
 
+	// Coalesce rapid successive updates of the same photo?
+	if delay := c.BackupYamlDelay(); delay > 0 && p.PhotoUID != "" {
+		photoYamlQueue.Schedule(p.PhotoUID, delay)
+		return
+	}
+
+	savePhotoAsYaml(p)
+}
+
+// savePhotoAsYaml writes photo data to its YAML sidecar file, unless it is unchanged,
+// and returns true if the file was written.
+func savePhotoAsYaml(p entity.Photo) (written bool) {
+	c := get.Config()
+
+	if !c.BackupYaml() {
+		return false
+	}
+
 	fileName := p.YamlFileName(c.OriginalsPath(), c.SidecarPath())
 
-	if err := p.SaveAsYaml(fileName); err != nil {
+	// Only write the file if its contents have changed.
+	written, err := p.UpdateYaml(fileName)
+
+	if err != nil {
 		log.Errorf("photo: %s (update yaml)", err)
-	} else {
+	} else if written {
 		log.Debugf("photo: updated yaml file %s", clean.Log(filepath.Base(fileName)))
+	} else {
+		log.Debugf("photo: yaml file %s is unchanged", clean.Log(filepath.Base(fileName)))
 	}
+
+	return written
 }
 
-// GetPhoto returns photo details as JSON.
+// GetPhoto returns photo details as JSON, or status 410 Gone if the photo has been removed.
 //
 // Route : GET /api/v1/photos/:uid
 // Params:
 // - uid (string) PhotoUID as returned by the API
+// - include (string) "full" (default) for all details, or "basic" for title, date, and primary file hash only
 func GetPhoto(router *gin.RouterGroup) {
 	router.GET("/photos/:uid", func(c *gin.Context) {
 		s := Auth(c, acl.ResourcePhotos, acl.ActionView)
@@ -49,19 +80,69 @@ func GetPhoto(router *gin.RouterGroup) {
 			return
 		}
 
-		p, err := query.PhotoPreloadByUID(clean.UID(c.Param("uid")))
+		uid := clean.UID(c.Param("uid"))
+
+		switch clean.TypeLower(c.Query("include")) {
+		case "", "full":
+		case "basic":
+			getPhotoBasic(c, uid, s.PreviewToken)
+			return
+		default:
+			AbortBadRequest(c)
+			return
+		}
+
+		p, err := query.PhotoPreloadByUID(uid)
 
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if p.DeletedAt != nil {
+			if deletedAt, removed := query.PhotoRemoved(uid); removed {
+				AbortRemoved(c, uid, deletedAt)
+				return
+			}
+		}
+
+		// Add thumbnail preload hints for HTTP/2 clients.
+		for _, f := range p.Files {
+			if f.FilePrimary {
+				AddThumbPreloadHeaders(c, f.FileHash, s.PreviewToken)
+				break
+			}
 		}
 
 		c.IndentedJSON(http.StatusOK, p)
 	})
 }
 
+// getPhotoBasic responds with a lightweight projection of the photo, skipping the expensive preloads.
+func getPhotoBasic(c *gin.Context, uid, previewToken string) {
+	p, err := query.PhotoBasicByUID(uid)
+
+	if err != nil {
+		AbortEntityNotFound(c)
+		return
+	} else if p.DeletedAt != nil {
+		if deletedAt, removed := query.PhotoRemoved(uid); removed {
+			AbortRemoved(c, uid, deletedAt)
+			return
+		}
+	}
+
+	if p.FileHash != "" {
+		AddThumbPreloadHeaders(c, p.FileHash, previewToken)
+	}
+
+	c.IndentedJSON(http.StatusOK, p)
+}
+
 // UpdatePhoto updates photo details and returns them as JSON.
 //
+// If the request has an Idempotency-Key header, the response is remembered for a short time, so that
+// it can be returned again without saving the photo twice when clients retry the same request.
+// Reusing a key for a different request body fails with status 422.
+//
 // PUT /api/v1/photos/:uid
 func UpdatePhoto(router *gin.RouterGroup) {
 	router.PUT("/photos/:uid", func(c *gin.Context) {
@@ -72,6 +153,16 @@ func UpdatePhoto(router *gin.RouterGroup) {
 		}
 
 		uid := clean.UID(c.Param("uid"))
+		key := IdempotencyKey(c, s, uid)
+
+		// Return the previous response if the request is a retry.
+		if IdempotentReplay(c, key) {
+			return
+		}
+
+		// Allow the request to be retried if it fails.
+		defer IdempotentRelease(key)
+
 		m, err := query.PhotoByUID(uid)
 
 		if err != nil {
@@ -87,6 +178,8 @@ func UpdatePhoto(router *gin.RouterGroup) {
 			return
 		}
 
+		orig := f
+
 		// 2) Update form with values from request
 		if err := c.BindJSON(&f); err != nil {
 			Abort(c, http.StatusBadRequest, i18n.ErrBadRequest)
@@ -94,37 +187,118 @@ func UpdatePhoto(router *gin.RouterGroup) {
 		}
 
 		// 3) Save model with values from form
-		if err := entity.SavePhotoForm(m, f); err != nil {
+		if p := savePhotoForm(c, m, f, orig.Diff(f)...); p != nil {
+			SetIdempotentResponse(key, http.StatusOK, p)
+		}
+	})
+}
+
+// PatchPhoto updates only the photo metadata fields present in the request body, so that
+// concurrent edits of other fields are not overwritten.
+//
+// PATCH /api/v1/photos/:uid
+// Params:
+//
+//	uid: string PhotoUID as returned by the API
+func PatchPhoto(router *gin.RouterGroup) {
+	router.PATCH("/photos/:uid", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m, err := query.PhotoByUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		f, err := form.NewPhoto(m)
+
+		if err != nil {
 			Abort(c, http.StatusInternalServerError, i18n.ErrSaveFailed)
 			return
-		} else if f.PhotoPrivate {
-			FlushCoverCache()
 		}
 
-		PublishPhotoEvent(EntityUpdated, uid, c)
+		// Include the current details, so that they can be updated partially.
+		if err = deepcopier.Copy(m.GetDetails()).To(&f.Details); err != nil {
+			Abort(c, http.StatusInternalServerError, i18n.ErrSaveFailed)
+			return
+		}
 
-		event.SuccessMsg(i18n.MsgChangesSaved)
+		f.Details.PhotoID = m.ID
 
-		p, err := query.PhotoPreloadByUID(uid)
+		data, err := c.GetRawData()
 
 		if err != nil {
-			AbortEntityNotFound(c)
+			AbortBadRequest(c)
 			return
 		}
 
-		SavePhotoAsYaml(p)
+		// Apply the fields present in the request body.
+		if err = f.Patch(data); err != nil {
+			log.Debugf("photo: %s (patch)", err)
+			AbortBadRequest(c)
+			return
+		}
 
-		UpdateClientConfig()
+		fields, err := form.PatchFields(data)
 
-		c.JSON(http.StatusOK, p)
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		savePhotoForm(c, m, f, fields...)
 	})
 }
 
-// GetPhotoDownload returns the primary file matching that belongs to the photo.
+// savePhotoForm saves the photo form values, and sends the updated photo as response.
+// Form values that fail validation are returned with status code 422 and are not saved.
+// The names of the changed fields are included in the published event.
+// It returns the updated photo, or nil if it could not be saved.
+func savePhotoForm(c *gin.Context, m entity.Photo, f form.Photo, fields ...string) *entity.Photo {
+	var invalid form.ValidationErrors
+
+	if err := entity.SavePhotoForm(m, f); errors.As(err, &invalid) {
+		AbortInvalidValues(c, invalid)
+		return nil
+	} else if err != nil {
+		Abort(c, http.StatusInternalServerError, i18n.ErrSaveFailed)
+		return nil
+	} else if f.PhotoPrivate {
+		FlushCoverCache()
+	}
+
+	PublishPhotoEvent(EntityUpdated, m.PhotoUID, c, fields...)
+
+	event.SuccessMsg(i18n.MsgChangesSaved)
+
+	p, err := query.PhotoPreloadByUID(m.PhotoUID)
+
+	if err != nil {
+		AbortEntityNotFound(c)
+		return nil
+	}
+
+	SavePhotoAsYaml(p)
+
+	UpdateClientConfig()
+
+	c.JSON(http.StatusOK, p)
+
+	return &p
+}
+
+// GetPhotoDownload returns the primary file matching that belongs to the photo,
+// or status 410 Gone if the photo has been removed.
 //
 // Route :GET /api/v1/photos/:uid/dl
 // Params:
 // - uid (string) PhotoUID as returned by the API
+// - format (string) "mp4" to transcode videos to MPEG-4 AVC on the fly (optional)
 func GetPhotoDownload(router *gin.RouterGroup) {
 	router.GET("/photos/:uid/dl", func(c *gin.Context) {
 		if InvalidDownloadToken(c) {
@@ -132,35 +306,109 @@ func GetPhotoDownload(router *gin.RouterGroup) {
 			return
 		}
 
-		f, err := query.FileByPhotoUID(clean.UID(c.Param("uid")))
+		uid := clean.UID(c.Param("uid"))
+		f, err := query.FileByPhotoUID(uid)
 
-		if err != nil {
+		if err == nil {
+			photoFileDownload(c, f)
+		} else if deletedAt, removed := query.PhotoRemoved(uid); removed {
+			AbortRemoved(c, uid, deletedAt)
+		} else {
 			c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
+		}
+	})
+}
+
+// GetPhotoFileDownload returns a specific file that belongs to the photo, e.g. the RAW or video file of a stack.
+//
+// Route :GET /api/v1/photos/:uid/dl/:file_uid
+// Params:
+// - uid (string) PhotoUID as returned by the API
+// - file_uid (string) FileUID as returned by the API
+// - format (string) "mp4" to transcode videos to MPEG-4 AVC on the fly (optional)
+func GetPhotoFileDownload(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/dl/:file_uid", func(c *gin.Context) {
+		if InvalidDownloadToken(c) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
 			return
 		}
 
-		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+		f, err := query.FileByUID(clean.UID(c.Param("file_uid")))
 
-		if !fs.FileExists(fileName) {
-			log.Errorf("photo: file %s is missing", clean.Log(f.FileName))
+		// Make sure the file belongs to the photo.
+		if err != nil || f.PhotoUID != clean.UID(c.Param("uid")) {
 			c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
-
-			// Set missing flag so that the file doesn't show up in search results anymore.
-			logError("photo", f.Update("FileMissing", true))
-
 			return
 		}
 
-		c.FileAttachment(fileName, f.DownloadName(DownloadName(c), 0))
+		photoFileDownload(c, f)
 	})
 }
 
+// photoFileDownload sends the file as attachment, or flags it as missing if it does not exist.
+// Range requests are supported, e.g. for resuming downloads and seeking in videos. Files are served
+// inline if the "inline" query parameter is set, or for range requests of videos, i.e. playback. Videos can be downloaded as MP4 with "format=mp4".
+func photoFileDownload(c *gin.Context, f *entity.File) {
+	fileName := photoprism.FileName(f.FileRoot, f.FileName)
+
+	if !fs.FileExists(fileName) {
+		log.Errorf("photo: file %s is missing", clean.Log(f.FileName))
+		c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
+
+		// Set missing flag so that the file doesn't show up in search results anymore.
+		logError("photo", f.Update("FileMissing", true))
+
+		return
+	}
+
+	// Transcode videos to MP4 if requested, or send the original file if this is not possible.
+	if transcodeToMp4(c, f) && photoFileStreamMp4(c, f, fileName) {
+		return
+	}
+
+	// Return 304 Not Modified if the client already has the current version of the file.
+	if etag := AddETagHeader(c, f.FileHash); NotModified(c, etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	file, err := os.Open(fileName)
+
+	if err != nil {
+		log.Errorf("photo: %s in %s (download)", err, clean.Log(f.FileName))
+		c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
+		return
+	}
+
+	defer file.Close()
+
+	info, err := file.Stat()
+
+	if err != nil {
+		log.Errorf("photo: %s in %s (download)", err, clean.Log(f.FileName))
+		AbortUnexpected(c)
+		return
+	}
+
+	downloadName := f.DownloadName(DownloadName(c), 0)
+	inline := txt.Bool(c.Query("inline")) || f.FileVideo && c.GetHeader("Range") != ""
+
+	c.Header("Accept-Ranges", "bytes")
+	AddDispositionHeader(c, inline, downloadName)
+
+	// Sets the Last-Modified header and handles Range and If-Modified-Since requests based on the file modification time.
+	http.ServeContent(c.Writer, c.Request, downloadName, info.ModTime(), file)
+}
+
 // GetPhotoYaml returns photo details as YAML.
 //
 // GET /api/v1/photos/:uid/yaml
 // Params:
 //
 //	uid: string PhotoUID as returned by the API
+//	fields: string "full" (default) or "minimal" to omit technical Exif metadata
+//	refresh: bool rewrites the YAML sidecar file so that it matches the database (requires export permission),
+//	         the X-Yaml-Written response header indicates whether the file was written
 func GetPhotoYaml(router *gin.RouterGroup) {
 	router.GET("/photos/:uid/yaml", func(c *gin.Context) {
 		s := Auth(c, acl.ResourcePhotos, acl.AccessAll)
@@ -169,6 +417,21 @@ func GetPhotoYaml(router *gin.RouterGroup) {
 			return
 		}
 
+		fields, err := entity.ParseYamlFields(c.Query("fields"))
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		refresh := txt.Bool(c.Query("refresh"))
+
+		// Rewriting the sidecar file requires export permission.
+		if refresh && acl.Resources.Deny(acl.ResourcePhotos, s.User().AclRole(), acl.ActionExport) {
+			AbortForbidden(c)
+			return
+		}
+
 		p, err := query.PhotoPreloadByUID(clean.UID(c.Param("uid")))
 
 		if err != nil {
@@ -176,13 +439,17 @@ func GetPhotoYaml(router *gin.RouterGroup) {
 			return
 		}
 
-		data, err := p.Yaml()
+		data, err := p.YamlWith(fields)
 
 		if err != nil {
 			c.AbortWithStatus(http.StatusInternalServerError)
 			return
 		}
 
+		if refresh {
+			c.Header("X-Yaml-Written", strconv.FormatBool(savePhotoAsYaml(p)))
+		}
+
 		if c.Query("download") != "" {
 			AddDownloadHeader(c, clean.UID(c.Param("uid"))+fs.ExtYAML)
 		}
@@ -227,6 +494,149 @@ func ApprovePhoto(router *gin.RouterGroup) {
 	})
 }
 
+// ApprovePhotos marks multiple photos in review as approved and returns the result for each photo.
+//
+// POST /api/v1/photos/approve
+//
+// Request Body:
+//   - photos ([]string) photo UIDs to approve
+func ApprovePhotos(router *gin.RouterGroup) {
+	router.POST("/photos/approve", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.Selection
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if len(f.Photos) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		approved := make([]string, 0, len(f.Photos))
+		errs := make(map[string]string)
+		done := make(map[string]bool, len(f.Photos))
+
+		for _, uid := range f.Photos {
+			if done[uid] {
+				continue
+			}
+
+			done[uid] = true
+
+			m, err := query.PhotoByUID(clean.UID(uid))
+
+			if err != nil {
+				errs[uid] = i18n.Msg(i18n.ErrEntityNotFound)
+				continue
+			}
+
+			if err = m.Approve(); err != nil {
+				log.Errorf("photo: %s", err.Error())
+				errs[uid] = i18n.Msg(i18n.ErrSaveFailed)
+				continue
+			}
+
+			SavePhotoAsYaml(m)
+
+			PublishPhotoEvent(EntityUpdated, m.PhotoUID, c)
+
+			approved = append(approved, m.PhotoUID)
+		}
+
+		if len(approved) > 0 {
+			UpdateClientConfig()
+		}
+
+		c.JSON(http.StatusOK, gin.H{"approved": approved, "errors": errs})
+	})
+}
+
+// PhotosPrivate sets or clears the private flag of multiple photos and returns the result for each photo.
+// The cover cache is flushed once after all photos have been updated.
+//
+// POST /api/v1/photos/private
+//
+// Request Body:
+//   - photos ([]string) photo UIDs to update
+//   - private (bool) true to flag the photos as private, false to make them public
+func PhotosPrivate(router *gin.RouterGroup) {
+	router.POST("/photos/private", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.AccessPrivate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.PhotosPrivate
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if len(f.Photos) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		updated := make([]string, 0, len(f.Photos))
+		unchanged := make([]string, 0, len(f.Photos))
+		errs := make(map[string]string)
+		done := make(map[string]bool, len(f.Photos))
+
+		for _, uid := range f.Photos {
+			if done[uid] {
+				continue
+			}
+
+			done[uid] = true
+
+			m, err := query.PhotoByUID(clean.UID(uid))
+
+			if err != nil {
+				errs[uid] = i18n.Msg(i18n.ErrEntityNotFound)
+				continue
+			}
+
+			changed, err := m.SetPrivate(f.Private)
+
+			if err != nil {
+				log.Errorf("photo: %s", err.Error())
+				errs[uid] = i18n.Msg(i18n.ErrSaveFailed)
+				continue
+			} else if !changed {
+				unchanged = append(unchanged, m.PhotoUID)
+				continue
+			}
+
+			SavePhotoAsYaml(m)
+
+			PublishPhotoEvent(EntityUpdated, m.PhotoUID, c, PhotoPrivate)
+
+			updated = append(updated, m.PhotoUID)
+		}
+
+		if len(updated) > 0 {
+			// Update precalculated photo and file counts.
+			logWarn("index", entity.UpdateCounts())
+
+			UpdateClientConfig()
+
+			FlushCoverCache()
+		}
+
+		c.JSON(http.StatusOK, gin.H{"updated": updated, "unchanged": unchanged, "errors": errs})
+	})
+}
+
 // PhotoPrimary sets the primary file for a photo.
 //
 // POST /photos/:uid/files/:file_uid/primary
@@ -265,3 +675,69 @@ func PhotoPrimary(router *gin.RouterGroup) {
 		c.JSON(http.StatusOK, p)
 	})
 }
+
+// PhotoPrimaryAutoResponse represents the result of an automatic primary file selection.
+type PhotoPrimaryAutoResponse struct {
+	Photo entity.Photo `json:"Photo"`
+	File  PhotoFile    `json:"File"`
+}
+
+// PhotoPrimaryAuto automatically selects the file best suited as primary file for a photo,
+// preferring JPEG images with the highest resolution and the most complete metadata.
+//
+// POST /photos/:uid/files/primary/auto
+// Params:
+//
+//	uid: string PhotoUID as returned by the API
+func PhotoPrimaryAuto(router *gin.RouterGroup) {
+	router.POST("/photos/:uid/files/primary/auto", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+
+		if _, err := query.PhotoByUID(uid); err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		files, err := query.FilesByPhotoUID(uid)
+
+		if err != nil {
+			log.Errorf("files: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		f := files.BestPrimary()
+
+		if f == nil {
+			Abort(c, http.StatusNotFound, i18n.ErrFileNotFound)
+			return
+		}
+
+		if err = query.SetPhotoPrimary(uid, f.FileUID); err != nil {
+			log.Errorf("files: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		f.FilePrimary = true
+
+		PublishPhotoEvent(EntityUpdated, uid, c)
+
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		p, err := query.PhotoPreloadByUID(uid)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, PhotoPrimaryAutoResponse{Photo: p, File: NewPhotoFile(*f)})
+	})
+}
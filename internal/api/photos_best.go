@@ -0,0 +1,103 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// BestOfPhotos selects the pictures with the highest composite score based on quality, resolution,
+// faces, and favorites within a date range, and optionally creates an album from them.
+//
+// POST /api/v1/photos/best
+//
+// Request Body:
+//   - from, to (string) date range in the format "2006-01-02" or RFC 3339
+//   - album (string) optional album UID to select from
+//   - count (int) number of pictures to select, default 25
+//   - weights (object) optional scoring weights, overriding the configured defaults
+//   - createAlbum (bool) creates a new album with the selected pictures
+//   - title (string) title of the new album
+func BestOfPhotos(router *gin.RouterGroup) {
+	router.POST("/photos/best", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionSearch)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.BestOf
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		from, to, err := f.Range()
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		// Creating an album requires additional permissions.
+		if f.CreateAlbum && acl.Resources.Deny(acl.ResourceAlbums, s.User().AclRole(), acl.ActionCreate) {
+			AbortForbidden(c)
+			return
+		}
+
+		weights := search.NewBestOfWeights(get.Config().BestOfWeights()).Merge(f.Weights)
+
+		photos, err := search.BestOf(from, to, f.Album, f.Limit(), weights, s)
+
+		if err != nil {
+			log.Warnf("best of: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		result := gin.H{"photos": photos, "weights": weights}
+
+		if f.CreateAlbum && len(photos) > 0 {
+			title := clean.Name(f.Title)
+
+			if title == "" {
+				title = "Best Of"
+			}
+
+			albumMutex.Lock()
+			defer albumMutex.Unlock()
+
+			a := entity.NewUserAlbum(title, entity.AlbumManual, s.UserUID)
+
+			if err = a.Create(); err != nil {
+				log.Errorf("best of: %s (create album)", err)
+				AbortUnexpected(c)
+				return
+			}
+
+			a.AddPhotos(photos.UIDs())
+
+			UpdateClientConfig()
+
+			PublishAlbumEvent(EntityCreated, a.AlbumUID, c)
+
+			// Update album YAML backup.
+			SaveAlbumAsYaml(*a)
+
+			result["album"] = a
+		}
+
+		AddCountHeader(c, len(photos))
+		AddLimitHeader(c, f.Limit())
+
+		c.JSON(http.StatusOK, result)
+	})
+}
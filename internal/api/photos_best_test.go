@@ -0,0 +1,80 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestBestOfPhotos(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BestOfPhotos(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/best", `{"count": 5}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		count := gjson.Get(r.Body.String(), "photos.#").Int()
+
+		assert.GreaterOrEqual(t, count, int64(1))
+		assert.LessOrEqual(t, count, int64(5))
+		assert.Equal(t, fmt.Sprintf("%d", count), r.Header().Get("X-Count"))
+		assert.Equal(t, "5", r.Header().Get("X-Limit"))
+		assert.Equal(t, 3.0, gjson.Get(r.Body.String(), "weights.Favorite").Float())
+		assert.False(t, gjson.Get(r.Body.String(), "album").Exists())
+	})
+	t.Run("Weights", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BestOfPhotos(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/best", `{"count": 3, "weights": {"faces": 5, "favorite": 0}}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, 2.0, gjson.Get(r.Body.String(), "weights.Quality").Float())
+		assert.Equal(t, 5.0, gjson.Get(r.Body.String(), "weights.Faces").Float())
+		assert.Equal(t, 0.0, gjson.Get(r.Body.String(), "weights.Favorite").Float())
+	})
+	t.Run("CreateAlbum", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BestOfPhotos(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/best", `{"from": "2000-01-01", "to": "2030-12-31", "count": 2, "createAlbum": true, "title": "Best Of Test"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		uid := gjson.Get(r.Body.String(), "album.UID").String()
+
+		assert.NotEmpty(t, uid)
+		assert.Equal(t, "Best Of Test", gjson.Get(r.Body.String(), "album.Title").String())
+
+		a := entity.FindAlbum(entity.Album{AlbumUID: uid})
+
+		if a == nil {
+			t.Fatal("album not found")
+		}
+
+		var count int
+
+		if err := entity.Db().Model(&entity.PhotoAlbum{}).Where("album_uid = ? AND hidden = 0", uid).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, int(gjson.Get(r.Body.String(), "photos.#").Int()), count)
+
+		if err := a.DeletePermanently(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("InvalidRange", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BestOfPhotos(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/best", `{"from": "2020-12-31", "to": "2020-01-01"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BestOfPhotos(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/best", `{"count": "abc"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
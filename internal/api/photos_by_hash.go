@@ -0,0 +1,51 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// GetPhotoByHash returns the photo that a file with the content hash belongs to as JSON,
+// e.g. to check if a file has already been indexed.
+//
+// GET /api/v1/photos/by-hash/:hash
+//
+// Parameters:
+//
+//	hash: string SHA1 file hash as returned by the API
+func GetPhotoByHash(router *gin.RouterGroup) {
+	router.GET("/photos/by-hash/:hash", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		hash := clean.Hex(c.Param("hash"))
+
+		if hash == "" {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		p, err := query.PhotoByFileHash(hash)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		// Visitors can only access photos in shared albums.
+		if (s.IsVisitor() || s.NotRegistered()) && !query.PhotoShared(p.PhotoUID, s.SharedUIDs()) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		c.IndentedJSON(http.StatusOK, p)
+	})
+}
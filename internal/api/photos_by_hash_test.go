@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetPhotoByHash(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoByHash(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/by-hash/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", gjson.Get(r.Body.String(), "UID").String())
+		assert.True(t, gjson.Get(r.Body.String(), "Labels.#").Int() > 0)
+	})
+	t.Run("UpperCase", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoByHash(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/by-hash/2CAD9168FA6ACC5C5C2965DDF6EC465CA42FD818")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", gjson.Get(r.Body.String(), "UID").String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoByHash(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/by-hash/0000000000000000000000000000000000000000")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidHash", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoByHash(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/by-hash/xyz")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// GetPhotoClip returns a trimmed copy of a video as attachment, e.g. to download a segment of a long video.
+//
+// GET /api/v1/photos/:uid/clip
+//
+// Parameters:
+//
+//	uid: string PhotoUID as returned by the API
+//	start: float Start of the clip in seconds
+//	end: float End of the clip in seconds
+//	t: string Download token (optional)
+func GetPhotoClip(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/clip", func(c *gin.Context) {
+		// Links with a valid download token can be opened without a session.
+		if c.Query("t") != "" {
+			if InvalidDownloadToken(c) {
+				c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+				return
+			}
+		} else if s := Auth(c, acl.ResourcePhotos, acl.ActionDownload); s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if !conf.Settings().Features.Download {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		start, startErr := strconv.ParseFloat(c.Query("start"), 64)
+		end, endErr := strconv.ParseFloat(c.Query("end"), 64)
+
+		if startErr != nil || endErr != nil || start < 0 || end <= start {
+			AbortBadRequest(c)
+			return
+		}
+
+		startOffset := time.Duration(start * float64(time.Second))
+		endOffset := time.Duration(end * float64(time.Second))
+
+		f, err := query.VideoByPhotoUID(clean.UID(c.Param("uid")))
+
+		if err != nil || !f.FileVideo {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		// Round offsets and limit them to the video duration.
+		if startOffset, endOffset, err = photoprism.ClipRange(startOffset, endOffset, f.FileDuration); err != nil {
+			AbortBadRequest(c)
+			return
+		} else if !conf.FFmpegEnabled() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		mf, err := photoprism.NewMediaFile(photoprism.FileName(f.FileRoot, f.FileName))
+
+		if err != nil {
+			log.Errorf("clip: file %s is missing", clean.Log(f.FileName))
+			AbortEntityNotFound(c)
+			return
+		}
+
+		clipName, err := get.Convert().VideoClip(mf, f.FileHash, f.FileCodec, startOffset, endOffset, f.FileDuration)
+
+		if err != nil {
+			log.Errorf("clip: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		downloadName := f.DownloadName(DownloadName(c), 0)
+		downloadName = strings.TrimSuffix(downloadName, filepath.Ext(downloadName)) + "_" +
+			strconv.FormatFloat(startOffset.Seconds(), 'f', -1, 64) + "-" + strconv.FormatFloat(endOffset.Seconds(), 'f', -1, 64) + fs.ExtMP4
+
+		c.FileAttachment(clipName, downloadName)
+	})
+}
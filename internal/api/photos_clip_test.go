@@ -0,0 +1,96 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func TestGetPhotoClip(t *testing.T) {
+	app, router, conf := NewApiTest()
+	GetPhotoClip(router)
+
+	photo := &entity.Photo{PhotoTitle: "Video Clip", PhotoType: entity.MediaVideo}
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _, _ = photo.DeletePermanently() }()
+
+	fileName := "clip/" + photo.PhotoUID + ".mp4"
+	filePath := filepath.Join(conf.OriginalsPath(), fileName)
+
+	if err := os.MkdirAll(filepath.Dir(filePath), fs.ModeDir); err != nil {
+		t.Fatal(err)
+	} else if err = fs.Copy(filepath.Join(conf.ExamplesPath(), "gopher-video.mp4"), filePath); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = os.Remove(filePath) }()
+
+	file := &entity.File{
+		PhotoID:      photo.ID,
+		PhotoUID:     photo.PhotoUID,
+		FileRoot:     entity.RootOriginals,
+		FileName:     fileName,
+		FileHash:     rnd.GenerateUID('h'),
+		FileType:     fs.VideoMP4.String(),
+		FileCodec:    "avc1",
+		FileVideo:    true,
+		FileDuration: 2410 * time.Millisecond,
+		FilePrimary:  true,
+	}
+
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		if !conf.FFmpegEnabled() {
+			t.Skip("ffmpeg is not available")
+		}
+
+		r := PerformRequest(app, "GET", "/api/v1/photos/"+photo.PhotoUID+"/clip?start=0.5&end=1.5")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Header().Get("Content-Disposition"), "_0.5-1.5.mp4")
+		assert.NotEmpty(t, r.Body.Bytes())
+	})
+	t.Run("EndBeforeStart", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/photos/"+photo.PhotoUID+"/clip?start=2&end=1")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("EndAfterDuration", func(t *testing.T) {
+		if !conf.FFmpegEnabled() {
+			t.Skip("ffmpeg is not available")
+		}
+
+		r := PerformRequest(app, "GET", "/api/v1/photos/"+photo.PhotoUID+"/clip?start=1&end=5")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Header().Get("Content-Disposition"), "_1-2.41.mp4")
+	})
+	t.Run("StartAfterDuration", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/photos/"+photo.PhotoUID+"/clip?start=3&end=5")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("MissingRange", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/photos/"+photo.PhotoUID+"/clip")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yxx/clip?start=0&end=1")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidToken", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/photos/"+photo.PhotoUID+"/clip?start=0&end=1&t=xxx")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// PhotoComparison represents the perceptual difference between two photos.
+type PhotoComparison struct {
+	UID        string  `json:"UID"`
+	Other      string  `json:"Other"`
+	Distance   int     `json:"Distance"`
+	Similarity float64 `json:"Similarity"`
+}
+
+// ComparePhotos returns the similarity of two photos based on the perceptual hash of their primary files.
+//
+// GET /api/v1/photos/:uid/compare/:other
+//
+// Parameters:
+//
+//	uid: string PhotoUID as returned by the API
+//	other: string PhotoUID of the photo to compare with
+func ComparePhotos(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/compare/:other", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+		other := clean.UID(c.Param("other"))
+
+		a, err := query.FileByPhotoUID(uid)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		b, err := query.FileByPhotoUID(other)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		// Perceptual hashes only depend on the file content, so the distance can be cached by file hash.
+		cache := get.ThumbCache()
+		cacheKey := CacheKey("compare", a.FileHash, b.FileHash)
+
+		if a.FileHash > b.FileHash {
+			cacheKey = CacheKey("compare", b.FileHash, a.FileHash)
+		}
+
+		var distance int
+
+		if cacheData, ok := cache.Get(cacheKey); ok {
+			distance = cacheData.(int)
+		} else if hashA, err := perceptualHash(a); err != nil {
+			log.Errorf("compare: %s", err)
+			AbortEntityNotFound(c)
+			return
+		} else if hashB, err := perceptualHash(b); err != nil {
+			log.Errorf("compare: %s", err)
+			AbortEntityNotFound(c)
+			return
+		} else {
+			distance = thumb.HammingDistance(hashA, hashB)
+			cache.SetDefault(cacheKey, distance)
+		}
+
+		c.JSON(http.StatusOK, PhotoComparison{
+			UID:        uid,
+			Other:      other,
+			Distance:   distance,
+			Similarity: thumb.DistanceSimilarity(distance),
+		})
+	})
+}
+
+// perceptualHash returns the perceptual hash of a file based on its thumbnail.
+func perceptualHash(f *entity.File) (uint64, error) {
+	mf, err := photoprism.NewMediaFile(photoprism.FileName(f.FileRoot, f.FileName))
+
+	if err != nil {
+		return 0, err
+	}
+
+	img, err := mf.Resample(get.Config().ThumbCachePath(), thumb.Tile224)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return thumb.PerceptualHash(img), nil
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+func TestComparePhotos(t *testing.T) {
+	t.Run("Cached", func(t *testing.T) {
+		a, err := query.FileByPhotoUID("pt9jtdre2lvl0yh7")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := query.FileByPhotoUID("pt9jtdre2lvl0y11")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The fixture originals do not exist, so the result must be cached.
+		if a.FileHash < b.FileHash {
+			get.ThumbCache().SetDefault(CacheKey("compare", a.FileHash, b.FileHash), 16)
+		} else {
+			get.ThumbCache().SetDefault(CacheKey("compare", b.FileHash, a.FileHash), 16)
+		}
+
+		app, router, _ := NewApiTest()
+		ComparePhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/compare/pt9jtdre2lvl0y11")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", gjson.Get(r.Body.String(), "UID").String())
+		assert.Equal(t, "pt9jtdre2lvl0y11", gjson.Get(r.Body.String(), "Other").String())
+		assert.Equal(t, int64(16), gjson.Get(r.Body.String(), "Distance").Int())
+		assert.Equal(t, float64(75), gjson.Get(r.Body.String(), "Similarity").Float())
+
+		// The order of the photos does not matter.
+		r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y11/compare/pt9jtdre2lvl0yh7")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(16), gjson.Get(r.Body.String(), "Distance").Int())
+	})
+	t.Run("FileMissing", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ComparePhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/compare/pt9jtdre2lvl0yh8")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ComparePhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/compare/xxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+		r = PerformRequest(app, "GET", "/api/v1/photos/xxx/compare/pt9jtdre2lvl0yh7")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// DocumentResult contains the number of photos and thumbnails updated when marking photos as documents.
+type DocumentResult struct {
+	Photos int `json:"photos"`
+	Thumbs int `json:"thumbs"`
+}
+
+// PhotosDocument sets the type of multiple photos to document, so that their thumbnails are
+// filled from the top left corner and they are no longer shown on maps or used as album covers.
+//
+// POST /api/v1/photos/document
+func PhotosDocument(router *gin.RouterGroup) {
+	router.POST("/photos/document", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.Selection
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if len(f.Photos) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		log.Infof("photos: marking %s as documents", clean.Log(f.String()))
+
+		var result DocumentResult
+
+		if err := entity.Db().Transaction(func(tx *gorm.DB) error {
+			res := tx.Model(entity.Photo{}).Where("photo_uid IN (?) AND deleted_at IS NULL", f.Photos).
+				UpdateColumns(entity.Values{"photo_type": entity.MediaDocument, "type_src": entity.SrcManual})
+
+			result.Photos = int(res.RowsAffected)
+
+			return res.Error
+		}); err != nil {
+			log.Errorf("document: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		conf := get.Config()
+
+		// Fetch selection from index.
+		if photos, err := query.SelectedPhotos(f); err == nil {
+			for _, p := range photos {
+				result.Thumbs += reframeDocument(p.PhotoUID, conf.ThumbCachePath())
+				SavePhotoAsYaml(p)
+			}
+
+			event.EntitiesUpdated("photos", photos)
+		}
+
+		// Documents can no longer be used as album covers.
+		logWarn("index", query.UpdateCovers())
+
+		FlushCoverCache()
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// reframeDocument recreates the tile thumbnails of a document and returns their number.
+func reframeDocument(photoUID, thumbPath string) int {
+	f, err := query.FileByPhotoUID(photoUID)
+
+	if err != nil {
+		log.Debugf("document: %s in %s (find primary file)", err, clean.Log(photoUID))
+		return 0
+	}
+
+	mf, err := photoprism.NewMediaFile(photoprism.FileName(f.FileRoot, f.FileName))
+
+	if err != nil {
+		log.Debugf("document: %s in %s (reframe thumbnails)", err, clean.Log(f.FileName))
+		return 0
+	}
+
+	count, err := mf.ReframeThumbnails(thumbPath, thumb.ResampleFillTopLeft)
+
+	if err != nil {
+		log.Warnf("document: %s in %s (reframe thumbnails)", err, clean.Log(f.FileName))
+	}
+
+	return count
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
+)
+
+func TestPhotosDocument(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetPhoto(router)
+		PhotosDocument(router)
+
+		defer func() {
+			_ = entity.Db().Model(entity.Photo{}).Where("photo_uid IN (?)", []string{"pt9jtdre2lvl0y14", "pt9jtdre2lvl0y15"}).
+				UpdateColumns(entity.Values{"photo_type": entity.MediaImage, "type_src": entity.SrcAuto}).Error
+		}()
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/document", `{"photos": ["pt9jtdre2lvl0y14", "pt9jtdre2lvl0y15"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(2), gjson.Get(r.Body.String(), "photos").Int())
+		assert.True(t, gjson.Get(r.Body.String(), "thumbs").Exists())
+
+		r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y14")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, entity.MediaDocument, gjson.Get(r.Body.String(), "Type").String())
+		assert.Equal(t, entity.SrcManual, gjson.Get(r.Body.String(), "TypeSrc").String())
+	})
+	t.Run("NoItemsSelected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PhotosDocument(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/document", `{"photos": []}`)
+		assert.Equal(t, i18n.Msg(i18n.ErrNoItemsSelected), gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PhotosDocument(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/document", `{"photos": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
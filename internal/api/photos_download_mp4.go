@@ -0,0 +1,150 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/ffmpeg"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/photoprism/photoprism/pkg/video"
+)
+
+// transcodeToMp4 checks if the download of the file was requested as MP4 and requires transcoding.
+func transcodeToMp4(c *gin.Context, f *entity.File) bool {
+	if clean.TypeLower(c.Query("format")) != fs.VideoMP4.String() {
+		return false
+	} else if !f.FileVideo && f.MediaType != entity.MediaVideo {
+		return false
+	}
+
+	// Videos that are already MPEG-4 AVC can be sent as they are.
+	return f.FileType != fs.VideoMP4.String() || video.Codecs[strings.ToLower(clean.Codec(f.FileCodec))] != video.CodecAVC
+}
+
+// streamMp4Slots limits the number of videos that are transcoded for streaming at the same time,
+// so that it does not use up all resources like the convert worker.
+var (
+	streamMp4Once  sync.Once
+	streamMp4Slots chan struct{}
+)
+
+// streamMp4Slot returns the channel used to limit concurrent transcoding.
+func streamMp4Slot() chan struct{} {
+	streamMp4Once.Do(func() {
+		streamMp4Slots = make(chan struct{}, get.Config().Workers())
+	})
+
+	return streamMp4Slots
+}
+
+// photoFileStreamMp4 sends the video transcoded to MPEG-4 AVC with AAC audio while it is being encoded.
+// It returns false if nothing has been sent yet because transcoding is disabled or failed, so that
+// the original file can be sent instead. If too many videos are transcoded already, it aborts with
+// status 429 and returns true.
+func photoFileStreamMp4(c *gin.Context, f *entity.File, fileName string) bool {
+	conf := get.Config()
+
+	if !conf.FFmpegEnabled() {
+		log.Debugf("photo: cannot transcode %s to mp4, ffmpeg is disabled", clean.Log(f.FileName))
+		return false
+	}
+
+	mf, err := photoprism.NewMediaFile(fileName)
+
+	if err != nil {
+		log.Errorf("photo: %s in %s (transcode)", err, clean.Log(f.FileName))
+		return false
+	}
+
+	opt, err := conf.FFmpegOptions(conf.FFmpegEncoder(), get.Convert().AvcBitrate(mf))
+
+	if err != nil {
+		log.Errorf("photo: %s in %s (transcode)", err, clean.Log(f.FileName))
+		return false
+	}
+
+	// Don't transcode more videos at the same time than there are workers.
+	select {
+	case streamMp4Slot() <- struct{}{}:
+		defer func() { <-streamMp4Slot() }()
+	default:
+		log.Warnf("photo: too many videos are being transcoded, cannot stream %s", clean.Log(f.FileName))
+		AbortBusy(c)
+		return true
+	}
+
+	// Stop ffmpeg if the client closes the connection.
+	cmd := exec.CommandContext(c.Request.Context(), opt.Bin, ffmpeg.StreamArgs(fileName, opt)...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Env = []string{fmt.Sprintf("HOME=%s", conf.CmdCachePath())}
+
+	stdout, err := cmd.StdoutPipe()
+
+	if err != nil {
+		log.Errorf("photo: %s in %s (transcode)", err, clean.Log(f.FileName))
+		return false
+	}
+
+	// Log exact command for debugging in trace mode.
+	log.Trace(cmd.String())
+
+	if err = cmd.Start(); err != nil {
+		log.Errorf("photo: %s in %s (transcode)", err, clean.Log(f.FileName))
+		return false
+	}
+
+	// Wait for the first chunk, so that the original can still be sent if ffmpeg fails.
+	buf := make([]byte, 32*1024)
+	n, err := io.ReadAtLeast(stdout, buf, 1)
+
+	if n == 0 {
+		_ = cmd.Wait()
+
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = errors.New(msg)
+		}
+
+		log.Errorf("photo: %s in %s (transcode)", err, clean.Log(f.FileName))
+
+		return false
+	}
+
+	downloadName := f.DownloadName(DownloadName(c), 0)
+	downloadName = strings.TrimSuffix(downloadName, filepath.Ext(downloadName)) + fs.ExtMP4
+
+	c.Header("Content-Type", fs.MimeTypeMP4)
+	c.Header("Accept-Ranges", "none")
+	AddDispositionHeader(c, txt.Bool(c.Query("inline")), downloadName)
+	c.Status(http.StatusOK)
+
+	if _, err = c.Writer.Write(buf[:n]); err == nil {
+		_, err = io.Copy(c.Writer, stdout)
+	}
+
+	if err != nil {
+		log.Debugf("photo: %s while sending %s (transcode)", err, clean.Log(f.FileName))
+		_ = cmd.Process.Kill()
+	}
+
+	if err = cmd.Wait(); err != nil && c.Request.Context().Err() == nil {
+		log.Warnf("photo: %s in %s (transcode)", err, clean.Log(f.FileName))
+	}
+
+	return true
+}
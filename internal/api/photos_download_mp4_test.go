@@ -0,0 +1,142 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestTranscodeToMp4(t *testing.T) {
+	ctx := func(query string) *gin.Context {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/photos/pt9jtdre2lvl0y17/dl"+query, nil)
+		return c
+	}
+
+	mov := &entity.File{FileType: "mov", FileCodec: "hvc1", FileVideo: true, MediaType: entity.MediaVideo}
+	avc := &entity.File{FileType: "mp4", FileCodec: "avc1", FileVideo: true, MediaType: entity.MediaVideo}
+	jpg := &entity.File{FileType: "jpg", MediaType: entity.MediaImage}
+
+	assert.True(t, transcodeToMp4(ctx("?format=mp4"), mov))
+	assert.True(t, transcodeToMp4(ctx("?format=MP4"), mov))
+	assert.False(t, transcodeToMp4(ctx(""), mov))
+	assert.False(t, transcodeToMp4(ctx("?format=avi"), mov))
+	assert.False(t, transcodeToMp4(ctx("?format=mp4"), avc))
+	assert.False(t, transcodeToMp4(ctx("?format=mp4"), jpg))
+}
+
+func TestGetPhotoFileDownloadMp4(t *testing.T) {
+	t.Run("AlreadyMp4", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetPhotoFileDownload(router)
+
+		f := entity.FileFixtures.Get("Video.mp4")
+		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+
+		if fs.FileExists(fileName) {
+			t.Skipf("%s already exists", fileName)
+		} else if err := os.MkdirAll(filepath.Dir(fileName), fs.ModeDir); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(fileName, []byte("stacked video"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		r := PerformRequest(app, "GET", "/api/v1/photos/"+f.PhotoUID+"/dl/"+f.FileUID+"?format=mp4&t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, `"`+f.FileHash+`"`, r.Header().Get("ETag"))
+		assert.Equal(t, "stacked video", r.Body.String())
+	})
+	t.Run("Fallback", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetPhotoFileDownload(router)
+
+		photo := entity.Photo{PhotoTitle: "Transcode Fallback", PhotoType: entity.MediaVideo}
+
+		if err := photo.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _, _ = photo.DeletePermanently() }()
+
+		f := entity.File{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FileName: "transcode/fallback.mov", FileRoot: entity.RootOriginals, FileHash: "transcodefallback", FileType: "mov", FileCodec: "hvc1", FileVideo: true, MediaType: entity.MediaVideo}
+
+		if err := f.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+
+		if err := os.MkdirAll(filepath.Dir(fileName), fs.ModeDir); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(fileName, []byte("not a video"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(filepath.Dir(fileName))
+
+		// The original is sent if ffmpeg is disabled or cannot transcode the file.
+		r := PerformRequest(app, "GET", "/api/v1/photos/"+photo.PhotoUID+"/dl/"+f.FileUID+"?format=mp4&t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "not a video", r.Body.String())
+		assert.Contains(t, r.Header().Get("Content-Disposition"), ".mov")
+	})
+	t.Run("Busy", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetPhotoFileDownload(router)
+
+		if !conf.FFmpegEnabled() {
+			t.Skip("ffmpeg is not available")
+		}
+
+		photo := entity.Photo{PhotoTitle: "Transcode Busy", PhotoType: entity.MediaVideo}
+
+		if err := photo.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _, _ = photo.DeletePermanently() }()
+
+		f := entity.File{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FileName: "transcode/busy.mov", FileRoot: entity.RootOriginals, FileHash: "transcodebusy", FileType: "mov", FileCodec: "hvc1", FileVideo: true, MediaType: entity.MediaVideo}
+
+		if err := f.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+
+		if err := os.MkdirAll(filepath.Dir(fileName), fs.ModeDir); err != nil {
+			t.Fatal(err)
+		} else if err = fs.Copy(filepath.Join(conf.ExamplesPath(), "gopher-video.mp4"), fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(filepath.Dir(fileName))
+
+		// Occupy all transcoding slots.
+		slots := streamMp4Slot()
+
+		for i := 0; i < cap(slots); i++ {
+			slots <- struct{}{}
+		}
+
+		defer func() {
+			for i := 0; i < cap(slots); i++ {
+				<-slots
+			}
+		}()
+
+		r := PerformRequest(app, "GET", "/api/v1/photos/"+photo.PhotoUID+"/dl/"+f.FileUID+"?format=mp4&t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusTooManyRequests, r.Code)
+	})
+}
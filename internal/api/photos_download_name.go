@@ -0,0 +1,63 @@
+package api
+
+import (
+	"net/http"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/customize"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// PhotoDownloadName represents the file name used when downloading the primary file of a photo.
+type PhotoDownloadName struct {
+	UID     string                 `json:"UID"`
+	FileUID string                 `json:"FileUID"`
+	Type    customize.DownloadName `json:"Type"`
+	Name    string                 `json:"Name"`
+	Base    string                 `json:"Base"`
+	Ext     string                 `json:"Ext"`
+}
+
+// GetPhotoDownloadName returns the name of the file that is downloaded for a photo,
+// so that clients can avoid name collisions before downloading it.
+//
+// GET /api/v1/photos/:uid/download-name
+//
+// Parameters:
+//
+//	uid: string PhotoUID as returned by the API
+//	name: string "file", "share", or "original" to override the download name setting
+func GetPhotoDownloadName(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/download-name", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionDownload)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+		f, err := query.FileByPhotoUID(uid)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		nameType := DownloadName(c)
+		name := f.DownloadName(nameType, 0)
+
+		c.JSON(http.StatusOK, PhotoDownloadName{
+			UID:     uid,
+			FileUID: f.FileUID,
+			Type:    nameType,
+			Name:    name,
+			Base:    fs.StripExt(name),
+			Ext:     filepath.Ext(name),
+		})
+	})
+}
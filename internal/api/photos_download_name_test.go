@@ -0,0 +1,48 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetPhotoDownloadName(t *testing.T) {
+	t.Run("File", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoDownloadName(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y11/download-name?name=file")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "pt9jtdre2lvl0y11", gjson.Get(r.Body.String(), "UID").String())
+		assert.Equal(t, "ft2es39w45bnlqdw", gjson.Get(r.Body.String(), "FileUID").String())
+		assert.Equal(t, "file", gjson.Get(r.Body.String(), "Type").String())
+		assert.Equal(t, "bridge.jpg", gjson.Get(r.Body.String(), "Name").String())
+		assert.Equal(t, "bridge", gjson.Get(r.Body.String(), "Base").String())
+		assert.Equal(t, ".jpg", gjson.Get(r.Body.String(), "Ext").String())
+	})
+	t.Run("Share", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoDownloadName(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y11/download-name?name=share")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "share", gjson.Get(r.Body.String(), "Type").String())
+		assert.Equal(t, gjson.Get(r.Body.String(), "Base").String()+".jpg", gjson.Get(r.Body.String(), "Name").String())
+		assert.Equal(t, ".jpg", gjson.Get(r.Body.String(), "Ext").String())
+	})
+	t.Run("Path", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoDownloadName(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y11/download-name?name=path")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "path", gjson.Get(r.Body.String(), "Type").String())
+		assert.Equal(t, "Germany/bridge.jpg", gjson.Get(r.Body.String(), "Name").String())
+		assert.Equal(t, ".jpg", gjson.Get(r.Body.String(), "Ext").String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoDownloadName(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0xxx/download-name")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
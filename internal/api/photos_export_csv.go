@@ -0,0 +1,139 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/report"
+)
+
+// ContentTypeCsv is the media type of comma-separated values.
+const ContentTypeCsv = "text/csv; charset=utf-8"
+
+// ExportPhotosCsv returns the metadata of selected pictures as a single CSV file.
+//
+// POST /api/v1/photos/export-csv
+//
+// Request Body:
+//   - photos ([]string) photo UIDs to export
+//   - fields ([]string) optional field names: uid, title, takenAt, camera, lat, lng, keywords, size
+func ExportPhotosCsv(router *gin.RouterGroup) {
+	router.POST("/photos/export-csv", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionExport)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.ExportCsv
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if len(f.Photos) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		} else if len(f.Photos) > form.ExportCsvMaxPhotos {
+			AbortBadRequest(c)
+			return
+		}
+
+		cols, err := f.Columns()
+
+		if err != nil {
+			log.Debugf("export: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		rows := make([][]string, 0, len(f.Photos))
+
+		for _, uid := range f.Photos {
+			p, err := query.PhotoPreloadByUID(clean.UID(uid))
+
+			if err != nil {
+				log.Debugf("export: %s in %s", err, clean.Log(uid))
+				continue
+			}
+
+			rows = append(rows, PhotoCsvRow(p, cols))
+		}
+
+		data, err := report.CsvExport(rows, cols, ',')
+
+		if err != nil {
+			log.Errorf("export: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		AddCountHeader(c, len(rows))
+		AddDownloadHeader(c, fmt.Sprintf("photoprism-metadata-%s.csv", time.Now().Format("20060102-150405")))
+
+		c.Data(http.StatusOK, ContentTypeCsv, []byte(data))
+	})
+}
+
+// PhotoCsvRow returns the values of the specified metadata fields, see form.ExportCsvFields.
+func PhotoCsvRow(p entity.Photo, cols []string) []string {
+	row := make([]string, len(cols))
+
+	for i, col := range cols {
+		switch col {
+		case "uid":
+			row[i] = p.PhotoUID
+		case "title":
+			row[i] = CsvText(p.PhotoTitle)
+		case "takenAt":
+			row[i] = p.TakenAt.UTC().Format(time.RFC3339)
+		case "camera":
+			if p.Camera != nil && !p.Camera.Unknown() {
+				row[i] = CsvText(p.Camera.CameraName)
+			}
+		case "lat":
+			if p.HasLatLng() {
+				row[i] = strconv.FormatFloat(float64(p.PhotoLat), 'f', -1, 32)
+			}
+		case "lng":
+			if p.HasLatLng() {
+				row[i] = strconv.FormatFloat(float64(p.PhotoLng), 'f', -1, 32)
+			}
+		case "keywords":
+			if p.Details != nil {
+				row[i] = CsvText(p.Details.Keywords)
+			}
+		case "size":
+			for _, file := range p.Files {
+				if file.FilePrimary {
+					row[i] = strconv.FormatInt(file.FileSize, 10)
+					break
+				}
+			}
+		}
+	}
+
+	return row
+}
+
+// CsvText prefixes text values with a single quote if they start with a character that spreadsheet
+// applications interpret as the beginning of a formula, so that opening an export cannot run formulas.
+func CsvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+
+	return s
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestExportPhotosCsv(t *testing.T) {
+	t.Run("Fields", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ExportPhotosCsv(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/export-csv", `{"photos": ["pt9jtdre2lvl0y11", "pt9jtdre2lvl0yh8"], "fields": ["uid", "takenAt", "camera", "lat", "lng"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, ContentTypeCsv, r.Header().Get("Content-Type"))
+		assert.Contains(t, r.Header().Get("Content-Disposition"), "attachment; filename=photoprism-metadata-")
+		assert.Equal(t, "2", r.Header().Get("X-Count"))
+
+		lines := strings.Split(strings.TrimSpace(r.Body.String()), "\n")
+
+		if assert.Len(t, lines, 3) {
+			assert.Equal(t, "uid,takenAt,camera,lat,lng", lines[0])
+			assert.Equal(t, "pt9jtdre2lvl0y11,2014-07-17T15:42:12Z,Canon EOS 6D,,", lines[1])
+			assert.Equal(t, "pt9jtdre2lvl0yh8,2006-01-01T02:00:00Z,Canon EOS 6D,48.519234,9.057997", lines[2])
+		}
+	})
+	t.Run("AllFields", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ExportPhotosCsv(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/export-csv", `{"photos": ["pt9jtdre2lvl0y12", "pt9jtdre2lvl0xxx"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		lines := strings.Split(strings.TrimSpace(r.Body.String()), "\n")
+
+		if assert.Len(t, lines, 2) {
+			assert.Equal(t, strings.Join(form.ExportCsvFields, ","), lines[0])
+			assert.Equal(t, "pt9jtdre2lvl0y12,Reunion,2015-11-01T00:00:00Z,Canon EOS 6D,-21.342636,55.466946,,81858", lines[1])
+		}
+	})
+	t.Run("InvalidField", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ExportPhotosCsv(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/export-csv", `{"photos": ["pt9jtdre2lvl0y12"], "fields": ["uid", "password"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NoItemsSelected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ExportPhotosCsv(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/export-csv", `{"photos": []}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ExportPhotosCsv(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/export-csv", `{"photos": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestPhotoCsvRow(t *testing.T) {
+	p := entity.PhotoFixtures.Get("Photo04")
+	p.Details = &entity.Details{Keywords: "bridge, nature"}
+	p.Files = []entity.File{{FileSize: 500}, {FileSize: 961858, FilePrimary: true}}
+
+	row := PhotoCsvRow(p, []string{"title", "keywords", "size", "camera"})
+
+	assert.Equal(t, []string{"Neckarbrücke", "bridge, nature", "961858", "Canon EOS 6D"}, row)
+
+	p.PhotoTitle = "=cmd|' /C calc'!A0"
+	p.Details = &entity.Details{Keywords: "@SUM(1+1)"}
+
+	row = PhotoCsvRow(p, []string{"title", "keywords"})
+
+	assert.Equal(t, []string{"'=cmd|' /C calc'!A0", "'@SUM(1+1)"}, row)
+}
+
+func TestCsvText(t *testing.T) {
+	t.Run("Formulas", func(t *testing.T) {
+		assert.Equal(t, "'=HYPERLINK(\"https://example.com\")", CsvText("=HYPERLINK(\"https://example.com\")"))
+		assert.Equal(t, "'+1+2", CsvText("+1+2"))
+		assert.Equal(t, "'-1+2", CsvText("-1+2"))
+		assert.Equal(t, "'@SUM(A1:A2)", CsvText("@SUM(A1:A2)"))
+		assert.Equal(t, "'\t=1", CsvText("\t=1"))
+	})
+	t.Run("Text", func(t *testing.T) {
+		assert.Equal(t, "", CsvText(""))
+		assert.Equal(t, "Neckarbrücke", CsvText("Neckarbrücke"))
+		assert.Equal(t, "a=b", CsvText("a=b"))
+	})
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"archive/zip"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// ContentTypeZip is the media type of zip archives.
+const ContentTypeZip = "application/zip"
+
+// ExportYamlMaxPhotos limits the number of pictures that can be exported as YAML at once.
+const ExportYamlMaxPhotos = 10000
+
+// ExportPhotosYaml streams a zip archive that contains the YAML sidecar data of the selected pictures,
+// e.g. for backups and migrations. Pictures that cannot be found are skipped.
+//
+// POST /api/v1/photos/yaml
+//
+// Request Body:
+//   - photos ([]string) photo UIDs to export
+func ExportPhotosYaml(router *gin.RouterGroup) {
+	router.POST("/photos/yaml", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionExport)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.Selection
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if len(f.Photos) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		} else if len(f.Photos) > ExportYamlMaxPhotos {
+			AbortBadRequest(c)
+			return
+		}
+
+		AddDownloadHeader(c, fmt.Sprintf("photoprism-yaml-%s.zip", time.Now().Format("20060102-150405")))
+
+		c.Header("Content-Type", ContentTypeZip)
+		c.Status(http.StatusOK)
+
+		zipWriter := zip.NewWriter(c.Writer)
+
+		done := make(map[string]bool, len(f.Photos))
+		count := 0
+
+		for _, uid := range f.Photos {
+			uid = clean.UID(uid)
+
+			if uid == "" || done[uid] {
+				continue
+			}
+
+			done[uid] = true
+
+			p, err := query.PhotoPreloadByUID(uid)
+
+			if err != nil {
+				log.Warnf("export: %s in %s (yaml)", err, clean.Log(uid))
+				continue
+			}
+
+			data, err := p.Yaml()
+
+			if err != nil {
+				log.Warnf("export: %s in %s (yaml)", err, clean.Log(uid))
+				continue
+			}
+
+			w, err := zipWriter.CreateHeader(&zip.FileHeader{
+				Name:     uid + fs.ExtYAML,
+				Method:   zip.Deflate,
+				Modified: p.UpdatedAt,
+			})
+
+			if err != nil {
+				log.Errorf("export: %s (create zip entry)", err)
+				break
+			} else if _, err = w.Write(data); err != nil {
+				log.Errorf("export: %s (write zip entry)", err)
+				break
+			}
+
+			count++
+		}
+
+		if err := zipWriter.Close(); err != nil {
+			log.Errorf("export: %s (close zip)", err)
+			return
+		}
+
+		log.Infof("export: added %d pictures to yaml archive", count)
+	})
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportPhotosYaml(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ExportPhotosYaml(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/yaml", `{"photos": ["pt9jtdre2lvl0y11", "pt9jtdre2lvl0xxx", "pt9jtdre2lvl0yh8", "pt9jtdre2lvl0y11"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, ContentTypeZip, r.Header().Get("Content-Type"))
+		assert.Contains(t, r.Header().Get("Content-Disposition"), "attachment; filename=photoprism-yaml-")
+
+		body := r.Body.Bytes()
+		zipReader, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, zipReader.File, 2) {
+			assert.Equal(t, "pt9jtdre2lvl0y11.yml", zipReader.File[0].Name)
+			assert.Equal(t, "pt9jtdre2lvl0yh8.yml", zipReader.File[1].Name)
+
+			rc, err := zipReader.File[0].Open()
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			data, err := io.ReadAll(rc)
+			_ = rc.Close()
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Contains(t, string(data), "UID: pt9jtdre2lvl0y11")
+		}
+	})
+	t.Run("NoItemsSelected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ExportPhotosYaml(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/yaml", `{"photos": []}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ExportPhotosYaml(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/yaml", `{"photos": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Roles of the files that belong to a photo.
+const (
+	FileRolePrimary = "primary"
+	FileRoleStacked = "stacked"
+	FileRoleSidecar = "sidecar"
+	FileRoleMissing = "missing"
+)
+
+// PhotoFile represents a file that belongs to a photo in API responses.
+type PhotoFile struct {
+	UID       string `json:"UID"`
+	Name      string `json:"Name"`
+	Root      string `json:"Root"`
+	Role      string `json:"Role"`
+	Hash      string `json:"Hash"`
+	Size      int64  `json:"Size"`
+	FileType  string `json:"FileType"`
+	MediaType string `json:"MediaType"`
+	Mime      string `json:"Mime"`
+	Primary   bool   `json:"Primary"`
+	Sidecar   bool   `json:"Sidecar"`
+	Missing   bool   `json:"Missing"`
+	Error     string `json:"Error,omitempty"`
+}
+
+// NewPhotoFile creates a new photo file response based on the file entity.
+func NewPhotoFile(f entity.File) PhotoFile {
+	result := PhotoFile{
+		UID:       f.FileUID,
+		Name:      f.FileName,
+		Root:      f.FileRoot,
+		Hash:      f.FileHash,
+		Size:      f.FileSize,
+		FileType:  f.FileType,
+		MediaType: f.MediaType,
+		Mime:      f.FileMime,
+		Primary:   f.FilePrimary,
+		Sidecar:   f.FileSidecar,
+		Missing:   f.FileMissing,
+		Error:     f.FileError,
+	}
+
+	switch {
+	case f.FileMissing:
+		result.Role = FileRoleMissing
+	case f.FilePrimary:
+		result.Role = FileRolePrimary
+	case f.FileSidecar:
+		result.Role = FileRoleSidecar
+	default:
+		result.Role = FileRoleStacked
+	}
+
+	return result
+}
+
+// GetPhotoFiles returns all files that belong to a photo with the primary file first, including missing files
+// so that they can be purged.
+//
+// GET /api/v1/photos/:uid/files
+//
+// Parameters:
+//
+//	uid: string PhotoUID as returned by the API
+func GetPhotoFiles(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/files", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceFiles, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+
+		if _, err := query.PhotoByUID(uid); err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		files, err := query.FilesByPhotoUID(uid)
+
+		if err != nil {
+			log.Errorf("files: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		result := make([]PhotoFile, len(files))
+
+		for i, f := range files {
+			result[i] = NewPhotoFile(f)
+		}
+
+		AddCountHeader(c, len(result))
+
+		c.JSON(http.StatusOK, result)
+	})
+}
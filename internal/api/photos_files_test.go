@@ -0,0 +1,71 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestGetPhotoFiles(t *testing.T) {
+	t.Run("Stack", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoFiles(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y17/files")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		var files []PhotoFile
+
+		if err := json.Unmarshal(r.Body.Bytes(), &files); err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Greater(t, len(files), 1) {
+			assert.True(t, files[0].Primary)
+			assert.Equal(t, FileRolePrimary, files[0].Role)
+			assert.NotEmpty(t, files[0].Hash)
+
+			for _, f := range files[1:] {
+				assert.False(t, f.Primary)
+				assert.NotEqual(t, FileRolePrimary, f.Role)
+			}
+		}
+
+		assert.Equal(t, strconv.Itoa(len(files)), r.Header().Get("X-Count"))
+	})
+	t.Run("Missing", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoFiles(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y22/files")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		var files []PhotoFile
+
+		if err := json.Unmarshal(r.Body.Bytes(), &files); err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, files, 1) {
+			assert.True(t, files[0].Missing)
+			assert.Equal(t, FileRoleMissing, files[0].Role)
+			assert.Equal(t, "1990/missing.jpg", files[0].Name)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoFiles(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0xxx/files")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestNewPhotoFile(t *testing.T) {
+	assert.Equal(t, FileRolePrimary, NewPhotoFile(entity.File{FilePrimary: true}).Role)
+	assert.Equal(t, FileRoleSidecar, NewPhotoFile(entity.File{FileSidecar: true}).Role)
+	assert.Equal(t, FileRoleStacked, NewPhotoFile(entity.File{FileName: "2020/IMG_1234.heic"}).Role)
+	assert.Equal(t, FileRoleMissing, NewPhotoFile(entity.File{FilePrimary: true, FileMissing: true}).Role)
+}
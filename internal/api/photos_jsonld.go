@@ -0,0 +1,185 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/internal/viewer"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/video"
+)
+
+// ContentTypeJsonLd is the media type of JSON-LD documents.
+const ContentTypeJsonLd = "application/ld+json"
+
+// Schema.org object types.
+const (
+	SchemaImageObject    = "ImageObject"
+	SchemaVideoObject    = "VideoObject"
+	SchemaGeoCoordinates = "GeoCoordinates"
+	SchemaPerson         = "Person"
+)
+
+// SchemaGeo represents schema.org geo coordinates.
+type SchemaGeo struct {
+	Type      string  `json:"@type"`
+	Latitude  float32 `json:"latitude"`
+	Longitude float32 `json:"longitude"`
+	Elevation int     `json:"elevation,omitempty"`
+}
+
+// SchemaPersonRef represents a schema.org person, e.g. the creator of a photo.
+type SchemaPersonRef struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+// PhotoJsonLd represents a photo as schema.org ImageObject or VideoObject.
+type PhotoJsonLd struct {
+	Context         string           `json:"@context"`
+	Type            string           `json:"@type"`
+	Identifier      string           `json:"identifier"`
+	Name            string           `json:"name"`
+	Description     string           `json:"description,omitempty"`
+	ContentUrl      string           `json:"contentUrl"`
+	ThumbnailUrl    string           `json:"thumbnailUrl,omitempty"`
+	EncodingFormat  string           `json:"encodingFormat,omitempty"`
+	Width           int              `json:"width,omitempty"`
+	Height          int              `json:"height,omitempty"`
+	Duration        string           `json:"duration,omitempty"`
+	DateCreated     string           `json:"dateCreated"`
+	UploadDate      string           `json:"uploadDate,omitempty"`
+	Geo             *SchemaGeo       `json:"geo,omitempty"`
+	Creator         *SchemaPersonRef `json:"creator,omitempty"`
+	CopyrightNotice string           `json:"copyrightNotice,omitempty"`
+	License         string           `json:"license,omitempty"`
+}
+
+// GetPhotoJsonLd returns a photo as schema.org JSON-LD object with content urls signed by the session tokens.
+//
+// GET /api/v1/photos/:uid/jsonld
+//
+// Parameters:
+//
+//	uid: string PhotoUID as returned by the API
+func GetPhotoJsonLd(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/jsonld", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+
+		// Visitors can only access photos in shared albums.
+		if (s.IsVisitor() || s.NotRegistered()) && !query.PhotoShared(uid, s.SharedUIDs()) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		p, err := query.PhotoPreloadByUID(uid)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		result := NewPhotoJsonLd(p, s.PreviewToken, s.DownloadToken)
+
+		if result == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		data, err := json.Marshal(result)
+
+		if err != nil {
+			log.Errorf("jsonld: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.Data(http.StatusOK, ContentTypeJsonLd, data)
+	})
+}
+
+// NewPhotoJsonLd returns the schema.org representation of a photo, or nil if it has no primary file.
+func NewPhotoJsonLd(p entity.Photo, previewToken, downloadToken string) *PhotoJsonLd {
+	var primary, media *entity.File
+
+	for i := range p.Files {
+		f := &p.Files[i]
+
+		if f.FilePrimary && primary == nil {
+			primary = f
+		} else if f.FileVideo && media == nil {
+			media = f
+		}
+	}
+
+	if primary == nil {
+		return nil
+	}
+
+	conf := get.Config()
+	contentUri := conf.ContentUri()
+
+	result := &PhotoJsonLd{
+		Context:        "https://schema.org",
+		Type:           SchemaImageObject,
+		Identifier:     p.PhotoUID,
+		Name:           p.PhotoTitle,
+		Description:    p.PhotoDescription,
+		ContentUrl:     viewer.DownloadUrl(primary.FileHash, conf.ApiUri(), downloadToken),
+		ThumbnailUrl:   thumb.New(primary.FileWidth, primary.FileHeight, primary.FileHash, thumb.Sizes[thumb.Fit720], contentUri, previewToken).Src,
+		EncodingFormat: primary.FileMime,
+		Width:          primary.FileWidth,
+		Height:         primary.FileHeight,
+		DateCreated:    p.TakenAt.UTC().Format(time.RFC3339),
+	}
+
+	// Videos and live photos reference the playable video stream.
+	if media != nil && (p.PhotoType == entity.MediaVideo || p.PhotoType == entity.MediaLive) {
+		result.Type = SchemaVideoObject
+		result.ContentUrl = fmt.Sprintf("%s/videos/%s/%s/%s", contentUri, media.FileHash, previewToken, video.CodecAVC)
+		result.UploadDate = p.CreatedAt.UTC().Format(time.RFC3339)
+
+		if media.FileMime != "" {
+			result.EncodingFormat = media.FileMime
+		}
+
+		if p.PhotoDuration > 0 {
+			result.Duration = fmt.Sprintf("PT%dS", int(p.PhotoDuration.Seconds()))
+		}
+	}
+
+	if p.HasLatLng() {
+		result.Geo = &SchemaGeo{
+			Type:      SchemaGeoCoordinates,
+			Latitude:  p.PhotoLat,
+			Longitude: p.PhotoLng,
+			Elevation: p.PhotoAltitude,
+		}
+	}
+
+	if p.Details != nil {
+		if p.Details.Artist != "" {
+			result.Creator = &SchemaPersonRef{Type: SchemaPerson, Name: p.Details.Artist}
+		}
+
+		result.CopyrightNotice = p.Details.Copyright
+		result.License = p.Details.License
+	}
+
+	return result
+}
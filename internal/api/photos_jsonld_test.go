@@ -0,0 +1,66 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestGetPhotoJsonLd(t *testing.T) {
+	t.Run("Image", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoJsonLd(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/jsonld")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, ContentTypeJsonLd, r.Header().Get("Content-Type"))
+		body := r.Body.String()
+		assert.Equal(t, "https://schema.org", gjson.Get(body, "@context").String())
+		assert.Equal(t, SchemaImageObject, gjson.Get(body, "@type").String())
+		assert.Equal(t, "pt9jtdre2lvl0yh7", gjson.Get(body, "identifier").String())
+		assert.True(t, gjson.Get(body, "name").Exists())
+		assert.Contains(t, gjson.Get(body, "contentUrl").String(), "/api/v1/dl/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818?t=")
+		assert.NotEmpty(t, gjson.Get(body, "dateCreated").String())
+		assert.NotEmpty(t, gjson.Get(body, "thumbnailUrl").String())
+	})
+	t.Run("Video", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoJsonLd(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh0/jsonld")
+		assert.Equal(t, http.StatusOK, r.Code)
+		body := r.Body.String()
+		assert.Equal(t, SchemaVideoObject, gjson.Get(body, "@type").String())
+		assert.Equal(t, "pt9jtdre2lvl0yh0", gjson.Get(body, "identifier").String())
+		assert.True(t, gjson.Get(body, "name").Exists())
+		assert.Contains(t, gjson.Get(body, "contentUrl").String(), "/api/v1/videos/")
+		assert.NotEmpty(t, gjson.Get(body, "dateCreated").String())
+		assert.NotEmpty(t, gjson.Get(body, "uploadDate").String())
+		assert.Equal(t, SchemaGeoCoordinates, gjson.Get(body, "geo.@type").String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoJsonLd(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/xxx/jsonld")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("VisitorShared", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		GetPhotoJsonLd(router)
+		r := AuthenticatedRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh0/jsonld", "69be27ac5ca305b394046a83f6fda18167ca3d3f2dbe7ac3")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, SchemaVideoObject, gjson.Get(r.Body.String(), "@type").String())
+	})
+	t.Run("VisitorNotShared", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		GetPhotoJsonLd(router)
+		r := AuthenticatedRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh8/jsonld", "69be27ac5ca305b394046a83f6fda18167ca3d3f2dbe7ac3")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GetPhotoMap returns named places near the location of a photo, ordered by distance.
+// An empty list is returned if the photo has no GPS coordinates.
+//
+// GET /api/v1/photos/:uid/map
+//
+// Parameters:
+//
+//	uid: string PhotoUID as returned by the API
+//	dist: int search radius in km (default 20, max 1000)
+//	count: int maximum number of results (default and max 100)
+func GetPhotoMap(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/map", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+
+		// Visitors can only access photos in shared albums.
+		if (s.IsVisitor() || s.NotRegistered()) && !query.PhotoShared(uid, s.SharedUIDs()) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		p, err := query.PhotoByUID(uid)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		results, err := query.NearbyPlacesByLatLng(float64(p.PhotoLat), float64(p.PhotoLng), float64(txt.Int(c.Query("dist"))), txt.Int(c.Query("count")))
+
+		if err != nil {
+			log.Errorf("map: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		AddCountHeader(c, len(results))
+
+		c.JSON(http.StatusOK, results)
+	})
+}
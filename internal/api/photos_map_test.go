@@ -0,0 +1,44 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestGetPhotoMap(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		place := entity.PlaceFixtures.Get("Germany")
+		photo := entity.Photo{PhotoTitle: "Nearby Places", PhotoType: entity.MediaImage, PhotoLat: 48.519234, PhotoLng: 9.057997, PlaceID: place.ID}
+
+		if err := photo.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _, _ = photo.DeletePermanently() }()
+
+		app, router, _ := NewApiTest()
+		GetPhotoMap(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/"+photo.PhotoUID+"/map")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, gjson.Get(r.Body.String(), "#.PlaceID").String(), place.ID)
+		assert.True(t, gjson.Get(r.Body.String(), "0.Distance").Exists())
+	})
+	t.Run("NoCoordinates", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoMap(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/map")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "[]", r.Body.String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoMap(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0xxx/map")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// photoOrderSession checks if the current user may change their manual sort order and returns the session.
+func photoOrderSession(c *gin.Context) *entity.Session {
+	s := AuthAny(c, acl.ResourcePhotos, acl.Permissions{acl.ActionUpdate, acl.ActionReact})
+
+	if s.Abort(c) {
+		return nil
+	}
+
+	// The manual sort order is stored per user, so it requires a registered account.
+	if s.UserUID == "" || s.NotRegistered() {
+		AbortForbidden(c)
+		return nil
+	}
+
+	return s
+}
+
+// selectedPhotoUIDs returns the uids of existing photos in the order in which they were selected.
+func selectedPhotoUIDs(c *gin.Context) (uids []string, ok bool) {
+	var f form.Selection
+
+	if err := c.BindJSON(&f); err != nil {
+		AbortBadRequest(c)
+		return uids, false
+	} else if len(f.Photos) == 0 {
+		Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+		return uids, false
+	}
+
+	photos, err := query.SelectedPhotos(form.Selection{Photos: f.Photos})
+
+	if err != nil {
+		log.Errorf("photos: %s (find selection)", err)
+		AbortBadRequest(c)
+		return uids, false
+	}
+
+	found := make(map[string]bool, len(photos))
+
+	for _, p := range photos {
+		found[p.PhotoUID] = true
+	}
+
+	for _, uid := range f.Photos {
+		if found[uid] {
+			uids = append(uids, uid)
+		}
+	}
+
+	return uids, true
+}
+
+// GetPhotoOrder returns the photo uids in the manual sort order of the current user.
+//
+// GET /api/v1/photos/order
+func GetPhotoOrder(router *gin.RouterGroup) {
+	router.GET("/photos/order", func(c *gin.Context) {
+		s := photoOrderSession(c)
+
+		if s == nil {
+			return
+		}
+
+		orders, err := entity.FindPhotoOrders(s.UserUID)
+
+		if err != nil {
+			log.Errorf("photos: %s (find order)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"photos": orders.UIDs()})
+	})
+}
+
+// AddPhotoOrder appends the selected photos to the manual sort order of the current user.
+//
+// POST /api/v1/photos/order
+func AddPhotoOrder(router *gin.RouterGroup) {
+	router.POST("/photos/order", func(c *gin.Context) {
+		s := photoOrderSession(c)
+
+		if s == nil {
+			return
+		}
+
+		uids, ok := selectedPhotoUIDs(c)
+
+		if !ok {
+			return
+		}
+
+		added, err := entity.AddPhotoOrders(s.UserUID, uids)
+
+		if err != nil {
+			log.Errorf("photos: %s (add to order)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		orders, err := entity.FindPhotoOrders(s.UserUID)
+
+		if err != nil {
+			log.Errorf("photos: %s (find order)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgChangesSaved), "photos": orders.UIDs(), "added": added})
+	})
+}
+
+// UpdatePhotoOrder replaces the manual sort order of the current user with the selected sequence of photos.
+//
+// PUT /api/v1/photos/order
+func UpdatePhotoOrder(router *gin.RouterGroup) {
+	router.PUT("/photos/order", func(c *gin.Context) {
+		s := photoOrderSession(c)
+
+		if s == nil {
+			return
+		}
+
+		uids, ok := selectedPhotoUIDs(c)
+
+		if !ok {
+			return
+		}
+
+		orders, err := entity.SetPhotoOrders(s.UserUID, uids)
+
+		if err != nil {
+			log.Errorf("photos: %s (update order)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgChangesSaved), "photos": orders.UIDs()})
+	})
+}
+
+// RemovePhotoOrder removes the selected photos from the manual sort order of the current user.
+//
+// DELETE /api/v1/photos/order
+func RemovePhotoOrder(router *gin.RouterGroup) {
+	router.DELETE("/photos/order", func(c *gin.Context) {
+		s := photoOrderSession(c)
+
+		if s == nil {
+			return
+		}
+
+		var f form.Selection
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		} else if len(f.Photos) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		removed, err := entity.RemovePhotoOrders(s.UserUID, f.Photos)
+
+		if err != nil {
+			log.Errorf("photos: %s (remove from order)", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		orders, err := entity.FindPhotoOrders(s.UserUID)
+
+		if err != nil {
+			log.Errorf("photos: %s (find order)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgChangesSaved), "photos": orders.UIDs(), "removed": removed})
+	})
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestPhotoOrder(t *testing.T) {
+	app, router, conf := NewApiTest()
+	conf.SetAuthMode(config.AuthModePasswd)
+	defer conf.SetAuthMode(config.AuthModePublic)
+
+	SearchPhotos(router)
+	GetPhotoOrder(router)
+	AddPhotoOrder(router)
+	UpdatePhotoOrder(router)
+	RemovePhotoOrder(router)
+
+	alice := AuthenticateUser(app, router, "alice", "Alice123!")
+
+	// Sessions are shared, but the login handler can only be registered once per router.
+	bobApp, bobRouter, _ := NewApiTest()
+	bob := AuthenticateUser(bobApp, bobRouter, "bob", "Bobbob123!")
+
+	r := AuthenticatedRequest(app, http.MethodGet, "/api/v1/photos?count=10&merged=true", alice)
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	var uids []string
+
+	for _, uid := range gjson.Get(r.Body.String(), "#.UID").Array() {
+		uids = append(uids, uid.String())
+	}
+
+	if len(uids) < 3 {
+		t.Fatalf("expected at least 3 photos, got %d", len(uids))
+	}
+
+	uids = uids[0:3]
+
+	body := func(uids ...string) string {
+		return fmt.Sprintf(`{"photos": ["%s"]}`, strings.Join(uids, `", "`))
+	}
+
+	photos := func(r *httptest.ResponseRecorder) (result []string) {
+		for _, uid := range gjson.Get(r.Body.String(), "photos").Array() {
+			result = append(result, uid.String())
+		}
+
+		return result
+	}
+
+	t.Run("Add", func(t *testing.T) {
+		r := AuthenticatedRequestWithBody(app, http.MethodPost, "/api/v1/photos/order", body(uids[0], uids[1], "pt9jtdre2lvl0xxx"), alice)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, []string{uids[0], uids[1]}, photos(r))
+
+		r = AuthenticatedRequestWithBody(app, http.MethodPost, "/api/v1/photos/order", body(uids[2], uids[0]), alice)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, uids, photos(r))
+	})
+	t.Run("Reorder", func(t *testing.T) {
+		r := AuthenticatedRequestWithBody(app, http.MethodPut, "/api/v1/photos/order", body(uids[2], uids[0], uids[1]), alice)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, []string{uids[2], uids[0], uids[1]}, photos(r))
+
+		r = AuthenticatedRequest(app, http.MethodGet, "/api/v1/photos/order", alice)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, []string{uids[2], uids[0], uids[1]}, photos(r))
+	})
+	t.Run("SortManual", func(t *testing.T) {
+		r := AuthenticatedRequest(app, http.MethodGet, "/api/v1/photos?count=10&merged=true&order=manual", alice)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		var result []string
+
+		for _, uid := range gjson.Get(r.Body.String(), "#.UID").Array() {
+			result = append(result, uid.String())
+		}
+
+		assert.Equal(t, []string{uids[2], uids[0], uids[1]}, result)
+	})
+	t.Run("OtherUser", func(t *testing.T) {
+		r := AuthenticatedRequest(app, http.MethodGet, "/api/v1/photos/order", bob)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Empty(t, photos(r))
+
+		r = AuthenticatedRequest(app, http.MethodGet, "/api/v1/photos?count=10&merged=true&order=manual", bob)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "#").Int())
+	})
+	t.Run("Remove", func(t *testing.T) {
+		r := AuthenticatedRequestWithBody(app, http.MethodDelete, "/api/v1/photos/order", body(uids[0]), alice)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, []string{uids[2], uids[1]}, photos(r))
+
+		r = AuthenticatedRequestWithBody(app, http.MethodPut, "/api/v1/photos/order", `{"photos": []}`, alice)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		r = AuthenticatedRequestWithBody(app, http.MethodDelete, "/api/v1/photos/order", body(uids[2], uids[1]), alice)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Empty(t, photos(r))
+	})
+	t.Run("Unauthorized", func(t *testing.T) {
+		r := PerformRequest(app, http.MethodGet, "/api/v1/photos/order")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
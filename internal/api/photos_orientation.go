@@ -0,0 +1,271 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// OrientationIssue represents a file whose Exif orientation is likely inconsistent with its pixel data.
+type OrientationIssue struct {
+	PhotoUID    string `json:"PhotoUID"`
+	FileUID     string `json:"FileUID"`
+	FileName    string `json:"FileName"`
+	Orientation int    `json:"Orientation"`
+	Suggested   int    `json:"Suggested"`
+}
+
+// GetPhotoOrientationIssues returns primary files whose Exif orientation is likely inconsistent with
+// their pixel data, so that thumbnails are displayed with the wrong rotation. Limit and offset refer
+// to the checked files with an orientation that swaps width and height.
+//
+// GET /api/v1/photos/orientation-issues
+//
+// Query:
+//   - count (int) maximum number of files to check, default 100
+//   - offset (int) number of files to skip
+func GetPhotoOrientationIssues(router *gin.RouterGroup) {
+	router.GET("/photos/orientation-issues", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceFiles, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+
+		if limit <= 0 || limit > 1000 {
+			limit = 100
+		}
+
+		files, err := query.RotatedFiles(limit, offset)
+
+		if err != nil {
+			log.Errorf("orientation: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		issues := make([]OrientationIssue, 0, len(files))
+
+		for _, f := range files {
+			mf, err := photoprism.NewMediaFile(photoprism.FileName(f.FileRoot, f.FileName))
+
+			if err != nil {
+				log.Debugf("orientation: %s in %s", err, clean.Log(f.FileName))
+				continue
+			}
+
+			if suggested, mismatch := mf.OrientationMismatch(); mismatch {
+				issues = append(issues, OrientationIssue{
+					PhotoUID:    f.PhotoUID,
+					FileUID:     f.FileUID,
+					FileName:    f.FileName,
+					Orientation: mf.Orientation(),
+					Suggested:   suggested,
+				})
+			}
+		}
+
+		AddCountHeader(c, len(issues))
+		AddLimitHeader(c, limit)
+		AddOffsetHeader(c, offset)
+
+		c.JSON(http.StatusOK, issues)
+	})
+}
+
+// FixPhotoOrientation sets the suggested orientation for the primary file of a photo if its
+// Exif orientation is inconsistent with the pixel data.
+//
+// POST /api/v1/photos/:uid/orientation/fix
+//
+// Parameters:
+//
+//	uid: string Photo UID as returned by the API
+func FixPhotoOrientation(router *gin.RouterGroup) {
+	router.POST("/photos/:uid/orientation/fix", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceFiles, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		// Abort in read-only mode or if editing is disabled.
+		if conf.ReadOnly() || !conf.Settings().Features.Edit {
+			c.AbortWithStatusJSON(http.StatusForbidden, i18n.NewResponse(http.StatusForbidden, i18n.ErrReadOnly))
+			return
+		}
+
+		photoUid := clean.UID(c.Param("uid"))
+
+		m, err := query.FileByPhotoUID(photoUid)
+
+		// Abort if the primary file was not found.
+		if err != nil {
+			log.Errorf("orientation: %s (fix)", err)
+			AbortEntityNotFound(c)
+			return
+		}
+
+		mf, err := photoprism.NewMediaFile(photoprism.FileName(m.FileRoot, m.FileName))
+
+		// Check if file exists.
+		if err != nil {
+			Abort(c, http.StatusInternalServerError, i18n.ErrFileNotFound)
+			return
+		}
+
+		// Update file header and index if the orientation is inconsistent.
+		if suggested, mismatch := mf.OrientationMismatch(); mismatch {
+			if conf.DisableExifTool() {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, "exiftool is disabled")
+				return
+			}
+
+			if err = mf.ChangeOrientation(suggested); err != nil {
+				log.Debugf("orientation: %s in %s (fix)", err, clean.Log(mf.BaseName()))
+				Abort(c, http.StatusInternalServerError, i18n.ErrSaveFailed)
+				return
+			}
+
+			ind := get.Index()
+			if res := ind.FileName(mf.FileName(), photoprism.IndexOptionsSingle()); res.Failed() {
+				log.Errorf("orientation: %s in %s (fix)", res.Err, clean.Log(mf.BaseName()))
+				AbortSaveFailed(c)
+				return
+			}
+
+			PublishPhotoEvent(EntityUpdated, m.PhotoUID, c)
+		}
+
+		// Return updated photo.
+		p, err := query.PhotoPreloadByUID(m.PhotoUID)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, p)
+	})
+}
+
+// RotatePhoto rotates a photo clockwise by changing the Exif orientation of its primary file,
+// and regenerates the cached thumbnails so that clients see the corrected image immediately.
+//
+// POST /api/v1/photos/:uid/rotate
+//
+// Parameters:
+//
+//	uid: string Photo UID as returned by the API
+//
+// Request Body:
+//   - degrees (int) clockwise rotation: 90, 180, or 270
+func RotatePhoto(router *gin.RouterGroup) {
+	router.POST("/photos/:uid/rotate", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		// Abort in read-only mode or if editing is disabled.
+		if conf.ReadOnly() || !conf.Settings().Features.Edit {
+			c.AbortWithStatusJSON(http.StatusForbidden, i18n.NewResponse(http.StatusForbidden, i18n.ErrReadOnly))
+			return
+		}
+
+		var f form.PhotoRotate
+
+		if err := c.BindJSON(&f); err != nil || !f.Valid() {
+			AbortBadRequest(c)
+			return
+		}
+
+		m, err := query.FileByPhotoUID(clean.UID(c.Param("uid")))
+
+		// Abort if the primary file was not found.
+		if err != nil {
+			log.Errorf("orientation: %s (rotate)", err)
+			AbortEntityNotFound(c)
+			return
+		}
+
+		fileName := photoprism.FileName(m.FileRoot, m.FileName)
+		mf, err := photoprism.NewMediaFile(fileName)
+
+		// Check if file exists.
+		if err != nil {
+			Abort(c, http.StatusInternalServerError, i18n.ErrFileNotFound)
+			return
+		} else if conf.DisableExifTool() {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, "exiftool is disabled")
+			return
+		}
+
+		orientation, err := thumb.RotateOrientation(mf.Orientation(), f.Degrees)
+
+		if err != nil {
+			log.Debugf("orientation: %s in %s (rotate)", err, clean.Log(mf.BaseName()))
+			AbortBadRequest(c)
+			return
+		}
+
+		// Update file header.
+		if err = mf.ChangeOrientation(orientation); err != nil {
+			log.Debugf("orientation: %s in %s (rotate)", err, clean.Log(mf.BaseName()))
+			Abort(c, http.StatusInternalServerError, i18n.ErrSaveFailed)
+			return
+		}
+
+		// Update index.
+		ind := get.Index()
+		if res := ind.FileName(mf.FileName(), photoprism.IndexOptionsSingle()); res.Failed() {
+			log.Errorf("orientation: %s in %s (rotate)", res.Err, clean.Log(mf.BaseName()))
+			AbortSaveFailed(c)
+			return
+		}
+
+		// Remove thumbnails with the previous orientation from the cache.
+		if _, err = thumb.RemoveCached(m.FileHash, conf.ThumbCachePath()); err != nil {
+			log.Warnf("orientation: %s in %s (remove thumbnails)", err, clean.Log(mf.BaseName()))
+		}
+
+		// Regenerate thumbnails based on the changed file.
+		if mf, err = photoprism.NewMediaFile(fileName); err != nil {
+			log.Warnf("orientation: %s in %s (create thumbnails)", err, clean.Log(m.FileName))
+		} else if err = mf.CreateThumbnails(conf.ThumbCachePath(), true); err != nil {
+			log.Warnf("orientation: %s in %s (create thumbnails)", err, clean.Log(mf.BaseName()))
+		}
+
+		// Return updated photo.
+		p, err := query.PhotoPreloadByUID(m.PhotoUID)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		SavePhotoAsYaml(p)
+
+		PublishPhotoEvent(EntityUpdated, m.PhotoUID, c)
+
+		c.JSON(http.StatusOK, p)
+	})
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetPhotoOrientationIssues(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoOrientationIssues(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/orientation-issues?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Parse(r.Body.String()).IsArray())
+		assert.Equal(t, "10", r.Header().Get("X-Limit"))
+	})
+}
+
+func TestFixPhotoOrientation(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		FixPhotoOrientation(router)
+		r := PerformRequest(app, "POST", "/api/v1/photos/xxx/orientation/fix")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("FileNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		FixPhotoOrientation(router)
+		r := PerformRequest(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh7/orientation/fix")
+		assert.Equal(t, http.StatusInternalServerError, r.Code)
+	})
+}
+
+func TestRotatePhoto(t *testing.T) {
+	t.Run("InvalidDegrees", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RotatePhoto(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh7/rotate", `{"degrees": 45}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RotatePhoto(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh7/rotate", `{"degrees": "90"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RotatePhoto(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0xxx/rotate", `{"degrees": 90}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("FileNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RotatePhoto(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh7/rotate", `{"degrees": 90}`)
+		assert.Equal(t, http.StatusInternalServerError, r.Code)
+	})
+}
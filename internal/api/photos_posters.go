@@ -0,0 +1,144 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// VideoPoster represents a video poster frame in API responses.
+type VideoPoster struct {
+	Percent int     `json:"Percent"`
+	Time    float64 `json:"Time"`
+	Url     string  `json:"Url"`
+}
+
+// GetPhotoPosters generates and returns poster frames at several positions of a video, e.g. for hover previews.
+//
+// GET /api/v1/photos/:uid/posters
+//
+// Parameters:
+//
+//	uid: string PhotoUID as returned by the API
+func GetPhotoPosters(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/posters", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if !conf.FFmpegEnabled() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		f, err := query.VideoByPhotoUID(clean.UID(c.Param("uid")))
+
+		if err != nil || !f.FileVideo {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		posters, err := videoPosters(f)
+
+		if err != nil {
+			log.Errorf("posters: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		result := make([]VideoPoster, 0, len(posters))
+
+		for _, p := range posters {
+			result = append(result, VideoPoster{
+				Percent: p.Percent,
+				Time:    p.Offset.Seconds(),
+				Url:     fmt.Sprintf("%s/posters/%s/%s/%d", conf.ContentUri(), f.FileHash, s.PreviewToken, p.Percent),
+			})
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// GetVideoPoster returns a video poster frame as JPEG image.
+//
+// GET /api/v1/posters/:hash/:token/:percent
+//
+// Parameters:
+//
+//	hash: string The video file hash as returned by the posters endpoint
+//	token: string url security token, see config
+//	percent: int Relative position of the frame in the video
+func GetVideoPoster(router *gin.RouterGroup) {
+	router.GET("/posters/:hash/:token/:percent", func(c *gin.Context) {
+		if InvalidPreviewToken(c) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		conf := get.Config()
+		fileHash := clean.Token(c.Param("hash"))
+		percent, err := strconv.Atoi(c.Param("percent"))
+
+		if err != nil {
+			c.Data(http.StatusBadRequest, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		fileName, err := photoprism.PosterFileName(fileHash, conf.ThumbCachePath(), percent)
+
+		if err != nil {
+			c.Data(http.StatusBadRequest, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		// Generate the poster frames if they are not cached yet.
+		if !fs.FileExists(fileName) {
+			f, err := query.FileByHash(fileHash)
+
+			if err != nil || !f.FileVideo || !conf.FFmpegEnabled() {
+				c.Data(http.StatusNotFound, "image/svg+xml", videoIconSvg)
+				return
+			} else if _, err = videoPosters(f); err != nil {
+				log.Errorf("posters: %s", err)
+				c.Data(http.StatusNotFound, "image/svg+xml", videoIconSvg)
+				return
+			}
+		}
+
+		if !fs.FileExists(fileName) {
+			c.Data(http.StatusNotFound, "image/svg+xml", videoIconSvg)
+			return
+		}
+
+		// Add HTTP cache header.
+		AddImmutableCacheHeader(c)
+
+		c.File(fileName)
+	})
+}
+
+// videoPosters extracts the poster frames of a video file if they are not cached yet.
+func videoPosters(f *entity.File) (photoprism.Posters, error) {
+	mf, err := photoprism.NewMediaFile(photoprism.FileName(f.FileRoot, f.FileName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return get.Convert().VideoPosters(mf, f.FileHash, f.FileDuration)
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestGetPhotoPosters(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetPhotoPosters(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/posters")
+
+		if conf.FFmpegEnabled() {
+			assert.Equal(t, http.StatusNotFound, r.Code)
+		} else {
+			assert.Equal(t, http.StatusForbidden, r.Code)
+		}
+	})
+}
+
+func TestGetVideoPoster(t *testing.T) {
+	t.Run("WrongToken", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		GetVideoPoster(router)
+		r := PerformRequest(app, "GET", "/api/v1/posters/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/xxx/50")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("InvalidPercent", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideoPoster(router)
+		r := PerformRequest(app, "GET", "/api/v1/posters/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/abc")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideoPoster(router)
+		r := PerformRequest(app, "GET", "/api/v1/posters/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/"+conf.PreviewToken()+"/50")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// ReindexPhoto runs the complete processing pipeline for the files of a single photo, so that
+// metadata, thumbnails, colors, faces, and labels are refreshed without indexing other files.
+//
+// POST /api/v1/photos/:uid/reindex
+//
+// Parameters:
+//
+//	uid: string PhotoUID as returned by the API
+func ReindexPhoto(router *gin.RouterGroup) {
+	router.POST("/photos/:uid/reindex", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if !conf.Settings().Features.Library {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+		p, err := query.PhotoPreloadByUID(uid)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		// Prevent running at the same time as the main index worker.
+		if err = mutex.MainWorker.Start(); err != nil {
+			AbortBusy(c)
+			return
+		}
+
+		defer mutex.MainWorker.Stop()
+
+		start := time.Now()
+
+		indOpt := photoprism.IndexOptionsSingle()
+		indOpt.SetUser(s.User())
+
+		ind := get.Index()
+		indexed := 0
+
+		for _, file := range p.Files {
+			// Sidecar and missing files can't be indexed on their own.
+			if file.FileSidecar || file.FileMissing || file.FileRoot != entity.RootOriginals {
+				continue
+			}
+
+			event.Publish("index.updating", event.Data{
+				"uid":      indOpt.UID,
+				"action":   indOpt.Action,
+				"step":     "reindex",
+				"photo":    p.PhotoUID,
+				"fileName": file.FileName,
+			})
+
+			fileName := photoprism.FileName(file.FileRoot, file.FileName)
+
+			if res := ind.FileName(fileName, indOpt); res.Failed() {
+				log.Errorf("reindex: %s in %s", res.Err, clean.Log(file.FileName))
+			} else {
+				indexed++
+			}
+		}
+
+		if indexed == 0 {
+			AbortSaveFailed(c)
+			return
+		}
+
+		log.Infof("reindex: updated %s of %s [%s]", english.Plural(indexed, "file", "files"), p.String(), time.Since(start))
+
+		// Return refreshed photo.
+		if p, err = query.PhotoPreloadByUID(uid); err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		event.Publish("index.completed", event.Data{
+			"uid":     indOpt.UID,
+			"action":  indOpt.Action,
+			"photo":   p.PhotoUID,
+			"seconds": int(time.Since(start).Seconds()),
+		})
+
+		PublishPhotoEvent(EntityUpdated, p.PhotoUID, c)
+
+		c.JSON(http.StatusOK, p)
+	})
+}
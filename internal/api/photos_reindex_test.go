@@ -0,0 +1,74 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func TestReindexPhoto(t *testing.T) {
+	app, router, conf := NewApiTest()
+	ReindexPhoto(router)
+
+	photo := &entity.Photo{PhotoTitle: "Stale Title", PhotoType: entity.MediaImage}
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _, _ = photo.DeletePermanently() }()
+
+	fileName := "reindex/" + photo.PhotoUID + ".jpg"
+	filePath := filepath.Join(conf.OriginalsPath(), fileName)
+
+	if err := os.MkdirAll(filepath.Dir(filePath), fs.ModeDir); err != nil {
+		t.Fatal(err)
+	} else if err = fs.Copy(filepath.Join(conf.ExamplesPath(), "cat_black.jpg"), filePath); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = os.Remove(filePath) }()
+
+	// Create file with stale metadata.
+	file := &entity.File{
+		PhotoID:       photo.ID,
+		PhotoUID:      photo.PhotoUID,
+		FileRoot:      entity.RootOriginals,
+		FileName:      fileName,
+		FileHash:      rnd.GenerateUID('h'),
+		FileType:      fs.ImageJPEG.String(),
+		FileWidth:     1,
+		FileHeight:    1,
+		FileMainColor: "",
+		FilePrimary:   true,
+	}
+
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/photos/"+photo.PhotoUID+"/reindex")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		body := r.Body.String()
+
+		assert.Equal(t, photo.PhotoUID, gjson.Get(body, "UID").String())
+		assert.Greater(t, gjson.Get(body, "Files.0.Width").Int(), int64(1))
+		assert.Greater(t, gjson.Get(body, "Files.0.Height").Int(), int64(1))
+		assert.NotEmpty(t, gjson.Get(body, "Files.0.MainColor").String())
+		assert.NotEqual(t, file.FileHash, gjson.Get(body, "Files.0.Hash").String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yxx/reindex")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
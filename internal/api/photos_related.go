@@ -0,0 +1,79 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GetPhotoRelated returns photos that have subjects, the place, or a close date in common with a photo,
+// ranked by the number of shared attributes, e.g. for "you might also like" suggestions.
+//
+// GET /api/v1/photos/:uid/related
+//
+// Parameters:
+//
+//	uid: string PhotoUID as returned by the API
+//	count: int maximum number of results (default and max 100)
+func GetPhotoRelated(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/related", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionSearch)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+		visitor := s.IsVisitor() || s.NotRegistered()
+
+		// Visitors can only access photos in shared albums.
+		if visitor && !query.PhotoShared(uid, s.SharedUIDs()) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		p, err := query.PhotoByUID(uid)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+
+		if limit <= 0 || limit > query.RelatedPhotosMaxCount {
+			limit = query.RelatedPhotosMaxCount
+		}
+
+		results, err := query.Related(p, limit)
+
+		if err != nil {
+			log.Errorf("related: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		// Only return shared photos to visitors.
+		if visitor {
+			shared := make(query.RelatedPhotos, 0, len(results))
+
+			for _, r := range results {
+				if query.PhotoShared(r.PhotoUID, s.SharedUIDs()) {
+					shared = append(shared, r)
+				}
+			}
+
+			results = shared
+		}
+
+		AddCountHeader(c, len(results))
+		AddLimitHeader(c, limit)
+
+		c.JSON(http.StatusOK, results)
+	})
+}
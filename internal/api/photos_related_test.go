@@ -0,0 +1,43 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+func TestGetPhotoRelated(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoRelated(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/related?count=3")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "3", r.Header().Get("X-Limit"))
+
+		var results query.RelatedPhotos
+
+		if err := json.Unmarshal(r.Body.Bytes(), &results); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, len(results), 3)
+
+		for i, p := range results {
+			assert.NotEqual(t, "pt9jtdre2lvl0yh7", p.PhotoUID)
+
+			if i > 0 {
+				assert.GreaterOrEqual(t, results[i-1].Score, p.Score)
+			}
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoRelated(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/xxx/related")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
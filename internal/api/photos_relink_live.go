@@ -0,0 +1,93 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// LiveLink represents an image and video pair that can be stacked as a live photo.
+type LiveLink struct {
+	ImageUID string `json:"ImageUID"`
+	VideoUID string `json:"VideoUID"`
+	Name     string `json:"Name"`
+	Stacked  bool   `json:"Stacked"`
+}
+
+// RelinkLivePhotos finds images and videos of live photos that were indexed as separate
+// photos, and stacks them unless the dry run parameter is set.
+//
+// POST /api/v1/photos/relink-live
+//
+// Parameters:
+//
+//	dry: bool Only returns the proposed links without changing the index
+func RelinkLivePhotos(router *gin.RouterGroup) {
+	router.POST("/photos/relink-live", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		dryRun := txt.Bool(c.Query("dry"))
+
+		pairs, err := query.LivePhotoPairs(photoprism.LivePhotoDurationLimit, photoprism.LivePhotoTimeTolerance)
+
+		if err != nil {
+			log.Errorf("live: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		result := make([]LiveLink, 0, len(pairs))
+
+		var updated entity.Photos
+		var deleted []string
+
+		for _, pair := range pairs {
+			link := LiveLink{
+				ImageUID: pair.Image.PhotoUID,
+				VideoUID: pair.Video.PhotoUID,
+				Name:     pair.Image.PhotoName,
+			}
+
+			if !dryRun {
+				if err = pair.Image.StackLive(&pair.Video); err != nil {
+					log.Errorf("live: %s while stacking %s with %s", err, pair.Image.PhotoUID, pair.Video.PhotoUID)
+				} else if p, err := query.PhotoPreloadByUID(pair.Image.PhotoUID); err != nil {
+					log.Errorf("live: %s", err)
+				} else {
+					link.Stacked = true
+					updated = append(updated, p)
+					deleted = append(deleted, pair.Video.PhotoUID)
+					SavePhotoAsYaml(p)
+				}
+			}
+
+			result = append(result, link)
+		}
+
+		if len(updated) > 0 {
+			log.Infof("live: stacked %s", english.Plural(len(updated), "image and video pair", "image and video pairs"))
+
+			// Update precalculated photo and file counts.
+			logWarn("index", entity.UpdateCounts())
+
+			UpdateClientConfig()
+
+			event.EntitiesUpdated("photos", updated)
+			event.EntitiesDeleted("photos", deleted)
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
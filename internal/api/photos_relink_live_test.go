@@ -0,0 +1,103 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func TestRelinkLivePhotos(t *testing.T) {
+	takenAt := time.Date(2022, 8, 14, 18, 5, 0, 0, time.UTC)
+
+	image := &entity.Photo{PhotoName: "IMG_9001", PhotoPath: "2022/08", PhotoType: entity.MediaImage, TakenAt: takenAt, TakenAtLocal: takenAt}
+	video := &entity.Photo{PhotoName: "IMG_9001", PhotoPath: "2022/08", PhotoType: entity.MediaVideo, PhotoDuration: 2 * time.Second, TakenAt: takenAt, TakenAtLocal: takenAt}
+	other := &entity.Photo{PhotoName: "IMG_9002", PhotoPath: "2022/08", PhotoType: entity.MediaImage, TakenAt: takenAt, TakenAtLocal: takenAt}
+	otherVideo := &entity.Photo{PhotoName: "IMG_9002", PhotoPath: "2022/08", PhotoType: entity.MediaVideo, PhotoDuration: 2 * time.Second, TakenAt: takenAt.Add(24 * time.Hour), TakenAtLocal: takenAt}
+
+	for _, p := range []*entity.Photo{image, video, other, otherVideo} {
+		if err := p.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func(p *entity.Photo) { _, _ = p.DeletePermanently() }(p)
+	}
+
+	videoFile := &entity.File{
+		PhotoID:     video.ID,
+		PhotoUID:    video.PhotoUID,
+		FileRoot:    entity.RootOriginals,
+		FileName:    "2022/08/IMG_9001.mov",
+		FileHash:    rnd.GenerateUID('h'),
+		FileType:    "mov",
+		FileVideo:   true,
+		FilePrimary: true,
+	}
+
+	if err := videoFile.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	app, router, _ := NewApiTest()
+	RelinkLivePhotos(router)
+
+	t.Run("DryRun", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/photos/relink-live?dry=true")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		body := r.Body.String()
+		assert.Equal(t, video.PhotoUID, gjson.Get(body, `#(ImageUID=="`+image.PhotoUID+`").VideoUID`).String())
+		assert.False(t, gjson.Get(body, `#(ImageUID=="`+image.PhotoUID+`").Stacked`).Bool())
+
+		p, err := query.PhotoByUID(image.PhotoUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, entity.MediaImage, p.PhotoType)
+	})
+	t.Run("NoMatch", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/photos/relink-live?dry=true")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.False(t, gjson.Get(r.Body.String(), `#(ImageUID=="`+other.PhotoUID+`")`).Exists())
+	})
+	t.Run("Stack", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/photos/relink-live")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), `#(ImageUID=="`+image.PhotoUID+`").Stacked`).Bool())
+
+		p, err := query.PhotoByUID(image.PhotoUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, entity.MediaLive, p.PhotoType)
+		assert.Equal(t, 2*time.Second, p.PhotoDuration)
+
+		f, err := query.FileByHash(videoFile.FileHash)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, image.PhotoUID, f.PhotoUID)
+
+		if deleted, err := query.PhotoByUID(video.PhotoUID); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.NotNil(t, deleted.DeletedAt)
+		}
+
+		// Stacked pairs are not proposed again.
+		r = PerformRequest(app, "POST", "/api/v1/photos/relink-live?dry=true")
+		assert.False(t, gjson.Get(r.Body.String(), `#(ImageUID=="`+image.PhotoUID+`")`).Exists())
+	})
+}
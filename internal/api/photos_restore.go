@@ -0,0 +1,98 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// RestorePhoto undoes the soft delete of a photo and its files that still exist, re-indexes it so
+// that it reappears in search results, and returns the restored photo. Status 404 is returned if
+// the photo has been removed permanently.
+//
+// POST /api/v1/photos/:uid/restore
+//
+// Parameters:
+//
+//	uid: string PhotoUID as returned by the API
+func RestorePhoto(router *gin.RouterGroup) {
+	router.POST("/photos/:uid/restore", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m, err := query.PhotoByUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		var mainFile string
+
+		// Restore deleted or missing files if they still exist.
+		for _, f := range m.AllFiles() {
+			fileName := photoprism.FileName(f.FileRoot, f.FileName)
+
+			if !fs.FileExists(fileName) {
+				continue
+			} else if f.DeletedAt != nil || f.FileMissing {
+				if err = f.Found(); err != nil {
+					log.Errorf("restore: %s in %s", err, clean.Log(f.FileName))
+					continue
+				}
+			}
+
+			if mainFile == "" || f.FilePrimary {
+				mainFile = fileName
+			}
+		}
+
+		if m.DeletedAt != nil {
+			if err = m.Restore(); err != nil {
+				log.Errorf("restore: %s", err)
+				AbortSaveFailed(c)
+				return
+			}
+		}
+
+		// Re-index the photo so that it reappears in search results.
+		if mainFile == "" {
+			log.Warnf("restore: photo %s has no existing files", clean.Log(m.PhotoUID))
+		} else if res := get.Index().FileName(mainFile, photoprism.IndexOptionsSingle()); res.Failed() {
+			log.Errorf("restore: %s in %s", res.Err, clean.Log(m.PhotoUID))
+		}
+
+		// Update precalculated photo and file counts.
+		logWarn("index", entity.UpdateCounts())
+
+		// Update album, subject, and label cover thumbs.
+		logWarn("index", query.UpdateCovers())
+
+		UpdateClientConfig()
+
+		event.EntitiesRestored("photos", []string{m.PhotoUID})
+
+		p, err := query.PhotoPreloadByUID(m.PhotoUID)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		SavePhotoAsYaml(p)
+
+		c.JSON(http.StatusOK, p)
+	})
+}
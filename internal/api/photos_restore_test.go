@@ -0,0 +1,99 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func TestRestorePhoto(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		RestorePhoto(router)
+
+		photo := &entity.Photo{PhotoTitle: "Restore", PhotoType: entity.MediaImage, PhotoQuality: 3}
+
+		if err := photo.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _, _ = photo.DeletePermanently() }()
+
+		fileName := "photos-restore/" + photo.PhotoUID + ".jpg"
+		filePath := filepath.Join(conf.OriginalsPath(), fileName)
+
+		if err := os.MkdirAll(filepath.Dir(filePath), fs.ModeDir); err != nil {
+			t.Fatal(err)
+		} else if err = fs.Copy(filepath.Join(conf.ExamplesPath(), "cat_black.jpg"), filePath); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _ = os.RemoveAll(filepath.Dir(filePath)) }()
+
+		file := &entity.File{
+			PhotoID:     photo.ID,
+			PhotoUID:    photo.PhotoUID,
+			FileRoot:    entity.RootOriginals,
+			FileName:    fileName,
+			FileHash:    rnd.GenerateUID('h'),
+			FileType:    fs.ImageJPEG.String(),
+			FilePrimary: true,
+		}
+
+		if err := file.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := photo.Delete(false); err != nil {
+			t.Fatal(err)
+		}
+
+		_, removed := query.PhotoRemoved(photo.PhotoUID)
+		assert.True(t, removed)
+
+		r := PerformRequest(app, "POST", "/api/v1/photos/"+photo.PhotoUID+"/restore")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, photo.PhotoUID, gjson.Get(r.Body.String(), "UID").String())
+		assert.Nil(t, gjson.Get(r.Body.String(), "DeletedAt").Value())
+
+		_, removed = query.PhotoRemoved(photo.PhotoUID)
+		assert.False(t, removed)
+
+		if f, err := query.FileByUID(file.FileUID); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Nil(t, f.DeletedAt)
+			assert.False(t, f.FileMissing)
+		}
+	})
+	t.Run("Purged", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RestorePhoto(router)
+
+		photo := &entity.Photo{PhotoTitle: "Purged", PhotoType: entity.MediaImage}
+
+		if err := photo.Create(); err != nil {
+			t.Fatal(err)
+		} else if _, err = photo.DeletePermanently(); err != nil {
+			t.Fatal(err)
+		}
+
+		r := PerformRequest(app, "POST", "/api/v1/photos/"+photo.PhotoUID+"/restore")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RestorePhoto(router)
+		r := PerformRequest(app, "POST", "/api/v1/photos/pt9jtdre2lvl0xxx/restore")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// GetPhotosReview returns pictures with only low-confidence labels that need to be reviewed.
+// See form.SearchPhotos for supported search params and data types.
+//
+// GET /api/v1/photos/review
+func GetPhotosReview(router *gin.RouterGroup) {
+	router.GET("/photos/review", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.SearchPhotos
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil || f.Count <= 0 {
+			AbortBadRequest(c)
+			return
+		}
+
+		f.Uncertain = true
+
+		result, count, err := search.UserPhotos(f, s)
+
+		if err != nil {
+			event.AuditWarn([]string{ClientIP(c), "session %s", string(acl.ResourcePhotos), "review", "%s"}, s.RefID, err)
+			AbortBadRequest(c)
+			return
+		}
+
+		// Add response headers.
+		AddCountHeader(c, count)
+		AddLimitHeader(c, f.Count)
+		AddOffsetHeader(c, f.Offset)
+		AddTokenHeaders(c, s)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// ClearPhotosReview removes the selected pictures from the review queue once their labels have been checked.
+//
+// POST /api/v1/photos/review
+func ClearPhotosReview(router *gin.RouterGroup) {
+	router.POST("/photos/review", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.Selection
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if len(f.Photos) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		log.Infof("photos: marking %s as reviewed", clean.Log(f.String()))
+
+		// Fetch selection from index.
+		photos, err := query.SelectedPhotos(f)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		var reviewed entity.Photos
+
+		for _, p := range photos {
+			if !p.ReviewNeeded {
+				continue
+			} else if err = p.MarkReviewed(); err != nil {
+				log.Errorf("review: %s", err)
+			} else {
+				reviewed = append(reviewed, p)
+				SavePhotoAsYaml(p)
+			}
+		}
+
+		event.EntitiesUpdated("photos", reviewed)
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgChangesSaved))
+	})
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func TestPhotosReview(t *testing.T) {
+	mediaID := rnd.GenerateUID('m')
+	takenAt := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	photo := &entity.Photo{
+		TakenAt:      takenAt,
+		TakenAtLocal: takenAt,
+		PhotoTitle:   "Blurry Animal",
+		PhotoQuality: 3,
+		ReviewNeeded: true,
+	}
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _, _ = photo.DeletePermanently() }()
+
+	file := &entity.File{
+		PhotoID:     photo.ID,
+		PhotoUID:    photo.PhotoUID,
+		FileRoot:    entity.RootOriginals,
+		FileName:    "review/" + photo.PhotoUID + ".jpg",
+		FileHash:    rnd.GenerateUID('h'),
+		FileType:    "jpg",
+		FilePrimary: true,
+		MediaID:     &mediaID,
+	}
+
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	app, router, _ := NewApiTest()
+	GetPhotosReview(router)
+	ClearPhotosReview(router)
+
+	t.Run("Queue", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/photos/review?count=100")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), `#(UID=="`+photo.PhotoUID+`").ReviewNeeded`).Bool())
+
+		for _, uid := range gjson.Get(r.Body.String(), "#.UID").Array() {
+			assert.NotEqual(t, "pt9jtdre2lvl0yh7", uid.String())
+		}
+	})
+	t.Run("Clear", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/review", `{"photos": ["`+photo.PhotoUID+`"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/photos/review?count=100")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.False(t, gjson.Get(r.Body.String(), `#(UID=="`+photo.PhotoUID+`")`).Exists())
+
+		if found := entity.FindPhoto(*photo); assert.NotNil(t, found) {
+			assert.False(t, found.ReviewNeeded)
+		}
+	})
+	t.Run("NoItemsSelected", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/review", `{"photos": []}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"net/http"
+	"path/filepath"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/rules"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ApplyPhotoRules applies the custom metadata rules in rules.yml to all indexed photos,
+// e.g. after rules have been added or changed.
+//
+// POST /api/v1/photos/rules
+func ApplyPhotoRules(router *gin.RouterGroup) {
+	router.POST("/photos/rules", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+		fileName := conf.RulesYaml()
+
+		r, err := rules.Load(fileName)
+
+		if err != nil {
+			log.Errorf("%s in %s", err, clean.Log(filepath.Base(fileName)))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		var updated entity.Photos
+
+		limit := 1000
+		offset := 0
+
+		for len(r) > 0 {
+			photos, err := query.PhotosWithMetadata(limit, offset)
+
+			if err != nil {
+				log.Errorf("rules: %s", err)
+				AbortUnexpected(c)
+				return
+			}
+
+			for i := range photos {
+				if matched, err := photoprism.ApplyRules(r, &photos[i]); err != nil {
+					log.Errorf("rules: %s in %s", err, photos[i].String())
+				} else if matched {
+					updated = append(updated, photos[i])
+					SavePhotoAsYaml(photos[i])
+				}
+			}
+
+			if len(photos) < limit {
+				break
+			}
+
+			offset += limit
+		}
+
+		if len(updated) > 0 {
+			log.Infof("rules: updated %s", english.Plural(len(updated), "picture", "pictures"))
+			event.EntitiesUpdated("photos", updated)
+		}
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgChangesSaved), "rules": len(r), "updated": len(updated)})
+	})
+}
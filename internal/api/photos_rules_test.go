@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestApplyPhotoRules(t *testing.T) {
+	app, router, conf := NewApiTest()
+	ApplyPhotoRules(router)
+
+	takenAt := time.Date(2021, 9, 12, 16, 0, 0, 0, time.UTC)
+	photo := &entity.Photo{PhotoName: "rules-api-test", PhotoIso: 12800, TakenAt: takenAt, TakenAtLocal: takenAt}
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _, _ = photo.DeletePermanently() }()
+
+	fileName := conf.RulesYaml()
+
+	defer func() { _ = os.Remove(fileName) }()
+
+	t.Run("Apply", func(t *testing.T) {
+		if err := os.WriteFile(fileName, []byte("- When: name = rules-api-test and iso > 3200\n  Keywords: [highiso]\n"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		r := PerformRequest(app, "POST", "/api/v1/photos/rules")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "rules").Int())
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "updated").Int())
+
+		p, err := query.PhotoPreloadByUID(photo.PhotoUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, p.GetDetails().Keywords, "highiso")
+	})
+	t.Run("InvalidSyntax", func(t *testing.T) {
+		if err := os.WriteFile(fileName, []byte("- When: iso >> 3200\n  Keywords: [highiso]\n"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		r := PerformRequest(app, "POST", "/api/v1/photos/rules")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Contains(t, gjson.Get(r.Body.String(), "error").String(), "rule 1")
+	})
+	t.Run("NoRules", func(t *testing.T) {
+		_ = os.Remove(fileName)
+
+		r := PerformRequest(app, "POST", "/api/v1/photos/rules")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "updated").Int())
+	})
+}
@@ -13,12 +13,14 @@ import (
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/pkg/sortby"
 )
 
 // SearchPhotos searches the pictures index and returns the result as JSON.
 // See form.SearchPhotos for supported search params and data types.
 //
 // GET /api/v1/photos
+// GET /api/v1/photos?after=&before=&offset=&limit=
 func SearchPhotos(router *gin.RouterGroup) {
 	// searchPhotos checking authorization and parses the search request.
 	searchForm := func(c *gin.Context) (f form.SearchPhotos, s *entity.Session, err error) {
@@ -36,6 +38,23 @@ func SearchPhotos(router *gin.RouterGroup) {
 			return f, s, err
 		}
 
+		// Requests with a limit instead of a count find pictures taken on or between the
+		// after and before dates, newest first, e.g. for timeline views.
+		if f.Count <= 0 && f.Limit > 0 {
+			f.Count = f.Limit
+			f.Order = sortby.Newest
+			f.Merged = true
+			f.Total = true
+			f.Inclusive = true
+		}
+
+		// Abort if the number of results is missing.
+		if f.Count <= 0 {
+			err = i18n.Error(i18n.ErrBadRequest)
+			AbortBadRequest(c)
+			return f, s, err
+		}
+
 		settings := get.Config().Settings()
 
 		// Ignore private flag if feature is disabled.
@@ -63,7 +82,7 @@ func SearchPhotos(router *gin.RouterGroup) {
 		}
 
 		// Find matching pictures.
-		result, count, err := search.UserPhotos(f, s)
+		result, count, total, err := search.UserPhotosTotal(f, s)
 
 		// Ok?
 		if err != nil {
@@ -78,6 +97,10 @@ func SearchPhotos(router *gin.RouterGroup) {
 		AddOffsetHeader(c, f.Offset)
 		AddTokenHeaders(c, s)
 
+		if f.Total {
+			AddTotalHeader(c, total)
+		}
+
 		// Return as JSON.
 		c.JSON(http.StatusOK, result)
 	}
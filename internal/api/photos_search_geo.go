@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -19,6 +20,7 @@ import (
 // See form.SearchPhotosGeo for supported search params and data types.
 //
 // GET /api/v1/geo
+// GET /api/v1/geo/clusters?level=10&public=true
 func SearchGeo(router *gin.RouterGroup) {
 	handler := func(c *gin.Context) {
 		s := AuthAny(c, acl.ResourcePlaces, acl.Permissions{acl.ActionSearch, acl.ActionView, acl.AccessShared})
@@ -75,6 +77,8 @@ func SearchGeo(router *gin.RouterGroup) {
 		switch clean.Token(c.Param("format")) {
 		case "view":
 			resp, err = photos.ViewerJSON(conf.ContentUri(), conf.ApiUri(), s.PreviewToken, s.DownloadToken)
+		case "clusters":
+			resp, err = json.Marshal(photos.Clusters(f.Level))
 		default:
 			resp, err = photos.GeoJSON()
 		}
@@ -1,10 +1,14 @@
 package api
 
 import (
+	"encoding/json"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/search"
 )
 
 func TestSearchGeo(t *testing.T) {
@@ -26,4 +30,26 @@ func TestSearchGeo(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 		t.Logf("response: %s", r.Body.String())
 	})
+	t.Run("Clusters", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		SearchGeo(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/geo/clusters?level=8&public=true")
+
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		var clusters search.GeoClusters
+
+		if err := json.Unmarshal(r.Body.Bytes(), &clusters); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, strings.HasPrefix(r.Body.String(), "["))
+
+		for _, c := range clusters {
+			assert.True(t, strings.HasPrefix(c.Cell, "s2:"))
+			assert.GreaterOrEqual(t, c.Count, 1)
+		}
+	})
 }
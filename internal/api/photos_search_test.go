@@ -1,12 +1,16 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/tidwall/gjson"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
 )
 
 func TestSearchPhotos(t *testing.T) {
@@ -39,3 +43,79 @@ func TestSearchPhotos(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, result.Code)
 	})
 }
+
+func TestSearchPhotosDateRange(t *testing.T) {
+	var uids []string
+
+	for i := 0; i < 3; i++ {
+		takenAt := time.Date(1975, time.Month(3+i), 1, 12, 0, 0, 0, time.UTC)
+		photo := entity.Photo{PhotoTitle: "Timeline", PhotoType: entity.MediaImage, TakenAt: takenAt, TakenAtLocal: takenAt, PhotoQuality: 3}
+
+		if err := photo.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _, _ = photo.DeletePermanently() }()
+
+		file := entity.File{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, PhotoTakenAt: takenAt, FileName: fmt.Sprintf("timeline/%d.jpg", i), FileHash: fmt.Sprintf("timeline%d", i), FileType: "jpg", FilePrimary: true}
+
+		if err := file.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		uids = append(uids, photo.PhotoUID)
+	}
+
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos?q=title:Timeline&after=1975-01-01&before=1975-12-31&order=newest&merged=true&total=true&offset=1&count=1")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "3", r.Header().Get("X-Total"))
+		assert.Equal(t, "1", r.Header().Get("X-Count"))
+		assert.Equal(t, "1", r.Header().Get("X-Limit"))
+		assert.Equal(t, "1", r.Header().Get("X-Offset"))
+		assert.Equal(t, uids[1], gjson.Get(r.Body.String(), "0.UID").String())
+	})
+	t.Run("Limit", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos?q=title:Timeline&after=1975-01-01&before=1975-12-31&offset=1&limit=1")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "3", r.Header().Get("X-Total"))
+		assert.Equal(t, "1", r.Header().Get("X-Count"))
+		assert.Equal(t, "1", r.Header().Get("X-Limit"))
+		assert.Equal(t, "1", r.Header().Get("X-Offset"))
+		assert.Equal(t, uids[1], gjson.Get(r.Body.String(), "0.UID").String())
+	})
+	t.Run("Before", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchPhotos(router)
+
+		// Pictures taken on the before date are excluded from regular searches.
+		r := PerformRequest(app, "GET", "/api/v1/photos?q=title:Timeline&after=1975-01-01&before=1975-04-01&merged=true&total=true&count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "1", r.Header().Get("X-Total"))
+		assert.Equal(t, uids[0], gjson.Get(r.Body.String(), "0.UID").String())
+
+		// Date range requests include them.
+		r = PerformRequest(app, "GET", "/api/v1/photos?q=title:Timeline&after=1975-01-01&before=1975-04-01&limit=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "2", r.Header().Get("X-Total"))
+		assert.Equal(t, uids[1], gjson.Get(r.Body.String(), "0.UID").String())
+		assert.Equal(t, uids[0], gjson.Get(r.Body.String(), "1.UID").String())
+	})
+	t.Run("NoTotal", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos?q=title:Timeline&after=1975-01-01&before=1975-12-31&merged=true&count=1")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "", r.Header().Get("X-Total"))
+	})
+	t.Run("CountRequired", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos?after=1975-01-01&offset=10")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
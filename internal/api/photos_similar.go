@@ -0,0 +1,67 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GetPhotoSimilar returns photos that look similar to a photo based on the color and luminance maps of
+// their primary files, ranked by distance, e.g. for "more like this" suggestions.
+//
+// GET /api/v1/photos/:uid/similar
+//
+// Parameters:
+//
+//	uid: string PhotoUID as returned by the API
+//	limit: int maximum number of results (default and max 100)
+func GetPhotoSimilar(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/similar", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+		visitor := s.IsVisitor() || s.NotRegistered()
+
+		// Visitors can only access photos in shared albums.
+		if visitor && !query.PhotoShared(uid, s.SharedUIDs()) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		p, err := query.PhotoByUID(uid)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		limit := txt.Int(c.Query("limit"))
+
+		if limit <= 0 || limit > query.SimilarPhotosMaxCount {
+			limit = query.SimilarPhotosMaxCount
+		}
+
+		// Visitors can only see photos in shared albums.
+		results, err := query.Similar(p, limit, visitor, s.SharedUIDs())
+
+		if err != nil {
+			log.Errorf("similar: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		AddCountHeader(c, len(results))
+		AddLimitHeader(c, limit)
+
+		c.JSON(http.StatusOK, results)
+	})
+}
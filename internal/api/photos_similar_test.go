@@ -0,0 +1,45 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+func TestGetPhotoSimilar(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoSimilar(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y11/similar?limit=3")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "3", r.Header().Get("X-Limit"))
+
+		var results query.SimilarPhotos
+
+		if err := json.Unmarshal(r.Body.Bytes(), &results); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+		assert.LessOrEqual(t, len(results), 3)
+
+		for i, p := range results {
+			assert.NotEqual(t, "pt9jtdre2lvl0y11", p.PhotoUID)
+			assert.NotEmpty(t, p.FileHash)
+
+			if i > 0 {
+				assert.LessOrEqual(t, results[i-1].Distance, p.Distance)
+			}
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoSimilar(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/xxx/similar")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
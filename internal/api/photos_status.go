@@ -0,0 +1,76 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// PhotoStatus represents the index and processing status of a photo.
+type PhotoStatus struct {
+	UID        string `json:"UID"`
+	Metadata   bool   `json:"Metadata"`
+	Thumbnails bool   `json:"Thumbnails"`
+	Faces      bool   `json:"Faces"`
+	Labels     bool   `json:"Labels"`
+	Ready      bool   `json:"Ready"`
+}
+
+// NewPhotoStatus derives the processing status of a photo from its entity fields.
+func NewPhotoStatus(p entity.Photo) PhotoStatus {
+	result := PhotoStatus{
+		UID:    p.PhotoUID,
+		Faces:  p.PhotoFaces > 0,
+		Labels: len(p.Labels) > 0,
+	}
+
+	for _, f := range p.Files {
+		if !f.FilePrimary {
+			continue
+		}
+
+		// The image dimensions are extracted when indexing the file.
+		result.Metadata = f.FileWidth > 0 && f.FileHeight > 0
+
+		// Colors are determined from the thumbnails, so they must have been created before.
+		result.Thumbnails = f.FileMainColor != "" && f.FileColors != ""
+
+		break
+	}
+
+	result.Ready = result.Metadata && result.Thumbnails
+
+	return result
+}
+
+// GetPhotoStatus returns the index and processing status of a photo as JSON,
+// e.g. so that clients can check whether thumbnails are ready after an upload.
+//
+// GET /api/v1/photos/:uid/status
+//
+// Parameters:
+//
+//	uid: string PhotoUID as returned by the API
+func GetPhotoStatus(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/status", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		p, err := query.PhotoPreloadByUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, NewPhotoStatus(p))
+	})
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestNewPhotoStatus(t *testing.T) {
+	t.Run("Created", func(t *testing.T) {
+		photo := entity.Photo{PhotoUID: "ps6sg6be2lvl0y11"}
+		status := NewPhotoStatus(photo)
+
+		assert.Equal(t, "ps6sg6be2lvl0y11", status.UID)
+		assert.False(t, status.Metadata)
+		assert.False(t, status.Thumbnails)
+		assert.False(t, status.Faces)
+		assert.False(t, status.Labels)
+		assert.False(t, status.Ready)
+	})
+	t.Run("Indexed", func(t *testing.T) {
+		photo := entity.Photo{
+			PhotoUID: "ps6sg6be2lvl0y12",
+			Files:    []entity.File{{FilePrimary: true, FileWidth: 1920, FileHeight: 1080}},
+		}
+
+		status := NewPhotoStatus(photo)
+
+		assert.True(t, status.Metadata)
+		assert.False(t, status.Thumbnails)
+		assert.False(t, status.Ready)
+	})
+	t.Run("Thumbnails", func(t *testing.T) {
+		photo := entity.Photo{
+			PhotoUID: "ps6sg6be2lvl0y13",
+			Files: []entity.File{
+				{FileSidecar: true},
+				{FilePrimary: true, FileWidth: 1920, FileHeight: 1080, FileMainColor: "blue", FileColors: "226611CC1"},
+			},
+		}
+
+		status := NewPhotoStatus(photo)
+
+		assert.True(t, status.Metadata)
+		assert.True(t, status.Thumbnails)
+		assert.False(t, status.Faces)
+		assert.False(t, status.Labels)
+		assert.True(t, status.Ready)
+	})
+	t.Run("Complete", func(t *testing.T) {
+		photo := entity.Photo{
+			PhotoUID:   "ps6sg6be2lvl0y14",
+			PhotoFaces: 2,
+			Files:      []entity.File{{FilePrimary: true, FileWidth: 1920, FileHeight: 1080, FileMainColor: "blue", FileColors: "226611CC1"}},
+			Labels:     []entity.PhotoLabel{{LabelID: 1, Uncertainty: 20}},
+		}
+
+		status := NewPhotoStatus(photo)
+
+		assert.True(t, status.Metadata)
+		assert.True(t, status.Thumbnails)
+		assert.True(t, status.Faces)
+		assert.True(t, status.Labels)
+		assert.True(t, status.Ready)
+	})
+}
+
+func TestGetPhotoStatus(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoStatus(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/status")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", gjson.Get(r.Body.String(), "UID").String())
+		assert.True(t, gjson.Get(r.Body.String(), "Metadata").Exists())
+		assert.True(t, gjson.Get(r.Body.String(), "Thumbnails").Exists())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoStatus(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/xxx/status")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
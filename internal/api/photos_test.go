@@ -2,14 +2,46 @@ package api
 
 import (
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
 )
 
+// removedPhoto creates a photo and removes it from the index, as if its files had been purged.
+func removedPhoto(t *testing.T) entity.Photo {
+	takenAt := time.Date(2004, 5, 6, 7, 8, 9, 0, time.UTC)
+	photo := entity.Photo{PhotoTitle: "Removed", PhotoType: entity.MediaImage, TakenAt: takenAt, TakenAtLocal: takenAt, TakenSrc: entity.SrcMeta}
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _, _ = photo.DeletePermanently() })
+
+	file := entity.File{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FileName: "removed/" + photo.PhotoUID + ".jpg", FileHash: "removed" + photo.PhotoUID, FileType: "jpg", FilePrimary: true}
+
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	} else if _, err = photo.Delete(false); err != nil {
+		t.Fatal(err)
+	}
+
+	return photo
+}
+
 func TestGetPhoto(t *testing.T) {
 	t.Run("Ok", func(t *testing.T) {
 		app, router, _ := NewApiTest()
@@ -18,6 +50,101 @@ func TestGetPhoto(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 		val := gjson.Get(r.Body.String(), "Iso")
 		assert.Equal(t, "200", val.String())
+		assert.Equal(t, "WigKNBqAF3h4iHeId4eAcQjoiA", gjson.Get(r.Body.String(), "ThumbHash").String())
+		assert.Equal(t, "LKO2?U%2Tw=w]~RBVZRi};RPxuwH", gjson.Get(r.Body.String(), "BlurHash").String())
+		assert.Equal(t, "meta", gjson.Get(r.Body.String(), "TakenSrc").String())
+	})
+	t.Run("IncludeBasic", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhoto(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7?include=basic")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", gjson.Get(r.Body.String(), "UID").String())
+		assert.Equal(t, "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", gjson.Get(r.Body.String(), "Hash").String())
+		assert.True(t, gjson.Get(r.Body.String(), "TakenAt").Exists())
+		assert.True(t, gjson.Get(r.Body.String(), "Title").Exists())
+		assert.False(t, gjson.Get(r.Body.String(), "Files").Exists())
+		assert.False(t, gjson.Get(r.Body.String(), "Labels").Exists())
+		assert.False(t, gjson.Get(r.Body.String(), "Details").Exists())
+	})
+	t.Run("IncludeFull", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhoto(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7?include=full")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "Files").Exists())
+		assert.True(t, gjson.Get(r.Body.String(), "Labels").Exists())
+	})
+	t.Run("IncludeInvalid", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhoto(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7?include=foo")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("IncludeBasicNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhoto(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0xxx?include=basic")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("IncludeBasicRemoved", func(t *testing.T) {
+		photo := removedPhoto(t)
+		app, router, _ := NewApiTest()
+		GetPhoto(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/"+photo.PhotoUID+"?include=basic")
+		assert.Equal(t, http.StatusGone, r.Code)
+	})
+
+	t.Run("Archived", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhoto(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y25")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("Removed", func(t *testing.T) {
+		photo := removedPhoto(t)
+		app, router, _ := NewApiTest()
+		GetPhoto(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/"+photo.PhotoUID)
+		assert.Equal(t, http.StatusGone, r.Code)
+		assert.Equal(t, i18n.Msg(i18n.ErrEntityRemoved), gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, photo.PhotoUID, gjson.Get(r.Body.String(), "uid").String())
+		assert.NotEmpty(t, gjson.Get(r.Body.String(), "deletedAt").String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhoto(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0xxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+
+	t.Run("PreloadHeaders", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.Options().ThumbPreload = "tile_500,fit_1920"
+		defer func() { conf.Options().ThumbPreload = "" }()
+		GetPhoto(router)
+		req, _ := http.NewRequest("GET", "/api/v1/photos/pt9jtdre2lvl0yh7", nil)
+		req.Proto, req.ProtoMajor, req.ProtoMinor = "HTTP/2.0", 2, 0
+		r := httptest.NewRecorder()
+		app.ServeHTTP(r, req)
+		assert.Equal(t, http.StatusOK, r.Code)
+		links := r.Header().Values("Link")
+		if assert.Len(t, links, 2) {
+			assert.True(t, strings.HasPrefix(links[0], "<"+conf.ContentUri()+"/t/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/"))
+			assert.True(t, strings.HasSuffix(links[0], "/tile_500>; rel=preload; as=image"))
+			assert.True(t, strings.HasPrefix(links[1], "<"+conf.ContentUri()+"/t/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/"))
+			assert.True(t, strings.HasSuffix(links[1], "/fit_1920>; rel=preload; as=image"))
+		}
+	})
+
+	t.Run("NoPreloadHeadersHttp1", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.Options().ThumbPreload = "tile_500,fit_1920"
+		defer func() { conf.Options().ThumbPreload = "" }()
+		GetPhoto(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Empty(t, r.Header().Values("Link"))
 	})
 
 	t.Run("NotFound", func(t *testing.T) {
@@ -39,6 +166,71 @@ func TestUpdatePhoto(t *testing.T) {
 		assert.Equal(t, "de", val2.String())
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("EventFields", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePhoto(router)
+
+		s := event.Subscribe("photos.updated")
+		defer event.Unsubscribe(s)
+
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y13", `{"Title": "Event Fields", "Favorite": true}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		select {
+		case msg := <-s.Receiver:
+			assert.Equal(t, []string{"Title", "Favorite"}, msg.Fields["fields"])
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+	})
+
+	t.Run("IdempotencyKey", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePhoto(router)
+
+		update := func(key, body string) *httptest.ResponseRecorder {
+			req, _ := http.NewRequest("PUT", "/api/v1/photos/pt9jtdre2lvl0y13", strings.NewReader(body))
+
+			if key != "" {
+				req.Header.Set(IdempotencyKeyHeader, key)
+			}
+
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, req)
+
+			return w
+		}
+
+		r := update("3f1c5a3e-b5a4-4bd1-9a8e-d2c3b4a5e6f7", `{"Title": "Idempotent01"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Idempotent01", gjson.Get(r.Body.String(), "Title").String())
+		assert.Empty(t, r.Header().Get(IdempotentReplayedHeader))
+
+		// Retries with the same key must return the previous response without saving again.
+		r = update("3f1c5a3e-b5a4-4bd1-9a8e-d2c3b4a5e6f7", `{"Title": "Idempotent01"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Idempotent01", gjson.Get(r.Body.String(), "Title").String())
+		assert.Equal(t, "true", r.Header().Get(IdempotentReplayedHeader))
+
+		// Reusing the key for a different request must fail.
+		r = update("3f1c5a3e-b5a4-4bd1-9a8e-d2c3b4a5e6f7", `{"Title": "Idempotent02"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, r.Code)
+		assert.Empty(t, r.Header().Get(IdempotentReplayedHeader))
+
+		if m, err := query.PhotoByUID("pt9jtdre2lvl0y13"); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, "Idempotent01", m.PhotoTitle)
+		}
+
+		// Requests with a different or without key are saved.
+		r = update("0b7e1d2c-9f8a-4c3b-8e2d-1a0b9c8d7e6f", `{"Title": "Idempotent02"}`)
+		assert.Equal(t, "Idempotent02", gjson.Get(r.Body.String(), "Title").String())
+		assert.Empty(t, r.Header().Get(IdempotentReplayedHeader))
+
+		r = update("", `{"Title": "Idempotent03"}`)
+		assert.Equal(t, "Idempotent03", gjson.Get(r.Body.String(), "Title").String())
+	})
 
 	t.Run("BadRequest", func(t *testing.T) {
 		app, router, _ := NewApiTest()
@@ -47,6 +239,25 @@ func TestUpdatePhoto(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
 
+	t.Run("InvalidValues", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePhoto(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y13", `{"Title": "Invalid", "Lat": 91, "Month": 13}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, r.Code)
+		assert.Equal(t, i18n.Msg(i18n.ErrInvalidValues), gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, "Lat", gjson.Get(r.Body.String(), "fields.0.field").String())
+		assert.Equal(t, "must be between -90 and 90", gjson.Get(r.Body.String(), "fields.0.message").String())
+		assert.Equal(t, "Month", gjson.Get(r.Body.String(), "fields.1.field").String())
+
+		m, err := query.PhotoByUID("pt9jtdre2lvl0y13")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEqual(t, "Invalid", m.PhotoTitle)
+	})
+
 	t.Run("NotFound", func(t *testing.T) {
 		app, router, _ := NewApiTest()
 		UpdatePhoto(router)
@@ -57,6 +268,81 @@ func TestUpdatePhoto(t *testing.T) {
 	})
 }
 
+func TestPatchPhoto(t *testing.T) {
+	t.Run("EventFields", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PatchPhoto(router)
+
+		s := event.Subscribe("photos.updated")
+		defer event.Unsubscribe(s)
+
+		r := PerformRequestWithBody(app, "PATCH", "/api/v1/photos/pt9jtdre2lvl0y16", `{"Title": "Patched Title", "Details": {"Notes": "Patched Notes"}}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		select {
+		case msg := <-s.Receiver:
+			assert.Equal(t, []string{"Details", "Title"}, msg.Fields["fields"])
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+	})
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PatchPhoto(router)
+		r := PerformRequestWithBody(app, "PATCH", "/api/v1/photos/pt9jtdre2lvl0y16", `{"Title": "Patched Title", "Description": "Patched Description"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Patched Title", gjson.Get(r.Body.String(), "Title").String())
+		assert.Equal(t, "manual", gjson.Get(r.Body.String(), "TitleSrc").String())
+		assert.Equal(t, "Patched Description", gjson.Get(r.Body.String(), "Description").String())
+		lat := gjson.Get(r.Body.String(), "Lat").Float()
+		lng := gjson.Get(r.Body.String(), "Lng").Float()
+
+		// Only update the description.
+		r = PerformRequestWithBody(app, "PATCH", "/api/v1/photos/pt9jtdre2lvl0y16", `{"Description": "Second Description"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Patched Title", gjson.Get(r.Body.String(), "Title").String())
+		assert.Equal(t, "Second Description", gjson.Get(r.Body.String(), "Description").String())
+		assert.Equal(t, "manual", gjson.Get(r.Body.String(), "DescriptionSrc").String())
+		assert.Equal(t, lat, gjson.Get(r.Body.String(), "Lat").Float())
+		assert.Equal(t, lng, gjson.Get(r.Body.String(), "Lng").Float())
+
+		// Only update the location.
+		r = PerformRequestWithBody(app, "PATCH", "/api/v1/photos/pt9jtdre2lvl0y16", `{"Lat": 48.519234, "Lng": 9.057997}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Patched Title", gjson.Get(r.Body.String(), "Title").String())
+		assert.Equal(t, "Second Description", gjson.Get(r.Body.String(), "Description").String())
+		assert.InDelta(t, 48.519234, gjson.Get(r.Body.String(), "Lat").Float(), 0.00001)
+		assert.InDelta(t, 9.057997, gjson.Get(r.Body.String(), "Lng").Float(), 0.00001)
+		assert.Equal(t, "manual", gjson.Get(r.Body.String(), "PlaceSrc").String())
+	})
+	t.Run("Details", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PatchPhoto(router)
+		r := PerformRequestWithBody(app, "PATCH", "/api/v1/photos/pt9jtdre2lvl0y17", `{"Details": {"Artist": "Patched Artist"}}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Patched Artist", gjson.Get(r.Body.String(), "Details.Artist").String())
+		assert.Equal(t, "manual", gjson.Get(r.Body.String(), "Details.ArtistSrc").String())
+	})
+	t.Run("UnknownField", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PatchPhoto(router)
+		r := PerformRequestWithBody(app, "PATCH", "/api/v1/photos/pt9jtdre2lvl0y16", `{"Name": "Patched"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("BadRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PatchPhoto(router)
+		r := PerformRequestWithBody(app, "PATCH", "/api/v1/photos/pt9jtdre2lvl0y16", `{"Country": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PatchPhoto(router)
+		r := PerformRequestWithBody(app, "PATCH", "/api/v1/photos/xxx", `{"Title": "Patched"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
 func TestGetPhotoDownload(t *testing.T) {
 	t.Run("OriginalMissing", func(t *testing.T) {
 		app, router, conf := NewApiTest()
@@ -72,6 +358,15 @@ func TestGetPhotoDownload(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
 
+	t.Run("Removed", func(t *testing.T) {
+		photo := removedPhoto(t)
+		app, router, conf := NewApiTest()
+		GetPhotoDownload(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/"+photo.PhotoUID+"/dl?t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusGone, r.Code)
+		assert.Equal(t, photo.PhotoUID, gjson.Get(r.Body.String(), "uid").String())
+	})
+
 	t.Run("InvalidToken", func(t *testing.T) {
 		app, router, conf := NewApiTest()
 		conf.SetAuthMode(config.AuthModePasswd)
@@ -80,6 +375,173 @@ func TestGetPhotoDownload(t *testing.T) {
 		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/dl?t=xxx")
 		assert.Equal(t, http.StatusForbidden, r.Code)
 	})
+
+	t.Run("ConditionalRequest", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetPhotoDownload(router)
+
+		f, err := query.FileByPhotoUID("pt9jtdre2lvl0y11")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+
+		if fs.FileExists(fileName) {
+			t.Skipf("%s already exists", fileName)
+		} else if err = os.MkdirAll(filepath.Dir(fileName), fs.ModeDir); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(fileName, []byte("conditional request"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		modTime := time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC)
+
+		if err = os.Chtimes(fileName, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+
+		url := "/api/v1/photos/pt9jtdre2lvl0y11/dl?t=" + conf.DownloadToken()
+		etag := `"` + f.FileHash + `"`
+
+		r := PerformRequest(app, "GET", url)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, etag, r.Header().Get("ETag"))
+		assert.Equal(t, modTime.Format(http.TimeFormat), r.Header().Get("Last-Modified"))
+		assert.Equal(t, "conditional request", r.Body.String())
+
+		req, _ := http.NewRequest("GET", url, nil)
+		req.Header.Set("If-None-Match", etag)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Equal(t, etag, w.Header().Get("ETag"))
+		assert.Empty(t, w.Body.String())
+
+		req, _ = http.NewRequest("GET", url, nil)
+		req.Header.Set("If-None-Match", `"outdated"`)
+		w = httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req, _ = http.NewRequest("GET", url, nil)
+		req.Header.Set("If-Modified-Since", modTime.Format(http.TimeFormat))
+		w = httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+	})
+	t.Run("RangeRequest", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetPhotoDownload(router)
+
+		f, err := query.FileByPhotoUID("pt9jtdre2lvl0y11")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+
+		if fs.FileExists(fileName) {
+			t.Skipf("%s already exists", fileName)
+		} else if err = os.MkdirAll(filepath.Dir(fileName), fs.ModeDir); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(fileName, []byte("range request"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		url := "/api/v1/photos/pt9jtdre2lvl0y11/dl?t=" + conf.DownloadToken()
+
+		r := PerformRequest(app, "GET", url)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "bytes", r.Header().Get("Accept-Ranges"))
+		assert.Equal(t, `attachment; filename=bridge.jpg`, r.Header().Get("Content-Disposition"))
+		assert.Equal(t, "range request", r.Body.String())
+
+		req, _ := http.NewRequest("GET", url, nil)
+		req.Header.Set("Range", "bytes=0-4")
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusPartialContent, w.Code)
+		assert.Equal(t, "bytes 0-4/13", w.Header().Get("Content-Range"))
+		assert.Equal(t, `attachment; filename=bridge.jpg`, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "range", w.Body.String())
+
+		req, _ = http.NewRequest("GET", url, nil)
+		req.Header.Set("Range", "bytes=100-")
+		w = httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, w.Code)
+
+		r = PerformRequest(app, "GET", url+"&inline=true")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, `inline; filename=bridge.jpg`, r.Header().Get("Content-Disposition"))
+	})
+}
+
+func TestGetPhotoFileDownload(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetPhotoFileDownload(router)
+
+		f, err := query.FileByUID("ft71s39w45bnlqdw")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+
+		if fs.FileExists(fileName) {
+			t.Skipf("%s already exists", fileName)
+		} else if err = os.MkdirAll(filepath.Dir(fileName), fs.ModeDir); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(fileName, []byte("stacked video"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y17/dl/ft71s39w45bnlqdw?name=file&t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Header().Get("Content-Disposition"), "Video.mp4")
+		assert.Equal(t, `"`+f.FileHash+`"`, r.Header().Get("ETag"))
+		assert.Equal(t, "stacked video", r.Body.String())
+
+		// Range requests of videos are served inline for playback.
+		req, _ := http.NewRequest("GET", "/api/v1/photos/pt9jtdre2lvl0y17/dl/ft71s39w45bnlqdw?name=file&t="+conf.DownloadToken(), nil)
+		req.Header.Set("Range", "bytes=0-6")
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusPartialContent, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "inline;")
+		assert.Equal(t, "stacked", w.Body.String())
+	})
+	t.Run("WrongPhoto", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetPhotoFileDownload(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y11/dl/ft71s39w45bnlqdw?t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetPhotoFileDownload(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y17/dl/ft71s39w45bnlxxx?t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		GetPhotoFileDownload(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y17/dl/ft71s39w45bnlqdw?t=xxx")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
 }
 
 func TestLikePhoto(t *testing.T) {
@@ -93,6 +555,24 @@ func TestLikePhoto(t *testing.T) {
 		val := gjson.Get(r2.Body.String(), "Favorite")
 		assert.Equal(t, "true", val.String())
 	})
+	t.Run("EventFields", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		LikePhoto(router)
+
+		s := event.Subscribe("photos.updated")
+		defer event.Unsubscribe(s)
+
+		r := PerformRequest(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh9/like")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		select {
+		case msg := <-s.Receiver:
+			assert.Equal(t, []string{PhotoFavorite}, msg.Fields["fields"])
+			assert.NotNil(t, msg.Fields["entities"])
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+	})
 
 	t.Run("NotFound", func(t *testing.T) {
 		app, router, _ := NewApiTest()
@@ -147,6 +627,33 @@ func TestPhotoPrimary(t *testing.T) {
 	})
 }
 
+func TestPhotoPrimaryAuto(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PhotoPrimaryAuto(router)
+		r := PerformRequest(app, "POST", "/api/v1/photos/pt9jtxrexxvl0y22/files/primary/auto")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "pt9jtxrexxvl0y22", gjson.Get(r.Body.String(), "Photo.UID").String())
+		assert.Equal(t, "ft2es49qhhinlplj", gjson.Get(r.Body.String(), "File.UID").String())
+		assert.Equal(t, FileRolePrimary, gjson.Get(r.Body.String(), "File.Role").String())
+		assert.True(t, gjson.Get(r.Body.String(), "File.Primary").Bool())
+	})
+	t.Run("NoSuitableFile", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PhotoPrimaryAuto(router)
+		r := PerformRequest(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y22/files/primary/auto")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+		assert.Equal(t, i18n.Msg(i18n.ErrFileNotFound), gjson.Get(r.Body.String(), "error").String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PhotoPrimaryAuto(router)
+		r := PerformRequest(app, "POST", "/api/v1/photos/pt9jtdre2lvl0xxx/files/primary/auto")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+		assert.Equal(t, i18n.Msg(i18n.ErrEntityNotFound), gjson.Get(r.Body.String(), "error").String())
+	})
+}
+
 func TestGetPhotoYaml(t *testing.T) {
 	t.Run("Ok", func(t *testing.T) {
 		app, router, _ := NewApiTest()
@@ -154,6 +661,55 @@ func TestGetPhotoYaml(t *testing.T) {
 		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/yaml")
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("Minimal", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoYaml(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/yaml?fields=minimal")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), "UID: pt9jtdre2lvl0yh7")
+		assert.NotContains(t, r.Body.String(), "ISO:")
+	})
+	t.Run("Full", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoYaml(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/yaml?fields=full")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), "UID: pt9jtdre2lvl0yh7")
+	})
+	t.Run("Refresh", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetPhotoYaml(router)
+
+		p, err := query.PhotoPreloadByUID("pt9jtdre2lvl0yh7")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fileName := p.YamlFileName(conf.OriginalsPath(), conf.SidecarPath())
+		_ = os.Remove(fileName)
+
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/yaml?refresh=true")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), "UID: pt9jtdre2lvl0yh7")
+		assert.Equal(t, "true", r.Header().Get("X-Yaml-Written"))
+		assert.True(t, fs.FileExists(fileName))
+
+		// The file is not written again if it is unchanged.
+		r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/yaml?refresh=true")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "false", r.Header().Get("X-Yaml-Written"))
+
+		// Without refresh, no header is added.
+		r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/yaml")
+		assert.Equal(t, "", r.Header().Get("X-Yaml-Written"))
+	})
+	t.Run("InvalidFields", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoYaml(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/yaml?fields=all")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
 
 	t.Run("NotFound", func(t *testing.T) {
 		app, router, _ := NewApiTest()
@@ -185,3 +741,91 @@ func TestApprovePhoto(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
 }
+
+func TestPhotosPrivate(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		photos := make([]entity.Photo, 2)
+
+		for i := range photos {
+			photos[i] = entity.Photo{PhotoTitle: "Private Selection", PhotoType: entity.MediaImage, PhotoPrivate: i == 1}
+
+			if err := photos[i].Create(); err != nil {
+				t.Fatal(err)
+			}
+
+			defer func(p entity.Photo) { _, _ = p.DeletePermanently() }(photos[i])
+		}
+
+		app, router, _ := NewApiTest()
+		PhotosPrivate(router)
+		body := `{"photos": ["` + photos[0].PhotoUID + `", "` + photos[0].PhotoUID + `", "` + photos[1].PhotoUID + `", "pt9jtdre2lvl0xxx"], "private": true}`
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/private", body)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, `["`+photos[0].PhotoUID+`"]`, gjson.Get(r.Body.String(), "updated").Raw)
+		assert.Equal(t, `["`+photos[1].PhotoUID+`"]`, gjson.Get(r.Body.String(), "unchanged").Raw)
+		assert.Equal(t, i18n.Msg(i18n.ErrEntityNotFound), gjson.Get(r.Body.String(), "errors.pt9jtdre2lvl0xxx").String())
+		assert.Len(t, gjson.Get(r.Body.String(), "errors").Map(), 1)
+
+		for _, p := range photos {
+			if m, err := query.PhotoByUID(p.PhotoUID); err != nil {
+				t.Fatal(err)
+			} else {
+				assert.True(t, m.PhotoPrivate)
+			}
+		}
+
+		// Make the photos public again.
+		body = `{"photos": ["` + photos[0].PhotoUID + `", "` + photos[1].PhotoUID + `"], "private": false}`
+		r = PerformRequestWithBody(app, "POST", "/api/v1/photos/private", body)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Len(t, gjson.Get(r.Body.String(), "updated").Array(), 2)
+
+		if m, err := query.PhotoByUID(photos[1].PhotoUID); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.False(t, m.PhotoPrivate)
+		}
+	})
+	t.Run("NoItemsSelected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PhotosPrivate(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/private", `{"photos": [], "private": true}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PhotosPrivate(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/private", `{"photos": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestApprovePhotos(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhoto(router)
+		ApprovePhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtxrexxvl0y21")
+		assert.Equal(t, "1", gjson.Get(r.Body.String(), "Quality").String())
+		r = PerformRequestWithBody(app, "POST", "/api/v1/photos/approve", `{"photos": ["pt9jtxrexxvl0y21", "pt9jtxrexxvl0y21", "pt9jtdre2lvl0y12", "pt9jtdre2lvl0xxx", "xxx"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, `["pt9jtxrexxvl0y21","pt9jtdre2lvl0y12"]`, gjson.Get(r.Body.String(), "approved").Raw)
+		assert.Equal(t, i18n.Msg(i18n.ErrEntityNotFound), gjson.Get(r.Body.String(), "errors.pt9jtdre2lvl0xxx").String())
+		assert.Equal(t, i18n.Msg(i18n.ErrEntityNotFound), gjson.Get(r.Body.String(), "errors.xxx").String())
+		assert.Len(t, gjson.Get(r.Body.String(), "errors").Map(), 2)
+		r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtxrexxvl0y21")
+		assert.Equal(t, "3", gjson.Get(r.Body.String(), "Quality").String())
+	})
+	t.Run("NoItemsSelected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ApprovePhotos(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/approve", `{"photos": []}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ApprovePhotos(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/approve", `{"photos": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"net/http"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// ThumbRegenerateResponse represents the result of regenerating a single thumbnail size.
+type ThumbRegenerateResponse struct {
+	UID     string `json:"UID"`
+	FileUID string `json:"FileUID"`
+	Size    string `json:"Size"`
+	Path    string `json:"Path"`
+}
+
+// RegeneratePhotoThumb re-renders a single thumbnail size for the primary file of a photo,
+// e.g. if the cached file is corrupt or has been deleted, and returns its path relative to
+// the thumbnail cache.
+//
+// POST /api/v1/photos/:uid/thumbs/:size/regenerate
+//
+// Parameters:
+//
+//	uid: string PhotoUID as returned by the API
+//	size: string Thumbnail size name, e.g. "tile_500"
+func RegeneratePhotoThumb(router *gin.RouterGroup) {
+	router.POST("/photos/:uid/thumbs/:size/regenerate", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+		sizeName := thumb.Name(clean.Token(c.Param("size")))
+		size, ok := thumb.Sizes[sizeName]
+
+		// Only sizes that can be cached with the current config may be regenerated.
+		if !ok || size.ExceedsLimit() || size.Uncached() && !conf.ThumbUncached() {
+			log.Debugf("thumbs: invalid size %s (regenerate)", clean.Log(sizeName.String()))
+			AbortBadRequest(c)
+			return
+		}
+
+		f, err := query.FileByPhotoUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+
+		if !fs.FileExists(fileName) {
+			log.Errorf("thumbs: file %s is missing (regenerate)", clean.Log(f.FileName))
+			Abort(c, http.StatusNotFound, i18n.ErrFileNotFound)
+			return
+		}
+
+		thumbPath := conf.ThumbCachePath()
+		thumbName, err := size.Regenerate(fileName, f.FileHash, thumbPath, f.FileOrientation)
+
+		if err != nil {
+			log.Errorf("thumbs: %s in %s (regenerate)", err, clean.Log(f.FileName))
+			AbortUnexpected(c)
+			return
+		}
+
+		// Remove the cached thumbnail filename so that it is looked up again.
+		get.ThumbCache().Delete(CacheKey("thumbs", f.FileHash, string(sizeName)))
+
+		relName, err := filepath.Rel(thumbPath, thumbName)
+
+		if err != nil {
+			relName = filepath.Base(thumbName)
+		}
+
+		c.JSON(http.StatusOK, ThumbRegenerateResponse{UID: f.PhotoUID, FileUID: f.FileUID, Size: sizeName.String(), Path: relName})
+	})
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestRegeneratePhotoThumb(t *testing.T) {
+	app, router, conf := NewApiTest()
+	RegeneratePhotoThumb(router)
+
+	photo := &entity.Photo{PhotoTitle: "Regenerate Thumb", PhotoType: entity.MediaImage}
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _, _ = photo.DeletePermanently() }()
+
+	fileName := "thumbs-regenerate/" + photo.PhotoUID + ".jpg"
+	filePath := filepath.Join(conf.OriginalsPath(), fileName)
+
+	if err := os.MkdirAll(filepath.Dir(filePath), fs.ModeDir); err != nil {
+		t.Fatal(err)
+	} else if err = fs.Copy(filepath.Join(conf.ExamplesPath(), "cat_black.jpg"), filePath); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = os.RemoveAll(filepath.Dir(filePath)) }()
+
+	hash := fs.Hash(filePath)
+	thumbPath := conf.ThumbCachePath()
+
+	file := &entity.File{
+		PhotoID:     photo.ID,
+		PhotoUID:    photo.PhotoUID,
+		FileRoot:    entity.RootOriginals,
+		FileName:    fileName,
+		FileHash:    hash,
+		FileType:    fs.ImageJPEG.String(),
+		FilePrimary: true,
+	}
+
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _, _ = thumb.RemoveCached(hash, thumbPath) }()
+
+	tile224, err := thumb.Sizes[thumb.Tile224].FileName(hash, thumbPath)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Corrupt", func(t *testing.T) {
+		if err = os.MkdirAll(filepath.Dir(tile224), fs.ModeDir); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(tile224, []byte("corrupt"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		r := PerformRequest(app, "POST", "/api/v1/photos/"+photo.PhotoUID+"/thumbs/tile_224/regenerate")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, photo.PhotoUID, gjson.Get(r.Body.String(), "UID").String())
+		assert.Equal(t, file.FileUID, gjson.Get(r.Body.String(), "FileUID").String())
+		assert.Equal(t, "tile_224", gjson.Get(r.Body.String(), "Size").String())
+		assert.Equal(t, tile224, filepath.Join(thumbPath, gjson.Get(r.Body.String(), "Path").String()))
+
+		info, err := os.Stat(tile224)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Greater(t, info.Size(), int64(len("corrupt")))
+	})
+	t.Run("Deleted", func(t *testing.T) {
+		if err = os.Remove(tile224); err != nil {
+			t.Fatal(err)
+		}
+
+		r := PerformRequest(app, "POST", "/api/v1/photos/"+photo.PhotoUID+"/thumbs/tile_224/regenerate")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.FileExists(t, tile224)
+	})
+	t.Run("InvalidSize", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/photos/"+photo.PhotoUID+"/thumbs/tile_123/regenerate")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/photos/pt9jtdre2lvl0xxx/thumbs/tile_224/regenerate")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("FileMissing", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y11/thumbs/tile_224/regenerate")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ThumbsWarmResponse represents the result of warming the thumbnail cache for a photo.
+type ThumbsWarmResponse struct {
+	UID     string `json:"UID"`
+	FileUID string `json:"FileUID"`
+	Async   bool   `json:"Async"`
+	Created int    `json:"Created"`
+	Cached  int    `json:"Cached"`
+}
+
+// ThumbsWarmQueueSize is the maximum number of photos for which thumbnails can be
+// waiting to be created in the background.
+const ThumbsWarmQueueSize = 100
+
+// thumbsWarmSlots limits the number of photos for which thumbnails are created at the
+// same time, so that warming the cache does not slow down regular requests too much,
+// and thumbsWarmQueue limits the number of pending background requests.
+var (
+	thumbsWarmOnce  sync.Once
+	thumbsWarmSlots chan struct{}
+	thumbsWarmQueue chan struct{}
+)
+
+// thumbsWarmInit creates the channels used to limit cache warming.
+func thumbsWarmInit() {
+	thumbsWarmOnce.Do(func() {
+		thumbsWarmSlots = make(chan struct{}, get.Config().Workers())
+		thumbsWarmQueue = make(chan struct{}, ThumbsWarmQueueSize)
+	})
+}
+
+// thumbsWarmSlot returns the channel used to limit concurrent cache warming.
+func thumbsWarmSlot() chan struct{} {
+	thumbsWarmInit()
+	return thumbsWarmSlots
+}
+
+// thumbsWarmPending returns the channel used to limit the number of pending background requests.
+func thumbsWarmPending() chan struct{} {
+	thumbsWarmInit()
+	return thumbsWarmQueue
+}
+
+// WarmPhotoThumbs creates all cached thumbnail sizes for the primary file of a photo,
+// e.g. after editing it, so that they don't need to be rendered when first requested.
+// Thumbnails are created in the background unless the "wait" parameter is set. If too
+// many requests are already pending, it returns 429 Too Many Requests.
+//
+// POST /api/v1/photos/:uid/thumbs/warm
+//
+// Parameters:
+//
+//	uid: string PhotoUID as returned by the API
+//	wait: bool Wait until all thumbnails have been created (optional)
+func WarmPhotoThumbs(router *gin.RouterGroup) {
+	router.POST("/photos/:uid/thumbs/warm", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		f, err := query.FileByPhotoUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		mf, err := photoprism.NewMediaFile(photoprism.FileName(f.FileRoot, f.FileName))
+
+		if err != nil {
+			log.Errorf("thumbs: %s in %s (warm)", err, clean.Log(f.FileName))
+			Abort(c, http.StatusNotFound, i18n.ErrFileNotFound)
+			return
+		}
+
+		thumbPath := get.Config().ThumbCachePath()
+		slots := thumbsWarmSlot()
+		result := ThumbsWarmResponse{UID: f.PhotoUID, FileUID: f.FileUID}
+
+		if !txt.Bool(c.Query("wait")) {
+			queue := thumbsWarmPending()
+
+			// Reject the request if the queue is full.
+			select {
+			case queue <- struct{}{}:
+			default:
+				AbortBusy(c)
+				return
+			}
+
+			result.Async = true
+
+			go func() {
+				defer func() { <-queue }()
+
+				slots <- struct{}{}
+				defer func() { <-slots }()
+
+				if _, _, err := mf.WarmThumbnails(thumbPath, false); err != nil {
+					log.Errorf("thumbs: %s in %s (warm)", err, clean.Log(f.FileName))
+				}
+			}()
+
+			c.JSON(http.StatusAccepted, result)
+			return
+		}
+
+		// Wait for a free slot, unless the request is canceled.
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+		case <-c.Request.Context().Done():
+			AbortBusy(c)
+			return
+		}
+
+		if result.Created, result.Cached, err = mf.WarmThumbnails(thumbPath, false); err != nil {
+			log.Errorf("thumbs: %s in %s (warm)", err, clean.Log(f.FileName))
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
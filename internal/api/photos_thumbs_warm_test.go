@@ -0,0 +1,121 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func TestWarmPhotoThumbs(t *testing.T) {
+	app, router, conf := NewApiTest()
+	WarmPhotoThumbs(router)
+
+	photo := &entity.Photo{PhotoTitle: "Warm Thumbs", PhotoType: entity.MediaImage}
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _, _ = photo.DeletePermanently() }()
+
+	fileName := "thumbs-warm/" + photo.PhotoUID + ".jpg"
+	filePath := filepath.Join(conf.OriginalsPath(), fileName)
+
+	if err := os.MkdirAll(filepath.Dir(filePath), fs.ModeDir); err != nil {
+		t.Fatal(err)
+	} else if err = fs.Copy(filepath.Join(conf.ExamplesPath(), "cat_black.jpg"), filePath); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = os.RemoveAll(filepath.Dir(filePath)) }()
+
+	file := &entity.File{
+		PhotoID:     photo.ID,
+		PhotoUID:    photo.PhotoUID,
+		FileRoot:    entity.RootOriginals,
+		FileName:    fileName,
+		FileHash:    rnd.GenerateUID('h'),
+		FileType:    fs.ImageJPEG.String(),
+		FilePrimary: true,
+	}
+
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	hash := fs.Hash(filePath)
+	thumbPath := conf.ThumbCachePath()
+
+	defer func() { _, _ = thumb.RemoveCached(hash, thumbPath) }()
+
+	url := "/api/v1/photos/" + photo.PhotoUID + "/thumbs/warm"
+
+	t.Run("Async", func(t *testing.T) {
+		r := PerformRequest(app, "POST", url)
+		assert.Equal(t, http.StatusAccepted, r.Code)
+		assert.Equal(t, photo.PhotoUID, gjson.Get(r.Body.String(), "UID").String())
+		assert.Equal(t, file.FileUID, gjson.Get(r.Body.String(), "FileUID").String())
+		assert.True(t, gjson.Get(r.Body.String(), "Async").Bool())
+
+		tile50, err := thumb.Sizes[thumb.Tile50].FileName(hash, thumbPath)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Wait until the thumbnails have been created in the background.
+		assert.Eventually(t, func() bool {
+			return fs.FileExists(tile50) && len(thumbsWarmSlot()) == 0 && len(thumbsWarmPending()) == 0
+		}, 10*time.Second, 10*time.Millisecond)
+	})
+	t.Run("QueueFull", func(t *testing.T) {
+		queue := thumbsWarmPending()
+
+		for i := 0; i < cap(queue); i++ {
+			queue <- struct{}{}
+		}
+
+		r := PerformRequest(app, "POST", url)
+
+		for i := 0; i < cap(queue); i++ {
+			<-queue
+		}
+
+		assert.Equal(t, http.StatusTooManyRequests, r.Code)
+	})
+	t.Run("Cached", func(t *testing.T) {
+		r := PerformRequest(app, "POST", url+"?wait=true")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.False(t, gjson.Get(r.Body.String(), "Async").Bool())
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "Created").Int())
+		assert.Greater(t, gjson.Get(r.Body.String(), "Cached").Int(), int64(0))
+	})
+	t.Run("Wait", func(t *testing.T) {
+		if _, err := thumb.RemoveCached(hash, thumbPath); err != nil {
+			t.Fatal(err)
+		}
+
+		r := PerformRequest(app, "POST", url+"?wait=true")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Greater(t, gjson.Get(r.Body.String(), "Created").Int(), int64(0))
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "Cached").Int())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/photos/pt9jtdre2lvl0xxx/thumbs/warm")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("FileMissing", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y11/thumbs/warm?wait=true")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// SetPhotoTime changes the time when a photo was taken and its time zone, so that the local time
+// and the sort order are updated consistently without sending the complete photo form.
+//
+// POST /api/v1/photos/:uid/time
+//
+// Parameters:
+//
+//	uid: string PhotoUID as returned by the API
+//
+// Request Body:
+//   - TakenAt (string) ISO timestamp, refers to the local time if it has no UTC offset
+//   - TimeZone (string) IANA time zone, e.g. "Europe/Berlin", default is UTC
+func SetPhotoTime(router *gin.RouterGroup) {
+	router.POST("/photos/:uid/time", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.PhotoTime
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		var invalid form.ValidationErrors
+
+		takenAt, loc, err := f.Parse()
+
+		// Return the invalid fields, e.g. an unknown time zone, with status code 422.
+		if errors.As(err, &invalid) {
+			AbortInvalidValues(c, invalid)
+			return
+		} else if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		m, err := query.PhotoByUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		// Remember the previous date, so that the counts are only updated if it has changed.
+		prevYear, prevMonth := m.PhotoYear, m.PhotoMonth
+
+		if err = m.SaveTime(takenAt, loc.String()); err != nil {
+			log.Errorf("photo: %s (set time)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		PublishPhotoEvent(EntityUpdated, m.PhotoUID, c, PhotoTakenAt, PhotoTakenAtLocal, PhotoTimeZone)
+
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		p, err := query.PhotoPreloadByUID(m.PhotoUID)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		SavePhotoAsYaml(p)
+
+		// Update precalculated counts and the years in the client config.
+		if m.PhotoYear != prevYear || m.PhotoMonth != prevMonth {
+			logWarn("index", entity.UpdateCounts())
+		}
+
+		UpdateClientConfig()
+
+		c.JSON(http.StatusOK, p)
+	})
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestSetPhotoTime(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		photo := entity.Photo{PhotoTitle: "Set Time", PhotoType: entity.MediaImage}
+
+		if err := photo.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _, _ = photo.DeletePermanently() }()
+
+		app, router, _ := NewApiTest()
+		SetPhotoTime(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/"+photo.PhotoUID+"/time", `{"TakenAt": "2019-07-04T12:00:00", "TimeZone": "Europe/Berlin"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Europe/Berlin", gjson.Get(r.Body.String(), "TimeZone").String())
+		assert.Equal(t, entity.SrcManual, gjson.Get(r.Body.String(), "TakenSrc").String())
+
+		takenAt, err := time.Parse(time.RFC3339, gjson.Get(r.Body.String(), "TakenAt").String())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, time.Date(2019, 7, 4, 10, 0, 0, 0, time.UTC), takenAt.UTC())
+		assert.Contains(t, gjson.Get(r.Body.String(), "TakenAtLocal").String(), "2019-07-04T12:00:00")
+		assert.Equal(t, int64(2019), gjson.Get(r.Body.String(), "Year").Int())
+	})
+	t.Run("InvalidTimeZone", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SetPhotoTime(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh7/time", `{"TakenAt": "2019-07-04T12:00:00", "TimeZone": "Mars/Olympus"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, r.Code)
+		assert.Equal(t, "TimeZone", gjson.Get(r.Body.String(), "fields.0.field").String())
+	})
+	t.Run("InvalidTimestamp", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SetPhotoTime(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh7/time", `{"TakenAt": "yesterday", "TimeZone": "UTC"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, r.Code)
+		assert.Equal(t, "TakenAt", gjson.Get(r.Body.String(), "fields.0.field").String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SetPhotoTime(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0xxx/time", `{"TakenAt": "2019-07-04T12:00:00Z"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("BadRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SetPhotoTime(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh7/time", `{"TakenAt": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
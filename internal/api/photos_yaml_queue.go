@@ -0,0 +1,89 @@
+package api
+
+import (
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// photoYamlQueue coalesces YAML sidecar file updates of the same photo.
+var photoYamlQueue = &PhotoYamlQueue{pending: make(map[string]*time.Timer)}
+
+// PhotoYamlQueue delays photo YAML file updates so that rapid successive edits result in a single write.
+type PhotoYamlQueue struct {
+	mutex   sync.Mutex
+	pending map[string]*time.Timer
+}
+
+// Schedule updates the YAML file of a photo after the delay, unless an update is already pending.
+func (q *PhotoYamlQueue) Schedule(photoUID string, delay time.Duration) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if _, ok := q.pending[photoUID]; ok {
+		return
+	}
+
+	q.pending[photoUID] = time.AfterFunc(delay, func() {
+		q.mutex.Lock()
+		_, ok := q.pending[photoUID]
+		delete(q.pending, photoUID)
+		q.mutex.Unlock()
+
+		// Skip if the update has already been flushed.
+		if ok {
+			q.save(photoUID)
+		}
+	})
+}
+
+// Pending returns the number of pending updates.
+func (q *PhotoYamlQueue) Pending() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return len(q.pending)
+}
+
+// Flush immediately writes all pending updates and returns the number of updated photos.
+func (q *PhotoYamlQueue) Flush() (count int) {
+	q.mutex.Lock()
+	uids := make([]string, 0, len(q.pending))
+
+	for photoUID, timer := range q.pending {
+		timer.Stop()
+		uids = append(uids, photoUID)
+	}
+
+	q.pending = make(map[string]*time.Timer)
+	q.mutex.Unlock()
+
+	for _, photoUID := range uids {
+		if q.save(photoUID) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// save loads the current photo data from the index and writes it to the YAML file.
+func (q *PhotoYamlQueue) save(photoUID string) bool {
+	p, err := query.PhotoPreloadByUID(photoUID)
+
+	if err != nil {
+		log.Errorf("photo: %s in %s (update yaml)", err, clean.Log(photoUID))
+		return false
+	}
+
+	savePhotoAsYaml(p)
+
+	return true
+}
+
+// FlushPhotoYaml writes all pending photo YAML file updates, e.g. before shutting down.
+func FlushPhotoYaml() int {
+	return photoYamlQueue.Flush()
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestPhotoYamlQueue(t *testing.T) {
+	t.Run("Coalesced", func(t *testing.T) {
+		conf := get.Config()
+		conf.Options().BackupYamlDelay = 60
+		defer func() { conf.Options().BackupYamlDelay = 0 }()
+
+		p, err := query.PhotoPreloadByUID("pt9jtdre2lvl0yh7")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fileName := p.YamlFileName(conf.OriginalsPath(), conf.SidecarPath())
+
+		for i := 0; i < 5; i++ {
+			SavePhotoAsYaml(p)
+		}
+
+		assert.Equal(t, 1, photoYamlQueue.Pending())
+		assert.Equal(t, 1, FlushPhotoYaml())
+		assert.Equal(t, 0, photoYamlQueue.Pending())
+		assert.True(t, fs.FileExists(fileName))
+		assert.Equal(t, 0, FlushPhotoYaml())
+	})
+	t.Run("Timer", func(t *testing.T) {
+		q := &PhotoYamlQueue{pending: make(map[string]*time.Timer)}
+
+		q.Schedule("pt9jtdre2lvl0yh7", time.Millisecond)
+		q.Schedule("pt9jtdre2lvl0yh7", time.Millisecond)
+
+		assert.Eventually(t, func() bool { return q.Pending() == 0 }, time.Second, 5*time.Millisecond)
+		assert.Equal(t, 0, q.Flush())
+	})
+	t.Run("NoDelay", func(t *testing.T) {
+		p, err := query.PhotoPreloadByUID("pt9jtdre2lvl0yh7")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		SavePhotoAsYaml(p)
+
+		assert.Equal(t, 0, photoYamlQueue.Pending())
+	})
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/crop"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// GetSubjectThumb returns a square thumbnail cropped to the face of a subject,
+// using the marker with the highest quality and size as source.
+//
+// GET /api/v1/subjects/:uid/thumb
+//
+// Parameters:
+//
+//	uid: string Subject UID as returned by the API
+//	size: string Crop size name, e.g. tile_320 (default) or tile_160
+func GetSubjectThumb(router *gin.RouterGroup) {
+	router.GET("/subjects/:uid/thumb", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePeople, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		cropName := crop.Tile320
+
+		if name := clean.Token(c.Query("size")); name != "" {
+			cropName = crop.Name(name)
+		}
+
+		cropSize, ok := crop.Sizes[cropName]
+
+		if !ok {
+			log.Errorf("subject: invalid thumb size %s", clean.Log(string(cropName)))
+			AbortBadRequest(c)
+			return
+		}
+
+		subj := entity.FindSubject(clean.UID(c.Param("uid")))
+
+		if subj == nil {
+			Abort(c, http.StatusNotFound, i18n.ErrSubjectNotFound)
+			return
+		}
+
+		placeholder := thumb.Size{Width: cropSize.Width, Height: cropSize.Height}
+
+		marker, err := query.BestSubjectMarker(subj.SubjUID)
+
+		if err != nil {
+			log.Debugf("subject: no face found for %s", clean.Log(subj.SubjName))
+			thumbPlaceholder(c, placeholder, nil)
+			return
+		}
+
+		// Crops are cached by file hash and marker area.
+		fileHash, cropArea := crop.ParseThumb(marker.Thumb)
+
+		if cropArea == "" {
+			cropArea = crop.NewArea("face", marker.X, marker.Y, marker.W, marker.H).String()
+		}
+
+		fileName, err := crop.FromRequest(fileHash, cropArea, cropSize, get.Config().ThumbCachePath())
+
+		if err != nil {
+			log.Warnf("subject: %s (crop marker %s)", err, clean.Log(marker.MarkerUID))
+			thumbPlaceholder(c, placeholder, nil)
+			return
+		}
+
+		// The best marker may change, so this thumb is not immutable.
+		AddCoverCacheHeader(c)
+
+		c.File(fileName)
+	})
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/crop"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestGetSubjectThumb(t *testing.T) {
+	app, router, conf := NewApiTest()
+	GetSubjectThumb(router)
+
+	hash := "1b0c4f5ad2b13e4c0d43e5f1b2a0c6e8f9a7b6c5"
+
+	// Create a thumbnail with a red face area and a smaller green face area.
+	img := image.NewRGBA(image.Rect(0, 0, 720, 720))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: color.RGBA{B: 255, A: 255}}, image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(360, 180, 540, 360), &image.Uniform{C: color.RGBA{R: 255, A: 255}}, image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(0, 0, 90, 90), &image.Uniform{C: color.RGBA{G: 255, A: 255}}, image.Point{}, draw.Src)
+
+	thumbName := filepath.Join(conf.ThumbCachePath(), hash[0:1], hash[1:2], hash[2:3], hash+"_720x720_fit.jpg")
+
+	if err := os.MkdirAll(filepath.Dir(thumbName), fs.ModeDir); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+
+	if err := jpeg.Encode(buf, img, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatal(err)
+	} else if err = os.WriteFile(thumbName, buf.Bytes(), fs.ModeFile); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = os.RemoveAll(filepath.Dir(thumbName)) }()
+
+	subj := entity.NewSubject("Thumb Test", entity.SubjPerson, entity.SrcManual)
+
+	if err := subj.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = entity.UnscopedDb().Delete(subj).Error }()
+
+	file := entity.File{FileUID: "ft8es39w45bnlqdw", FileHash: hash}
+
+	best := entity.NewMarker(file, crop.NewArea("face", 0.5, 0.25, 0.25, 0.25), subj.SubjUID, entity.SrcManual, entity.MarkerFace, 180, 100)
+	small := entity.NewMarker(file, crop.NewArea("face", 0, 0, 0.125, 0.125), subj.SubjUID, entity.SrcManual, entity.MarkerFace, 90, 100)
+
+	for _, m := range []*entity.Marker{best, small} {
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func(m *entity.Marker) { _ = entity.UnscopedDb().Delete(m).Error }(m)
+	}
+
+	t.Run("Crop", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/subjects/"+subj.SubjUID+"/thumb?size=tile_160")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		result, _, err := image.Decode(r.Body)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 160, result.Bounds().Dx())
+		assert.Equal(t, 160, result.Bounds().Dy())
+
+		// The crop must be centered on the red face area.
+		for _, p := range []image.Point{{80, 80}, {10, 10}, {150, 10}, {10, 150}, {150, 150}} {
+			r, g, b, _ := result.At(p.X, p.Y).RGBA()
+			assert.Greater(t, r>>8, uint32(200), "red at %v", p)
+			assert.Less(t, g>>8, uint32(60), "green at %v", p)
+			assert.Less(t, b>>8, uint32(60), "blue at %v", p)
+		}
+	})
+	t.Run("InvalidSize", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/subjects/"+subj.SubjUID+"/thumb?size=tile_999")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/subjects/jqu0xs11qekk9xxx/thumb")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
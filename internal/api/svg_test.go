@@ -32,6 +32,13 @@ func TestGetSvg(t *testing.T) {
 		assert.Equal(t, fileIconSvg, r.Body.Bytes())
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("document", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSvg(router)
+		r := PerformRequest(app, "GET", "/api/v1/svg/document")
+		assert.Equal(t, documentIconSvg, r.Body.Bytes())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
 	t.Run("video", func(t *testing.T) {
 		app, router, conf := NewApiTest()
 		t.Log(conf)
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image/png"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/thumb"
+)
+
+// GetThumbHash decodes a ThumbHash placeholder for clients that cannot decode it themselves and returns it as PNG image.
+//
+// GET /api/v1/thumbhash/:token/:hash
+//
+// Parameters:
+//
+//	token: string url security token, see config
+//	hash: string URL-safe base64 encoded ThumbHash as returned by the photo API, padding is optional
+func GetThumbHash(router *gin.RouterGroup) {
+	router.GET("/thumbhash/:token/:hash", func(c *gin.Context) {
+		if InvalidPreviewToken(c) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		// Hashes indexed before they were stored with the URL-safe alphabet may still contain "+".
+		hash, err := base64.RawURLEncoding.DecodeString(strings.ReplaceAll(strings.TrimRight(c.Param("hash"), "="), "+", "-"))
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		img, err := thumb.DecodeThumbHash(hash)
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		var buf bytes.Buffer
+
+		if err = png.Encode(&buf, img); err != nil {
+			log.Errorf("thumbhash: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		// Add HTTP cache header.
+		AddImmutableCacheHeader(c)
+
+		c.Data(http.StatusOK, "image/png", buf.Bytes())
+	})
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image/png"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetThumbHash(t *testing.T) {
+	hash := base64.RawURLEncoding.EncodeToString([]byte{90, 40, 10, 52, 26, 128, 23, 120, 120, 136, 119, 136, 119, 135, 128, 113, 8, 232, 136})
+
+	t.Run("Ok", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetThumbHash(router)
+		r := PerformRequest(app, "GET", "/api/v1/thumbhash/"+conf.PreviewToken()+"/"+hash)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "image/png", r.Header().Get("Content-Type"))
+
+		if img, err := png.Decode(bytes.NewReader(r.Body.Bytes())); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, 18, img.Bounds().Dx())
+			assert.Equal(t, 32, img.Bounds().Dy())
+		}
+	})
+	t.Run("PhotoThumbHash", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetPhoto(router)
+		GetThumbHash(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7")
+		assert.Equal(t, http.StatusOK, r.Code)
+		photoHash := gjson.Get(r.Body.String(), "ThumbHash").String()
+		assert.NotEmpty(t, photoHash)
+		r = PerformRequest(app, "GET", "/api/v1/thumbhash/"+conf.PreviewToken()+"/"+photoHash)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "image/png", r.Header().Get("Content-Type"))
+	})
+	t.Run("StdEncoding", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetThumbHash(router)
+		r := PerformRequest(app, "GET", "/api/v1/thumbhash/"+conf.PreviewToken()+"/WigKNBqAF3h4iHeId4eAcQjoiA==")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("InvalidHash", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetThumbHash(router)
+		r := PerformRequest(app, "GET", "/api/v1/thumbhash/"+conf.PreviewToken()+"/AAAA")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		r = PerformRequest(app, "GET", "/api/v1/thumbhash/"+conf.PreviewToken()+"/$$$")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/photoprism/photoprism/internal/crop"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
@@ -25,6 +26,7 @@ import (
 //	thumb: string sha1 file hash plus optional crop area
 //	token: string url security token, see config
 //	size: string thumb type, see thumb.Sizes
+//	crop: string optional fill method, e.g. "left" (query)
 func GetThumb(router *gin.RouterGroup) {
 	router.GET("/t/:thumb/:token/:size", func(c *gin.Context) {
 		if InvalidPreviewToken(c) {
@@ -55,11 +57,11 @@ func GetThumb(router *gin.RouterGroup) {
 
 			if err != nil {
 				log.Warnf("%s: %s", logPrefix, err)
-				c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+				thumbPlaceholder(c, thumb.Size{Width: cropSize.Width, Height: cropSize.Height}, nil)
 				return
 			} else if fileName == "" {
 				log.Errorf("%s: empty file name, potential bug", logPrefix)
-				c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+				thumbPlaceholder(c, thumb.Size{Width: cropSize.Width, Height: cropSize.Height}, nil)
 				return
 			}
 
@@ -95,8 +97,16 @@ func GetThumb(router *gin.RouterGroup) {
 			}
 		}
 
+		cacheName := string(sizeName)
+
+		// Use a different fill method if requested, e.g. "left" for documents.
+		if method, ok := thumb.FillMethod(clean.Token(c.Query("crop"))); ok && method != thumb.ResampleFillCenter && size.FillCenter() {
+			size = size.Reframe(method)
+			cacheName += "_" + thumb.ResampleMethods[method]
+		}
+
 		cache := get.ThumbCache()
-		cacheKey := CacheKey("thumbs", fileHash, string(sizeName))
+		cacheKey := CacheKey("thumbs", fileHash, cacheName)
 
 		if cacheData, ok := cache.Get(cacheKey); ok {
 			log.Tracef("api-v1: cache hit for %s [%s]", cacheKey, time.Since(start))
@@ -105,7 +115,7 @@ func GetThumb(router *gin.RouterGroup) {
 
 			if !fs.FileExists(cached.FileName) {
 				log.Errorf("%s: %s not found", logPrefix, fileHash)
-				c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+				thumbPlaceholder(c, size, nil)
 				return
 			}
 
@@ -148,12 +158,16 @@ func GetThumb(router *gin.RouterGroup) {
 			if err != nil {
 				c.Data(http.StatusOK, "image/svg+xml", fileIconSvg)
 				return
+			} else if f.NoJPEG() && f.NoPNG() && f.MediaType == entity.MediaDocument {
+				// Show a document icon if no preview could be rendered, e.g. from the first page of a PDF.
+				c.Data(http.StatusOK, "image/svg+xml", documentIconSvg)
+				return
 			}
 		}
 
-		// Return SVG icon as placeholder if file has errors.
+		// Return placeholder if file has errors.
 		if f.FileError != "" {
-			c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+			thumbPlaceholder(c, size, f)
 			return
 		}
 
@@ -161,7 +175,7 @@ func GetThumb(router *gin.RouterGroup) {
 
 		if fileName, err = fs.Resolve(fileName); err != nil {
 			log.Errorf("%s: file %s is missing", logPrefix, clean.Log(f.FileName))
-			c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+			thumbPlaceholder(c, size, f)
 
 			// Set missing flag so that the file doesn't show up in search results anymore.
 			logError(logPrefix, f.Update("FileMissing", true))
@@ -199,7 +213,7 @@ func GetThumb(router *gin.RouterGroup) {
 		var thumbName string
 
 		// Try to find or create thumbnail image.
-		if conf.ThumbUncached() || size.Uncached() {
+		if conf.ThumbUncached() || conf.ThumbLazy() || size.Uncached() {
 			thumbName, err = size.FromFile(fileName, f.FileHash, conf.ThumbCachePath(), f.FileOrientation)
 		} else {
 			thumbName, err = size.FromCache(fileName, f.FileHash, conf.ThumbCachePath())
@@ -208,11 +222,11 @@ func GetThumb(router *gin.RouterGroup) {
 		// Failed?
 		if err != nil {
 			log.Errorf("%s: %s", logPrefix, err)
-			c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+			thumbPlaceholder(c, size, f)
 			return
 		} else if thumbName == "" {
 			log.Errorf("%s: %s has empty thumb name - possible bug", logPrefix, filepath.Base(fileName))
-			c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+			thumbPlaceholder(c, size, f)
 			return
 		}
 
@@ -231,3 +245,32 @@ func GetThumb(router *gin.RouterGroup) {
 		}
 	})
 }
+
+// thumbPlaceholder returns an "unavailable" placeholder with the expected thumbnail size so that grid layouts
+// stay intact, or a generic SVG icon depending on the configured fallback style.
+func thumbPlaceholder(c *gin.Context, size thumb.Size, f *entity.File) {
+	style := get.Config().ThumbFallback()
+
+	if style.Icon() {
+		c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+		return
+	}
+
+	width, height := size.Width, size.Height
+
+	// Fit sizes preserve the aspect ratio of the original.
+	if size.Fit && f != nil && f.FileWidth > 0 && f.FileHeight > 0 {
+		t := thumb.New(f.FileWidth, f.FileHeight, f.FileHash, size, "", "")
+		width, height = t.W, t.H
+	}
+
+	data, err := thumb.Placeholder(width, height, style)
+
+	if err != nil {
+		log.Debugf("thumb: %s", err)
+		c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+		return
+	}
+
+	c.Data(http.StatusOK, "image/png", data)
+}
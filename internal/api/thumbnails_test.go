@@ -1,12 +1,21 @@
 package api
 
 import (
+	"bytes"
+	"image/png"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
 )
 
 func TestGetThumb(t *testing.T) {
@@ -57,5 +66,196 @@ func TestGetThumb(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("FileErrorIcon", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.Options().ThumbFallback = "icon"
+		defer func() { conf.Options().ThumbFallback = "" }()
+		GetThumb(router)
+		r := PerformRequest(app, "GET", "/api/v1/t/acad9168fa6acc5c5c2965ddf6ec465ca42fd832/"+conf.PreviewToken()+"/fit_7680")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "image/svg+xml", r.Header().Get("Content-Type"))
+	})
+}
+
+func TestGetThumb_Placeholder(t *testing.T) {
+	app, router, conf := NewApiTest()
+	GetThumb(router)
+
+	takenAt := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	photo := &entity.Photo{
+		TakenAt:      takenAt,
+		TakenAtLocal: takenAt,
+		PhotoTitle:   "Corrupt Image",
+	}
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _, _ = photo.DeletePermanently() }()
+
+	// Create a corrupt source image.
+	fileName := "placeholder/" + photo.PhotoUID + ".jpg"
+	filePath := filepath.Join(conf.OriginalsPath(), fileName)
+
+	if err := os.MkdirAll(filepath.Dir(filePath), fs.ModeDir); err != nil {
+		t.Fatal(err)
+	} else if err = os.WriteFile(filePath, []byte("this is not a jpeg image"), fs.ModeFile); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = os.Remove(filePath) }()
+
+	file := &entity.File{
+		PhotoID:     photo.ID,
+		PhotoUID:    photo.PhotoUID,
+		FileRoot:    entity.RootOriginals,
+		FileName:    fileName,
+		FileHash:    rnd.GenerateUID('h'),
+		FileType:    "jpg",
+		FileMime:    fs.MimeTypeJPEG,
+		FileWidth:   1600,
+		FileHeight:  1200,
+		FilePrimary: true,
+	}
+
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	size := func(t *testing.T, data []byte) (int, int) {
+		img, err := png.Decode(bytes.NewReader(data))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return img.Bounds().Dx(), img.Bounds().Dy()
+	}
+
+	t.Run("Icon", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/t/"+file.FileHash+"/"+conf.PreviewToken()+"/tile_224")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "image/svg+xml", r.Header().Get("Content-Type"))
+	})
+
+	conf.Options().ThumbFallback = thumb.PlaceholderGray.String()
+	defer func() { conf.Options().ThumbFallback = "" }()
+
+	t.Run("Tile224", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/t/"+file.FileHash+"/"+conf.PreviewToken()+"/tile_224")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "image/png", r.Header().Get("Content-Type"))
+
+		w, h := size(t, r.Body.Bytes())
+		assert.Equal(t, 224, w)
+		assert.Equal(t, 224, h)
+	})
+	t.Run("Fit720", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/t/"+file.FileHash+"/"+conf.PreviewToken()+"/fit_720")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "image/png", r.Header().Get("Content-Type"))
+
+		w, h := size(t, r.Body.Bytes())
+		assert.Equal(t, 720, w)
+		assert.Equal(t, 540, h)
+	})
+	t.Run("Dark", func(t *testing.T) {
+		conf.Options().ThumbFallback = thumb.PlaceholderDark.String()
+		defer func() { conf.Options().ThumbFallback = thumb.PlaceholderGray.String() }()
 
+		r := PerformRequest(app, "GET", "/api/v1/t/"+file.FileHash+"/"+conf.PreviewToken()+"/tile_500")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "image/png", r.Header().Get("Content-Type"))
+
+		w, h := size(t, r.Body.Bytes())
+		assert.Equal(t, 500, w)
+		assert.Equal(t, 500, h)
+	})
+}
+
+func TestGetThumb_Document(t *testing.T) {
+	app, router, conf := NewApiTest()
+	GetThumb(router)
+
+	photo := &entity.Photo{PhotoTitle: "Scanned Document", PhotoType: entity.MediaDocument}
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _, _ = photo.DeletePermanently() }()
+
+	// A PDF document without a rendered preview image.
+	file := &entity.File{
+		PhotoID:     photo.ID,
+		PhotoUID:    photo.PhotoUID,
+		FileRoot:    entity.RootOriginals,
+		FileName:    "documents/" + photo.PhotoUID + ".pdf",
+		FileHash:    rnd.GenerateUID('h'),
+		FileType:    fs.DocumentPDF.String(),
+		FileMime:    fs.MimeTypePDF,
+		MediaType:   entity.MediaDocument,
+		FilePrimary: true,
+	}
+
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := PerformRequest(app, "GET", "/api/v1/t/"+file.FileHash+"/"+conf.PreviewToken()+"/tile_224")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "image/svg+xml", r.Header().Get("Content-Type"))
+	assert.Equal(t, documentIconSvg, r.Body.Bytes())
+}
+
+func TestGetThumb_Crop(t *testing.T) {
+	app, router, conf := NewApiTest()
+	GetThumb(router)
+
+	hash := rnd.GenerateUID('h')
+	size := thumb.Sizes[thumb.Tile224]
+
+	centerName, err := size.FileName(hash, conf.ThumbCachePath())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leftName, err := size.Reframe(thumb.ResampleFillTopLeft).FileName(hash, conf.ThumbCachePath())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEqual(t, centerName, leftName)
+
+	if err = os.WriteFile(centerName, []byte("center"), fs.ModeFile); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Remove(centerName)
+
+	if err = os.WriteFile(leftName, []byte("left"), fs.ModeFile); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Remove(leftName)
+
+	t.Run("Center", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/t/"+hash+"/"+conf.PreviewToken()+"/tile_224")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "center", r.Body.String())
+	})
+	t.Run("Left", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/t/"+hash+"/"+conf.PreviewToken()+"/tile_224?crop=left")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "left", r.Body.String())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/t/"+hash+"/"+conf.PreviewToken()+"/tile_224?crop=fit")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "center", r.Body.String())
+	})
 }
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GetTrashExpired returns archived pictures whose retention period has ended, so that admins
+// can check what would be deleted without actually deleting anything (dry run).
+//
+// GET /api/v1/trash/expired
+//
+// Query:
+//   - count (int) maximum number of results, default 100
+func GetTrashExpired(router *gin.RouterGroup) {
+	router.GET("/trash/expired", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionDelete)
+
+		if s.Abort(c) {
+			return
+		}
+
+		trash := get.Trash()
+
+		if trash.Disabled() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+
+		if limit <= 0 || limit > photoprism.TrashBatchSize {
+			limit = 100
+		}
+
+		photos, err := trash.Expired(limit)
+
+		if err != nil {
+			log.Errorf("trash: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		AddCountHeader(c, len(photos))
+		AddLimitHeader(c, limit)
+
+		c.JSON(http.StatusOK, photos)
+	})
+}
+
+// PurgeTrash permanently deletes archived pictures and their files once the retention period has ended.
+//
+// POST /api/v1/trash/purge
+func PurgeTrash(router *gin.RouterGroup) {
+	router.POST("/trash/purge", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionDelete)
+
+		if s.Abort(c) {
+			return
+		}
+
+		trash := get.Trash()
+
+		if trash.Disabled() {
+			AbortFeatureDisabled(c)
+			return
+		} else if mutex.TrashWorker.Running() {
+			AbortBusy(c)
+			return
+		}
+
+		deleted, err := trash.Purge()
+
+		if err != nil {
+			log.Errorf("trash: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		if len(deleted) > 0 {
+			UpdateClientConfig()
+		}
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgPermanentlyDeleted), "deleted": deleted.UIDs()})
+	})
+}
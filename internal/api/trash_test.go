@@ -0,0 +1,93 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+func TestGetTrashExpired(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetTrashExpired(router)
+		r := PerformRequest(app, "GET", "/api/v1/trash/expired")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("Ok", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		conf.Options().TrashRetention = 36500
+		defer func() { conf.Options().TrashRetention = 0 }()
+
+		conf.Settings().Features.Delete = true
+		defer func() { conf.Settings().Features.Delete = false }()
+
+		assert.False(t, get.Trash().Disabled())
+
+		GetTrashExpired(router)
+		r := PerformRequest(app, "GET", "/api/v1/trash/expired?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Parse(r.Body.String()).IsArray())
+		assert.Equal(t, "10", r.Header().Get("X-Limit"))
+	})
+}
+
+func TestPurgeTrash(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PurgeTrash(router)
+		r := PerformRequest(app, "POST", "/api/v1/trash/purge")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("Ok", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		// Use a retention period that no fixture is affected by.
+		conf.Options().TrashRetention = 36500
+		defer func() { conf.Options().TrashRetention = 0 }()
+
+		conf.Settings().Features.Delete = true
+		defer func() { conf.Settings().Features.Delete = false }()
+
+		PurgeTrash(router)
+		r := PerformRequest(app, "POST", "/api/v1/trash/purge")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "deleted.#").Int())
+	})
+	t.Run("DeleteDisabled", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		conf.Options().TrashRetention = 36500
+		defer func() { conf.Options().TrashRetention = 0 }()
+
+		assert.False(t, conf.Settings().Features.Delete)
+
+		PurgeTrash(router)
+		r := PerformRequest(app, "POST", "/api/v1/trash/purge")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("Busy", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		conf.Options().TrashRetention = 36500
+		defer func() { conf.Options().TrashRetention = 0 }()
+
+		conf.Settings().Features.Delete = true
+		defer func() { conf.Settings().Features.Delete = false }()
+
+		if err := mutex.TrashWorker.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer mutex.TrashWorker.Stop()
+
+		PurgeTrash(router)
+		r := PerformRequest(app, "POST", "/api/v1/trash/purge")
+		assert.Equal(t, http.StatusTooManyRequests, r.Code)
+	})
+}
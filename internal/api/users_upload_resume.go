@@ -0,0 +1,280 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// Request headers used for resumable uploads.
+const (
+	HeaderUploadOffset = "Upload-Offset"
+	HeaderUploadLength = "Upload-Length"
+	HeaderUploadHash   = "Upload-Hash"
+)
+
+// UploadOffset represents the state of a resumable upload.
+type UploadOffset struct {
+	Name     string `json:"name"`
+	Offset   int64  `json:"offset"`
+	Length   int64  `json:"length,omitempty"`
+	Complete bool   `json:"complete"`
+}
+
+// uploadResumeNames returns the partial and the final file name of a resumable upload. The folders are
+// only created if create is true, so that status requests do not leave empty folders behind.
+func uploadResumeNames(c *gin.Context, conf *config.Config, s *entity.Session, create bool) (partName, fileName string, err error) {
+	name := clean.FileName(filepath.Base(c.Query("name")))
+
+	if name == "" {
+		return "", "", errors.New("invalid file name")
+	} else if !rnd.IsUID(s.UserUID, 0) {
+		return "", "", errors.New("invalid uid")
+	}
+
+	token := clean.Token(s.RefID + clean.Token(c.Param("token")))
+
+	if !create {
+		userDir := filepath.Join(conf.UsersStoragePath(), s.UserUID)
+		return filepath.Join(userDir, "partial", token, name), filepath.Join(userDir, "upload", token, name), nil
+	}
+
+	uploadDir, err := conf.UserUploadPath(s.UserUID, token)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	partialDir, err := conf.UserPartialUploadPath(s.UserUID, token)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	return filepath.Join(partialDir, name), filepath.Join(uploadDir, name), nil
+}
+
+// uploadResumeSession checks if uploads are enabled and the user may upload files.
+func uploadResumeSession(c *gin.Context, conf *config.Config) *entity.Session {
+	// Abort in public mode or when the upload feature is disabled.
+	if conf.ReadOnly() || !conf.Settings().Features.Upload {
+		Abort(c, http.StatusForbidden, i18n.ErrReadOnly)
+		return nil
+	}
+
+	// Check permission.
+	s := AuthAny(c, acl.ResourceFiles, acl.Permissions{acl.ActionManage, acl.ActionUpload})
+
+	if s.Abort(c) {
+		return nil
+	}
+
+	// Users may only upload their own files.
+	if s.User().UserUID != clean.UID(c.Param("uid")) {
+		event.AuditErr([]string{ClientIP(c), "session %s", "upload files", "user does not match"}, s.RefID)
+		AbortForbidden(c)
+		return nil
+	}
+
+	return s
+}
+
+// GetUserUploadOffset returns the number of bytes received so far, so that an interrupted upload can be resumed.
+//
+// GET /users/:uid/upload/:token/resume?name=:filename
+func GetUserUploadOffset(router *gin.RouterGroup) {
+	router.GET("/users/:uid/upload/:token/resume", func(c *gin.Context) {
+		conf := get.Config()
+		s := uploadResumeSession(c, conf)
+
+		if s == nil {
+			return
+		}
+
+		partName, fileName, err := uploadResumeNames(c, conf, s, false)
+
+		if err != nil {
+			log.Errorf("upload: %s", err)
+			Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+			return
+		}
+
+		result := UploadOffset{Name: filepath.Base(fileName)}
+
+		if info, err := os.Stat(fileName); err == nil {
+			result.Offset = info.Size()
+			result.Length = info.Size()
+			result.Complete = true
+		} else if info, err = os.Stat(partName); err == nil {
+			result.Offset = info.Size()
+		}
+
+		c.Header(HeaderUploadOffset, strconv.FormatInt(result.Offset, 10))
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// ResumeUserUpload appends a chunk to a resumable upload. The Upload-Offset header must match the number
+// of bytes received so far, and Upload-Length must contain the total file size. Once complete, the hash in
+// Upload-Hash is verified and the file is moved to the upload folder, from where it is imported
+// and indexed when processing is triggered with PUT /users/:uid/upload/:token.
+//
+// PATCH /users/:uid/upload/:token/resume?name=:filename
+func ResumeUserUpload(router *gin.RouterGroup) {
+	router.PATCH("/users/:uid/upload/:token/resume", func(c *gin.Context) {
+		conf := get.Config()
+		s := uploadResumeSession(c, conf)
+
+		if s == nil {
+			return
+		}
+
+		partName, fileName, err := uploadResumeNames(c, conf, s, true)
+
+		if err != nil {
+			log.Errorf("upload: %s", err)
+			Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+			return
+		}
+
+		baseName := filepath.Base(fileName)
+
+		offset, offsetErr := strconv.ParseInt(c.GetHeader(HeaderUploadOffset), 10, 64)
+		length, lengthErr := strconv.ParseInt(c.GetHeader(HeaderUploadLength), 10, 64)
+
+		if offsetErr != nil || lengthErr != nil || offset < 0 || length <= 0 || offset > length {
+			log.Errorf("upload: invalid offset or length for %s", clean.Log(baseName))
+			AbortBadRequest(c)
+			return
+		} else if limit := conf.OriginalsByteLimit(); limit > 0 && length > limit {
+			log.Errorf("upload: %s exceeds the file size limit", clean.Log(baseName))
+			Abort(c, http.StatusRequestEntityTooLarge, i18n.ErrFileTooLarge)
+			return
+		}
+
+		// Serialize requests for the same file, so that the size check and the append are atomic.
+		unlock := mutex.UploadFiles.Lock(partName)
+		defer unlock()
+
+		var received int64
+
+		if info, err := os.Stat(partName); err == nil {
+			received = info.Size()
+		}
+
+		// Reject chunks that do not continue where the previous upload stopped.
+		if offset != received {
+			log.Warnf("upload: expected offset %d for %s, got %d", received, clean.Log(baseName), offset)
+			c.Header(HeaderUploadOffset, strconv.FormatInt(received, 10))
+			c.AbortWithStatusJSON(http.StatusConflict, UploadOffset{Name: baseName, Offset: received, Length: length})
+			return
+		}
+
+		f, err := os.OpenFile(partName, os.O_CREATE|os.O_WRONLY|os.O_APPEND, fs.ModeFile)
+
+		if err != nil {
+			log.Errorf("upload: %s", err)
+			Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+			return
+		}
+
+		written, err := io.Copy(f, io.LimitReader(c.Request.Body, length-offset))
+
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+
+		offset += written
+
+		if err != nil {
+			log.Errorf("upload: %s after %d bytes of %s", err, offset, clean.Log(baseName))
+			Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+			return
+		}
+
+		result := UploadOffset{Name: baseName, Offset: offset, Length: length}
+
+		c.Header(HeaderUploadOffset, strconv.FormatInt(offset, 10))
+
+		if offset < length {
+			c.JSON(http.StatusOK, result)
+			return
+		}
+
+		hash := strings.ToLower(strings.TrimSpace(c.GetHeader(HeaderUploadHash)))
+
+		// The hash is required to complete the upload. The partial file is kept, so the client may
+		// retry with an empty chunk and the missing header.
+		if hash == "" {
+			log.Errorf("upload: missing hash for %s", clean.Log(baseName))
+			AbortBadRequest(c)
+			return
+		}
+
+		// Verify the file hash.
+		if hash != fs.Hash(partName) {
+			log.Errorf("upload: hash mismatch for %s", clean.Log(baseName))
+
+			if err = os.Remove(partName); err != nil {
+				log.Errorf("upload: %s", err)
+			}
+
+			Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+			return
+		}
+
+		// Check if uploaded file is safe.
+		if !conf.UploadNSFW() {
+			if labels, err := get.NsfwDetector().File(partName); err != nil {
+				log.Debug(err)
+			} else if !labels.IsSafe() {
+				log.Infof("nsfw: %s might be offensive", clean.Log(baseName))
+
+				if err = os.Remove(partName); err != nil {
+					log.Errorf("nsfw: could not delete %s", clean.Log(baseName))
+				}
+
+				Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
+				return
+			}
+		}
+
+		// Move the assembled file to the upload folder.
+		if err = os.Rename(partName, fileName); err != nil {
+			log.Errorf("upload: %s", err)
+			Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+			return
+		}
+
+		// Delete empty partial upload folder.
+		if partialDir := filepath.Dir(partName); fs.DirIsEmpty(partialDir) {
+			if err = os.Remove(partialDir); err != nil {
+				log.Warnf("upload: %s", err)
+			}
+		}
+
+		log.Debugf("upload: saved file %s", clean.Log(baseName))
+		event.Publish("upload.saved", event.Data{"uid": s.UserUID, "file": baseName})
+
+		result.Complete = true
+
+		c.JSON(http.StatusOK, result)
+	})
+}
@@ -0,0 +1,257 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// performUploadChunk sends a chunk of a resumable upload.
+func performUploadChunk(app *gin.Engine, reqUrl, chunk string, offset, length int, hash string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("PATCH", reqUrl, strings.NewReader(chunk))
+	req.Header.Set(HeaderUploadOffset, strconv.Itoa(offset))
+	req.Header.Set(HeaderUploadLength, strconv.Itoa(length))
+
+	if hash != "" {
+		req.Header.Set(HeaderUploadHash, hash)
+	}
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestResumeUserUpload(t *testing.T) {
+	app, router, conf := NewApiTest()
+
+	GetUserUploadOffset(router)
+	ResumeUserUpload(router)
+
+	adminUid := entity.Admin.UserUID
+	refId := get.Session().Public().RefID
+
+	content := "0123456789abcdefghijklmnopqrstuvwxyz"
+	length := len(content)
+
+	// Compute the expected file hash.
+	hashFile := filepath.Join(t.TempDir(), "hash.txt")
+
+	if err := os.WriteFile(hashFile, []byte(content), fs.ModeFile); err != nil {
+		t.Fatal(err)
+	}
+
+	hash := fs.Hash(hashFile)
+
+	t.Run("Chunks", func(t *testing.T) {
+		token := "chunks123456"
+		reqUrl := fmt.Sprintf("/api/v1/users/%s/upload/%s/resume?name=video.mp4", adminUid, token)
+
+		uploadDir, err := conf.UserUploadPath(adminUid, refId+token)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _ = os.RemoveAll(uploadDir) }()
+
+		r := performUploadChunk(app, reqUrl, content[:10], 0, length, hash)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(10), gjson.Get(r.Body.String(), "offset").Int())
+		assert.False(t, gjson.Get(r.Body.String(), "complete").Bool())
+
+		r = performUploadChunk(app, reqUrl, content[10:20], 10, length, hash)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "20", r.Header().Get(HeaderUploadOffset))
+
+		r = performUploadChunk(app, reqUrl, content[20:], 20, length, hash)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(length), gjson.Get(r.Body.String(), "offset").Int())
+		assert.True(t, gjson.Get(r.Body.String(), "complete").Bool())
+
+		data, err := os.ReadFile(filepath.Join(uploadDir, "video.mp4"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, content, string(data))
+
+		r = PerformRequest(app, "GET", reqUrl)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "complete").Bool())
+	})
+	t.Run("Resume", func(t *testing.T) {
+		token := "resume123456"
+		reqUrl := fmt.Sprintf("/api/v1/users/%s/upload/%s/resume?name=video.mp4", adminUid, token)
+
+		uploadDir, err := conf.UserUploadPath(adminUid, refId+token)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _ = os.RemoveAll(uploadDir) }()
+
+		r := performUploadChunk(app, reqUrl, content[:15], 0, length, "")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		// The connection dropped: the client retries with an outdated offset.
+		r = performUploadChunk(app, reqUrl, content[:15], 0, length, "")
+		assert.Equal(t, http.StatusConflict, r.Code)
+		assert.Equal(t, int64(15), gjson.Get(r.Body.String(), "offset").Int())
+
+		// Query the current offset and continue from there.
+		r = PerformRequest(app, "GET", reqUrl)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.False(t, gjson.Get(r.Body.String(), "complete").Bool())
+
+		offset := int(gjson.Get(r.Body.String(), "offset").Int())
+		assert.Equal(t, 15, offset)
+
+		r = performUploadChunk(app, reqUrl, content[offset:], offset, length, hash)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "complete").Bool())
+
+		data, err := os.ReadFile(filepath.Join(uploadDir, "video.mp4"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, content, string(data))
+	})
+	t.Run("HashMismatch", func(t *testing.T) {
+		token := "mismatch1234"
+		reqUrl := fmt.Sprintf("/api/v1/users/%s/upload/%s/resume?name=video.mp4", adminUid, token)
+
+		uploadDir, err := conf.UserUploadPath(adminUid, refId+token)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _ = os.RemoveAll(uploadDir) }()
+
+		r := performUploadChunk(app, reqUrl, content, 0, length, "0000000000000000000000000000000000000000")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.False(t, fs.FileExists(filepath.Join(uploadDir, "video.mp4")))
+
+		r = PerformRequest(app, "GET", reqUrl)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "offset").Int())
+	})
+	t.Run("MissingHash", func(t *testing.T) {
+		token := "nohash123456"
+		reqUrl := fmt.Sprintf("/api/v1/users/%s/upload/%s/resume?name=video.mp4", adminUid, token)
+
+		uploadDir, err := conf.UserUploadPath(adminUid, refId+token)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _ = os.RemoveAll(uploadDir) }()
+
+		// The upload cannot be completed without a hash.
+		r := performUploadChunk(app, reqUrl, content, 0, length, "")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.False(t, fs.FileExists(filepath.Join(uploadDir, "video.mp4")))
+
+		// The received data is kept, so the client can complete the upload with the hash.
+		r = PerformRequest(app, "GET", reqUrl)
+		assert.Equal(t, int64(length), gjson.Get(r.Body.String(), "offset").Int())
+		assert.False(t, gjson.Get(r.Body.String(), "complete").Bool())
+
+		r = performUploadChunk(app, reqUrl, "", length, length, hash)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "complete").Bool())
+		assert.True(t, fs.FileExists(filepath.Join(uploadDir, "video.mp4")))
+	})
+	t.Run("Concurrent", func(t *testing.T) {
+		token := "concurrent12"
+		reqUrl := fmt.Sprintf("/api/v1/users/%s/upload/%s/resume?name=video.mp4", adminUid, token)
+
+		partialDir, err := conf.UserPartialUploadPath(adminUid, refId+token)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _ = os.RemoveAll(partialDir) }()
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+
+		codes := make(map[int]int)
+
+		// Requests with the same offset must not append the chunk more than once.
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				r := performUploadChunk(app, reqUrl, content[:10], 0, length, "")
+
+				mu.Lock()
+				codes[r.Code]++
+				mu.Unlock()
+			}()
+		}
+
+		wg.Wait()
+
+		assert.Equal(t, 1, codes[http.StatusOK])
+		assert.Equal(t, 7, codes[http.StatusConflict])
+
+		data, err := os.ReadFile(filepath.Join(partialDir, "video.mp4"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, content[:10], string(data))
+	})
+	t.Run("StatusNoFolders", func(t *testing.T) {
+		token := "status123456"
+		reqUrl := fmt.Sprintf("/api/v1/users/%s/upload/%s/resume?name=video.mp4", adminUid, token)
+
+		r := PerformRequest(app, "GET", reqUrl)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "offset").Int())
+
+		userDir := filepath.Join(conf.UsersStoragePath(), adminUid)
+
+		assert.NoDirExists(t, filepath.Join(userDir, "upload", refId+token))
+		assert.NoDirExists(t, filepath.Join(userDir, "partial", refId+token))
+	})
+	t.Run("InvalidName", func(t *testing.T) {
+		reqUrl := fmt.Sprintf("/api/v1/users/%s/upload/invalid12345/resume", adminUid)
+		r := performUploadChunk(app, reqUrl, content, 0, length, "")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidOffset", func(t *testing.T) {
+		reqUrl := fmt.Sprintf("/api/v1/users/%s/upload/invalid12345/resume?name=video.mp4", adminUid)
+		r := performUploadChunk(app, reqUrl, content, 50, length, "")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("WrongUser", func(t *testing.T) {
+		reqUrl := "/api/v1/users/uqxc08w3d0ej2283/upload/invalid12345/resume?name=video.mp4"
+		r := performUploadChunk(app, reqUrl, content, 0, length, "")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/photoprism/photoprism/internal/ffmpeg"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
@@ -70,10 +71,21 @@ func GetVideo(router *gin.RouterGroup) {
 		// File format supported by the client/browser?
 		supported := f.FileCodec != "" && f.FileCodec == string(format.Codec) || format.Codec == video.UnknownCodec && f.FileType == string(format.File)
 
-		// File bitrate too high (for streaming)?
 		conf := get.Config()
+
+		// Check the stored codec instead if incompatible videos should always be transcoded.
+		if f.FileCodec != "" && conf.FFmpegTranscode() == ffmpeg.TranscodeIncompatible {
+			supported = video.Compatible(f.FileCodec)
+		}
+
+		// File bitrate too high (for streaming)?
 		transcode := !supported || conf.FFmpegEnabled() && conf.FFmpegBitrateExceeded(fileBitrate)
 
+		// Always stream the original video if transcoding is disabled.
+		if conf.FFmpegTranscode() == ffmpeg.TranscodeNone {
+			transcode = false
+		}
+
 		if mf, err := photoprism.NewMediaFile(fileName); err != nil {
 			// Set missing flag so that the file doesn't show up in search results anymore.
 			logError("video", f.Update("FileMissing", true))
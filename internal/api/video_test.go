@@ -3,12 +3,20 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/ffmpeg"
 	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
 )
 
 func TestGetVideo(t *testing.T) {
@@ -59,3 +67,97 @@ func TestGetVideo(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 }
+
+func TestGetVideo_Transcode(t *testing.T) {
+	app, router, conf := NewApiTest()
+	GetVideo(router)
+
+	photo := &entity.Photo{PhotoTitle: "HEVC Video", PhotoType: entity.MediaVideo}
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _, _ = photo.DeletePermanently() }()
+
+	fileName := "transcode/" + photo.PhotoUID + ".mov"
+	filePath := filepath.Join(conf.OriginalsPath(), fileName)
+
+	if err := os.MkdirAll(filepath.Dir(filePath), fs.ModeDir); err != nil {
+		t.Fatal(err)
+	} else if err = fs.Copy(filepath.Join(conf.ExamplesPath(), "gopher-video.mp4"), filePath); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = os.Remove(filePath) }()
+
+	// Use a cached AVC rendition if FFmpeg is not available for transcoding.
+	avcPath := fs.FileName(filePath, conf.SidecarPath(), conf.OriginalsPath(), fs.ExtAVC)
+
+	if !conf.FFmpegEnabled() || !fs.FileExists(conf.FFmpegBin()) {
+		if err := fs.Copy(filepath.Join(conf.ExamplesPath(), "gopher-video.mp4"), avcPath); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defer func() { _ = os.Remove(avcPath) }()
+
+	file := &entity.File{
+		PhotoID:      photo.ID,
+		PhotoUID:     photo.PhotoUID,
+		FileRoot:     entity.RootOriginals,
+		FileName:     fileName,
+		FileHash:     rnd.GenerateUID('h'),
+		FileType:     fs.VideoMOV.String(),
+		FileMime:     "video/quicktime",
+		FileCodec:    "hvc1",
+		FileVideo:    true,
+		FileDuration: 2410 * time.Millisecond,
+		FilePrimary:  true,
+	}
+
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	videoUrl := "/api/v1/videos/" + file.FileHash + "/" + conf.PreviewToken() + "/hevc"
+
+	t.Run("Incompatible", func(t *testing.T) {
+		conf.Options().FFmpegTranscode = ffmpeg.TranscodeIncompatible
+		defer func() { conf.Options().FFmpegTranscode = "" }()
+
+		r := PerformRequest(app, "GET", videoUrl)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, ContentTypeAvc, r.Header().Get("Content-Type"))
+
+		// MP4 files start with an "ftyp" box.
+		if body := r.Body.Bytes(); assert.Greater(t, len(body), 8) {
+			assert.Equal(t, "ftyp", string(body[4:8]))
+		}
+	})
+	t.Run("Range", func(t *testing.T) {
+		conf.Options().FFmpegTranscode = ffmpeg.TranscodeIncompatible
+		defer func() { conf.Options().FFmpegTranscode = "" }()
+
+		req, _ := http.NewRequest("GET", videoUrl, nil)
+		req.Header.Set("Range", "bytes=0-99")
+		r := httptest.NewRecorder()
+		app.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusPartialContent, r.Code)
+		assert.Equal(t, 100, r.Body.Len())
+	})
+	t.Run("Auto", func(t *testing.T) {
+		r := PerformRequest(app, "GET", videoUrl)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, `video/quicktime; codecs="hvc1"`, r.Header().Get("Content-Type"))
+	})
+	t.Run("None", func(t *testing.T) {
+		conf.Options().FFmpegTranscode = ffmpeg.TranscodeNone
+		defer func() { conf.Options().FFmpegTranscode = "" }()
+
+		r := PerformRequest(app, "GET", "/api/v1/videos/"+file.FileHash+"/"+conf.PreviewToken()+"/avc")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, `video/quicktime; codecs="hvc1"`, r.Header().Get("Content-Type"))
+	})
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/customize"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/i18n"
@@ -55,23 +57,10 @@ func ZipCreate(router *gin.RouterGroup) {
 			return
 		}
 
-		// Configure file selection based on user settings.
-		var selection query.FileSelection
-		if dl := conf.Settings().Download; dl.Disabled {
-			AbortFeatureDisabled(c)
-			return
-		} else {
-			selection = query.DownloadSelection(dl.MediaRaw, dl.MediaSidecar, dl.Originals)
-		}
-
 		// Find files to download.
-		files, err := query.SelectedFiles(f, selection)
+		files, ok := zipSelectedFiles(c, f)
 
-		if err != nil {
-			Error(c, http.StatusBadRequest, err, i18n.ErrZipFailed)
-			return
-		} else if len(files) == 0 {
-			Abort(c, http.StatusNotFound, i18n.ErrNoFilesForDownload)
+		if !ok {
 			return
 		}
 
@@ -83,52 +72,31 @@ func ZipCreate(router *gin.RouterGroup) {
 		zipFileName := path.Join(zipPath, zipBaseName)
 
 		// Create temp directory.
-		if err = os.MkdirAll(zipPath, 0700); err != nil {
+		if err := os.MkdirAll(zipPath, 0700); err != nil {
 			Error(c, http.StatusInternalServerError, err, i18n.ErrZipFailed)
 			return
 		}
 
 		// Create new zip file.
-		var newZipFile *os.File
-		if newZipFile, err = os.Create(zipFileName); err != nil {
+		newZipFile, err := os.Create(zipFileName)
+
+		if err != nil {
 			Error(c, http.StatusInternalServerError, err, i18n.ErrZipFailed)
 			return
-		} else {
-			defer newZipFile.Close()
 		}
 
+		defer newZipFile.Close()
+
 		// Create zip writer.
 		zipWriter := zip.NewWriter(newZipFile)
 		defer func(w *zip.Writer) {
 			logError("zip", w.Close())
 		}(zipWriter)
 
-		var aliases = make(map[string]int)
-
 		// Add files to zip.
-		for _, file := range files {
-			fileName := photoprism.FileName(file.FileRoot, file.FileName)
-			alias := file.DownloadName(dlName, 0)
-			key := strings.ToLower(alias)
-
-			if seq := aliases[key]; seq > 0 {
-				alias = file.DownloadName(dlName, seq)
-			}
-
-			aliases[key] += 1
-
-			if fs.FileExists(fileName) {
-				if err := addFileToZip(zipWriter, fileName, alias); err != nil {
-					log.Errorf("zip: failed adding %s to zip (%s)", clean.Log(file.FileName), err)
-					Abort(c, http.StatusInternalServerError, i18n.ErrZipFailed)
-					return
-				}
-
-				log.Infof("zip: added %s as %s", clean.Log(file.FileName), clean.Log(alias))
-			} else {
-				log.Warnf("zip: media file %s is missing", clean.Log(file.FileName))
-				logError("zip", file.Update("FileMissing", true))
-			}
+		if _, _, err = addFilesToZip(zipWriter, files, dlName, conf.Settings().Download.Unique); err != nil {
+			Abort(c, http.StatusInternalServerError, i18n.ErrZipFailed)
+			return
 		}
 
 		elapsed := int(time.Since(start).Seconds())
@@ -180,6 +148,83 @@ func ZipDownload(router *gin.RouterGroup) {
 	})
 }
 
+// zipSelectedFiles returns the selected files based on the download settings of the user,
+// or aborts the request if no files can be downloaded.
+func zipSelectedFiles(c *gin.Context, f form.Selection) (files entity.Files, ok bool) {
+	// Configure file selection based on user settings.
+	dl := get.Config().Settings().Download
+
+	if dl.Disabled {
+		AbortFeatureDisabled(c)
+		return files, false
+	}
+
+	files, err := query.SelectedFiles(f, query.DownloadSelection(dl.MediaRaw, dl.MediaSidecar, dl.Originals))
+
+	if err != nil {
+		Error(c, http.StatusBadRequest, err, i18n.ErrZipFailed)
+		return files, false
+	} else if len(files) == 0 {
+		Abort(c, http.StatusNotFound, i18n.ErrNoFilesForDownload)
+		return files, false
+	}
+
+	return files, true
+}
+
+// addFilesToZip adds the files to a zip archive and returns the number of files added,
+// as well as the files that are missing in storage.
+func addFilesToZip(zipWriter *zip.Writer, files entity.Files, dlName customize.DownloadName, unique customize.DownloadUnique) (added int, missing entity.Files, err error) {
+	aliases := make(map[string]bool, len(files))
+
+	for _, file := range files {
+		fileName := photoprism.FileName(file.FileRoot, file.FileName)
+
+		if !fs.FileExists(fileName) {
+			log.Warnf("zip: media file %s is missing", clean.Log(file.FileName))
+			logError("zip", file.Update("FileMissing", true))
+			missing = append(missing, file)
+			continue
+		}
+
+		alias := uniqueZipName(aliases, &file, dlName, unique)
+
+		if err = addFileToZip(zipWriter, fileName, alias); err != nil {
+			log.Errorf("zip: failed adding %s to zip (%s)", clean.Log(file.FileName), err)
+			return added, missing, err
+		}
+
+		added++
+
+		log.Infof("zip: added %s as %s", clean.Log(file.FileName), clean.Log(alias))
+	}
+
+	return added, missing, nil
+}
+
+// uniqueZipName returns a unique zip archive entry name for the file, either by adding a sequence number
+// to duplicate names, see entity.File.DownloadName, or by prefixing all names with the photo UID.
+func uniqueZipName(names map[string]bool, file *entity.File, dlName customize.DownloadName, unique customize.DownloadUnique) string {
+	name := func(seq int) string {
+		if unique == customize.DownloadUniqueUID && file.PhotoUID != "" {
+			return fmt.Sprintf("%s-%s", file.PhotoUID, file.DownloadName(dlName, seq))
+		}
+
+		return file.DownloadName(dlName, seq)
+	}
+
+	result := name(0)
+
+	// Names are compared case-insensitively, as not all file systems are case-sensitive.
+	for seq := 1; names[strings.ToLower(result)]; seq++ {
+		result = name(seq)
+	}
+
+	names[strings.ToLower(result)] = true
+
+	return result
+}
+
 // addFileToZip adds a file to a zip archive.
 func addFileToZip(zipWriter *zip.Writer, fileName, fileAlias string) error {
 	fileToZip, err := os.Open(fileName)
@@ -6,6 +6,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/customize"
+	"github.com/photoprism/photoprism/internal/entity"
 )
 
 func TestZip(t *testing.T) {
@@ -37,3 +40,44 @@ func TestZip(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
 }
+
+func TestUniqueZipName(t *testing.T) {
+	jpeg1 := &entity.File{PhotoUID: "pt9jtdre2lvl0y12", FileName: "2018/IMG_1234.jpg"}
+	jpeg2 := &entity.File{PhotoUID: "pt9jtdre2lvl0y11", FileName: "2019/img_1234.jpg"}
+	jpeg3 := &entity.File{PhotoUID: "pt9jtdre2lvl0y13", FileName: "2020/IMG_1234.jpg"}
+	raw := &entity.File{PhotoUID: "pt9jtdre2lvl0y12", FileName: "2018/IMG_1234.dng"}
+
+	t.Run("Seq", func(t *testing.T) {
+		names := make(map[string]bool)
+		assert.Equal(t, "IMG_1234.jpg", uniqueZipName(names, jpeg1, customize.DownloadNameFile, customize.DownloadUniqueSeq))
+		assert.Equal(t, "img_1234 (1).jpg", uniqueZipName(names, jpeg2, customize.DownloadNameFile, customize.DownloadUniqueSeq))
+		assert.Equal(t, "IMG_1234 (2).jpg", uniqueZipName(names, jpeg3, customize.DownloadNameFile, customize.DownloadUniqueSeq))
+		assert.Equal(t, "IMG_1234.dng", uniqueZipName(names, raw, customize.DownloadNameFile, customize.DownloadUniqueSeq))
+		assert.Len(t, names, 4)
+	})
+	t.Run("SeqCollision", func(t *testing.T) {
+		names := make(map[string]bool)
+		seq := &entity.File{PhotoUID: "pt9jtdre2lvl0y14", FileName: "2021/IMG_1234 (1).jpg"}
+		assert.Equal(t, "IMG_1234 (1).jpg", uniqueZipName(names, seq, customize.DownloadNameFile, customize.DownloadUniqueSeq))
+		assert.Equal(t, "IMG_1234.jpg", uniqueZipName(names, jpeg1, customize.DownloadNameFile, customize.DownloadUniqueSeq))
+		assert.Equal(t, "IMG_1234 (2).jpg", uniqueZipName(names, jpeg3, customize.DownloadNameFile, customize.DownloadUniqueSeq))
+	})
+	t.Run("Path", func(t *testing.T) {
+		names := make(map[string]bool)
+		assert.Equal(t, "2018/IMG_1234.jpg", uniqueZipName(names, jpeg1, customize.DownloadNamePath, customize.DownloadUniqueSeq))
+		assert.Equal(t, "2018/IMG_1234 (1).jpg", uniqueZipName(names, jpeg1, customize.DownloadNamePath, customize.DownloadUniqueSeq))
+		assert.Equal(t, "2020/IMG_1234.jpg", uniqueZipName(names, jpeg3, customize.DownloadNamePath, customize.DownloadUniqueSeq))
+	})
+	t.Run("UID", func(t *testing.T) {
+		names := make(map[string]bool)
+		assert.Equal(t, "pt9jtdre2lvl0y12-IMG_1234.jpg", uniqueZipName(names, jpeg1, customize.DownloadNameFile, customize.DownloadUniqueUID))
+		assert.Equal(t, "pt9jtdre2lvl0y11-img_1234.jpg", uniqueZipName(names, jpeg2, customize.DownloadNameFile, customize.DownloadUniqueUID))
+		assert.Equal(t, "pt9jtdre2lvl0y12-IMG_1234.dng", uniqueZipName(names, raw, customize.DownloadNameFile, customize.DownloadUniqueUID))
+		assert.Equal(t, "pt9jtdre2lvl0y12-IMG_1234 (1).jpg", uniqueZipName(names, jpeg1, customize.DownloadNameFile, customize.DownloadUniqueUID))
+	})
+	t.Run("Default", func(t *testing.T) {
+		names := make(map[string]bool)
+		assert.Equal(t, "IMG_1234.jpg", uniqueZipName(names, jpeg1, customize.DownloadNameFile, ""))
+		assert.Equal(t, "IMG_1234 (1).jpg", uniqueZipName(names, jpeg3, customize.DownloadNameFile, ""))
+	})
+}
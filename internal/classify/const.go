@@ -7,4 +7,5 @@ const (
 	SrcLocation = "location"
 	SrcImage    = "image"
 	SrcKeyword  = "keyword"
+	SrcRule     = "rule"
 )
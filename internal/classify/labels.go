@@ -6,6 +6,9 @@ import (
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
+// ReviewUncertainty is the label uncertainty above which pictures should be reviewed.
+const ReviewUncertainty = 60
+
 // Labels is list of MediaFile labels.
 type Labels []Label
 
@@ -34,6 +37,21 @@ func (l Labels) AppendLabel(label Label) Labels {
 	return append(l, label)
 }
 
+// Uncertain checks if the list contains only labels with an uncertainty above the ReviewUncertainty threshold.
+func (l Labels) Uncertain() bool {
+	if len(l) == 0 {
+		return false
+	}
+
+	for _, label := range l {
+		if label.Uncertainty <= ReviewUncertainty {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Keywords returns all keywords contains in Labels and their categories
 func (l Labels) Keywords() (result []string) {
 	for _, label := range l {
@@ -120,3 +120,29 @@ func TestLabel_Sort(t *testing.T) {
 	assert.Equal(t, "label 1", labels[7].Name)
 	assert.Equal(t, "label 8", labels[8].Name)
 }
+
+func TestLabels_Uncertain(t *testing.T) {
+	t.Run("LowConfidenceOnly", func(t *testing.T) {
+		labels := Labels{
+			{Name: "cat", Source: SrcImage, Uncertainty: 75},
+			{Name: "dog", Source: SrcImage, Uncertainty: 90},
+		}
+
+		assert.True(t, labels.Uncertain())
+	})
+	t.Run("Confident", func(t *testing.T) {
+		labels := Labels{
+			{Name: "cat", Source: SrcImage, Uncertainty: 75},
+			{Name: "dog", Source: SrcImage, Uncertainty: 20},
+		}
+
+		assert.False(t, labels.Uncertain())
+	})
+	t.Run("Threshold", func(t *testing.T) {
+		labels := Labels{{Name: "cat", Source: SrcImage, Uncertainty: ReviewUncertainty}}
+		assert.False(t, labels.Uncertain())
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.False(t, Labels{}.Uncertain())
+	})
+}
@@ -9,9 +9,11 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/dustin/go-humanize/english"
 	"github.com/sevlyar/go-daemon"
 	"github.com/urfave/cli"
 
+	"github.com/photoprism/photoprism/internal/api"
 	"github.com/photoprism/photoprism/internal/auto"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
@@ -143,6 +145,11 @@ func startAction(ctx *cli.Context) error {
 	session.Shutdown()
 	mutex.CancelAll()
 
+	// Write pending photo metadata backups to YAML files.
+	if count := api.FlushPhotoYaml(); count > 0 {
+		log.Infof("backup: updated %s", english.Plural(count, "yaml file", "yaml files"))
+	}
+
 	log.Info("shutting down...")
 	cancel()
 
@@ -180,6 +180,9 @@ func (c *Config) Propagate() {
 	thumb.SizeUncached = c.ThumbSizeUncached()
 	thumb.Filter = c.ThumbFilter()
 	thumb.JpegQuality = c.JpegQuality()
+	thumb.JpegDPI = c.JpegDPI()
+	thumb.WebpQuality = c.WebpQuality()
+	thumb.AvifQuality = c.AvifQuality()
 	thumb.CacheMaxAge = c.HttpCacheMaxAge()
 	thumb.CachePublic = c.HttpCachePublic()
 
@@ -698,6 +701,36 @@ func (c *Config) Workers() int {
 	return 1
 }
 
+// ImportWorkers returns the number of files that are imported in parallel.
+func (c *Config) ImportWorkers() int {
+	if c.options.ImportWorkers <= 0 {
+		return c.Workers()
+	}
+
+	workers := c.options.ImportWorkers
+
+	// Return explicit value if set and not too large.
+	if workers > runtime.NumCPU() {
+		workers = runtime.NumCPU()
+	}
+
+	// Limit number of workers when using SQLite3 to avoid database locking issues.
+	if c.DatabaseDriver() == SQLite3 && workers > 4 {
+		return 4
+	}
+
+	return workers
+}
+
+// TrashRetention returns the duration after which archived pictures are permanently deleted, or 0 if disabled.
+func (c *Config) TrashRetention() time.Duration {
+	if c.options.TrashRetention <= 0 {
+		return 0
+	}
+
+	return time.Duration(c.options.TrashRetention) * 24 * time.Hour
+}
+
 // WakeupInterval returns the duration between background worker runs
 // required for face recognition and index maintenance(1-86400s).
 func (c *Config) WakeupInterval() time.Duration {
@@ -748,6 +781,11 @@ func (c *Config) AutoImport() time.Duration {
 	return time.Duration(c.options.AutoImport) * time.Second
 }
 
+// FolderAlbums checks if albums should be created and updated for existing folders when indexing.
+func (c *Config) FolderAlbums() bool {
+	return c.options.FolderAlbums
+}
+
 // GeoApi returns the preferred geocoding api (places, or none).
 func (c *Config) GeoApi() string {
 	if c.options.DisablePlaces {
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+)
+
+// DefaultBestOfWeights specifies the default scoring weights for automatic best-of selections.
+const DefaultBestOfWeights = "quality=2,resolution=1,faces=1,favorite=3"
+
+// BestOfWeights returns the scoring weights for automatic best-of selections by name,
+// e.g. "quality", "resolution", "faces", and "favorite".
+func (c *Config) BestOfWeights() map[string]float64 {
+	result := ParseBestOfWeights(DefaultBestOfWeights)
+
+	for name, weight := range ParseBestOfWeights(c.options.BestOfWeights) {
+		result[name] = weight
+	}
+
+	return result
+}
+
+// ParseBestOfWeights parses scoring weights in the format "quality=2,resolution=1,faces=1,favorite=3",
+// ignoring unknown names and invalid or negative values.
+func ParseBestOfWeights(s string) map[string]float64 {
+	result := make(map[string]float64, 4)
+
+	for _, v := range strings.Split(s, ",") {
+		name, value, found := strings.Cut(v, "=")
+
+		if !found {
+			continue
+		}
+
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		switch name {
+		case "quality", "resolution", "faces", "favorite":
+			if weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && weight >= 0 {
+				result[name] = weight
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_BestOfWeights(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	expected := map[string]float64{"quality": 2, "resolution": 1, "faces": 1, "favorite": 3}
+
+	assert.Equal(t, expected, c.BestOfWeights())
+
+	c.options.BestOfWeights = "faces=5, favorite=0"
+
+	expected = map[string]float64{"quality": 2, "resolution": 1, "faces": 5, "favorite": 0}
+
+	assert.Equal(t, expected, c.BestOfWeights())
+
+	c.options.BestOfWeights = ""
+}
+
+func TestParseBestOfWeights(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		result := ParseBestOfWeights(DefaultBestOfWeights)
+		assert.Equal(t, map[string]float64{"quality": 2, "resolution": 1, "faces": 1, "favorite": 3}, result)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		result := ParseBestOfWeights("Quality=1.5,sharpness=2,faces=-1,favorite=abc,resolution")
+		assert.Equal(t, map[string]float64{"quality": 1.5}, result)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Empty(t, ParseBestOfWeights(""))
+	})
+}
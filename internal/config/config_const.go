@@ -16,6 +16,11 @@ const CustomStaticUri = "/c/static"
 const DefaultAutoIndexDelay = int(5 * 60)  // 5 Minutes
 const DefaultAutoImportDelay = int(3 * 60) // 3 Minutes
 
+// DefaultBackupYamlDelay and MaxBackupYamlDelay limit the number of seconds by
+// which photo metadata backups to YAML files may be delayed to coalesce updates.
+const DefaultBackupYamlDelay = 0       // Disabled
+const MaxBackupYamlDelay = int(5 * 60) // 5 Minutes
+
 // MinWakeupInterval and MaxWakeupInterval limit the interval duration
 // in which the background worker can be invoked.
 const MinWakeupInterval = time.Minute             // 1 Minute
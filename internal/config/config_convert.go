@@ -50,3 +50,14 @@ func (c *Config) DisableJpegXL() bool {
 
 	return c.options.DisableJpegXL
 }
+
+// PdfToPpmBin returns the Poppler pdftoppm executable file name.
+func (c *Config) PdfToPpmBin() string {
+	return findBin(c.options.PdfToPpmBin, "pdftoppm")
+}
+
+// PdfEnabled checks if indexing of PDF documents and rendering their first page as preview has been
+// enabled and pdftoppm is installed.
+func (c *Config) PdfEnabled() bool {
+	return c.options.IndexPdf && c.PdfToPpmBin() != ""
+}
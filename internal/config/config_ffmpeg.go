@@ -69,6 +69,16 @@ func (c *Config) FFmpegMapAudio() string {
 	return c.options.FFmpegMapAudio
 }
 
+// FFmpegRotate returns the rotation mode for video preview images.
+func (c *Config) FFmpegRotate() string {
+	return ffmpeg.RotateMode(c.options.FFmpegRotate)
+}
+
+// FFmpegTranscode returns the transcoding mode for streaming videos.
+func (c *Config) FFmpegTranscode() string {
+	return ffmpeg.TranscodeMode(c.options.FFmpegTranscode)
+}
+
 // FFmpegOptions returns the FFmpeg transcoding options.
 func (c *Config) FFmpegOptions(encoder ffmpeg.AvcEncoder, bitrate string) (ffmpeg.Options, error) {
 	// Transcode all other formats with FFmpeg.
@@ -74,6 +74,28 @@ func TestConfig_FFmpegMapAudio(t *testing.T) {
 	assert.Equal(t, ffmpeg.MapAudioDefault, c.FFmpegMapAudio())
 }
 
+func TestConfig_FFmpegRotate(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, ffmpeg.RotateAuto, c.FFmpegRotate())
+	c.options.FFmpegRotate = "Metadata"
+	assert.Equal(t, ffmpeg.RotateMetadata, c.FFmpegRotate())
+	c.options.FFmpegRotate = "none"
+	assert.Equal(t, ffmpeg.RotateNone, c.FFmpegRotate())
+	c.options.FFmpegRotate = "invalid"
+	assert.Equal(t, ffmpeg.RotateAuto, c.FFmpegRotate())
+}
+
+func TestConfig_FFmpegTranscode(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, ffmpeg.TranscodeAuto, c.FFmpegTranscode())
+	c.options.FFmpegTranscode = "Incompatible"
+	assert.Equal(t, ffmpeg.TranscodeIncompatible, c.FFmpegTranscode())
+	c.options.FFmpegTranscode = "none"
+	assert.Equal(t, ffmpeg.TranscodeNone, c.FFmpegTranscode())
+	c.options.FFmpegTranscode = "invalid"
+	assert.Equal(t, ffmpeg.TranscodeAuto, c.FFmpegTranscode())
+}
+
 func TestConfig_FFmpegOptions(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	bitrate := "25M"
@@ -236,6 +236,11 @@ func (c *Config) SettingsYaml() string {
 	return filepath.Join(c.ConfigPath(), "settings.yml")
 }
 
+// RulesYaml returns the filename of the custom rules that map metadata to keywords and labels.
+func (c *Config) RulesYaml() string {
+	return filepath.Join(c.ConfigPath(), "rules.yml")
+}
+
 // SettingsYamlDefaults returns the default settings YAML filename.
 func (c *Config) SettingsYamlDefaults(settingsYml string) string {
 	if settingsYml != "" && fs.FileExists(settingsYml) {
@@ -375,6 +380,21 @@ func (c *Config) UserUploadPath(userUid, token string) (string, error) {
 	return dir, nil
 }
 
+// UserPartialUploadPath returns the folder for storing incomplete chunks of resumable uploads.
+func (c *Config) UserPartialUploadPath(userUid, token string) (string, error) {
+	if !rnd.IsUID(userUid, 0) {
+		return "", fmt.Errorf("invalid uid")
+	}
+
+	dir := filepath.Join(c.UserStoragePath(userUid), "partial", clean.Token(token))
+
+	if err := os.MkdirAll(dir, fs.ModeDir); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
 // TempPath returns the cached temporary directory name e.g. for uploads and downloads.
 func (c *Config) TempPath() string {
 	// Return cached value?
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -69,6 +70,20 @@ func TestConfig_UserUploadPath(t *testing.T) {
 	}
 }
 
+func TestConfig_UserPartialUploadPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	if dir, err := c.UserPartialUploadPath("etaetyget", ""); err == nil {
+		t.Error("error expected")
+	} else {
+		assert.Equal(t, "", dir)
+	}
+	if dir, err := c.UserPartialUploadPath("urjult03ceelhw6k", "foo"); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.Contains(t, dir, "users/urjult03ceelhw6k/partial/foo")
+	}
+}
+
 func TestConfig_SidecarPathIsAbs(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -432,6 +447,11 @@ func TestConfig_SqliteBin(t *testing.T) {
 	assert.Contains(t, c.SqliteBin(), "sqlite")
 }
 
+func TestConfig_RulesYaml(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, filepath.Join(c.ConfigPath(), "rules.yml"), c.RulesYaml())
+}
+
 func TestConfig_SettingsYamlDefaults(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	name1 := c.SettingsYamlDefaults(c.SettingsYaml())
@@ -1,10 +1,26 @@
 package config
 
+import (
+	"strings"
+	"time"
+)
+
+// Sources that can take precedence when YAML sidecar edits conflict with file metadata.
+const (
+	PrecedenceSidecar = "sidecar"
+	PrecedenceFile    = "file"
+)
+
 // ExifBruteForce checks if a brute-force search should be performed when no Exif headers were found.
 func (c *Config) ExifBruteForce() bool {
 	return c.options.ExifBruteForce || !c.ExifToolJson()
 }
 
+// ExifStrict checks if all Exif metadata of files with a partially corrupt Exif block should be ignored.
+func (c *Config) ExifStrict() bool {
+	return c.options.ExifStrict
+}
+
 // ExifToolBin returns the exiftool executable file name.
 func (c *Config) ExifToolBin() string {
 	return findBin(c.options.ExifToolBin, "exiftool")
@@ -19,3 +35,23 @@ func (c *Config) ExifToolJson() bool {
 func (c *Config) BackupYaml() bool {
 	return !c.DisableBackups()
 }
+
+// BackupYamlDelay returns the minimum duration between updates of the same photo YAML file.
+func (c *Config) BackupYamlDelay() time.Duration {
+	if c.options.BackupYamlDelay <= 0 {
+		return 0
+	} else if c.options.BackupYamlDelay > MaxBackupYamlDelay {
+		return time.Duration(MaxBackupYamlDelay) * time.Second
+	}
+
+	return time.Duration(c.options.BackupYamlDelay) * time.Second
+}
+
+// YamlPrecedence returns the source that wins if values edited in YAML sidecar files conflict with file metadata.
+func (c *Config) YamlPrecedence() string {
+	if strings.ToLower(strings.TrimSpace(c.options.YamlPrecedence)) == PrecedenceSidecar {
+		return PrecedenceSidecar
+	}
+
+	return PrecedenceFile
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -12,6 +13,14 @@ func TestConfig_ExifBruteForce(t *testing.T) {
 	assert.Equal(t, false, c.ExifBruteForce())
 }
 
+func TestConfig_ExifStrict(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.False(t, c.ExifStrict())
+	c.options.ExifStrict = true
+	assert.True(t, c.ExifStrict())
+	c.options.ExifStrict = false
+}
+
 func TestConfig_ExifToolBin(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -41,3 +50,32 @@ func TestConfig_SidecarYaml(t *testing.T) {
 	assert.Equal(t, false, c.BackupYaml())
 	assert.Equal(t, c.DisableBackups(), !c.BackupYaml())
 }
+
+func TestConfig_BackupYamlDelay(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, time.Duration(0), c.BackupYamlDelay())
+
+	c.options.BackupYamlDelay = 5
+	assert.Equal(t, 5*time.Second, c.BackupYamlDelay())
+
+	c.options.BackupYamlDelay = 100000
+	assert.Equal(t, 5*time.Minute, c.BackupYamlDelay())
+
+	c.options.BackupYamlDelay = -1
+	assert.Equal(t, time.Duration(0), c.BackupYamlDelay())
+}
+
+func TestConfig_YamlPrecedence(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, PrecedenceFile, c.YamlPrecedence())
+
+	c.options.YamlPrecedence = "Sidecar"
+	assert.Equal(t, PrecedenceSidecar, c.YamlPrecedence())
+
+	c.options.YamlPrecedence = "foo"
+	assert.Equal(t, PrecedenceFile, c.YamlPrecedence())
+
+	c.options.YamlPrecedence = ""
+}
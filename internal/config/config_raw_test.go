@@ -103,3 +103,19 @@ func TestConfig_RsvgConvertEnabled(t *testing.T) {
 	c.options.DisableVectors = true
 	assert.False(t, c.RsvgConvertEnabled())
 }
+
+func TestConfig_PdfToPpmBin(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	if bin := c.PdfToPpmBin(); bin != "" {
+		assert.Contains(t, bin, "/bin/pdftoppm")
+	}
+}
+
+func TestConfig_PdfEnabled(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.False(t, c.PdfEnabled())
+
+	c.options.IndexPdf = true
+	assert.Equal(t, c.PdfToPpmBin() != "", c.PdfEnabled())
+}
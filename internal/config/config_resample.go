@@ -4,6 +4,14 @@ import (
 	"strings"
 
 	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/media"
+)
+
+// Thumbnail creation modes.
+const (
+	ThumbModeEager = "eager"
+	ThumbModeLazy  = "lazy"
 )
 
 // JpegSize returns the size limit for automatically converted files in `PIXELS` (720-30000).
@@ -33,6 +41,27 @@ func (c *Config) JpegQuality() thumb.Quality {
 	return thumb.ParseQuality(c.options.JpegQuality)
 }
 
+// JpegDPI returns the pixel density in dots per inch stored in JPEG images and thumbnails, or 0 if it should be omitted.
+func (c *Config) JpegDPI() int {
+	if c.options.JpegDPI <= 0 {
+		return 0
+	} else if c.options.JpegDPI > thumb.JpegDPIMax {
+		return thumb.JpegDPIMax
+	}
+
+	return c.options.JpegDPI
+}
+
+// WebpQuality returns the WebP image quality as thumb.Quality (25-100).
+func (c *Config) WebpQuality() thumb.Quality {
+	return thumb.ParseFormatQuality(c.options.WebpQuality, fs.ImageWebP)
+}
+
+// AvifQuality returns the AVIF image quality as thumb.Quality (25-100).
+func (c *Config) AvifQuality() thumb.Quality {
+	return thumb.ParseFormatQuality(c.options.AvifQuality, fs.ImageAVIF)
+}
+
 // ThumbFilter returns the thumbnail resample filter (best to worst: blackman, lanczos, cubic or linear).
 func (c *Config) ThumbFilter() thumb.ResampleFilter {
 	switch strings.ToLower(c.options.ThumbFilter) {
@@ -64,6 +93,26 @@ func (c *Config) ThumbUncached() bool {
 	return c.options.ThumbUncached
 }
 
+// ThumbMode returns the thumbnail creation mode (eager or lazy).
+func (c *Config) ThumbMode() string {
+	switch strings.ToLower(strings.TrimSpace(c.options.ThumbMode)) {
+	case ThumbModeLazy:
+		return ThumbModeLazy
+	default:
+		return ThumbModeEager
+	}
+}
+
+// ThumbFallback returns the placeholder style for thumbnails that cannot be created (icon, gray, light, or dark).
+func (c *Config) ThumbFallback() thumb.PlaceholderStyle {
+	return thumb.ParsePlaceholderStyle(c.options.ThumbFallback)
+}
+
+// ThumbLazy checks if thumbnails should only be created when they are first requested.
+func (c *Config) ThumbLazy() bool {
+	return c.ThumbMode() == ThumbModeLazy
+}
+
 // ThumbSizePrecached returns the pre-cached thumbnail size limit in pixels (720-7680).
 func (c *Config) ThumbSizePrecached() int {
 	size := c.options.ThumbSize
@@ -93,3 +142,47 @@ func (c *Config) ThumbSizeUncached() int {
 
 	return limit
 }
+
+// ThumbPreload returns the thumbnail size names to announce as preload hints for HTTP/2 clients.
+func (c *Config) ThumbPreload() (names []string) {
+	if c.options.ThumbPreload == "" {
+		return names
+	}
+
+	for _, s := range strings.Split(c.options.ThumbPreload, ",") {
+		name := thumb.Name(strings.ToLower(strings.TrimSpace(s)))
+
+		if _, ok := thumb.Sizes[name]; ok {
+			names = append(names, name.String())
+		}
+	}
+
+	return names
+}
+
+// ThumbSmartCrop returns the photo types whose square thumbnails are cropped to avoid cutting off text and watermarks.
+func (c *Config) ThumbSmartCrop() (types []string) {
+	if c.options.ThumbSmartCrop == "" {
+		return types
+	}
+
+	for _, s := range strings.Split(c.options.ThumbSmartCrop, ",") {
+		switch t := media.New(s); t {
+		case media.Image, media.Raw, media.Animated, media.Live, media.Video, media.Vector, media.Document, media.Text:
+			types = append(types, t.String())
+		}
+	}
+
+	return types
+}
+
+// ThumbSmartCropType checks if square thumbnails of the specified photo type should be cropped to avoid cutting off text.
+func (c *Config) ThumbSmartCropType(photoType string) bool {
+	for _, t := range c.ThumbSmartCrop() {
+		if media.Type(t).Equal(photoType) {
+			return true
+		}
+	}
+
+	return false
+}
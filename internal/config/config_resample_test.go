@@ -17,6 +17,43 @@ func TestConfig_ConvertSize(t *testing.T) {
 	assert.Equal(t, 800, c.JpegSize())
 }
 
+func TestConfig_WebpQuality(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, thumb.WebpQualityDefault, c.WebpQuality())
+	c.options.WebpQuality = "110"
+	assert.Equal(t, thumb.WebpQualityDefault, c.WebpQuality())
+	c.options.WebpQuality = "70"
+	assert.Equal(t, thumb.Quality(70), c.WebpQuality())
+	c.options.WebpQuality = ""
+	assert.Equal(t, thumb.WebpQualityDefault, c.WebpQuality())
+}
+
+func TestConfig_AvifQuality(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, thumb.AvifQualityDefault, c.AvifQuality())
+	c.options.AvifQuality = "best"
+	assert.Equal(t, thumb.AvifQualityDefault, c.AvifQuality())
+	c.options.AvifQuality = "50"
+	assert.Equal(t, thumb.Quality(50), c.AvifQuality())
+	c.options.AvifQuality = ""
+	assert.Equal(t, thumb.AvifQualityDefault, c.AvifQuality())
+}
+
+func TestConfig_JpegDPI(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 0, c.JpegDPI())
+	c.options.JpegDPI = 300
+	assert.Equal(t, 300, c.JpegDPI())
+	c.options.JpegDPI = -1
+	assert.Equal(t, 0, c.JpegDPI())
+	c.options.JpegDPI = 100000
+	assert.Equal(t, thumb.JpegDPIMax, c.JpegDPI())
+	c.options.JpegDPI = 0
+}
+
 func TestConfig_JpegQuality(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -63,6 +100,31 @@ func TestConfig_ThumbSizeUncached(t *testing.T) {
 	assert.False(t, c.ThumbUncached())
 }
 
+func TestConfig_ThumbMode(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, ThumbModeEager, c.ThumbMode())
+	assert.False(t, c.ThumbLazy())
+	c.options.ThumbMode = "Lazy "
+	assert.Equal(t, ThumbModeLazy, c.ThumbMode())
+	assert.True(t, c.ThumbLazy())
+	c.options.ThumbMode = "xxx"
+	assert.Equal(t, ThumbModeEager, c.ThumbMode())
+	assert.False(t, c.ThumbLazy())
+}
+
+func TestConfig_ThumbFallback(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, thumb.PlaceholderIcon, c.ThumbFallback())
+	c.options.ThumbFallback = "Gray"
+	assert.Equal(t, thumb.PlaceholderGray, c.ThumbFallback())
+	c.options.ThumbFallback = "dark"
+	assert.Equal(t, thumb.PlaceholderDark, c.ThumbFallback())
+	c.options.ThumbFallback = "xxx"
+	assert.Equal(t, thumb.PlaceholderIcon, c.ThumbFallback())
+}
+
 func TestConfig_ThumbSize(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -81,3 +143,25 @@ func TestConfig_ThumbSizeUncached2(t *testing.T) {
 	c.options.ThumbSize = 900
 	assert.Equal(t, int(900), c.ThumbSizeUncached())
 }
+
+func TestConfig_ThumbPreload(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Empty(t, c.ThumbPreload())
+	c.options.ThumbPreload = " Fit_720, xxx,tile_224 "
+	assert.Equal(t, []string{"fit_720", "tile_224"}, c.ThumbPreload())
+}
+
+func TestConfig_ThumbSmartCrop(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Empty(t, c.ThumbSmartCrop())
+	assert.False(t, c.ThumbSmartCropType("document"))
+	c.options.ThumbSmartCrop = " Document, xxx,image "
+	assert.Equal(t, []string{"document", "image"}, c.ThumbSmartCrop())
+	assert.True(t, c.ThumbSmartCropType("document"))
+	assert.True(t, c.ThumbSmartCropType("image"))
+	assert.False(t, c.ThumbSmartCropType("video"))
+	assert.False(t, c.ThumbSmartCropType(""))
+	c.options.ThumbSmartCrop = ""
+}
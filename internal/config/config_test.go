@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -330,6 +331,18 @@ func TestConfig_Workers(t *testing.T) {
 	assert.GreaterOrEqual(t, c.Workers(), 1)
 }
 
+func TestConfig_ImportWorkers(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, c.Workers(), c.ImportWorkers())
+	c.options.ImportWorkers = 1
+	assert.Equal(t, 1, c.ImportWorkers())
+	c.options.ImportWorkers = 1000
+	assert.GreaterOrEqual(t, c.ImportWorkers(), 1)
+	assert.LessOrEqual(t, c.ImportWorkers(), runtime.NumCPU())
+	c.options.ImportWorkers = 0
+}
+
 func TestConfig_WakeupInterval(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	i := c.WakeupInterval()
@@ -344,6 +357,16 @@ func TestConfig_WakeupInterval(t *testing.T) {
 	assert.Equal(t, "1h34m9s", c.WakeupInterval().String())
 }
 
+func TestConfig_TrashRetention(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, time.Duration(0), c.TrashRetention())
+	c.options.TrashRetention = 30
+	assert.Equal(t, 30*24*time.Hour, c.TrashRetention())
+	c.options.TrashRetention = -1
+	assert.Equal(t, time.Duration(0), c.TrashRetention())
+	c.options.TrashRetention = 0
+}
+
 func TestConfig_AutoIndex(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.Equal(t, time.Duration(0), c.AutoIndex())
@@ -354,6 +377,15 @@ func TestConfig_AutoImport(t *testing.T) {
 	assert.Equal(t, 2*time.Hour, c.AutoImport())
 }
 
+func TestConfig_FolderAlbums(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.FolderAlbums())
+	c.options.FolderAlbums = true
+	assert.True(t, c.FolderAlbums())
+	c.options.FolderAlbums = false
+}
+
 func TestConfig_GeoApi(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -184,6 +184,11 @@ var Flags = CliFlags{
 			Value:  cpuid.CPU.PhysicalCores / 2,
 			EnvVar: EnvVar("WORKERS"),
 		}}, {
+		Flag: cli.IntFlag{
+			Name:   "import-workers",
+			Usage:  "maximum `NUMBER` of files imported in parallel, stacks are resolved in a deterministic order (0 for the number of indexing workers)",
+			EnvVar: EnvVar("IMPORT_WORKERS"),
+		}}, {
 		Flag: cli.StringFlag{
 			Name:   "wakeup-interval, i",
 			Usage:  "`DURATION` between worker runs required for face recognition and index maintenance (1-86400s)",
@@ -202,6 +207,35 @@ var Flags = CliFlags{
 			Value:  DefaultAutoImportDelay,
 			EnvVar: EnvVar("AUTO_IMPORT"),
 		}}, {
+		Flag: cli.IntFlag{
+			Name:   "backup-yaml-delay",
+			Usage:  "minimum `SECONDS` between photo metadata backups to the same YAML file (0 to disable)",
+			Value:  DefaultBackupYamlDelay,
+			EnvVar: EnvVar("BACKUP_YAML_DELAY"),
+		}}, {
+		Flag: cli.IntFlag{
+			Name:   "trash-retention",
+			Usage:  "number of `DAYS` after which archived pictures and their files are permanently deleted (0 to disable)",
+			Value:  0,
+			EnvVar: EnvVar("TRASH_RETENTION"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "best-of-weights",
+			Usage:  "scoring `WEIGHTS` for automatic best-of selections (quality, resolution, faces, favorite)",
+			Value:  DefaultBestOfWeights,
+			EnvVar: EnvVar("BEST_OF_WEIGHTS"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "yaml-precedence",
+			Usage:  "`SOURCE` that wins if values edited in YAML sidecar files conflict with file metadata when re-indexing (file, sidecar)",
+			Value:  PrecedenceFile,
+			EnvVar: EnvVar("YAML_PRECEDENCE"),
+		}}, {
+		Flag: cli.BoolFlag{
+			Name:   "folder-albums",
+			Usage:  "create and update albums for existing folders and their parents when indexing",
+			EnvVar: EnvVar("FOLDER_ALBUMS"),
+		}}, {
 		Flag: cli.BoolFlag{
 			Name:   "read-only, r",
 			Usage:  "disable import, upload, delete, and all other operations that require write permissions",
@@ -302,11 +336,21 @@ var Flags = CliFlags{
 			Usage:  "enables applying user presets when converting RAW images (reduces performance)",
 			EnvVar: EnvVar("RAW_PRESETS"),
 		}}, {
+		Flag: cli.BoolFlag{
+			Name:   "index-pdf",
+			Usage:  "enables indexing of PDF documents and the rendering of their first page as preview (requires pdftoppm)",
+			EnvVar: EnvVar("INDEX_PDF"),
+		}}, {
 		Flag: cli.BoolFlag{
 			Name:   "exif-bruteforce",
 			Usage:  "always perform a brute-force search if no Exif headers were found",
 			EnvVar: EnvVar("EXIF_BRUTEFORCE"),
 		}}, {
+		Flag: cli.BoolFlag{
+			Name:   "exif-strict",
+			Usage:  "ignore all Exif metadata of files with a partially corrupt Exif block",
+			EnvVar: EnvVar("EXIF_STRICT"),
+		}}, {
 		Flag: cli.BoolFlag{
 			Name:   "detect-nsfw",
 			Usage:  "automatically flag photos as private that MAY be offensive (requires TensorFlow)",
@@ -603,6 +647,18 @@ var Flags = CliFlags{
 			Value:  ffmpeg.MapAudioDefault,
 			EnvVar: EnvVar("FFMPEG_MAP_AUDIO"),
 		}}, {
+		Flag: cli.StringFlag{
+			Name:   "ffmpeg-rotate",
+			Usage:  "rotation `MODE` for video preview images (auto, metadata, none)",
+			Value:  ffmpeg.RotateAuto,
+			EnvVar: EnvVar("FFMPEG_ROTATE"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "ffmpeg-transcode",
+			Usage:  "transcoding `MODE` for streaming videos in formats browsers may not support (auto, incompatible, none)",
+			Value:  ffmpeg.TranscodeAuto,
+			EnvVar: EnvVar("FFMPEG_TRANSCODE"),
+		}}, {
 		Flag: cli.StringFlag{
 			Name:   "exiftool-bin",
 			Usage:  "ExifTool `COMMAND` for extracting metadata",
@@ -663,6 +719,12 @@ var Flags = CliFlags{
 			Value:  "heif-convert",
 			EnvVar: EnvVar("HEIFCONVERT_BIN"),
 		}}, {
+		Flag: cli.StringFlag{
+			Name:   "pdftoppm-bin",
+			Usage:  "Poppler PDF rendering `COMMAND` for document previews",
+			Value:  "pdftoppm",
+			EnvVar: EnvVar("PDFTOPPM_BIN"),
+		}}, {
 		Flag: cli.StringFlag{
 			Name:   "download-token",
 			Usage:  "`DEFAULT` download URL token for originals (leave empty for a random value)",
@@ -702,12 +764,53 @@ var Flags = CliFlags{
 			Usage:  "enable on-demand creation of missing thumbnails (high memory and cpu usage)",
 			EnvVar: EnvVar("THUMB_UNCACHED"),
 		}}, {
+		Flag: cli.StringFlag{
+			Name:   "thumb-preload",
+			Usage:  "thumbnail size `NAMES` to announce as preload hints for HTTP/2 clients (separate multiple with commas)",
+			Value:  "tile_500,fit_1920",
+			EnvVar: EnvVar("THUMB_PRELOAD"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "thumb-mode",
+			Usage:  "thumbnail creation `MODE` (eager: when indexing or importing files, lazy: when first requested)",
+			Value:  ThumbModeEager,
+			EnvVar: EnvVar("THUMB_MODE"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "thumb-fallback",
+			Usage:  "placeholder `STYLE` for thumbnails that cannot be created (icon, gray, light, dark)",
+			Value:  thumb.PlaceholderDefault.String(),
+			EnvVar: EnvVar("THUMB_FALLBACK"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "thumb-smart-crop",
+			Usage:  "photo `TYPES` whose square thumbnails are cropped to avoid cutting off text and watermarks, e.g. document (separate multiple with commas)",
+			EnvVar: EnvVar("THUMB_SMART_CROP"),
+		}}, {
 		Flag: cli.StringFlag{
 			Name:   "jpeg-quality, q",
 			Usage:  "a higher value increases the `QUALITY` and file size of JPEG images and thumbnails (25-100)",
 			Value:  thumb.JpegQuality.String(),
 			EnvVar: EnvVar("JPEG_QUALITY"),
 		}}, {
+		Flag: cli.IntFlag{
+			Name:   "jpeg-dpi",
+			Usage:  "pixel `DENSITY` in dots per inch stored in JPEG images and thumbnails, e.g. for print tools (0 to omit)",
+			Value:  0,
+			EnvVar: EnvVar("JPEG_DPI"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "webp-quality",
+			Usage:  "a higher value increases the `QUALITY` and file size of WebP thumbnails (25-100)",
+			Value:  thumb.WebpQualityDefault.String(),
+			EnvVar: EnvVar("WEBP_QUALITY"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "avif-quality",
+			Usage:  "a higher value increases the `QUALITY` and file size of AVIF thumbnails (25-100)",
+			Value:  thumb.AvifQualityDefault.String(),
+			EnvVar: EnvVar("AVIF_QUALITY"),
+		}}, {
 		Flag: cli.IntFlag{
 			Name:   "jpeg-size",
 			Usage:  "maximum size of created JPEG sidecar files in `PIXELS` (720-30000)",
@@ -57,9 +57,15 @@ type Options struct {
 	CustomAssetsPath      string        `yaml:"-" json:"-" flag:"custom-assets-path"`
 	TempPath              string        `yaml:"TempPath" json:"-" flag:"temp-path"`
 	Workers               int           `yaml:"Workers" json:"Workers" flag:"workers"`
+	ImportWorkers         int           `yaml:"ImportWorkers" json:"ImportWorkers" flag:"import-workers"`
 	WakeupInterval        time.Duration `yaml:"WakeupInterval" json:"WakeupInterval" flag:"wakeup-interval"`
 	AutoIndex             int           `yaml:"AutoIndex" json:"AutoIndex" flag:"auto-index"`
 	AutoImport            int           `yaml:"AutoImport" json:"AutoImport" flag:"auto-import"`
+	BackupYamlDelay       int           `yaml:"BackupYamlDelay" json:"BackupYamlDelay" flag:"backup-yaml-delay"`
+	TrashRetention        int           `yaml:"TrashRetention" json:"TrashRetention" flag:"trash-retention"`
+	BestOfWeights         string        `yaml:"BestOfWeights" json:"BestOfWeights" flag:"best-of-weights"`
+	YamlPrecedence        string        `yaml:"YamlPrecedence" json:"YamlPrecedence" flag:"yaml-precedence"`
+	FolderAlbums          bool          `yaml:"FolderAlbums" json:"FolderAlbums" flag:"folder-albums"`
 	ReadOnly              bool          `yaml:"ReadOnly" json:"ReadOnly" flag:"read-only"`
 	Experimental          bool          `yaml:"Experimental" json:"Experimental" flag:"experimental"`
 	DisableSettings       bool          `yaml:"DisableSettings" json:"-" flag:"disable-settings"`
@@ -81,7 +87,9 @@ type Options struct {
 	DisableJpegXL         bool          `yaml:"DisableJpegXL" json:"DisableJpegXL" flag:"disable-jpegxl"`
 	DisableRaw            bool          `yaml:"DisableRaw" json:"DisableRaw" flag:"disable-raw"`
 	RawPresets            bool          `yaml:"RawPresets" json:"RawPresets" flag:"raw-presets"`
+	IndexPdf              bool          `yaml:"IndexPdf" json:"IndexPdf" flag:"index-pdf"`
 	ExifBruteForce        bool          `yaml:"ExifBruteForce" json:"ExifBruteForce" flag:"exif-bruteforce"`
+	ExifStrict            bool          `yaml:"ExifStrict" json:"ExifStrict" flag:"exif-strict"`
 	DetectNSFW            bool          `yaml:"DetectNSFW" json:"DetectNSFW" flag:"detect-nsfw"`
 	UploadNSFW            bool          `yaml:"UploadNSFW" json:"-" flag:"upload-nsfw"`
 	DefaultTheme          string        `yaml:"DefaultTheme" json:"DefaultTheme" flag:"default-theme"`
@@ -131,6 +139,8 @@ type Options struct {
 	FFmpegBitrate         int           `yaml:"FFmpegBitrate" json:"FFmpegBitrate" flag:"ffmpeg-bitrate"`
 	FFmpegMapVideo        string        `yaml:"FFmpegMapVideo" json:"FFmpegMapVideo" flag:"ffmpeg-map-video"`
 	FFmpegMapAudio        string        `yaml:"FFmpegMapAudio" json:"FFmpegMapAudio" flag:"ffmpeg-map-audio"`
+	FFmpegRotate          string        `yaml:"FFmpegRotate" json:"FFmpegRotate" flag:"ffmpeg-rotate"`
+	FFmpegTranscode       string        `yaml:"FFmpegTranscode" json:"FFmpegTranscode" flag:"ffmpeg-transcode"`
 	ExifToolBin           string        `yaml:"ExifToolBin" json:"-" flag:"exiftool-bin"`
 	DarktableBin          string        `yaml:"DarktableBin" json:"-" flag:"darktable-bin"`
 	DarktableCachePath    string        `yaml:"DarktableCachePath" json:"-" flag:"darktable-cache-path"`
@@ -142,6 +152,7 @@ type Options struct {
 	ImageMagickBlacklist  string        `yaml:"ImageMagickBlacklist" json:"-" flag:"imagemagick-blacklist"`
 	HeifConvertBin        string        `yaml:"HeifConvertBin" json:"-" flag:"heifconvert-bin"`
 	RsvgConvertBin        string        `yaml:"RsvgConvertBin" json:"-" flag:"rsvgconvert-bin"`
+	PdfToPpmBin           string        `yaml:"PdfToPpmBin" json:"-" flag:"pdftoppm-bin"`
 	DownloadToken         string        `yaml:"DownloadToken" json:"-" flag:"download-token"`
 	PreviewToken          string        `yaml:"PreviewToken" json:"-" flag:"preview-token"`
 	ThumbColor            string        `yaml:"ThumbColor" json:"ThumbColor" flag:"thumb-color"`
@@ -149,7 +160,14 @@ type Options struct {
 	ThumbSize             int           `yaml:"ThumbSize" json:"ThumbSize" flag:"thumb-size"`
 	ThumbSizeUncached     int           `yaml:"ThumbSizeUncached" json:"ThumbSizeUncached" flag:"thumb-size-uncached"`
 	ThumbUncached         bool          `yaml:"ThumbUncached" json:"ThumbUncached" flag:"thumb-uncached"`
+	ThumbPreload          string        `yaml:"ThumbPreload" json:"ThumbPreload" flag:"thumb-preload"`
+	ThumbMode             string        `yaml:"ThumbMode" json:"ThumbMode" flag:"thumb-mode"`
+	ThumbFallback         string        `yaml:"ThumbFallback" json:"ThumbFallback" flag:"thumb-fallback"`
+	ThumbSmartCrop        string        `yaml:"ThumbSmartCrop" json:"ThumbSmartCrop" flag:"thumb-smart-crop"`
 	JpegQuality           string        `yaml:"JpegQuality" json:"JpegQuality" flag:"jpeg-quality"`
+	JpegDPI               int           `yaml:"JpegDPI" json:"JpegDPI" flag:"jpeg-dpi"`
+	WebpQuality           string        `yaml:"WebpQuality" json:"WebpQuality" flag:"webp-quality"`
+	AvifQuality           string        `yaml:"AvifQuality" json:"AvifQuality" flag:"avif-quality"`
 	JpegSize              int           `yaml:"JpegSize" json:"JpegSize" flag:"jpeg-size"`
 	PngSize               int           `yaml:"PngSize" json:"PngSize" flag:"png-size"`
 	FaceSize              int           `yaml:"-" json:"-" flag:"face-size"`
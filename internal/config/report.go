@@ -80,9 +80,15 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 
 		// Workers.
 		{"workers", fmt.Sprintf("%d", c.Workers())},
+		{"import-workers", fmt.Sprintf("%d", c.ImportWorkers())},
 		{"wakeup-interval", c.WakeupInterval().String()},
 		{"auto-index", fmt.Sprintf("%d", c.AutoIndex()/time.Second)},
 		{"auto-import", fmt.Sprintf("%d", c.AutoImport()/time.Second)},
+		{"backup-yaml-delay", fmt.Sprintf("%d", c.BackupYamlDelay()/time.Second)},
+		{"trash-retention", fmt.Sprintf("%d", c.TrashRetention()/(24*time.Hour))},
+		{"best-of-weights", c.Options().BestOfWeights},
+		{"yaml-precedence", c.YamlPrecedence()},
+		{"folder-albums", fmt.Sprintf("%t", c.FolderAlbums())},
 
 		// Feature Flags.
 		{"read-only", fmt.Sprintf("%t", c.ReadOnly())},
@@ -108,7 +114,9 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 
 		// Format Flags.
 		{"raw-presets", fmt.Sprintf("%t", c.RawPresets())},
+		{"index-pdf", fmt.Sprintf("%t", c.PdfEnabled())},
 		{"exif-bruteforce", fmt.Sprintf("%t", c.ExifBruteForce())},
+		{"exif-strict", fmt.Sprintf("%t", c.ExifStrict())},
 
 		// TensorFlow.
 		{"detect-nsfw", fmt.Sprintf("%t", c.DetectNSFW())},
@@ -184,6 +192,8 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"ffmpeg-bitrate", fmt.Sprintf("%d", c.FFmpegBitrate())},
 		{"ffmpeg-map-video", c.FFmpegMapVideo()},
 		{"ffmpeg-map-audio", c.FFmpegMapAudio()},
+		{"ffmpeg-rotate", c.FFmpegRotate()},
+		{"ffmpeg-transcode", c.FFmpegTranscode()},
 		{"exiftool-bin", c.ExifToolBin()},
 		{"darktable-bin", c.DarktableBin()},
 		{"darktable-cache-path", c.DarktableCachePath()},
@@ -196,6 +206,7 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"heifconvert-bin", c.HeifConvertBin()},
 		{"rsvgconvert-bin", c.RsvgConvertBin()},
 		{"jpegxldecoder-bin", c.JpegXLDecoderBin()},
+		{"pdftoppm-bin", c.PdfToPpmBin()},
 
 		// Thumbnails.
 		{"download-token", c.DownloadToken()},
@@ -205,7 +216,14 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"thumb-size", fmt.Sprintf("%d", c.ThumbSizePrecached())},
 		{"thumb-size-uncached", fmt.Sprintf("%d", c.ThumbSizeUncached())},
 		{"thumb-uncached", fmt.Sprintf("%t", c.ThumbUncached())},
+		{"thumb-preload", strings.Join(c.ThumbPreload(), ",")},
+		{"thumb-mode", c.ThumbMode()},
+		{"thumb-fallback", c.ThumbFallback().String()},
+		{"thumb-smart-crop", strings.Join(c.ThumbSmartCrop(), ",")},
 		{"jpeg-quality", fmt.Sprintf("%d", c.JpegQuality())},
+		{"jpeg-dpi", fmt.Sprintf("%d", c.JpegDPI())},
+		{"webp-quality", fmt.Sprintf("%d", c.WebpQuality())},
+		{"avif-quality", fmt.Sprintf("%d", c.AvifQuality())},
 		{"jpeg-size", fmt.Sprintf("%d", c.JpegSize())},
 		{"png-size", fmt.Sprintf("%d", c.PngSize())},
 
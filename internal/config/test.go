@@ -184,6 +184,9 @@ func NewTestConfig(pkg string) *Config {
 	thumb.SizeUncached = c.ThumbSizeUncached()
 	thumb.Filter = c.ThumbFilter()
 	thumb.JpegQuality = c.JpegQuality()
+	thumb.JpegDPI = c.JpegDPI()
+	thumb.WebpQuality = c.WebpQuality()
+	thumb.AvifQuality = c.AvifQuality()
 
 	return c
 }
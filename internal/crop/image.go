@@ -79,7 +79,9 @@ func ImageFromThumb(thumbName string, area Area, size Size, cache bool) (img ima
 	img = imaging.Crop(img, image.Rect(min.X, min.Y, max.X, max.Y))
 
 	// Resample crop area.
-	img = thumb.Resample(img, size.Width, size.Height, size.Options...)
+	if img, err = thumb.Resample(img, size.Width, size.Height, size.Options...); err != nil {
+		return img, err
+	}
 
 	// Cache crop image?
 	if cache {
@@ -53,7 +53,9 @@ func FromRequest(hash, area string, size Size, thumbPath string) (fileName strin
 	img = imaging.Crop(img, image.Rect(min.X, min.Y, max.X, max.Y))
 
 	// Resample crop area.
-	img = thumb.Resample(img, size.Width, size.Height, size.Options...)
+	if img, err = thumb.Resample(img, size.Width, size.Height, size.Options...); err != nil {
+		return "", err
+	}
 
 	// Save crop image.
 	if err := imaging.Save(img, cropName); err != nil {
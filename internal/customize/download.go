@@ -6,23 +6,36 @@ const (
 	DownloadNameFile     DownloadName = "file"
 	DownloadNameOriginal DownloadName = "original"
 	DownloadNameShare    DownloadName = "share"
+	DownloadNamePath     DownloadName = "path"
 )
 
 var DownloadNameDefault = DownloadNameFile
 
+// DownloadUnique specifies how duplicate file names in zip archives are made unique.
+type DownloadUnique string
+
+const (
+	DownloadUniqueSeq DownloadUnique = "seq"
+	DownloadUniqueUID DownloadUnique = "uid"
+)
+
+var DownloadUniqueDefault = DownloadUniqueSeq
+
 // DownloadSettings represents content download settings.
 type DownloadSettings struct {
-	Name         DownloadName `json:"name" yaml:"Name"`
-	Disabled     bool         `json:"disabled" yaml:"Disabled"`
-	Originals    bool         `json:"originals" yaml:"Originals"`
-	MediaRaw     bool         `json:"mediaRaw" yaml:"MediaRaw"`
-	MediaSidecar bool         `json:"mediaSidecar" yaml:"MediaSidecar"`
+	Name         DownloadName   `json:"name" yaml:"Name"`
+	Unique       DownloadUnique `json:"unique" yaml:"Unique"`
+	Disabled     bool           `json:"disabled" yaml:"Disabled"`
+	Originals    bool           `json:"originals" yaml:"Originals"`
+	MediaRaw     bool           `json:"mediaRaw" yaml:"MediaRaw"`
+	MediaSidecar bool           `json:"mediaSidecar" yaml:"MediaSidecar"`
 }
 
 // NewDownloadSettings creates download settings with defaults.
 func NewDownloadSettings() DownloadSettings {
 	return DownloadSettings{
 		Name:         DownloadNameDefault,
+		Unique:       DownloadUniqueDefault,
 		Disabled:     false,
 		Originals:    true,
 		MediaRaw:     false,
@@ -475,6 +475,17 @@ func (m *Session) SharedUIDs() UIDs {
 	}
 }
 
+// Locale returns the user interface locale of the session, or the default locale if the user has no preference.
+func (m *Session) Locale() i18n.Locale {
+	if user := m.User(); !user.IsRegistered() {
+		return i18n.CurrentLocale()
+	} else if lang := user.Settings().UILanguage; lang != "" {
+		return i18n.NewLocale(lang)
+	}
+
+	return i18n.CurrentLocale()
+}
+
 // RedeemToken updates shared entity UIDs using the specified token.
 func (m *Session) RedeemToken(token string) (n int) {
 	if user := m.User(); user.IsRegistered() {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/pkg/rnd"
 )
 
@@ -158,3 +159,14 @@ func TestSession_Expired(t *testing.T) {
 		assert.Equal(t, m.ExpiresAt(), m.TimeoutAt())
 	})
 }
+
+func TestSession_Locale(t *testing.T) {
+	t.Run("UserSettings", func(t *testing.T) {
+		m := SessionFixtures.Pointer("bob")
+		assert.Equal(t, i18n.BrazilianPortuguese, m.Locale())
+	})
+	t.Run("Default", func(t *testing.T) {
+		m := SessionFixtures.Pointer("visitor")
+		assert.Equal(t, i18n.CurrentLocale(), m.Locale())
+	})
+}
@@ -24,6 +24,7 @@ const (
 	MediaLive     = string(media.Live)
 	MediaVideo    = string(media.Video)
 	MediaVector   = string(media.Vector)
+	MediaDocument = string(media.Document)
 	MediaText     = string(media.Text)
 )
 
@@ -30,6 +30,7 @@ var Entities = Tables{
 	FileSync{}.TableName():          &FileSync{},
 	Photo{}.TableName():             &Photo{},
 	PhotoUser{}.TableName():         &PhotoUser{},
+	PhotoOrder{}.TableName():        &PhotoOrder{},
 	Details{}.TableName():           &Details{},
 	Place{}.TableName():             &Place{},
 	Cell{}.TableName():              &Cell{},
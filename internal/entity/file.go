@@ -72,6 +72,7 @@ type File struct {
 	FileProjection     string        `gorm:"type:VARBINARY(64);" json:"Projection,omitempty" yaml:"Projection,omitempty"`
 	FileAspectRatio    float32       `gorm:"type:FLOAT;" json:"AspectRatio" yaml:"AspectRatio,omitempty"`
 	FileHDR            bool          `gorm:"column:file_hdr;"  json:"HDR" yaml:"HDR,omitempty"`
+	FileFlash          bool          `gorm:"column:file_flash;"  json:"Flash" yaml:"Flash,omitempty"`
 	FileWatermark      bool          `gorm:"column:file_watermark;"  json:"Watermark" yaml:"Watermark,omitempty"`
 	FileColorProfile   string        `gorm:"type:VARBINARY(64);" json:"ColorProfile,omitempty" yaml:"ColorProfile,omitempty"`
 	FileMainColor      string        `gorm:"type:VARBINARY(16);index;" json:"MainColor" yaml:"MainColor,omitempty"`
@@ -81,6 +82,7 @@ type File struct {
 	FileChroma         int16         `json:"Chroma" yaml:"Chroma,omitempty"`
 	FileSoftware       string        `gorm:"type:VARCHAR(64)" json:"Software" yaml:"Software,omitempty"`
 	FileError          string        `gorm:"type:VARBINARY(512)" json:"Error" yaml:"Error,omitempty"`
+	FileMetaError      string        `gorm:"type:VARBINARY(512)" json:"MetaError,omitempty" yaml:"MetaError,omitempty"`
 	ModTime            int64         `json:"ModTime" yaml:"-"`
 	CreatedAt          time.Time     `json:"CreatedAt" yaml:"-"`
 	CreatedIn          int64         `json:"CreatedIn" yaml:"-"`
@@ -213,6 +215,8 @@ func (m *File) DownloadName(n customize.DownloadName, seq int) string {
 		return m.Base(seq)
 	case customize.DownloadNameOriginal:
 		return m.OriginalBase(seq)
+	case customize.DownloadNamePath:
+		return m.PathName(seq)
 	default:
 		return m.ShareBase(seq)
 	}
@@ -233,6 +237,23 @@ func (m *File) Base(seq int) string {
 	return base
 }
 
+// PathName returns the file name including the folder path relative to the root directory, e.g. to mirror
+// the originals folder structure. Separators are normalized and names that could be used for directory
+// traversal are replaced by the file name without path.
+func (m *File) PathName(seq int) string {
+	name := clean.UserPath(m.FileName)
+
+	if name == "" {
+		return m.Base(seq)
+	}
+
+	if seq > 0 {
+		return fmt.Sprintf("%s (%d)%s", fs.StripExt(name), seq, filepath.Ext(name))
+	}
+
+	return name
+}
+
 // OriginalBase returns the original file name without path.
 func (m *File) OriginalBase(seq int) string {
 	if m.OriginalName == "" {
@@ -533,6 +554,31 @@ func (m *File) Undelete() error {
 	return nil
 }
 
+// Attach links this file to the specified photo, e.g. to re-attach an orphaned file.
+func (m *File) Attach(p *Photo) error {
+	if p == nil || !p.HasID() {
+		return fmt.Errorf("file %s: cannot attach to invalid photo", clean.Log(m.FileUID))
+	}
+
+	// Update database row.
+	if err := m.Updates(map[string]interface{}{
+		"PhotoID":     p.ID,
+		"PhotoUID":    p.PhotoUID,
+		"FilePrimary": false,
+	}); err != nil {
+		return err
+	}
+
+	log.Infof("file %s: attached to photo %s", clean.Log(m.FileUID), clean.Log(p.PhotoUID))
+
+	m.PhotoID = p.ID
+	m.PhotoUID = p.PhotoUID
+	m.FilePrimary = false
+	m.Photo = p
+
+	return nil
+}
+
 // RelatedPhoto returns the related photo entity.
 func (m *File) RelatedPhoto() *Photo {
 	if m.Photo != nil {
@@ -616,6 +662,18 @@ func (m *File) ResetHDR() {
 	m.FileHDR = false
 }
 
+// FlashFired returns true if the flash fired when the picture was taken.
+func (m *File) FlashFired() bool {
+	return m.FileFlash
+}
+
+// SetFlash sets the flash fired flag.
+func (m *File) SetFlash(fired bool) {
+	if fired {
+		m.FileFlash = true
+	}
+}
+
 // HasWatermark returns true if the file has a watermark.
 func (m *File) HasWatermark() bool {
 	return m.FileWatermark
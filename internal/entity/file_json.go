@@ -44,6 +44,7 @@ func (m *File) MarshalJSON() ([]byte, error) {
 		Diff           int           `json:",omitempty"`
 		Chroma         int16         `json:",omitempty"`
 		HDR            bool          `json:",omitempty"`
+		Flash          bool          `json:",omitempty"`
 		Watermark      bool          `json:",omitempty"`
 		Software       string        `json:",omitempty"`
 		Error          string        `json:",omitempty"`
@@ -91,6 +92,7 @@ func (m *File) MarshalJSON() ([]byte, error) {
 		Diff:           m.FileDiff,
 		Chroma:         m.FileChroma,
 		HDR:            m.FileHDR,
+		Flash:          m.FileFlash,
 		Watermark:      m.FileWatermark,
 		Software:       m.FileSoftware,
 		Error:          m.FileError,
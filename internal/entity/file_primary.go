@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/list"
+	"github.com/photoprism/photoprism/pkg/media"
+)
+
+// PrimaryCandidate tests if the file can be used as primary file of a photo.
+func (m *File) PrimaryCandidate() bool {
+	if m.FileMissing || m.FileSidecar || m.FileVideo || m.FileError != "" || m.DeletedAt != nil {
+		return false
+	}
+
+	return list.Contains(media.PreviewFileTypes, m.FileType)
+}
+
+// Pixels returns the resolution of the file in pixels.
+func (m *File) Pixels() int {
+	return m.FileWidth * m.FileHeight
+}
+
+// MetadataScore returns the number of metadata fields of the file that have a value.
+func (m *File) MetadataScore() (score int) {
+	if !m.PhotoTakenAt.IsZero() {
+		score++
+	}
+
+	if m.FileOrientation > 0 {
+		score++
+	}
+
+	if m.InstanceID != "" {
+		score++
+	}
+
+	if m.FileCodec != "" {
+		score++
+	}
+
+	if m.FileColorProfile != "" {
+		score++
+	}
+
+	if m.FileMainColor != "" && m.FileColors != "" {
+		score++
+	}
+
+	if m.FileSoftware != "" {
+		score++
+	}
+
+	if m.FileMetaError == "" {
+		score++
+	}
+
+	return score
+}
+
+// BestPrimary returns the file that is best suited as primary file of a photo, or nil if there is none.
+// JPEG images are preferred, followed by files with a higher resolution and more complete metadata.
+func (m Files) BestPrimary() (result *File) {
+	for i := range m {
+		f := &m[i]
+
+		if !f.PrimaryCandidate() {
+			continue
+		} else if result == nil || f.betterPrimary(result) {
+			result = f
+		}
+	}
+
+	return result
+}
+
+// betterPrimary tests if the file is better suited as primary file than the other file.
+func (m *File) betterPrimary(other *File) bool {
+	if jpeg := m.FileType == fs.ImageJPEG.String(); jpeg != (other.FileType == fs.ImageJPEG.String()) {
+		return jpeg
+	} else if m.Pixels() != other.Pixels() {
+		return m.Pixels() > other.Pixels()
+	} else if score := m.MetadataScore(); score != other.MetadataScore() {
+		return score > other.MetadataScore()
+	} else if m.FileHDR != other.FileHDR {
+		return m.FileHDR
+	}
+
+	// Keep the current primary file if there is no difference.
+	return m.FilePrimary && !other.FilePrimary
+}
@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFile_PrimaryCandidate(t *testing.T) {
+	t.Run("Jpeg", func(t *testing.T) {
+		f := File{FileType: "jpg"}
+		assert.True(t, f.PrimaryCandidate())
+	})
+	t.Run("Png", func(t *testing.T) {
+		f := File{FileType: "png"}
+		assert.True(t, f.PrimaryCandidate())
+	})
+	t.Run("Raw", func(t *testing.T) {
+		f := File{FileType: "raw"}
+		assert.False(t, f.PrimaryCandidate())
+	})
+	t.Run("Missing", func(t *testing.T) {
+		f := File{FileType: "jpg", FileMissing: true}
+		assert.False(t, f.PrimaryCandidate())
+	})
+	t.Run("Sidecar", func(t *testing.T) {
+		f := File{FileType: "jpg", FileSidecar: true}
+		assert.False(t, f.PrimaryCandidate())
+	})
+	t.Run("Error", func(t *testing.T) {
+		f := File{FileType: "jpg", FileError: "broken"}
+		assert.False(t, f.PrimaryCandidate())
+	})
+}
+
+func TestFile_MetadataScore(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		f := File{FileMetaError: "no metadata"}
+		assert.Equal(t, 0, f.MetadataScore())
+	})
+	t.Run("Complete", func(t *testing.T) {
+		f := File{
+			PhotoTakenAt:     time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC),
+			FileOrientation:  1,
+			InstanceID:       "uuid:a1b2c3",
+			FileCodec:        "jpeg",
+			FileColorProfile: "Display P3",
+			FileMainColor:    "red",
+			FileColors:       "225221C1E",
+			FileSoftware:     "Adobe Lightroom",
+		}
+		assert.Equal(t, 8, f.MetadataScore())
+	})
+}
+
+func TestFiles_BestPrimary(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Nil(t, Files{}.BestPrimary())
+	})
+	t.Run("NoCandidate", func(t *testing.T) {
+		files := Files{
+			{FileUID: "fqzuh65p4sjk3kdn", FileType: "raw", FileWidth: 6000, FileHeight: 4000},
+			{FileUID: "fqzuh65p4sjk3kdo", FileType: "jpg", FileWidth: 6000, FileHeight: 4000, FileMissing: true},
+		}
+		assert.Nil(t, files.BestPrimary())
+	})
+	t.Run("PreferJpeg", func(t *testing.T) {
+		files := Files{
+			{FileUID: "fqzuh65p4sjk3kdn", FileType: "png", FileWidth: 6000, FileHeight: 4000},
+			{FileUID: "fqzuh65p4sjk3kdo", FileType: "jpg", FileWidth: 1200, FileHeight: 800},
+		}
+		assert.Equal(t, "fqzuh65p4sjk3kdo", files.BestPrimary().FileUID)
+	})
+	t.Run("HighestResolution", func(t *testing.T) {
+		files := Files{
+			{FileUID: "fqzuh65p4sjk3kdn", FileType: "jpg", FileWidth: 1200, FileHeight: 800, FilePrimary: true},
+			{FileUID: "fqzuh65p4sjk3kdo", FileType: "jpg", FileWidth: 6000, FileHeight: 4000},
+			{FileUID: "fqzuh65p4sjk3kdp", FileType: "jpg", FileWidth: 8000, FileHeight: 6000, FileError: "broken"},
+		}
+		assert.Equal(t, "fqzuh65p4sjk3kdo", files.BestPrimary().FileUID)
+	})
+	t.Run("MostCompleteMetadata", func(t *testing.T) {
+		files := Files{
+			{FileUID: "fqzuh65p4sjk3kdn", FileType: "jpg", FileWidth: 1200, FileHeight: 800, FilePrimary: true},
+			{FileUID: "fqzuh65p4sjk3kdo", FileType: "jpg", FileWidth: 1200, FileHeight: 800, FileOrientation: 1, InstanceID: "uuid:a1b2c3"},
+		}
+		assert.Equal(t, "fqzuh65p4sjk3kdo", files.BestPrimary().FileUID)
+	})
+	t.Run("KeepPrimary", func(t *testing.T) {
+		files := Files{
+			{FileUID: "fqzuh65p4sjk3kdn", FileType: "jpg", FileWidth: 1200, FileHeight: 800},
+			{FileUID: "fqzuh65p4sjk3kdo", FileType: "jpg", FileWidth: 1200, FileHeight: 800, FilePrimary: true},
+		}
+		assert.Equal(t, "fqzuh65p4sjk3kdo", files.BestPrimary().FileUID)
+	})
+}
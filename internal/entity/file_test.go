@@ -469,6 +469,36 @@ func TestFile_DownloadName(t *testing.T) {
 		filename3 := file.DownloadName("xxx", 0)
 		assert.Contains(t, filename3, "20190115-000000-Berlin-Morning-Mood")
 	})
+	t.Run("DownloadNamePath", func(t *testing.T) {
+		file := &File{FileType: "jpg", FileHash: "e98eb86480a72bd585d228a709f0622f90e86cbc", FileName: "2019/01/filename.jpg"}
+
+		assert.Equal(t, "2019/01/filename.jpg", file.DownloadName(customize.DownloadNamePath, 0))
+		assert.Equal(t, "2019/01/filename (1).jpg", file.DownloadName(customize.DownloadNamePath, 1))
+	})
+}
+
+func TestFile_PathName(t *testing.T) {
+	t.Run("Folder", func(t *testing.T) {
+		file := &File{FileName: "Holiday/Berlin/IMG_1234.JPG"}
+		assert.Equal(t, "Holiday/Berlin/IMG_1234.JPG", file.PathName(0))
+		assert.Equal(t, "Holiday/Berlin/IMG_1234 (2).JPG", file.PathName(2))
+	})
+	t.Run("NoFolder", func(t *testing.T) {
+		file := &File{FileName: "IMG_1234.JPG"}
+		assert.Equal(t, "IMG_1234.JPG", file.PathName(0))
+	})
+	t.Run("Backslashes", func(t *testing.T) {
+		file := &File{FileName: `Holiday\Berlin\IMG_1234.JPG`}
+		assert.Equal(t, "Holiday/Berlin/IMG_1234.JPG", file.PathName(0))
+	})
+	t.Run("Absolute", func(t *testing.T) {
+		file := &File{FileName: "/etc/passwd.jpg"}
+		assert.Equal(t, "etc/passwd.jpg", file.PathName(0))
+	})
+	t.Run("Traversal", func(t *testing.T) {
+		file := &File{FileName: "../../etc/passwd.jpg"}
+		assert.Equal(t, "passwd.jpg", file.PathName(0))
+	})
 }
 
 func TestFile_Undelete(t *testing.T) {
@@ -495,6 +525,41 @@ func TestFile_Undelete(t *testing.T) {
 	})
 }
 
+func TestFile_Attach(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		photo := PhotoFixtures.Pointer("19800101_000002_D640C559")
+		file := &File{FileUID: "fs6sg6bw1attach1", PhotoID: 9999998, PhotoUID: "ps6sg6bw1attach1", FileName: "orphans/attach.jpg", FileRoot: RootOriginals, FileType: "jpg", FilePrimary: true}
+
+		if err := file.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer file.DeletePermanently()
+
+		if err := file.Attach(photo); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, photo.ID, file.PhotoID)
+		assert.Equal(t, photo.PhotoUID, file.PhotoUID)
+		assert.False(t, file.FilePrimary)
+
+		found := File{}
+
+		if err := UnscopedDb().First(&found, "file_uid = ?", file.FileUID).Error; err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, photo.ID, found.PhotoID)
+			assert.Equal(t, photo.PhotoUID, found.PhotoUID)
+		}
+	})
+	t.Run("InvalidPhoto", func(t *testing.T) {
+		file := &File{FileUID: "fs6sg6bw1attach2", PhotoID: 9999997}
+		assert.Error(t, file.Attach(nil))
+		assert.Error(t, file.Attach(&Photo{}))
+	})
+}
+
 func TestFile_AddFaces(t *testing.T) {
 	t.Run("Primary", func(t *testing.T) {
 		file := &File{FileUID: "fqzuh65p4sjk3kdn", FileHash: "346b3897eec9ef75e35fbf0bbc4c83c55ca41e31", FileType: "jpg", FileWidth: 720, FileName: "FacesTest", PhotoID: 1000003, FilePrimary: true}
@@ -673,6 +738,18 @@ func TestFile_SetHDR(t *testing.T) {
 	})
 }
 
+func TestFile_SetFlash(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		m := FileFixtures.Get("exampleFileName.jpg")
+
+		assert.Equal(t, false, m.FlashFired())
+		m.SetFlash(false)
+		assert.Equal(t, false, m.FlashFired())
+		m.SetFlash(true)
+		assert.Equal(t, true, m.FlashFired())
+	})
+}
+
 func TestFile_SetColorProfile(t *testing.T) {
 	t.Run("DisplayP3", func(t *testing.T) {
 		m := FileFixtures.Get("exampleFileName.jpg")
@@ -11,6 +11,7 @@ import (
 	"github.com/ulule/deepcopier"
 
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/photoprism/photoprism/pkg/sortby"
@@ -125,9 +126,9 @@ func (m *Folder) SetValuesFromPath() {
 		if date := txt.DateFromFilePath(m.Path); !date.IsZero() {
 			if txt.IsUInt(s) || txt.IsTime(s) {
 				if date.Day() > 1 {
-					m.FolderTitle = date.Format("January 2, 2006")
+					m.FolderTitle = i18n.CurrentLocale().Date(date)
 				} else {
-					m.FolderTitle = date.Format("January 2006")
+					m.FolderTitle = i18n.CurrentLocale().MonthYear(date)
 				}
 			}
 
@@ -164,7 +165,16 @@ func (m *Folder) Create() error {
 
 	if err := Db().Create(m).Error; err != nil {
 		return err
-	} else if m.Root != RootOriginals || m.Path == "" {
+	} else if err = m.UpdateAlbum(); err != nil {
+		log.Errorf("folder: %s (update album)", err)
+	}
+
+	return nil
+}
+
+// UpdateAlbum creates or updates the album of a folder in originals, unless it has been deleted.
+func (m *Folder) UpdateAlbum() error {
+	if m.Root != RootOriginals || m.Path == "" {
 		return nil
 	}
 
@@ -176,9 +186,10 @@ func (m *Folder) Create() error {
 	if a := FindFolderAlbum(m.Path); a != nil {
 		if a.DeletedAt != nil {
 			// Ignore.
-		} else if err := a.UpdateFolder(m.Path, f.Serialize()); err != nil {
-			log.Errorf("folder: %s (update album)", err.Error())
+			return nil
 		}
+
+		return a.UpdateFolder(m.Path, f.Serialize())
 	} else if a := NewFolderAlbum(m.Title(), m.Path, f.Serialize()); a != nil {
 		a.AlbumYear = m.FolderYear
 		a.AlbumMonth = m.FolderMonth
@@ -186,10 +197,10 @@ func (m *Folder) Create() error {
 		a.AlbumCountry = m.FolderCountry
 
 		if err := a.Create(); err != nil {
-			log.Errorf("folder: %s (add album)", err)
-		} else {
-			log.Infof("folder: added album %s (%s)", clean.Log(a.AlbumTitle), a.AlbumFilter)
+			return err
 		}
+
+		log.Infof("folder: added album %s (%s)", clean.Log(a.AlbumTitle), a.AlbumFilter)
 	}
 
 	return nil
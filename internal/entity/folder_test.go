@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -208,3 +209,65 @@ func TestFolder_Create(t *testing.T) {
 		assert.Equal(t, "Holiday 2020", result.Title())
 	})
 }
+
+func TestFolder_UpdateAlbum(t *testing.T) {
+	t.Run("Nested", func(t *testing.T) {
+		paths := []string{"2019", "2019/Iceland", "2019/Iceland/Reykjavik"}
+
+		for _, p := range paths {
+			folder := NewFolder(RootOriginals, p, time.Now())
+
+			// Updating the album again must not create duplicates.
+			for i := 0; i < 2; i++ {
+				if err := folder.UpdateAlbum(); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+
+		for _, p := range paths {
+			var albums Albums
+
+			if err := Db().Where("album_type = ? AND album_path = ?", AlbumFolder, p).Find(&albums).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if assert.Len(t, albums, 1) {
+				assert.Equal(t, fmt.Sprintf("path:%s public:true", p), albums[0].AlbumFilter)
+			}
+		}
+
+		if a := FindFolderAlbum("2019/Iceland/Reykjavik"); assert.NotNil(t, a) {
+			assert.Equal(t, "Reykjavik", a.AlbumTitle)
+			assert.Equal(t, 2019, a.AlbumYear)
+		}
+	})
+	t.Run("Deleted", func(t *testing.T) {
+		folder := NewFolder(RootOriginals, "2019/Deleted", time.Now())
+
+		if err := folder.UpdateAlbum(); err != nil {
+			t.Fatal(err)
+		}
+
+		a := FindFolderAlbum("2019/Deleted")
+
+		if a == nil {
+			t.Fatal("album should exist")
+		} else if err := a.Delete(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := folder.UpdateAlbum(); err != nil {
+			t.Fatal(err)
+		}
+
+		if found := FindFolderAlbum("2019/Deleted"); assert.NotNil(t, found) {
+			assert.NotNil(t, found.DeletedAt)
+		}
+	})
+	t.Run("Import", func(t *testing.T) {
+		folder := NewFolder(RootImport, "2019/Import", time.Now())
+		assert.NoError(t, folder.UpdateAlbum())
+		assert.Nil(t, FindFolderAlbum("2019/Import"))
+	})
+}
@@ -72,6 +72,7 @@ type Photo struct {
 	PhotoPrivate     bool          `json:"Private" yaml:"Private,omitempty"`
 	PhotoScan        bool          `json:"Scan" yaml:"Scan,omitempty"`
 	PhotoPanorama    bool          `json:"Panorama" yaml:"Panorama,omitempty"`
+	ReviewNeeded     bool          `gorm:"index;" json:"ReviewNeeded" yaml:"ReviewNeeded,omitempty"`
 	TimeZone         string        `gorm:"type:VARBINARY(64);" json:"TimeZone" yaml:"TimeZone,omitempty"`
 	PlaceID          string        `gorm:"type:VARBINARY(42);index;default:'zz'" json:"PlaceID" yaml:"-"`
 	PlaceSrc         string        `gorm:"type:VARBINARY(8);" json:"PlaceSrc" yaml:"PlaceSrc,omitempty"`
@@ -93,6 +94,8 @@ type Photo struct {
 	PhotoResolution  int           `gorm:"type:SMALLINT" json:"Resolution" yaml:"-"`
 	PhotoDuration    time.Duration `json:"Duration,omitempty" yaml:"Duration,omitempty"`
 	PhotoColor       int16         `json:"Color" yaml:"-"`
+	PhotoThumbHash   string        `gorm:"type:VARBINARY(64);" json:"ThumbHash,omitempty" yaml:"-"`
+	PhotoBlurHash    string        `gorm:"type:VARBINARY(64);" json:"BlurHash,omitempty" yaml:"-"`
 	CameraID         uint          `gorm:"index:idx_photos_camera_lens;default:1" json:"CameraID" yaml:"-"`
 	CameraSerial     string        `gorm:"type:VARBINARY(160);" json:"CameraSerial" yaml:"CameraSerial,omitempty"`
 	CameraSrc        string        `gorm:"type:VARBINARY(8);" json:"CameraSrc" yaml:"-"`
@@ -154,6 +157,10 @@ func NewUserPhoto(stackable bool, userUid string) Photo {
 
 // SavePhotoForm saves a model in the database using form data.
 func SavePhotoForm(model Photo, form form.Photo) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
 	locChanged := model.PhotoLat != form.PhotoLat || model.PhotoLng != form.PhotoLng || model.PhotoCountry != form.PhotoCountry
 
 	if err := deepcopier.Copy(&model).From(form); err != nil {
@@ -462,7 +469,8 @@ func (m *Photo) IndexKeywords() error {
 	keywords = append(keywords, txt.Keywords(details.Subject)...)
 	keywords = append(keywords, txt.Keywords(details.Artist)...)
 
-	keywords = txt.UniqueWords(keywords)
+	// Index keywords without diacritics as well for diacritic-insensitive search.
+	keywords = txt.UniqueWords(txt.FoldWords(keywords))
 
 	for _, w := range keywords {
 		kw := FirstOrCreateKeyword(NewKeyword(w))
@@ -870,6 +878,21 @@ func (m *Photo) SetFavorite(favorite bool) error {
 	return nil
 }
 
+// SetPrivate updates the private flag of a photo, and returns true if it has changed.
+func (m *Photo) SetPrivate(private bool) (changed bool, err error) {
+	if m.PhotoPrivate == private {
+		return false, nil
+	}
+
+	m.PhotoPrivate = private
+
+	if err = m.Update("PhotoPrivate", m.PhotoPrivate); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 // SetStack updates the stack flag of a photo.
 func (m *Photo) SetStack(stack int8) {
 	if m.PhotoStack != stack {
@@ -905,6 +928,18 @@ func (m *Photo) Approve() error {
 	return nil
 }
 
+// MarkReviewed clears the flag for photos with only low-confidence labels once they have been reviewed.
+func (m *Photo) MarkReviewed() error {
+	if !m.ReviewNeeded {
+		// Nothing to do.
+		return nil
+	}
+
+	m.ReviewNeeded = false
+
+	return m.Update("ReviewNeeded", false)
+}
+
 // Links returns all share links for this entity.
 func (m *Photo) Links() Links {
 	return FindLinks("", m.PhotoUID)
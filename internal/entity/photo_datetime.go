@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -76,6 +77,45 @@ func (m *Photo) SetTakenAt(taken, local time.Time, zone, source string) {
 	m.UpdateDateFields()
 }
 
+// SaveTime manually sets the time when the photo was taken and its time zone, and saves the changes,
+// so that the photo is sorted accordingly. The local time is calculated based on the time zone.
+func (m *Photo) SaveTime(taken time.Time, zone string) error {
+	if !m.HasID() {
+		return fmt.Errorf("photo id is missing")
+	} else if taken.IsZero() || taken.Year() < 1000 || taken.Year() > txt.YearMax {
+		return fmt.Errorf("invalid time")
+	}
+
+	m.TimeZone = zone
+	m.TakenAt = taken.UTC().Truncate(time.Second)
+	m.TakenAtLocal = m.GetTakenAtLocal()
+	m.TakenSrc = SrcManual
+	m.PhotoYear = m.TakenAtLocal.Year()
+	m.PhotoMonth = int(m.TakenAtLocal.Month())
+	m.PhotoDay = m.TakenAtLocal.Day()
+
+	edited := TimeStamp()
+	m.EditedAt = &edited
+
+	if err := m.Updates(Values{
+		"taken_at":       m.TakenAt,
+		"taken_at_local": m.TakenAtLocal,
+		"taken_src":      m.TakenSrc,
+		"time_zone":      m.TimeZone,
+		"photo_year":     m.PhotoYear,
+		"photo_month":    m.PhotoMonth,
+		"photo_day":      m.PhotoDay,
+		"edited_at":      m.EditedAt,
+	}); err != nil {
+		return err
+	}
+
+	// Update the time in related files, which are sorted by it.
+	m.UpdateDateFields()
+
+	return nil
+}
+
 // TimeZoneUTC tests if the current time zone is UTC.
 func (m *Photo) TimeZoneUTC() bool {
 	return strings.EqualFold(m.TimeZone, time.UTC.String())
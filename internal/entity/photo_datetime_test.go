@@ -336,3 +336,60 @@ func TestPhoto_UpdateTimeZone(t *testing.T) {
 		assert.Equal(t, "Europe/Berlin", photo.TimeZone)
 	})
 }
+
+func TestPhoto_SaveTime(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		m := Photo{PhotoTitle: "Save Time", PhotoType: MediaImage}
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _, _ = m.DeletePermanently() }()
+
+		file := File{PhotoID: m.ID, PhotoUID: m.PhotoUID, FileName: "save-time.jpg", FileHash: "savetime", FileType: "jpg", FilePrimary: true}
+
+		if err := file.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		taken := time.Date(2019, 12, 31, 23, 30, 15, 500, time.UTC)
+
+		if err := m.SaveTime(taken, "Asia/Tokyo"); err != nil {
+			t.Fatal(err)
+		}
+
+		found := FindPhoto(Photo{PhotoUID: m.PhotoUID})
+
+		if found == nil {
+			t.Fatal("photo not found")
+		}
+
+		assert.Equal(t, "Asia/Tokyo", found.TimeZone)
+		assert.Equal(t, SrcManual, found.TakenSrc)
+		assert.Equal(t, time.Date(2019, 12, 31, 23, 30, 15, 0, time.UTC), found.TakenAt.UTC())
+		assert.Equal(t, time.Date(2020, 1, 1, 8, 30, 15, 0, time.UTC), found.TakenAtLocal.UTC())
+		assert.Equal(t, 2020, found.PhotoYear)
+		assert.Equal(t, 1, found.PhotoMonth)
+		assert.Equal(t, 1, found.PhotoDay)
+		assert.NotNil(t, found.EditedAt)
+
+		var f File
+
+		if err := Db().Where("photo_uid = ?", m.PhotoUID).First(&f).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, time.Date(2020, 1, 1, 8, 30, 15, 0, time.UTC), f.PhotoTakenAt.UTC())
+	})
+	t.Run("NoID", func(t *testing.T) {
+		m := Photo{}
+
+		assert.Error(t, m.SaveTime(time.Now(), "UTC"))
+	})
+	t.Run("InvalidTime", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+
+		assert.Error(t, m.SaveTime(time.Time{}, "UTC"))
+	})
+}
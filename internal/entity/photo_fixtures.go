@@ -86,14 +86,16 @@ var PhotoFixtures = PhotoMap{
 			LabelFixtures.PhotoLabel(1000000, "flower", 38, "image"),
 			LabelFixtures.PhotoLabel(1000000, "cake", 38, "manual"),
 		},
-		CreatedAt:  time.Date(2009, 1, 1, 0, 0, 0, 0, time.UTC),
-		UpdatedAt:  time.Date(2008, 1, 1, 0, 0, 0, 0, time.UTC),
-		EditedAt:   nil,
-		CheckedAt:  &checkedTime,
-		DeletedAt:  nil,
-		PhotoColor: 9,
-		PhotoStack: 0,
-		PhotoFaces: 3,
+		CreatedAt:      time.Date(2009, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:      time.Date(2008, 1, 1, 0, 0, 0, 0, time.UTC),
+		EditedAt:       nil,
+		CheckedAt:      &checkedTime,
+		DeletedAt:      nil,
+		PhotoColor:     9,
+		PhotoThumbHash: "WigKNBqAF3h4iHeId4eAcQjoiA",
+		PhotoBlurHash:  "LKO2?U%2Tw=w]~RBVZRi};RPxuwH",
+		PhotoStack:     0,
+		PhotoFaces:     3,
 	},
 	"Photo01": { //DNG + XMP,  Indexed, lat/lng manually set
 		ID:               1000001,
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/jinzhu/gorm"
@@ -87,13 +88,6 @@ func (m *Photo) Merge(mergeMeta, mergeUuid bool) (original Photo, merged Photos,
 		return Photo{}, merged, err
 	}
 
-	logResult := func(res *gorm.DB) {
-		if res.Error != nil {
-			log.Errorf("merge: %s", res.Error.Error())
-			err = res.Error
-		}
-	}
-
 	for i, merge := range identical {
 		if i == 0 {
 			original = merge
@@ -101,27 +95,10 @@ func (m *Photo) Merge(mergeMeta, mergeUuid bool) (original Photo, merged Photos,
 			continue
 		}
 
-		deleted := TimeStamp()
-
-		logResult(UnscopedDb().Exec("UPDATE files SET photo_id = ?, photo_uid = ?, file_primary = 0 WHERE photo_id = ?", original.ID, original.PhotoUID, merge.ID))
-		logResult(UnscopedDb().Exec("UPDATE photos SET photo_quality = -1, deleted_at = ? WHERE id = ?", TimeStamp(), merge.ID))
-
-		switch DbDialect() {
-		case MySQL:
-			logResult(UnscopedDb().Exec("UPDATE IGNORE photos_keywords SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE IGNORE photos_labels SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE IGNORE photos_albums SET photo_uid = ? WHERE photo_uid = ?", original.PhotoUID, merge.PhotoUID))
-		case SQLite3:
-			logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_keywords SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_labels SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_albums SET photo_uid = ? WHERE photo_uid = ?", original.PhotoUID, merge.PhotoUID))
-		default:
-			log.Warnf("sql: unsupported dialect %s", DbDialect())
+		if mergeErr := mergePhoto(original, &merge); mergeErr != nil {
+			err = mergeErr
 		}
 
-		merge.DeletedAt = &deleted
-		merge.PhotoQuality = -1
-
 		merged = append(merged, merge)
 	}
 
@@ -135,3 +112,69 @@ func (m *Photo) Merge(mergeMeta, mergeUuid bool) (original Photo, merged Photos,
 
 	return original, merged, err
 }
+
+// StackLive stacks a video that was indexed as a separate photo with the matching image of a live photo.
+// The video photo is flagged as deleted once its files, keywords, labels, and albums have been moved.
+func (m *Photo) StackLive(video *Photo) error {
+	if video == nil {
+		return fmt.Errorf("video must not be nil")
+	} else if !m.HasID() || !video.HasID() {
+		return fmt.Errorf("photo id must not be empty")
+	} else if m.ID == video.ID {
+		return fmt.Errorf("cannot stack photo %s with itself", m.PhotoUID)
+	}
+
+	photoMergeMutex.Lock()
+	defer photoMergeMutex.Unlock()
+
+	if err := mergePhoto(*m, video); err != nil {
+		return err
+	}
+
+	values := Values{"PhotoType": MediaLive}
+
+	if video.PhotoDuration > 0 {
+		values["PhotoDuration"] = video.PhotoDuration
+	}
+
+	if err := m.Updates(values); err != nil {
+		return err
+	}
+
+	File{PhotoID: m.ID, PhotoUID: m.PhotoUID}.RegenerateIndex()
+
+	return nil
+}
+
+// mergePhoto moves the files, keywords, labels, and albums of a photo to the original and flags it as deleted.
+func mergePhoto(original Photo, merge *Photo) (err error) {
+	logResult := func(res *gorm.DB) {
+		if res.Error != nil {
+			log.Errorf("merge: %s", res.Error.Error())
+			err = res.Error
+		}
+	}
+
+	deleted := TimeStamp()
+
+	logResult(UnscopedDb().Exec("UPDATE files SET photo_id = ?, photo_uid = ?, file_primary = 0 WHERE photo_id = ?", original.ID, original.PhotoUID, merge.ID))
+	logResult(UnscopedDb().Exec("UPDATE photos SET photo_quality = -1, deleted_at = ? WHERE id = ?", deleted, merge.ID))
+
+	switch DbDialect() {
+	case MySQL:
+		logResult(UnscopedDb().Exec("UPDATE IGNORE photos_keywords SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
+		logResult(UnscopedDb().Exec("UPDATE IGNORE photos_labels SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
+		logResult(UnscopedDb().Exec("UPDATE IGNORE photos_albums SET photo_uid = ? WHERE photo_uid = ?", original.PhotoUID, merge.PhotoUID))
+	case SQLite3:
+		logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_keywords SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
+		logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_labels SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
+		logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_albums SET photo_uid = ? WHERE photo_uid = ?", original.PhotoUID, merge.PhotoUID))
+	default:
+		log.Warnf("sql: unsupported dialect %s", DbDialect())
+	}
+
+	merge.DeletedAt = &deleted
+	merge.PhotoQuality = -1
+
+	return err
+}
@@ -95,3 +95,51 @@ func TestPhoto_Merge(t *testing.T) {
 		assert.Equal(t, 1000024, int(merged[0].ID))
 	})
 }
+
+func TestPhoto_StackLive(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		takenAt := time.Date(2022, 7, 3, 12, 30, 0, 0, time.UTC)
+
+		image := &Photo{PhotoName: "IMG_4711", PhotoPath: "live", PhotoType: MediaImage, TakenAt: takenAt, TakenAtLocal: takenAt}
+		video := &Photo{PhotoName: "IMG_4711", PhotoPath: "live", PhotoType: MediaVideo, PhotoDuration: 2 * time.Second, TakenAt: takenAt, TakenAtLocal: takenAt}
+
+		if err := image.Create(); err != nil {
+			t.Fatal(err)
+		} else if err = video.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _, _ = image.DeletePermanently() }()
+		defer func() { _, _ = video.DeletePermanently() }()
+
+		file := &File{PhotoID: video.ID, PhotoUID: video.PhotoUID, FileName: "live/IMG_4711.mov", FileHash: "live4711", FileType: "mov", FileVideo: true, FilePrimary: true}
+
+		if err := file.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := image.StackLive(video); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, MediaLive, image.PhotoType)
+		assert.Equal(t, 2*time.Second, image.PhotoDuration)
+		assert.NotNil(t, video.DeletedAt)
+
+		if f, err := FirstFileByHash("live4711"); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, image.ID, f.PhotoID)
+			assert.Equal(t, image.PhotoUID, f.PhotoUID)
+			assert.False(t, f.FilePrimary)
+		}
+	})
+	t.Run("Nil", func(t *testing.T) {
+		image := PhotoFixtures.Get("Photo01")
+		assert.Error(t, image.StackLive(nil))
+	})
+	t.Run("Self", func(t *testing.T) {
+		image := PhotoFixtures.Get("Photo01")
+		assert.Error(t, image.StackLive(&image))
+	})
+}
@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// PhotoOrders represents a manual sort order of photos.
+type PhotoOrders []PhotoOrder
+
+// PhotoOrder represents the position of a photo in the manual sort order of a user.
+type PhotoOrder struct {
+	UserUID   string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false" json:"UserUID" yaml:"UserUID"`
+	PhotoUID  string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;index" json:"PhotoUID" yaml:"PhotoUID"`
+	Position  int       `json:"Position" yaml:"Position"`
+	UpdatedAt time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity table name.
+func (PhotoOrder) TableName() string {
+	return "photos_orders"
+}
+
+// FindPhotoOrders returns the manual sort order of photos for the specified user.
+func FindPhotoOrders(userUid string) (result PhotoOrders, err error) {
+	if userUid == "" {
+		return result, fmt.Errorf("missing user uid")
+	}
+
+	err = Db().Where("user_uid = ?", userUid).Order("position, photo_uid").Find(&result).Error
+
+	return result, err
+}
+
+// UIDs returns the photo uids in sort order.
+func (m PhotoOrders) UIDs() []string {
+	result := make([]string, len(m))
+
+	for i, el := range m {
+		result[i] = el.PhotoUID
+	}
+
+	return result
+}
+
+// AddPhotoOrders appends photos to the manual sort order of a user, unless they are already included.
+func AddPhotoOrders(userUid string, photoUids []string) (added []string, err error) {
+	current, err := FindPhotoOrders(userUid)
+
+	if err != nil {
+		return added, err
+	}
+
+	position := len(current)
+	found := make(map[string]bool, len(current)+len(photoUids))
+
+	for _, el := range current {
+		found[el.PhotoUID] = true
+
+		if el.Position >= position {
+			position = el.Position + 1
+		}
+	}
+
+	for _, uid := range photoUids {
+		if !rnd.IsUID(uid, PhotoUID) || found[uid] {
+			continue
+		}
+
+		entry := PhotoOrder{UserUID: userUid, PhotoUID: uid, Position: position}
+
+		if err = Db().Create(&entry).Error; err != nil {
+			return added, err
+		}
+
+		found[uid] = true
+		added = append(added, uid)
+		position++
+	}
+
+	return added, nil
+}
+
+// SetPhotoOrders replaces the manual sort order of a user with the specified sequence of photos.
+func SetPhotoOrders(userUid string, photoUids []string) (result PhotoOrders, err error) {
+	if userUid == "" {
+		return result, fmt.Errorf("missing user uid")
+	}
+
+	found := make(map[string]bool, len(photoUids))
+
+	for _, uid := range photoUids {
+		if !rnd.IsUID(uid, PhotoUID) || found[uid] {
+			continue
+		}
+
+		found[uid] = true
+		result = append(result, PhotoOrder{UserUID: userUid, PhotoUID: uid, Position: len(result)})
+	}
+
+	err = Db().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_uid = ?", userUid).Delete(&PhotoOrder{}).Error; err != nil {
+			return err
+		}
+
+		for i := range result {
+			if err := tx.Create(&result[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// RemovePhotoOrders removes photos from the manual sort order of a user.
+func RemovePhotoOrders(userUid string, photoUids []string) (removed int64, err error) {
+	if userUid == "" {
+		return 0, fmt.Errorf("missing user uid")
+	} else if len(photoUids) == 0 {
+		return 0, nil
+	}
+
+	res := Db().Where("user_uid = ? AND photo_uid IN (?)", userUid, photoUids).Delete(&PhotoOrder{})
+
+	return res.RowsAffected, res.Error
+}
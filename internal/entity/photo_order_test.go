@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoOrder_TableName(t *testing.T) {
+	assert.Equal(t, "photos_orders", PhotoOrder{}.TableName())
+}
+
+func TestPhotoOrders(t *testing.T) {
+	userUid := "uqxc08w3d0ej2ord"
+
+	t.Cleanup(func() {
+		UnscopedDb().Where("user_uid = ?", userUid).Delete(&PhotoOrder{})
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		added, err := AddPhotoOrders(userUid, []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8", "pt9jtdre2lvl0yh7", "invalid"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"}, added)
+
+		added, err = AddPhotoOrders(userUid, []string{"pt9jtdre2lvl0yh8", "pt9jtdre2lvl0yh9"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"pt9jtdre2lvl0yh9"}, added)
+
+		result, err := FindPhotoOrders(userUid)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8", "pt9jtdre2lvl0yh9"}, result.UIDs())
+	})
+	t.Run("Set", func(t *testing.T) {
+		result, err := SetPhotoOrders(userUid, []string{"pt9jtdre2lvl0yh9", "pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh9"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"pt9jtdre2lvl0yh9", "pt9jtdre2lvl0yh7"}, result.UIDs())
+
+		found, err := FindPhotoOrders(userUid)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"pt9jtdre2lvl0yh9", "pt9jtdre2lvl0yh7"}, found.UIDs())
+		assert.Equal(t, 0, found[0].Position)
+		assert.Equal(t, 1, found[1].Position)
+	})
+	t.Run("Remove", func(t *testing.T) {
+		removed, err := RemovePhotoOrders(userUid, []string{"pt9jtdre2lvl0yh9", "pt9jtdre2lvl0yh8"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, int64(1), removed)
+
+		found, err := FindPhotoOrders(userUid)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"pt9jtdre2lvl0yh7"}, found.UIDs())
+	})
+	t.Run("MissingUser", func(t *testing.T) {
+		_, err := FindPhotoOrders("")
+		assert.Error(t, err)
+
+		_, err = SetPhotoOrders("", []string{"pt9jtdre2lvl0yh7"})
+		assert.Error(t, err)
+
+		_, err = RemovePhotoOrders("", []string{"pt9jtdre2lvl0yh7"})
+		assert.Error(t, err)
+	})
+}
@@ -67,6 +67,18 @@ func TestSavePhotoForm(t *testing.T) {
 
 		t.Log(m.GetDetails().Keywords)
 	})
+	t.Run("InvalidValues", func(t *testing.T) {
+		m := PhotoFixtures["Photo08"]
+		f := form.Photo{PhotoTitle: "Invalid", PhotoLat: 95.5, TimeZone: "UTC"}
+
+		err := SavePhotoForm(m, f)
+
+		var errs form.ValidationErrors
+
+		if assert.ErrorAs(t, err, &errs) && assert.Len(t, errs, 1) {
+			assert.Equal(t, "Lat", errs[0].Field)
+		}
+	})
 }
 
 func TestPhoto_SaveLabels(t *testing.T) {
@@ -520,6 +532,39 @@ func TestPhoto_Updates(t *testing.T) {
 	})
 }
 
+func TestPhoto_SetPrivate(t *testing.T) {
+	photo := Photo{PhotoTitle: "Private Test"}
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _, _ = photo.DeletePermanently() }()
+
+	changed, err := photo.SetPrivate(true)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, changed)
+	assert.True(t, photo.PhotoPrivate)
+
+	changed, err = photo.SetPrivate(true)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, changed)
+
+	if found := FindPhoto(photo); found == nil {
+		t.Fatal("photo not found")
+	} else {
+		assert.True(t, found.PhotoPrivate)
+	}
+}
+
 func TestPhoto_SetFavorite(t *testing.T) {
 	t.Run("SetTrue", func(t *testing.T) {
 		photo := Photo{PhotoFavorite: true}
@@ -595,6 +640,34 @@ func TestPhoto_Approve(t *testing.T) {
 	})
 }
 
+func TestPhoto_MarkReviewed(t *testing.T) {
+	t.Run("ReviewNeeded", func(t *testing.T) {
+		photo := Photo{PhotoQuality: 3, ReviewNeeded: true}
+
+		if err := photo.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := photo.MarkReviewed(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, photo.ReviewNeeded)
+
+		found := FindPhoto(photo)
+
+		if assert.NotNil(t, found) {
+			assert.False(t, found.ReviewNeeded)
+		}
+	})
+	t.Run("NothingToDo", func(t *testing.T) {
+		photo := Photo{PhotoQuality: 3}
+
+		assert.NoError(t, photo.MarkReviewed())
+		assert.False(t, photo.ReviewNeeded)
+	})
+}
+
 func TestPhoto_Links(t *testing.T) {
 	t.Run("OneResult", func(t *testing.T) {
 		photo := Photo{PhotoUID: "pt9k3pw1wowuy3c3"}
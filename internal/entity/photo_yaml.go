@@ -1,22 +1,70 @@
 package entity
 
 import (
+	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
-	"github.com/photoprism/photoprism/pkg/fs"
 	"gopkg.in/yaml.v2"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 var photoYamlMutex = sync.Mutex{}
 
+// YamlFields specifies which photo fields are serialized as YAML.
+type YamlFields string
+
+// YamlFieldsFull includes all fields, while YamlFieldsMinimal omits technical Exif metadata.
+const (
+	YamlFieldsFull    YamlFields = "full"
+	YamlFieldsMinimal YamlFields = "minimal"
+)
+
+// ParseYamlFields returns the YAML fields matching the string, or the full fields if it is empty.
+func ParseYamlFields(s string) (YamlFields, error) {
+	switch YamlFields(strings.ToLower(strings.TrimSpace(s))) {
+	case "", YamlFieldsFull:
+		return YamlFieldsFull, nil
+	case YamlFieldsMinimal:
+		return YamlFieldsMinimal, nil
+	default:
+		return "", fmt.Errorf("invalid yaml fields %s", clean.Log(s))
+	}
+}
+
 // Yaml returns photo data as YAML string.
 func (m *Photo) Yaml() ([]byte, error) {
+	return m.YamlWith(YamlFieldsFull)
+}
+
+// YamlWith returns photo data as YAML string, including only the specified fields.
+func (m *Photo) YamlWith(fields YamlFields) ([]byte, error) {
 	// Load details if not done yet.
 	m.GetDetails()
 
-	out, err := yaml.Marshal(m)
+	p := m
+
+	// Omit technical Exif metadata.
+	if fields == YamlFieldsMinimal {
+		minimal := *m
+		minimal.PhotoIso = 0
+		minimal.PhotoExposure = ""
+		minimal.PhotoFNumber = 0
+		minimal.PhotoFocalLength = 0
+		minimal.PhotoQuality = 0
+		minimal.PhotoFaces = 0
+		minimal.PhotoDuration = 0
+		minimal.CameraSerial = ""
+		minimal.CellAccuracy = 0
+		p = &minimal
+	}
+
+	out, err := yaml.Marshal(p)
 
 	if err != nil {
 		return []byte{}, err
@@ -27,26 +75,45 @@ func (m *Photo) Yaml() ([]byte, error) {
 
 // SaveAsYaml saves photo data as YAML file.
 func (m *Photo) SaveAsYaml(fileName string) error {
+	_, err := m.saveAsYaml(fileName, false)
+	return err
+}
+
+// UpdateYaml saves photo data as YAML file, unless the existing file already contains the same data.
+// It returns true if the file has been written.
+func (m *Photo) UpdateYaml(fileName string) (written bool, err error) {
+	return m.saveAsYaml(fileName, true)
+}
+
+// saveAsYaml saves photo data as YAML file, optionally only if the file contents would change.
+func (m *Photo) saveAsYaml(fileName string, onlyChanged bool) (written bool, err error) {
 	data, err := m.Yaml()
 
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	// Make sure directory exists.
-	if err := os.MkdirAll(filepath.Dir(fileName), fs.ModeDir); err != nil {
-		return err
+	if err = os.MkdirAll(filepath.Dir(fileName), fs.ModeDir); err != nil {
+		return false, err
 	}
 
 	photoYamlMutex.Lock()
 	defer photoYamlMutex.Unlock()
 
+	// Skip write if the existing file contents are the same.
+	if onlyChanged {
+		if existing, readErr := os.ReadFile(fileName); readErr == nil && bytes.Equal(existing, data) {
+			return false, nil
+		}
+	}
+
 	// Write YAML data to file.
-	if err := os.WriteFile(fileName, data, fs.ModeFile); err != nil {
-		return err
+	if err = os.WriteFile(fileName, data, fs.ModeFile); err != nil {
+		return false, err
 	}
 
-	return nil
+	return true, nil
 }
 
 // LoadFromYaml photo data from a YAML file.
@@ -64,6 +131,96 @@ func (m *Photo) LoadFromYaml(fileName string) error {
 	return nil
 }
 
+// MergeYaml merges values that have been edited in a YAML sidecar file, so that they are not lost when
+// re-indexing. If the sidecar wins, edited values cannot be overwritten by file metadata afterwards.
+// Sidecar files that have not been modified since the photo was last edited are skipped.
+func (m *Photo) MergeYaml(fileName string, sidecarWins bool) (merged int, err error) {
+	info, err := os.Stat(fileName)
+
+	if err != nil {
+		return 0, err
+	}
+
+	// Stale sidecar files must not overwrite newer changes.
+	lastEdit := m.UpdatedAt
+
+	if m.EditedAt != nil {
+		lastEdit = *m.EditedAt
+	}
+
+	if !lastEdit.IsZero() && !info.ModTime().After(lastEdit) {
+		return 0, nil
+	}
+
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return 0, err
+	}
+
+	sidecar := Photo{}
+
+	if err = yaml.Unmarshal(data, &sidecar); err != nil {
+		return 0, err
+	} else if sidecar.PhotoUID != "" && sidecar.PhotoUID != m.PhotoUID {
+		return 0, fmt.Errorf("uid %s does not match", clean.Log(sidecar.PhotoUID))
+	}
+
+	// Returns the source of an edited sidecar value depending on its precedence.
+	src := func(sidecarSrc string) string {
+		if !sidecarWins {
+			return SrcYaml
+		} else if SrcPriority[sidecarSrc] > SrcPriority[SrcManual] {
+			return sidecarSrc
+		}
+
+		return SrcManual
+	}
+
+	// Set a string value if it has been edited in the sidecar file.
+	edited := func(current *string, currentSrc *string, value, valueSrc string) {
+		if value == "" || value == *current {
+			return
+		}
+
+		*current = value
+		*currentSrc = src(valueSrc)
+		merged++
+	}
+
+	edited(&m.PhotoTitle, &m.TitleSrc, sidecar.PhotoTitle, sidecar.TitleSrc)
+	edited(&m.PhotoDescription, &m.DescriptionSrc, sidecar.PhotoDescription, sidecar.DescriptionSrc)
+
+	if !sidecar.TakenAt.IsZero() && !sidecar.TakenAt.Equal(m.TakenAt) {
+		m.TakenAt = sidecar.TakenAt
+		m.TakenAtLocal = sidecar.TakenAtLocal
+		m.TimeZone = sidecar.TimeZone
+		m.TakenSrc = src(sidecar.TakenSrc)
+		merged++
+	}
+
+	if sidecar.HasLatLng() && (sidecar.PhotoLat != m.PhotoLat || sidecar.PhotoLng != m.PhotoLng) {
+		m.PhotoLat = sidecar.PhotoLat
+		m.PhotoLng = sidecar.PhotoLng
+		m.PhotoAltitude = sidecar.PhotoAltitude
+		m.PlaceSrc = src(sidecar.PlaceSrc)
+		merged++
+	}
+
+	if sidecar.Details != nil {
+		details := m.GetDetails()
+
+		edited(&details.Keywords, &details.KeywordsSrc, sidecar.Details.Keywords, sidecar.Details.KeywordsSrc)
+		edited(&details.Notes, &details.NotesSrc, sidecar.Details.Notes, sidecar.Details.NotesSrc)
+		edited(&details.Subject, &details.SubjectSrc, sidecar.Details.Subject, sidecar.Details.SubjectSrc)
+		edited(&details.Artist, &details.ArtistSrc, sidecar.Details.Artist, sidecar.Details.ArtistSrc)
+		edited(&details.Copyright, &details.CopyrightSrc, sidecar.Details.Copyright, sidecar.Details.CopyrightSrc)
+		edited(&details.License, &details.LicenseSrc, sidecar.Details.License, sidecar.Details.LicenseSrc)
+	}
+
+	return merged, nil
+}
+
 // YamlFileName returns the YAML file name.
 func (m *Photo) YamlFileName(originalsPath, sidecarPath string) string {
 	return fs.FileName(filepath.Join(originalsPath, m.PhotoPath, m.PhotoName), sidecarPath, originalsPath, fs.ExtYAML)
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -22,6 +23,63 @@ func TestPhoto_Yaml(t *testing.T) {
 	})
 }
 
+func TestPhoto_YamlWith(t *testing.T) {
+	m := PhotoFixtures.Get("Photo01")
+	m.PhotoIso = 200
+	m.PhotoExposure = "1/60"
+	m.CameraSerial = "123456"
+
+	t.Run("Full", func(t *testing.T) {
+		result, err := m.YamlWith(YamlFieldsFull)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		full, err := m.Yaml()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, string(full), string(result))
+		assert.Contains(t, string(result), "ISO: 200")
+		assert.Contains(t, string(result), "Exposure: 1/60")
+		assert.Contains(t, string(result), "CameraSerial: \"123456\"")
+	})
+	t.Run("Minimal", func(t *testing.T) {
+		result, err := m.YamlWith(YamlFieldsMinimal)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(result), "UID: "+m.PhotoUID)
+		assert.Contains(t, string(result), "Title: ")
+		assert.NotContains(t, string(result), "ISO:")
+		assert.NotContains(t, string(result), "Exposure:")
+		assert.NotContains(t, string(result), "CameraSerial:")
+		assert.Equal(t, 200, m.PhotoIso)
+	})
+}
+
+func TestParseYamlFields(t *testing.T) {
+	fields, err := ParseYamlFields("")
+	assert.NoError(t, err)
+	assert.Equal(t, YamlFieldsFull, fields)
+
+	fields, err = ParseYamlFields("Minimal")
+	assert.NoError(t, err)
+	assert.Equal(t, YamlFieldsMinimal, fields)
+
+	fields, err = ParseYamlFields("full")
+	assert.NoError(t, err)
+	assert.Equal(t, YamlFieldsFull, fields)
+
+	_, err = ParseYamlFields("all")
+	assert.Error(t, err)
+}
+
 func TestPhoto_SaveAsYaml(t *testing.T) {
 	t.Run("create from fixture", func(t *testing.T) {
 		m := PhotoFixtures.Get("Photo01")
@@ -43,6 +101,44 @@ func TestPhoto_SaveAsYaml(t *testing.T) {
 	})
 }
 
+func TestPhoto_UpdateYaml(t *testing.T) {
+	m := PhotoFixtures.Get("Photo01")
+	m.PreloadFiles()
+
+	fileName := filepath.Join(t.TempDir(), "photo.yml")
+
+	t.Run("NotExists", func(t *testing.T) {
+		written, err := m.UpdateYaml(fileName)
+
+		assert.NoError(t, err)
+		assert.True(t, written)
+		assert.FileExists(t, fileName)
+	})
+	t.Run("Unchanged", func(t *testing.T) {
+		written, err := m.UpdateYaml(fileName)
+
+		assert.NoError(t, err)
+		assert.False(t, written)
+	})
+	t.Run("Changed", func(t *testing.T) {
+		changed := m
+		changed.PhotoTitle = "Changed Title"
+
+		written, err := changed.UpdateYaml(fileName)
+
+		assert.NoError(t, err)
+		assert.True(t, written)
+
+		data, err := os.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(data), "Title: Changed Title")
+	})
+}
+
 func TestPhoto_YamlFileName(t *testing.T) {
 	t.Run("create from fixture", func(t *testing.T) {
 		m := PhotoFixtures.Get("Photo01")
@@ -54,3 +150,154 @@ func TestPhoto_YamlFileName(t *testing.T) {
 		}
 	})
 }
+
+func TestPhoto_MergeYaml(t *testing.T) {
+	// newPhoto returns an indexed photo with a title from file metadata.
+	newPhoto := func() *Photo {
+		return &Photo{
+			PhotoUID:   "pr2xu7myk7wrbk2y",
+			PhotoTitle: "File Title",
+			TitleSrc:   SrcMeta,
+			Details:    &Details{Keywords: "beach", KeywordsSrc: SrcMeta},
+		}
+	}
+
+	// saveSidecar saves a sidecar file with an edited title.
+	saveSidecar := func(t *testing.T, title string) string {
+		sidecar := newPhoto()
+		sidecar.PhotoTitle = title
+		sidecar.Details.Keywords = "beach, sunset"
+
+		fileName := filepath.Join(t.TempDir(), "photo.yml")
+
+		if err := sidecar.SaveAsYaml(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		return fileName
+	}
+
+	t.Run("SidecarWins", func(t *testing.T) {
+		m := newPhoto()
+
+		merged, err := m.MergeYaml(saveSidecar(t, "Sidecar Title"), true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 2, merged)
+		assert.Equal(t, SrcManual, m.TitleSrc)
+
+		// Apply fresh file metadata as when re-indexing.
+		m.SetTitle("File Title", SrcMeta)
+		m.Details.SetKeywords("beach", SrcMeta)
+
+		assert.Equal(t, "Sidecar Title", m.PhotoTitle)
+		assert.Equal(t, "beach, sunset", m.Details.Keywords)
+	})
+	t.Run("FileWins", func(t *testing.T) {
+		m := newPhoto()
+
+		merged, err := m.MergeYaml(saveSidecar(t, "Sidecar Title"), false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 2, merged)
+		assert.Equal(t, SrcYaml, m.TitleSrc)
+
+		// Apply fresh file metadata as when re-indexing.
+		m.SetTitle("File Title", SrcMeta)
+
+		assert.Equal(t, "File Title", m.PhotoTitle)
+		assert.Equal(t, SrcMeta, m.TitleSrc)
+	})
+	t.Run("FileWinsNoMetadata", func(t *testing.T) {
+		m := newPhoto()
+
+		if _, err := m.MergeYaml(saveSidecar(t, "Sidecar Title"), false); err != nil {
+			t.Fatal(err)
+		}
+
+		// Values remain if the file has no metadata.
+		m.SetTitle("", SrcMeta)
+		assert.Equal(t, "Sidecar Title", m.PhotoTitle)
+	})
+	t.Run("Unchanged", func(t *testing.T) {
+		m := newPhoto()
+		m.Details.Keywords = "beach, sunset"
+
+		merged, err := m.MergeYaml(saveSidecar(t, "File Title"), true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, merged)
+		assert.Equal(t, SrcMeta, m.TitleSrc)
+	})
+	t.Run("Stale", func(t *testing.T) {
+		m := newPhoto()
+		fileName := saveSidecar(t, "Sidecar Title")
+
+		// Edited after the sidecar file was saved.
+		editedAt := time.Now().Add(time.Hour)
+		m.EditedAt = &editedAt
+
+		merged, err := m.MergeYaml(fileName, true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, merged)
+		assert.Equal(t, "File Title", m.PhotoTitle)
+		assert.Equal(t, SrcMeta, m.TitleSrc)
+	})
+	t.Run("Updated", func(t *testing.T) {
+		m := newPhoto()
+		fileName := saveSidecar(t, "Sidecar Title")
+
+		// Last updated before the sidecar file was saved.
+		m.UpdatedAt = time.Now().Add(-1 * time.Hour)
+
+		merged, err := m.MergeYaml(fileName, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 2, merged)
+		assert.Equal(t, "Sidecar Title", m.PhotoTitle)
+
+		// Not merged again if the photo has been updated since.
+		m = newPhoto()
+		m.UpdatedAt = time.Now().Add(time.Hour)
+
+		merged, err = m.MergeYaml(fileName, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, merged)
+	})
+	t.Run("OtherPhoto", func(t *testing.T) {
+		m := newPhoto()
+		m.PhotoUID = "pr2xu7myk7wrbk3y"
+
+		_, err := m.MergeYaml(saveSidecar(t, "Sidecar Title"), true)
+
+		assert.Error(t, err)
+		assert.Equal(t, "File Title", m.PhotoTitle)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		m := newPhoto()
+
+		_, err := m.MergeYaml("testdata/missing.yml", true)
+
+		assert.Error(t, err)
+	})
+}
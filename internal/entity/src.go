@@ -15,6 +15,7 @@ const (
 	SrcLocation = classify.SrcLocation // Prio 8
 	SrcMarker   = "marker"             // Prio 8
 	SrcImage    = classify.SrcImage    // Prio 8
+	SrcRule     = classify.SrcRule     // Prio 8
 	SrcKeyword  = classify.SrcKeyword  // Prio 16
 	SrcMeta     = "meta"               // Prio 16
 	SrcXmp      = "xmp"                // Prio 32
@@ -42,6 +43,7 @@ var SrcPriority = Priorities{
 	SrcLocation: 8,
 	SrcMarker:   8,
 	SrcImage:    8,
+	SrcRule:     8,
 	SrcKeyword:  16,
 	SrcMeta:     16,
 	SrcXmp:      32,
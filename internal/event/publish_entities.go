@@ -14,16 +14,24 @@ const (
 	EntityRestored = "restored"
 )
 
-// PublishEntities publishes updated entity data.
-func PublishEntities(channel, ev string, entities interface{}) {
+// PublishEntities publishes updated entity data. If field names are passed, they are included in the
+// message, so that subscribers can update only the values that have changed.
+func PublishEntities(channel, ev string, entities interface{}, fields ...string) {
 	if channel == "" || ev == "" || entities == nil {
 		return
 	}
+
+	data := Data{
+		"entities": entities,
+	}
+
+	if len(fields) > 0 {
+		data["fields"] = fields
+	}
+
 	SharedHub().Publish(Message{
-		Name: strings.Join([]string{channel, ev}, "."),
-		Fields: Data{
-			"entities": entities,
-		},
+		Name:   strings.Join([]string{channel, ev}, "."),
+		Fields: data,
 	})
 }
 
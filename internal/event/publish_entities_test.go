@@ -81,3 +81,27 @@ func TestEntitiesRestored(t *testing.T) {
 
 	Unsubscribe(s)
 }
+
+func TestPublishEntities(t *testing.T) {
+	t.Run("Fields", func(t *testing.T) {
+		s := Subscribe("test.updated")
+
+		PublishEntities("test", EntityUpdated, "test", "Favorite")
+		msg := <-s.Receiver
+
+		assert.Equal(t, "test.updated", msg.Name)
+		assert.Equal(t, Data{"entities": "test", "fields": []string{"Favorite"}}, msg.Fields)
+
+		Unsubscribe(s)
+	})
+	t.Run("NoFields", func(t *testing.T) {
+		s := Subscribe("test.updated")
+
+		PublishEntities("test", EntityUpdated, "test")
+		msg := <-s.Receiver
+
+		assert.Equal(t, Data{"entities": "test"}, msg.Fields)
+
+		Unsubscribe(s)
+	})
+}
@@ -0,0 +1,27 @@
+package ffmpeg
+
+import (
+	"time"
+)
+
+// ClipArgs returns the command arguments for extracting the segment between the start and end offsets from a video.
+// If streamCopy is true, the streams are copied without re-encoding, so the cut snaps to the nearest keyframes.
+// Otherwise, the clip is transcoded to MPEG-4 AVC with the software encoder.
+func ClipArgs(videoName, clipName string, start, end time.Duration, streamCopy bool) (args []string) {
+	args = []string{"-y"}
+
+	// Seeking before the input file is specified is fast and accurate when transcoding.
+	if start > 0 {
+		args = append(args, "-ss", TimeOffset(start))
+	}
+
+	args = append(args, "-i", videoName, "-t", TimeOffset(end-start), "-map", "0:v:0", "-map", "0:a?")
+
+	if streamCopy {
+		args = append(args, "-c", "copy", "-avoid_negative_ts", "make_zero")
+	} else {
+		args = append(args, "-c:v", SoftwareEncoder.String(), "-pix_fmt", "yuv420p", "-c:a", "aac")
+	}
+
+	return append(args, "-movflags", "faststart", "-f", "mp4", clipName)
+}
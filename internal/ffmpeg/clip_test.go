@@ -0,0 +1,20 @@
+package ffmpeg
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClipArgs(t *testing.T) {
+	t.Run("StreamCopy", func(t *testing.T) {
+		args := ClipArgs("video.mp4", "clip.mp4", 1500*time.Millisecond, 4*time.Second, true)
+		assert.Equal(t, "-y -ss 00:00:01.500 -i video.mp4 -t 00:00:02.500 -map 0:v:0 -map 0:a? -c copy -avoid_negative_ts make_zero -movflags faststart -f mp4 clip.mp4", strings.Join(args, " "))
+	})
+	t.Run("Transcode", func(t *testing.T) {
+		args := ClipArgs("video.avi", "clip.mp4", 0, time.Minute+30*time.Second, false)
+		assert.Equal(t, "-y -i video.avi -t 00:01:30.000 -map 0:v:0 -map 0:a? -c:v libx264 -pix_fmt yuv420p -c:a aac -movflags faststart -f mp4 clip.mp4", strings.Join(args, " "))
+	})
+}
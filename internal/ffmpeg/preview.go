@@ -1,6 +1,9 @@
 package ffmpeg
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // PreviewTimeOffset returns an appropriate time offset depending on the duration for extracting a preview image.
 func PreviewTimeOffset(d time.Duration) string {
@@ -24,3 +27,32 @@ func PreviewTimeOffset(d time.Duration) string {
 
 	return result
 }
+
+// TimeOffset returns the time offset as string in the format expected by ffmpeg, e.g. "00:01:30.500".
+func TimeOffset(d time.Duration) string {
+	if d <= 0 {
+		return "00:00:00.001"
+	}
+
+	ms := d.Milliseconds()
+
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
+
+// PosterOffsets returns the time offsets at the specified percentages of the video duration.
+func PosterOffsets(d time.Duration, percent ...int) []time.Duration {
+	result := make([]time.Duration, len(percent))
+
+	for i, p := range percent {
+		switch {
+		case d <= 0 || p <= 0:
+			result[i] = 0
+		case p >= 100:
+			result[i] = d
+		default:
+			result[i] = d * time.Duration(p) / 100
+		}
+	}
+
+	return result
+}
@@ -16,3 +16,23 @@ func TestPreviewTimeOffset(t *testing.T) {
 	assert.Equal(t, "00:01:00.000", PreviewTimeOffset(time.Hour))
 	assert.Equal(t, "00:02:30.000", PreviewTimeOffset(3*time.Hour))
 }
+
+func TestTimeOffset(t *testing.T) {
+	assert.Equal(t, "00:00:00.001", TimeOffset(0))
+	assert.Equal(t, "00:00:00.250", TimeOffset(250*time.Millisecond))
+	assert.Equal(t, "00:00:09.000", TimeOffset(9*time.Second))
+	assert.Equal(t, "00:01:30.500", TimeOffset(90*time.Second+500*time.Millisecond))
+	assert.Equal(t, "02:05:00.000", TimeOffset(2*time.Hour+5*time.Minute))
+}
+
+func TestPosterOffsets(t *testing.T) {
+	t.Run("TenSeconds", func(t *testing.T) {
+		assert.Equal(t, []time.Duration{time.Second, 5 * time.Second, 9 * time.Second}, PosterOffsets(10*time.Second, 10, 50, 90))
+	})
+	t.Run("Bounds", func(t *testing.T) {
+		assert.Equal(t, []time.Duration{0, time.Minute}, PosterOffsets(time.Minute, -5, 120))
+	})
+	t.Run("NoDuration", func(t *testing.T) {
+		assert.Equal(t, []time.Duration{0, 0, 0}, PosterOffsets(0, 10, 50, 90))
+	})
+}
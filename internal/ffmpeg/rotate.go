@@ -0,0 +1,65 @@
+package ffmpeg
+
+import (
+	"strings"
+	"time"
+)
+
+// Rotation modes for video preview images.
+const (
+	RotateAuto     = "auto"     // FFmpeg applies the display matrix of the video stream.
+	RotateMetadata = "metadata" // The rotation found in the indexed metadata is applied.
+	RotateNone     = "none"     // Frames are extracted as stored.
+)
+
+// RotateMode returns a supported rotation mode, or RotateAuto if unknown.
+func RotateMode(s string) string {
+	switch s = strings.ToLower(strings.TrimSpace(s)); s {
+	case RotateMetadata, RotateNone:
+		return s
+	default:
+		return RotateAuto
+	}
+}
+
+// RotateFilter returns the video filter for rotating frames clockwise by the specified number of degrees.
+func RotateFilter(rotation int) string {
+	switch (rotation%360 + 360) % 360 {
+	case 90:
+		return "transpose=clock"
+	case 180:
+		return "hflip,vflip"
+	case 270:
+		return "transpose=cclock"
+	default:
+		return ""
+	}
+}
+
+// PreviewImageArgs returns the command arguments for extracting an upright preview image from a video.
+func PreviewImageArgs(videoName, imageName string, d time.Duration, mode string, rotation int) (args []string) {
+	return imageArgs(videoName, imageName, PreviewTimeOffset(d), mode, rotation)
+}
+
+// FrameImageArgs returns the command arguments for extracting an upright still image at the specified time offset.
+func FrameImageArgs(videoName, imageName string, offset time.Duration, mode string, rotation int) (args []string) {
+	return imageArgs(videoName, imageName, TimeOffset(offset), mode, rotation)
+}
+
+// imageArgs returns the command arguments for extracting a still image at the time offset string.
+func imageArgs(videoName, imageName, offset string, mode string, rotation int) (args []string) {
+	args = []string{"-y"}
+
+	// Automatic rotation must be disabled before the input file is specified.
+	if mode = RotateMode(mode); mode != RotateAuto {
+		args = append(args, "-noautorotate")
+	}
+
+	args = append(args, "-i", videoName, "-ss", offset)
+
+	if filter := RotateFilter(rotation); mode == RotateMetadata && filter != "" {
+		args = append(args, "-vf", filter)
+	}
+
+	return append(args, "-vframes", "1", imageName)
+}
@@ -0,0 +1,55 @@
+package ffmpeg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotateMode(t *testing.T) {
+	assert.Equal(t, RotateAuto, RotateMode(""))
+	assert.Equal(t, RotateAuto, RotateMode("foo"))
+	assert.Equal(t, RotateMetadata, RotateMode(" Metadata"))
+	assert.Equal(t, RotateNone, RotateMode("none"))
+}
+
+func TestRotateFilter(t *testing.T) {
+	assert.Equal(t, "", RotateFilter(0))
+	assert.Equal(t, "transpose=clock", RotateFilter(90))
+	assert.Equal(t, "hflip,vflip", RotateFilter(180))
+	assert.Equal(t, "hflip,vflip", RotateFilter(-180))
+	assert.Equal(t, "transpose=cclock", RotateFilter(270))
+	assert.Equal(t, "transpose=cclock", RotateFilter(-90))
+	assert.Equal(t, "", RotateFilter(45))
+}
+
+func TestPreviewImageArgs(t *testing.T) {
+	t.Run("Auto", func(t *testing.T) {
+		args := PreviewImageArgs("rotated.mp4", "rotated.jpg", time.Second, RotateAuto, 90)
+		assert.Equal(t, []string{"-y", "-i", "rotated.mp4", "-ss", "00:00:00.001", "-vframes", "1", "rotated.jpg"}, args)
+	})
+	t.Run("Metadata", func(t *testing.T) {
+		args := PreviewImageArgs("rotated.mp4", "rotated.jpg", time.Minute, RotateMetadata, 90)
+		assert.Equal(t, []string{"-y", "-noautorotate", "-i", "rotated.mp4", "-ss", "00:00:03.000", "-vf", "transpose=clock", "-vframes", "1", "rotated.jpg"}, args)
+	})
+	t.Run("MetadataUpright", func(t *testing.T) {
+		args := PreviewImageArgs("upright.mp4", "upright.jpg", time.Second, RotateMetadata, 0)
+		assert.Equal(t, []string{"-y", "-noautorotate", "-i", "upright.mp4", "-ss", "00:00:00.001", "-vframes", "1", "upright.jpg"}, args)
+	})
+	t.Run("None", func(t *testing.T) {
+		args := PreviewImageArgs("rotated.mp4", "rotated.jpg", time.Second, RotateNone, 270)
+		assert.Equal(t, []string{"-y", "-noautorotate", "-i", "rotated.mp4", "-ss", "00:00:00.001", "-vframes", "1", "rotated.jpg"}, args)
+	})
+}
+
+func TestFrameImageArgs(t *testing.T) {
+	t.Run("Auto", func(t *testing.T) {
+		args := FrameImageArgs("video.mp4", "poster.jpg", 1500*time.Millisecond, RotateAuto, 90)
+		assert.Equal(t, []string{"-y", "-i", "video.mp4", "-ss", "00:00:01.500", "-vframes", "1", "poster.jpg"}, args)
+	})
+	t.Run("Metadata", func(t *testing.T) {
+		args := FrameImageArgs("video.mp4", "poster.jpg", 90*time.Second, RotateMetadata, 90)
+		assert.Equal(t, []string{"-y", "-noautorotate", "-i", "video.mp4", "-ss", "00:01:30.000", "-vf", "transpose=clock", "-vframes", "1", "poster.jpg"}, args)
+	})
+}
@@ -0,0 +1,41 @@
+package ffmpeg
+
+// StreamArgs returns the command arguments for transcoding a video to MPEG-4 AVC with AAC audio and writing
+// it to stdout using the configured encoder and bitrate. The output is fragmented, so that it can be sent
+// to the client while it is being encoded.
+func StreamArgs(videoName string, opt Options) (args []string) {
+	args = []string{"-hide_banner", "-loglevel", "error"}
+
+	// Hardware encoders may require the input to be decoded or uploaded accordingly.
+	switch opt.Encoder {
+	case IntelEncoder:
+		args = append(args, "-qsv_device", "/dev/dri/renderD128")
+	case VAAPIEncoder:
+		args = append(args, "-hwaccel", "vaapi")
+	case NvidiaEncoder:
+		args = append(args, "-hwaccel", "auto")
+	}
+
+	args = append(args, "-i", videoName, "-map", "0:v:0", "-map", "0:a?")
+
+	switch opt.Encoder {
+	case "", SoftwareEncoder:
+		args = append(args, "-c:v", SoftwareEncoder.String(), "-preset", "veryfast", "-pix_fmt", "yuv420p")
+	case IntelEncoder:
+		args = append(args, "-c:v", opt.Encoder.String(), "-vf", "format=rgb32")
+	case VAAPIEncoder:
+		args = append(args, "-c:v", opt.Encoder.String(), "-vf", "format=nv12,hwupload")
+	default:
+		args = append(args, "-c:v", opt.Encoder.String(), "-vf", "format=yuv420p")
+	}
+
+	if opt.Bitrate != "" {
+		args = append(args, "-b:v", opt.Bitrate)
+	}
+
+	return append(args,
+		"-c:a", "aac",
+		"-movflags", "frag_keyframe+empty_moov+default_base_moof",
+		"-f", "mp4", "pipe:1",
+	)
+}
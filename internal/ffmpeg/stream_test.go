@@ -0,0 +1,27 @@
+package ffmpeg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamArgs(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		args := StreamArgs("video.mov", Options{})
+		assert.Equal(t, "-hide_banner -loglevel error -i video.mov -map 0:v:0 -map 0:a? -c:v libx264 -preset veryfast -pix_fmt yuv420p -c:a aac -movflags frag_keyframe+empty_moov+default_base_moof -f mp4 pipe:1", strings.Join(args, " "))
+	})
+	t.Run("Software", func(t *testing.T) {
+		args := StreamArgs("video.mov", Options{Encoder: SoftwareEncoder, Bitrate: "8M"})
+		assert.Equal(t, "-hide_banner -loglevel error -i video.mov -map 0:v:0 -map 0:a? -c:v libx264 -preset veryfast -pix_fmt yuv420p -b:v 8M -c:a aac -movflags frag_keyframe+empty_moov+default_base_moof -f mp4 pipe:1", strings.Join(args, " "))
+	})
+	t.Run("VAAPI", func(t *testing.T) {
+		args := StreamArgs("video.mov", Options{Encoder: VAAPIEncoder, Bitrate: "4M"})
+		assert.Equal(t, "-hide_banner -loglevel error -hwaccel vaapi -i video.mov -map 0:v:0 -map 0:a? -c:v h264_vaapi -vf format=nv12,hwupload -b:v 4M -c:a aac -movflags frag_keyframe+empty_moov+default_base_moof -f mp4 pipe:1", strings.Join(args, " "))
+	})
+	t.Run("Nvidia", func(t *testing.T) {
+		args := StreamArgs("video.mov", Options{Encoder: NvidiaEncoder, Bitrate: "4M"})
+		assert.Equal(t, "-hide_banner -loglevel error -hwaccel auto -i video.mov -map 0:v:0 -map 0:a? -c:v h264_nvenc -vf format=yuv420p -b:v 4M -c:a aac -movflags frag_keyframe+empty_moov+default_base_moof -f mp4 pipe:1", strings.Join(args, " "))
+	})
+}
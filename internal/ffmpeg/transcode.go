@@ -0,0 +1,20 @@
+package ffmpeg
+
+import "strings"
+
+// Transcoding modes for streaming videos to browsers.
+const (
+	TranscodeAuto         = "auto"         // Videos are transcoded if the format requested by the client does not match.
+	TranscodeIncompatible = "incompatible" // Videos with codecs browsers cannot play, e.g. HEVC or ProRes, are transcoded to AVC.
+	TranscodeNone         = "none"         // Original videos are always streamed directly.
+)
+
+// TranscodeMode returns a supported transcoding mode, or TranscodeAuto if unknown.
+func TranscodeMode(s string) string {
+	switch s = strings.ToLower(strings.TrimSpace(s)); s {
+	case TranscodeIncompatible, TranscodeNone:
+		return s
+	default:
+		return TranscodeAuto
+	}
+}
@@ -0,0 +1,14 @@
+package ffmpeg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranscodeMode(t *testing.T) {
+	assert.Equal(t, TranscodeAuto, TranscodeMode(""))
+	assert.Equal(t, TranscodeAuto, TranscodeMode("foo"))
+	assert.Equal(t, TranscodeIncompatible, TranscodeMode(" Incompatible"))
+	assert.Equal(t, TranscodeNone, TranscodeMode("none"))
+}
@@ -0,0 +1,36 @@
+package form
+
+import "time"
+
+// BestOfCount and BestOfMaxCount limit the number of pictures in an automatic best-of selection.
+const (
+	BestOfCount    = 25
+	BestOfMaxCount = 1000
+)
+
+// BestOf represents a request to automatically select the best pictures taken within a date range.
+type BestOf struct {
+	From        string             `json:"from"`
+	To          string             `json:"to"`
+	Album       string             `json:"album"`
+	Count       int                `json:"count"`
+	Weights     map[string]float64 `json:"weights"`
+	CreateAlbum bool               `json:"createAlbum"`
+	Title       string             `json:"title"`
+}
+
+// Range returns the parsed date range, see RebuildSidecars.Range.
+func (f BestOf) Range() (from, to time.Time, err error) {
+	return RebuildSidecars{From: f.From, To: f.To}.Range()
+}
+
+// Limit returns the number of pictures to select.
+func (f BestOf) Limit() int {
+	if f.Count <= 0 {
+		return BestOfCount
+	} else if f.Count > BestOfMaxCount {
+		return BestOfMaxCount
+	}
+
+	return f.Count
+}
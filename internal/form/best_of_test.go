@@ -0,0 +1,29 @@
+package form
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBestOf_Range(t *testing.T) {
+	t.Run("Dates", func(t *testing.T) {
+		from, to, err := BestOf{From: "2020-01-01", To: "2020-12-31"}.Range()
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), from)
+		assert.Equal(t, time.Date(2020, 12, 31, 23, 59, 59, 0, time.UTC), to)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, _, err := BestOf{From: "2020-12-31", To: "2020-01-01"}.Range()
+
+		assert.Error(t, err)
+	})
+}
+
+func TestBestOf_Limit(t *testing.T) {
+	assert.Equal(t, BestOfCount, BestOf{}.Limit())
+	assert.Equal(t, 10, BestOf{Count: 10}.Limit())
+	assert.Equal(t, BestOfMaxCount, BestOf{Count: 5000}.Limit())
+}
@@ -0,0 +1,46 @@
+package form
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExportCsvMaxPhotos limits the number of pictures that can be exported at once.
+const ExportCsvMaxPhotos = 10000
+
+// ExportCsvFields lists the supported metadata fields in their default order.
+var ExportCsvFields = []string{"uid", "title", "takenAt", "camera", "lat", "lng", "keywords", "size"}
+
+// ExportCsv represents a request to export the metadata of selected pictures as CSV.
+type ExportCsv struct {
+	Photos []string `json:"photos"`
+	Fields []string `json:"fields"`
+}
+
+// Columns returns the validated field names, or all fields if none were specified.
+func (f ExportCsv) Columns() (cols []string, err error) {
+	if len(f.Fields) == 0 {
+		return ExportCsvFields, nil
+	}
+
+	cols = make([]string, 0, len(f.Fields))
+
+	for _, s := range f.Fields {
+		name := ""
+
+		for _, field := range ExportCsvFields {
+			if strings.EqualFold(strings.TrimSpace(s), field) {
+				name = field
+				break
+			}
+		}
+
+		if name == "" {
+			return nil, fmt.Errorf("unknown field %q", s)
+		}
+
+		cols = append(cols, name)
+	}
+
+	return cols, nil
+}
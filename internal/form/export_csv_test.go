@@ -0,0 +1,28 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportCsv_Columns(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cols, err := ExportCsv{Photos: []string{"pt9jtdre2lvl0yh7"}}.Columns()
+
+		assert.NoError(t, err)
+		assert.Equal(t, ExportCsvFields, cols)
+	})
+	t.Run("Selected", func(t *testing.T) {
+		cols, err := ExportCsv{Fields: []string{"UID", " takenat", "keywords"}}.Columns()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"uid", "takenAt", "keywords"}, cols)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		cols, err := ExportCsv{Fields: []string{"uid", "password"}}.Columns()
+
+		assert.Error(t, err)
+		assert.Nil(t, cols)
+	})
+}
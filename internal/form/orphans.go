@@ -0,0 +1,9 @@
+package form
+
+// OrphanFiles represents a cleanup action for files that are not linked to a photo.
+type OrphanFiles struct {
+	Action string   `json:"action"`
+	Files  []string `json:"files"`
+	Photo  string   `json:"photo"`
+	All    bool     `json:"all"`
+}
@@ -1,6 +1,8 @@
 package form
 
 import (
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/ulule/deepcopier"
@@ -68,3 +70,26 @@ func NewPhoto(m interface{}) (f Photo, err error) {
 
 	return f, err
 }
+
+// Diff returns the JSON names of the fields that have a different value in the other form.
+func (f Photo) Diff(other Photo) (fields []string) {
+	a := reflect.ValueOf(f)
+	b := reflect.ValueOf(other)
+	t := a.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		va, vb := a.Field(i).Interface(), b.Field(i).Interface()
+
+		if ta, ok := va.(time.Time); ok {
+			if ta.Equal(vb.(time.Time)) {
+				continue
+			}
+		} else if reflect.DeepEqual(va, vb) {
+			continue
+		}
+
+		fields = append(fields, strings.Split(t.Field(i).Tag.Get("json"), ",")[0])
+	}
+
+	return fields
+}
@@ -0,0 +1,139 @@
+package form
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// srcManual is the source of values changed with a partial update.
+const srcManual = "manual"
+
+// Fields that have a source, which is set to manual if their value is updated without also specifying it.
+var (
+	photoPatchSrc = map[string][]string{
+		"TitleSrc":       {"Title"},
+		"DescriptionSrc": {"Description"},
+		"TakenSrc":       {"TakenAt", "TakenAtLocal", "TimeZone", "Year", "Month", "Day"},
+		"PlaceSrc":       {"Lat", "Lng", "Altitude", "Country", "CellID", "PlaceID"},
+		"CameraSrc":      {"CameraID"},
+		"TypeSrc":        {"Type"},
+	}
+	detailsPatchSrc = map[string][]string{
+		"KeywordsSrc":  {"Keywords"},
+		"NotesSrc":     {"Notes"},
+		"SubjectSrc":   {"Subject"},
+		"ArtistSrc":    {"Artist"},
+		"CopyrightSrc": {"Copyright"},
+		"LicenseSrc":   {"License"},
+	}
+)
+
+// Patch updates the form with the fields present in a JSON request body, so that all other fields
+// remain unchanged. Updated values whose source is not specified are marked as manually set.
+func (f *Photo) Patch(data []byte) error {
+	var fields map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+
+	if err := d.Decode(f); err != nil {
+		return err
+	}
+
+	for srcKey, keys := range photoPatchSrc {
+		if patched(fields, srcKey, keys) {
+			f.setSrc(srcKey)
+		}
+	}
+
+	if data, ok := fields["Details"]; ok {
+		var details map[string]json.RawMessage
+
+		if err := json.Unmarshal(data, &details); err != nil {
+			return err
+		}
+
+		for srcKey, keys := range detailsPatchSrc {
+			if patched(details, srcKey, keys) {
+				f.Details.setSrc(srcKey)
+			}
+		}
+	}
+
+	return nil
+}
+
+// PatchFields returns the sorted names of the fields present in a JSON request body.
+func PatchFields(data []byte) ([]string, error) {
+	var fields map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(fields))
+
+	for key := range fields {
+		result = append(result, key)
+	}
+
+	sort.Strings(result)
+
+	return result, nil
+}
+
+// patched tests if at least one of the keys, but not the source key, is present.
+func patched(fields map[string]json.RawMessage, srcKey string, keys []string) bool {
+	if _, ok := fields[srcKey]; ok {
+		return false
+	}
+
+	for _, key := range keys {
+		if _, ok := fields[key]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setSrc sets the specified photo value source to manual.
+func (f *Photo) setSrc(srcKey string) {
+	switch srcKey {
+	case "TitleSrc":
+		f.TitleSrc = srcManual
+	case "DescriptionSrc":
+		f.DescriptionSrc = srcManual
+	case "TakenSrc":
+		f.TakenSrc = srcManual
+	case "PlaceSrc":
+		f.PlaceSrc = srcManual
+	case "CameraSrc":
+		f.CameraSrc = srcManual
+	case "TypeSrc":
+		f.TypeSrc = srcManual
+	}
+}
+
+// setSrc sets the specified details value source to manual.
+func (f *Details) setSrc(srcKey string) {
+	switch srcKey {
+	case "KeywordsSrc":
+		f.KeywordsSrc = srcManual
+	case "NotesSrc":
+		f.NotesSrc = srcManual
+	case "SubjectSrc":
+		f.SubjectSrc = srcManual
+	case "ArtistSrc":
+		f.ArtistSrc = srcManual
+	case "CopyrightSrc":
+		f.CopyrightSrc = srcManual
+	case "LicenseSrc":
+		f.LicenseSrc = srcManual
+	}
+}
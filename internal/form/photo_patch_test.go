@@ -0,0 +1,103 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoto_Patch(t *testing.T) {
+	newForm := func() Photo {
+		return Photo{
+			PhotoTitle:       "Black beach",
+			TitleSrc:         "meta",
+			PhotoDescription: "Sand and sea",
+			DescriptionSrc:   "meta",
+			PhotoLat:         9.9999,
+			PhotoLng:         8.8888,
+			PhotoCountry:     "de",
+			PlaceSrc:         "meta",
+			Details:          Details{Keywords: "beach, sand", KeywordsSrc: "meta", Artist: "Jane", ArtistSrc: "meta"},
+		}
+	}
+
+	t.Run("Title", func(t *testing.T) {
+		f := newForm()
+
+		if err := f.Patch([]byte(`{"Title": "White beach"}`)); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "White beach", f.PhotoTitle)
+		assert.Equal(t, "manual", f.TitleSrc)
+		assert.Equal(t, "Sand and sea", f.PhotoDescription)
+		assert.Equal(t, "meta", f.DescriptionSrc)
+		assert.Equal(t, float32(9.9999), f.PhotoLat)
+		assert.Equal(t, "meta", f.PlaceSrc)
+	})
+	t.Run("DescriptionWithSrc", func(t *testing.T) {
+		f := newForm()
+
+		if err := f.Patch([]byte(`{"Description": "", "DescriptionSrc": "xmp"}`)); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Black beach", f.PhotoTitle)
+		assert.Equal(t, "", f.PhotoDescription)
+		assert.Equal(t, "xmp", f.DescriptionSrc)
+	})
+	t.Run("Location", func(t *testing.T) {
+		f := newForm()
+
+		if err := f.Patch([]byte(`{"Lat": 1.5, "Lng": -2.5, "Country": "fr"}`)); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, float32(1.5), f.PhotoLat)
+		assert.Equal(t, float32(-2.5), f.PhotoLng)
+		assert.Equal(t, "fr", f.PhotoCountry)
+		assert.Equal(t, "manual", f.PlaceSrc)
+		assert.Equal(t, "Black beach", f.PhotoTitle)
+		assert.Equal(t, "meta", f.TitleSrc)
+	})
+	t.Run("Details", func(t *testing.T) {
+		f := newForm()
+
+		if err := f.Patch([]byte(`{"Details": {"Artist": "John"}}`)); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "John", f.Details.Artist)
+		assert.Equal(t, "manual", f.Details.ArtistSrc)
+		assert.Equal(t, "beach, sand", f.Details.Keywords)
+		assert.Equal(t, "meta", f.Details.KeywordsSrc)
+	})
+	t.Run("UnknownField", func(t *testing.T) {
+		f := newForm()
+
+		assert.Error(t, f.Patch([]byte(`{"Title": "White beach", "Password": "secret"}`)))
+	})
+	t.Run("InvalidJson", func(t *testing.T) {
+		f := newForm()
+
+		assert.Error(t, f.Patch([]byte(`{"Title": 123}`)))
+		assert.Error(t, f.Patch([]byte(`[]`)))
+	})
+}
+
+func TestPatchFields(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		fields, err := PatchFields([]byte(`{"Title": "White beach", "Details": {"Notes": ""}, "Favorite": true}`))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Details", "Favorite", "Title"}, fields)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		fields, err := PatchFields([]byte(`{}`))
+		assert.NoError(t, err)
+		assert.Empty(t, fields)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := PatchFields([]byte(`[1, 2]`))
+		assert.Error(t, err)
+	})
+}
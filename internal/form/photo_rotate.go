@@ -0,0 +1,16 @@
+package form
+
+// PhotoRotate represents a request to rotate a photo clockwise by 90, 180, or 270 degrees.
+type PhotoRotate struct {
+	Degrees int `json:"degrees"`
+}
+
+// Valid checks if the rotation is supported.
+func (f PhotoRotate) Valid() bool {
+	switch f.Degrees {
+	case 90, 180, 270:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,16 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoRotate_Valid(t *testing.T) {
+	assert.True(t, PhotoRotate{Degrees: 90}.Valid())
+	assert.True(t, PhotoRotate{Degrees: 180}.Valid())
+	assert.True(t, PhotoRotate{Degrees: 270}.Valid())
+	assert.False(t, PhotoRotate{Degrees: 0}.Valid())
+	assert.False(t, PhotoRotate{Degrees: 360}.Valid())
+	assert.False(t, PhotoRotate{Degrees: -90}.Valid())
+}
@@ -67,3 +67,21 @@ func TestNewPhoto(t *testing.T) {
 		assert.Equal(t, "de", r.PhotoCountry)
 	})
 }
+
+func TestPhoto_Diff(t *testing.T) {
+	takenAt := time.Date(2008, 1, 1, 2, 0, 0, 0, time.UTC)
+	f := Photo{PhotoTitle: "Black beach", TakenAt: takenAt, Details: Details{Notes: "Sand"}}
+
+	t.Run("Equal", func(t *testing.T) {
+		other := f
+		other.TakenAt = takenAt.In(time.FixedZone("CET", 3600))
+		assert.Empty(t, f.Diff(other))
+	})
+	t.Run("Changed", func(t *testing.T) {
+		other := f
+		other.PhotoTitle = "White beach"
+		other.PhotoFavorite = true
+		other.Details.Notes = "Sea"
+		assert.Equal(t, []string{"Title", "Details", "Favorite"}, f.Diff(other))
+	})
+}
@@ -0,0 +1,86 @@
+package form
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// photoTimeLayouts are the supported timestamp layouts without UTC offset, which refer to the local time.
+var photoTimeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+}
+
+// PhotoTime represents a request to change the time when a photo was taken and its time zone.
+type PhotoTime struct {
+	TakenAt  string `json:"TakenAt"`
+	TimeZone string `json:"TimeZone"`
+}
+
+// Location returns the normalized IANA time zone, or an error if it is unknown.
+// An empty value, "Z", or "UTC" in any case refers to UTC.
+func (f PhotoTime) Location() (*time.Location, error) {
+	zone := strings.TrimSpace(f.TimeZone)
+
+	switch strings.ToUpper(zone) {
+	case "", "Z", "UTC":
+		return time.UTC, nil
+	case "LOCAL":
+		return nil, fmt.Errorf("unknown time zone %s", zone)
+	}
+
+	return time.LoadLocation(zone)
+}
+
+// Time returns the time when the photo was taken in UTC. ISO timestamps with UTC offset, e.g.
+// "2019-07-04T12:00:00+02:00", refer to an absolute time, and timestamps without offset to
+// the local time in the specified location.
+func (f PhotoTime) Time(loc *time.Location) (t time.Time, err error) {
+	s := strings.TrimSpace(f.TakenAt)
+
+	if s == "" {
+		return t, fmt.Errorf("timestamp is missing")
+	}
+
+	if t, err = time.Parse(time.RFC3339Nano, s); err != nil {
+		for _, layout := range photoTimeLayouts {
+			if t, err = time.ParseInLocation(layout, s, loc); err == nil {
+				break
+			}
+		}
+	}
+
+	if err != nil {
+		return t, fmt.Errorf("invalid timestamp %s", s)
+	} else if y := t.Year(); y < 1000 || y > txt.YearMax {
+		return t, fmt.Errorf("year %d is out of range", y)
+	}
+
+	return t.UTC(), nil
+}
+
+// Parse validates the form values and returns the normalized time in UTC and time zone,
+// or ValidationErrors if they are invalid.
+func (f PhotoTime) Parse() (takenAt time.Time, loc *time.Location, err error) {
+	var errs ValidationErrors
+
+	if loc, err = f.Location(); err != nil {
+		errs.Add("TimeZone", "must be a valid IANA time zone, e.g. Europe/Berlin")
+		loc = time.UTC
+	}
+
+	if takenAt, err = f.Time(loc); err != nil {
+		errs.Add("TakenAt", "must be an ISO timestamp, e.g. 2019-07-04T12:00:00")
+	}
+
+	if len(errs) > 0 {
+		return takenAt, loc, errs
+	}
+
+	return takenAt, loc, nil
+}
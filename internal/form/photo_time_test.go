@@ -0,0 +1,57 @@
+package form
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoTime_Parse(t *testing.T) {
+	t.Run("LocalTime", func(t *testing.T) {
+		takenAt, loc, err := PhotoTime{TakenAt: "2019-07-04T12:00:00", TimeZone: " Europe/Berlin "}.Parse()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Europe/Berlin", loc.String())
+		assert.Equal(t, time.Date(2019, 7, 4, 10, 0, 0, 0, time.UTC), takenAt)
+		assert.Equal(t, time.UTC, takenAt.Location())
+	})
+	t.Run("Offset", func(t *testing.T) {
+		takenAt, loc, err := PhotoTime{TakenAt: "2019-07-04T12:00:00-04:00", TimeZone: "America/New_York"}.Parse()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "America/New_York", loc.String())
+		assert.Equal(t, time.Date(2019, 7, 4, 16, 0, 0, 0, time.UTC), takenAt)
+	})
+	t.Run("UTC", func(t *testing.T) {
+		takenAt, loc, err := PhotoTime{TakenAt: "2019-07-04 12:00", TimeZone: "utc"}.Parse()
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.UTC, loc)
+		assert.Equal(t, time.Date(2019, 7, 4, 12, 0, 0, 0, time.UTC), takenAt)
+	})
+	t.Run("InvalidTimeZone", func(t *testing.T) {
+		_, _, err := PhotoTime{TakenAt: "2019-07-04T12:00:00", TimeZone: "Europe/Atlantis"}.Parse()
+
+		if errs, ok := err.(ValidationErrors); assert.True(t, ok) && assert.Len(t, errs, 1) {
+			assert.Equal(t, "TimeZone", errs[0].Field)
+		}
+	})
+	t.Run("LocalTimeZone", func(t *testing.T) {
+		_, _, err := PhotoTime{TakenAt: "2019-07-04T12:00:00", TimeZone: "Local"}.Parse()
+
+		assert.Error(t, err)
+	})
+	t.Run("InvalidTimestamp", func(t *testing.T) {
+		_, _, err := PhotoTime{TakenAt: "July 4th", TimeZone: "UTC"}.Parse()
+
+		if errs, ok := err.(ValidationErrors); assert.True(t, ok) && assert.Len(t, errs, 1) {
+			assert.Equal(t, "TakenAt", errs[0].Field)
+		}
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, _, err := PhotoTime{}.Parse()
+
+		assert.Error(t, err)
+	})
+}
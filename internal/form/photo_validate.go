@@ -0,0 +1,90 @@
+package form
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Maximum length of photo text values, see entity.Photo.
+const (
+	PhotoTitleMaxLen       = 200
+	PhotoDescriptionMaxLen = 4096
+)
+
+// photoUnknownDate is the year, month, and day value of an unknown date, see entity.UnknownYear.
+// A zero value is accepted as well, since the date fields are updated based on TakenAt when saving.
+const photoUnknownDate = -1
+
+// ValidationError represents an invalid form field value.
+type ValidationError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error returns the field name and message as string.
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, e.Message)
+}
+
+// ValidationErrors represents a list of invalid form field values.
+type ValidationErrors []ValidationError
+
+// Add appends a field error to the list.
+func (e *ValidationErrors) Add(field, message string) {
+	*e = append(*e, ValidationError{Field: field, Message: message})
+}
+
+// Error returns all field errors as string.
+func (e ValidationErrors) Error() string {
+	s := make([]string, len(e))
+
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+
+	return strings.Join(s, ", ")
+}
+
+// Validate checks the photo form values and returns ValidationErrors if they are invalid.
+func (f *Photo) Validate() error {
+	var errs ValidationErrors
+
+	if n := utf8.RuneCountInString(f.PhotoTitle); n > PhotoTitleMaxLen {
+		errs.Add("Title", fmt.Sprintf("must not be longer than %d characters", PhotoTitleMaxLen))
+	}
+
+	if n := utf8.RuneCountInString(f.PhotoDescription); n > PhotoDescriptionMaxLen {
+		errs.Add("Description", fmt.Sprintf("must not be longer than %d characters", PhotoDescriptionMaxLen))
+	}
+
+	if f.PhotoLat < -90 || f.PhotoLat > 90 {
+		errs.Add("Lat", "must be between -90 and 90")
+	}
+
+	if f.PhotoLng < -180 || f.PhotoLng > 180 {
+		errs.Add("Lng", "must be between -180 and 180")
+	}
+
+	if f.PhotoYear < photoUnknownDate || f.PhotoYear > 9999 {
+		errs.Add("Year", "must be between 1 and 9999")
+	}
+
+	if f.PhotoMonth < photoUnknownDate || f.PhotoMonth > 12 {
+		errs.Add("Month", "must be between 1 and 12")
+	}
+
+	if f.PhotoDay < photoUnknownDate || f.PhotoDay > 31 {
+		errs.Add("Day", "must be between 1 and 31")
+	}
+
+	if f.PhotoCountry != "" && len(f.PhotoCountry) != 2 {
+		errs.Add("Country", "must be a two-letter country code")
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
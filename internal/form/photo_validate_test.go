@@ -0,0 +1,55 @@
+package form
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoto_Validate(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		f := Photo{PhotoTitle: "Lake", PhotoLat: 48.519234, PhotoLng: 9.057997, PhotoYear: 2020, PhotoMonth: 7, PhotoDay: 31, PhotoCountry: "de"}
+
+		assert.NoError(t, f.Validate())
+	})
+	t.Run("UnknownDate", func(t *testing.T) {
+		f := Photo{PhotoYear: -1, PhotoMonth: -1, PhotoDay: -1}
+
+		assert.NoError(t, f.Validate())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		f := Photo{
+			PhotoTitle:   strings.Repeat("a", PhotoTitleMaxLen+1),
+			PhotoLat:     91,
+			PhotoLng:     -181,
+			PhotoMonth:   13,
+			PhotoDay:     -2,
+			PhotoCountry: "deu",
+		}
+
+		err := f.Validate()
+
+		var errs ValidationErrors
+
+		if !errors.As(err, &errs) {
+			t.Fatalf("expected validation errors, got %#v", err)
+		}
+
+		fields := make([]string, len(errs))
+
+		for i, e := range errs {
+			fields[i] = e.Field
+			assert.NotEmpty(t, e.Message)
+		}
+
+		assert.Equal(t, []string{"Title", "Lat", "Lng", "Month", "Day", "Country"}, fields)
+		assert.Contains(t, err.Error(), "Lat must be between -90 and 90")
+	})
+	t.Run("MultibyteTitle", func(t *testing.T) {
+		f := Photo{PhotoTitle: strings.Repeat("ü", PhotoTitleMaxLen)}
+
+		assert.NoError(t, f.Validate())
+	})
+}
@@ -0,0 +1,7 @@
+package form
+
+// PhotosPrivate represents a request to set or clear the private flag of multiple photos.
+type PhotosPrivate struct {
+	Photos  []string `json:"photos"`
+	Private bool     `json:"private"`
+}
@@ -9,6 +9,7 @@ import (
 // SearchPhotos represents search form fields for "/api/v1/photos".
 type SearchPhotos struct {
 	Query     string    `form:"q"`
+	Fulltext  bool      `form:"fulltext" notes:"Searches the query in titles, descriptions, notes, and keywords ranked by relevance"`
 	Scope     string    `form:"s" serialize:"-" example:"s:ariqwb43p5dh9h13" notes:"Limits the results to one album or another scope, if specified"`
 	Filter    string    `form:"filter" serialize:"-" notes:"-"`
 	ID        string    `form:"id" example:"id:123e4567-e89b-..." notes:"Finds pictures by Exif UID, XMP Document ID or Instance ID"`
@@ -33,6 +34,8 @@ type SearchPhotos struct {
 	Live      bool      `form:"live" notes:"Finds Live Photos and short videos"`
 	Scan      bool      `form:"scan" notes:"Finds scanned images and documents"`
 	Panorama  bool      `form:"panorama" notes:"Finds pictures with an aspect ratio > 1.9:1"`
+	Flash     bool      `form:"flash" notes:"Finds pictures taken with flash"`
+	HDR       bool      `form:"hdr" notes:"Finds high dynamic range pictures"`
 	Portrait  bool      `form:"portrait" notes:"Finds pictures in portrait format"`
 	Landscape bool      `form:"landscape" notes:"Finds pictures in landscape format"`
 	Square    bool      `form:"square" notes:"Finds images with an aspect ratio of 1:1"`
@@ -48,21 +51,28 @@ type SearchPhotos struct {
 	Dist      uint      `form:"dist" example:"dist:5" notes:"Distance in km in combination with lat/lng"`
 	Fmin      float32   `form:"fmin" notes:"F-number (min)"`
 	Fmax      float32   `form:"fmax" notes:"F-number (max)"`
+	Iso       string    `form:"iso" example:"iso:>1600" notes:"ISO Speed, e.g. >1600, <=400, or 100-800"`
+	Aperture  string    `form:"aperture" example:"aperture:<2.8" notes:"F-number, e.g. <2.8, >=f/8, or 4-8"`
+	Shutter   string    `form:"shutter" example:"shutter:>1/60" notes:"Exposure Time in Seconds, e.g. >1/60, <=2, or 1/250-1/60"`
+	Focal     string    `form:"focal" example:"focal:35-85" notes:"Focal Length in mm, e.g. 35-85 or >=200"`
 	Chroma    int16     `form:"chroma" example:"chroma:70" notes:"Chroma (0-100)"`
 	Diff      uint32    `form:"diff" notes:"Differential Perceptual Hash (000000-FFFFFF)"`
+	Size      string    `form:"size" example:"size:>20MB" notes:"File Size in Bytes, KB, MB, or GB, e.g. >20MB, <100KB, or 1MB-5MB"`
+	DateSrc   string    `form:"datesource" example:"datesource:exif" notes:"Source of the Date Taken (exif, name, sidecar, manual, estimate, or auto), OR search with |"`
 	Mono      bool      `form:"mono" notes:"Finds pictures with few or no colors"`
 	Geo       bool      `form:"geo" notes:"Finds pictures with GPS location"`
 	Keywords  string    `form:"keywords"  example:"keywords:\"buffalo&water\"" notes:"Keywords, can be combined with & and |"`                                                                                        // Filter by keyword(s)
 	Label     string    `form:"label" example:"label:cat|dog" notes:"Label Name, OR search with |"`                                                                                                                   // Label name
-	Category  string    `form:"category"  notes:"Location Category Name"`                                                                                                                                             // Moments
+	Category  string    `form:"category" example:"category:animal" notes:"Location or Label Category Name, includes all subcategories of labels"`                                                                     // Moments
 	Country   string    `form:"country" example:"country:\"de|us\"" notes:"Country Code, OR search with |"`                                                                                                           // Moments
 	State     string    `form:"state" example:"state:\"Baden-Württemberg\"" notes:"Name of State (Location), OR search with |"`                                                                                       // Moments
 	City      string    `form:"city" example:"city:\"Berlin\"" notes:"Name of City (Location), OR search with |"`                                                                                                     // Moments
 	Year      string    `form:"year" example:"year:1990|2003" notes:"Year Number, OR search with |"`                                                                                                                  // Moments
 	Month     string    `form:"month" example:"month:7|10" notes:"Month (1-12), OR search with |"`                                                                                                                    // Moments
 	Day       string    `form:"day" example:"day:3|13" notes:"Day of Month (1-31), OR search with |"`                                                                                                                 // Moments
-	Face      string    `form:"face" example:"face:PN6QO5INYTUSAATOFL43LL2ABAV5ACZG" notes:"Face ID, yes, no, new, or kind"`                                                                                          // UIDs
-	Faces     string    `form:"faces" example:"faces:yes faces:3" notes:"Minimum number of Faces (yes = 1)"`                                                                                                          // Find or exclude faces if detected.
+	TimeOfDay string    `form:"timeofday" example:"timeofday:18:00-20:00" notes:"Local Time Range (HH:MM-HH:MM), may wrap past midnight"`                                                                             // Moments
+	Face      string    `form:"face" example:"face:PN6QO5INYTUSAATOFL43LL2ABAV5ACZG" notes:"Face ID, yes, no, new, unnamed, or kind"`                                                                                 // UIDs
+	Faces     string    `form:"faces" example:"faces:yes faces:3" notes:"Minimum number of Faces (yes = 1), or unnamed"`                                                                                              // Find or exclude faces if detected.
 	Subject   string    `form:"subject" example:"subject:\"Jane Doe & John Doe\"" notes:"Alias for person"`                                                                                                           // UIDs
 	Person    string    `form:"person" example:"person:\"Jane Doe & John Doe\"" notes:"Subject Names, exact matches, can be combined with & and |"`                                                                   // Alias for Subject
 	Subjects  string    `form:"subjects" example:"subjects:\"Jane & John\"" notes:"Alias for people"`                                                                                                                 // People names
@@ -72,14 +82,18 @@ type SearchPhotos struct {
 	Color     string    `form:"color" example:"color:\"red|blue\"" notes:"Color Name (purple, magenta, pink, red, orange, gold, yellow, lime, green, teal, cyan, blue, brown, white, grey, black), OR search with |"` // Main color
 	Quality   int       `form:"quality" notes:"Quality Score (0-7)"`                                                                                                                                                  // Photo quality score
 	Review    bool      `form:"review" notes:"Finds pictures in review"`                                                                                                                                              // Find photos in review
+	Uncertain bool      `form:"uncertain" notes:"Finds pictures with only low-confidence labels that need to be reviewed"`                                                                                            // Find photos that need label review
 	Camera    string    `form:"camera" example:"camera:canon" notes:"Camera Make/Model Name"`                                                                                                                         // Camera UID or name
 	Lens      string    `form:"lens" example:"lens:ef24" notes:"Lens Make/Model Name"`                                                                                                                                // Lens UID or name
 	Before    time.Time `form:"before" time_format:"2006-01-02" notes:"Finds pictures taken before this date"`                                                                                                        // Finds images taken before date
 	After     time.Time `form:"after" time_format:"2006-01-02" notes:"Finds pictures taken after this date"`                                                                                                          // Finds images taken after date
-	Count     int       `form:"count" binding:"required" serialize:"-"`                                                                                                                                               // Result FILE limit
+	Count     int       `form:"count" serialize:"-"`                                                                                                                                                                  // Result FILE limit
+	Limit     int       `form:"limit" serialize:"-"`                                                                                                                                                                  // Alias for count, finds pictures taken within a date range
 	Offset    int       `form:"offset" serialize:"-"`                                                                                                                                                                 // Result FILE offset
 	Order     string    `form:"order" serialize:"-"`                                                                                                                                                                  // Sort order
 	Merged    bool      `form:"merged" serialize:"-"`                                                                                                                                                                 // Merge FILES in response
+	Total     bool      `form:"total" serialize:"-"`                                                                                                                                                                  // Count all matching results
+	Inclusive bool      `form:"-" serialize:"-"`                                                                                                                                                                      // Include pictures taken on the before date
 }
 
 func (f *SearchPhotos) GetQuery() string {
@@ -31,6 +31,8 @@ type SearchPhotosGeo struct {
 	Live      bool      `form:"live"`
 	Scan      bool      `form:"scan"`
 	Panorama  bool      `form:"panorama"`
+	Flash     bool      `form:"flash"`
+	HDR       bool      `form:"hdr"`
 	Portrait  bool      `form:"portrait"`
 	Landscape bool      `form:"landscape"`
 	Square    bool      `form:"square"`
@@ -61,9 +63,12 @@ type SearchPhotosGeo struct {
 	Year      string    `form:"year"`  // Moments
 	Month     string    `form:"month"` // Moments
 	Day       string    `form:"day"`   // Moments
+	TimeOfDay string    `form:"timeofday"`
+	Size      string    `form:"size"`
 	Color     string    `form:"color"`
 	Camera    int       `form:"camera"`
 	Lens      int       `form:"lens"`
+	Level     int       `form:"level" serialize:"-"`
 	Count     int       `form:"count" serialize:"-"`
 	Offset    int       `form:"offset" serialize:"-"`
 }
@@ -25,6 +25,70 @@ func TestParseQueryString(t *testing.T) {
 
 		assert.Equal(t, "Jens & Mander", form.Subjects)
 	})
+	t.Run("timeofday", func(t *testing.T) {
+		form := &SearchPhotos{Query: "timeofday:22:30-01:00 cat"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "22:30-01:00", form.TimeOfDay)
+		assert.Equal(t, "cat", form.Query)
+	})
+	t.Run("size", func(t *testing.T) {
+		form := &SearchPhotos{Query: "size:>20MB cat"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, ">20MB", form.Size)
+		assert.Equal(t, "cat", form.Query)
+	})
+	t.Run("operators", func(t *testing.T) {
+		form := &SearchPhotos{Query: "title:>cat label:<dog"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Comparison operators are only kept in numeric filters.
+		assert.Equal(t, "cat", form.Title)
+		assert.Equal(t, "dog", form.Label)
+	})
+	t.Run("datesource", func(t *testing.T) {
+		form := &SearchPhotos{Query: "datesource:sidecar cat"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "sidecar", form.DateSrc)
+		assert.Equal(t, "cat", form.Query)
+	})
+	t.Run("exif", func(t *testing.T) {
+		form := &SearchPhotos{Query: "iso:>1600 aperture:<2.8 shutter:>1/60 focal:35-85 cat"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, ">1600", form.Iso)
+		assert.Equal(t, "<2.8", form.Aperture)
+		assert.Equal(t, ">1/60", form.Shutter)
+		assert.Equal(t, "35-85", form.Focal)
+		assert.Equal(t, "cat", form.Query)
+	})
 	t.Run("aliases", func(t *testing.T) {
 		form := &SearchPhotos{Query: "people:\"Jens & Mander\" folder:Foo person:Bar"}
 
@@ -299,6 +363,19 @@ func TestParseQueryString(t *testing.T) {
 
 		assert.True(t, form.Scan)
 	})
+	t.Run("query for flash and hdr", func(t *testing.T) {
+		form := &SearchPhotos{Query: "flash:true hdr:yes panorama:true"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, form.Flash)
+		assert.True(t, form.HDR)
+		assert.True(t, form.Panorama)
+	})
 	t.Run("query for panorama with uncommon bool value", func(t *testing.T) {
 		form := &SearchPhotos{Query: "panorama:*cat"}
 
@@ -78,6 +78,15 @@ func Serialize(f interface{}, all bool) string {
 	return strings.Join(q, " ")
 }
 
+// compareFilters contains the names of the filters whose values may start with a comparison operator.
+var compareFilters = map[string]bool{
+	"size":     true,
+	"iso":      true,
+	"aperture": true,
+	"shutter":  true,
+	"focal":    true,
+}
+
 func Unserialize(f SearchForm, q string) (result error) {
 	var key, value []rune
 	var escaped, isKeyValue bool
@@ -150,7 +159,16 @@ func Unserialize(f SearchForm, q string) (result error) {
 							field.SetUint(uint64(intValue))
 						}
 					case string:
-						field.SetString(clean.SearchString(stringValue))
+						var op string
+
+						// Keep leading comparison operators of numeric filters, e.g. in "size:>20MB".
+						if compareFilters[formName] {
+							if i := strings.IndexFunc(stringValue, func(r rune) bool { return r != '<' && r != '>' && r != '=' }); i > 0 {
+								op, stringValue = stringValue[:i], stringValue[i:]
+							}
+						}
+
+						field.SetString(op + clean.SearchString(stringValue))
 					case bool:
 						field.SetBool(txt.Bool(stringValue))
 					default:
@@ -167,7 +185,8 @@ func Unserialize(f SearchForm, q string) (result error) {
 			isKeyValue = false
 			key = key[:0]
 			value = value[:0]
-		} else if char == ':' && !escaped {
+		} else if char == ':' && !escaped && !(isKeyValue && string(key) == "timeofday") {
+			// Colons are only kept in time of day values, e.g. "timeofday:18:00-20:00".
 			isKeyValue = true
 		} else if char == '"' {
 			escaped = !escaped
@@ -0,0 +1,52 @@
+package form
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RebuildSidecars represents a request to rewrite the YAML sidecar files of photos taken within a date range.
+type RebuildSidecars struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Range returns the parsed date range, with dates in the format "2006-01-02" or RFC 3339.
+// Plain dates include the whole day, and an empty value leaves the range open on this side.
+func (f RebuildSidecars) Range() (from, to time.Time, err error) {
+	if from, err = parseSidecarsDate(f.From, false); err != nil {
+		return from, to, err
+	} else if to, err = parseSidecarsDate(f.To, true); err != nil {
+		return from, to, err
+	}
+
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return from, to, fmt.Errorf("invalid date range")
+	}
+
+	return from, to, nil
+}
+
+// parseSidecarsDate parses a date or timestamp, optionally returning the end of the day for plain dates.
+func parseSidecarsDate(s string, endOfDay bool) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC(), nil
+	}
+
+	t, err := time.Parse("2006-01-02", s)
+
+	if err != nil {
+		return t, fmt.Errorf("invalid date %s", s)
+	} else if endOfDay {
+		t = t.Add(24*time.Hour - time.Second)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,49 @@
+package form
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebuildSidecars_Range(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		from, to, err := RebuildSidecars{}.Range()
+
+		assert.NoError(t, err)
+		assert.True(t, from.IsZero())
+		assert.True(t, to.IsZero())
+	})
+	t.Run("Dates", func(t *testing.T) {
+		from, to, err := RebuildSidecars{From: "2020-01-01", To: "2020-12-31"}.Range()
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), from)
+		assert.Equal(t, time.Date(2020, 12, 31, 23, 59, 59, 0, time.UTC), to)
+	})
+	t.Run("Timestamps", func(t *testing.T) {
+		from, to, err := RebuildSidecars{From: "2020-01-01T10:00:00Z", To: "2020-01-01T12:00:00+02:00"}.Range()
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC), from)
+		assert.Equal(t, time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC), to)
+	})
+	t.Run("OpenEnd", func(t *testing.T) {
+		from, to, err := RebuildSidecars{From: "2020-01-01"}.Range()
+
+		assert.NoError(t, err)
+		assert.False(t, from.IsZero())
+		assert.True(t, to.IsZero())
+	})
+	t.Run("InvalidDate", func(t *testing.T) {
+		_, _, err := RebuildSidecars{From: "01.01.2020"}.Range()
+
+		assert.Error(t, err)
+	})
+	t.Run("InvalidRange", func(t *testing.T) {
+		_, _, err := RebuildSidecars{From: "2021-01-01", To: "2020-01-01"}.Range()
+
+		assert.Error(t, err)
+	})
+}
@@ -57,6 +57,8 @@ var services struct {
 	FaceNet     *face.Net
 	Query       *query.Query
 	Thumbs      *photoprism.Thumbs
+	Sidecars    *photoprism.Sidecars
+	Trash       *photoprism.Trash
 	Session     *session.Session
 }
 
@@ -69,6 +69,14 @@ func TestResample(t *testing.T) {
 	assert.IsType(t, &photoprism.Thumbs{}, Thumbs())
 }
 
+func TestSidecars(t *testing.T) {
+	assert.IsType(t, &photoprism.Sidecars{}, Sidecars())
+}
+
+func TestTrash(t *testing.T) {
+	assert.IsType(t, &photoprism.Trash{}, Trash())
+}
+
 func TestSession(t *testing.T) {
 	assert.IsType(t, &session.Session{}, Session())
 }
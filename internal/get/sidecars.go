@@ -0,0 +1,19 @@
+package get
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+var onceSidecars sync.Once
+
+func initSidecars() {
+	services.Sidecars = photoprism.NewSidecars(Config())
+}
+
+func Sidecars() *photoprism.Sidecars {
+	onceSidecars.Do(initSidecars)
+
+	return services.Sidecars
+}
@@ -0,0 +1,19 @@
+package get
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+var onceTrash sync.Once
+
+func initTrash() {
+	services.Trash = photoprism.NewTrash(Config())
+}
+
+func Trash() *photoprism.Trash {
+	onceTrash.Do(initTrash)
+
+	return services.Trash
+}
@@ -0,0 +1,125 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// dateFormat specifies the month names and the order of date components in a language.
+type dateFormat struct {
+	Months    [12]string // Month names when used without a day.
+	DayMonths [12]string // Month names when used with a day, e.g. genitive case in Polish and Russian.
+	MonthYear string     // Format with %[1]s month and %[2]d year.
+	Date      string     // Format with %[1]d day, %[2]s month, and %[3]d year.
+}
+
+var dateFormats = map[Locale]dateFormat{
+	English: {
+		Months:    [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		MonthYear: "%[1]s %[2]d",
+		Date:      "%[2]s %[1]d, %[3]d",
+	},
+	German: {
+		Months:    [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		MonthYear: "%[1]s %[2]d",
+		Date:      "%[1]d. %[2]s %[3]d",
+	},
+	Spanish: {
+		Months:    [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		MonthYear: "%[1]s de %[2]d",
+		Date:      "%[1]d de %[2]s de %[3]d",
+	},
+	French: {
+		Months:    [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		MonthYear: "%[1]s %[2]d",
+		Date:      "%[1]d %[2]s %[3]d",
+	},
+	Dutch: {
+		Months:    [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		MonthYear: "%[1]s %[2]d",
+		Date:      "%[1]d %[2]s %[3]d",
+	},
+	Polish: {
+		Months:    [12]string{"styczeń", "luty", "marzec", "kwiecień", "maj", "czerwiec", "lipiec", "sierpień", "wrzesień", "październik", "listopad", "grudzień"},
+		DayMonths: [12]string{"stycznia", "lutego", "marca", "kwietnia", "maja", "czerwca", "lipca", "sierpnia", "września", "października", "listopada", "grudnia"},
+		MonthYear: "%[1]s %[2]d",
+		Date:      "%[1]d %[2]s %[3]d",
+	},
+	Portuguese: {
+		Months:    [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		MonthYear: "%[1]s de %[2]d",
+		Date:      "%[1]d de %[2]s de %[3]d",
+	},
+	Russian: {
+		Months:    [12]string{"январь", "февраль", "март", "апрель", "май", "июнь", "июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь"},
+		DayMonths: [12]string{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября", "октября", "ноября", "декабря"},
+		MonthYear: "%[1]s %[2]d",
+		Date:      "%[1]d %[2]s %[3]d г.",
+	},
+	ChineseSimplified: {
+		Months:    [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		MonthYear: "%[2]d年%[1]s",
+		Date:      "%[3]d年%[2]s%[1]d日",
+	},
+}
+
+// CurrentLocale returns the default locale, as set with SetLocale.
+func CurrentLocale() Locale {
+	return locale
+}
+
+// NewLocale returns a supported locale based on the language name, e.g. from user settings.
+func NewLocale(loc string) Locale {
+	switch len(loc) {
+	case 2:
+		return Locale(strings.ToLower(loc))
+	case 5:
+		return Locale(strings.ToLower(loc[:2]) + "_" + strings.ToUpper(loc[3:5]))
+	default:
+		return Default
+	}
+}
+
+// dateFormat returns the date format of the locale, with a fallback to the base language and English.
+func (l Locale) dateFormat() dateFormat {
+	if f, ok := dateFormats[l]; ok {
+		return f
+	} else if len(l) > 2 {
+		if f, ok = dateFormats[l[:2]]; ok {
+			return f
+		}
+	}
+
+	return dateFormats[Default]
+}
+
+// MonthName returns the localized name of the month.
+func (l Locale) MonthName(m time.Month) string {
+	if m < time.January || m > time.December {
+		return ""
+	}
+
+	return l.dateFormat().Months[m-1]
+}
+
+// MonthYear returns the localized month and year, e.g. "January 2006" for use in titles.
+func (l Locale) MonthYear(t time.Time) string {
+	f := l.dateFormat()
+
+	return txt.UpperFirst(fmt.Sprintf(f.MonthYear, f.Months[t.Month()-1], t.Year()))
+}
+
+// Date returns the localized day, month and year, e.g. "January 2, 2006".
+func (l Locale) Date(t time.Time) string {
+	f := l.dateFormat()
+	month := f.DayMonths[t.Month()-1]
+
+	if month == "" {
+		month = f.Months[t.Month()-1]
+	}
+
+	return fmt.Sprintf(f.Date, t.Day(), month, t.Year())
+}
@@ -0,0 +1,53 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLocale(t *testing.T) {
+	assert.Equal(t, German, NewLocale("DE"))
+	assert.Equal(t, BrazilianPortuguese, NewLocale("pt-br"))
+	assert.Equal(t, Default, NewLocale(""))
+	assert.Equal(t, Default, NewLocale("german"))
+}
+
+func TestLocale_MonthName(t *testing.T) {
+	assert.Equal(t, "March", English.MonthName(time.March))
+	assert.Equal(t, "März", German.MonthName(time.March))
+	assert.Equal(t, "mars", French.MonthName(time.March))
+	assert.Equal(t, "", German.MonthName(0))
+	assert.Equal(t, "", German.MonthName(13))
+}
+
+func TestLocale_MonthYear(t *testing.T) {
+	date := time.Date(2021, 3, 7, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "March 2021", English.MonthYear(date))
+	assert.Equal(t, "März 2021", German.MonthYear(date))
+	assert.Equal(t, "Marzo de 2021", Spanish.MonthYear(date))
+	assert.Equal(t, "Março de 2021", BrazilianPortuguese.MonthYear(date))
+	assert.Equal(t, "Marzec 2021", Polish.MonthYear(date))
+	assert.Equal(t, "2021年3月", ChineseTraditional.MonthYear(date))
+	assert.Equal(t, "March 2021", Locale("xx").MonthYear(date))
+}
+
+func TestLocale_Date(t *testing.T) {
+	date := time.Date(2021, 3, 7, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "March 7, 2021", English.Date(date))
+	assert.Equal(t, "7. März 2021", German.Date(date))
+	assert.Equal(t, "7 de marzo de 2021", Spanish.Date(date))
+	assert.Equal(t, "7 marca 2021", Polish.Date(date))
+	assert.Equal(t, "7 марта 2021 г.", Russian.Date(date))
+	assert.Equal(t, "2021年3月7日", ChineseSimplified.Date(date))
+}
+
+func TestCurrentLocale(t *testing.T) {
+	SetLocale("de")
+	assert.Equal(t, German, CurrentLocale())
+	SetLocale("")
+	assert.Equal(t, Default, CurrentLocale())
+}
@@ -1,8 +1,6 @@
 package i18n
 
 import (
-	"strings"
-
 	"github.com/leonelquinteros/gotext"
 )
 
@@ -31,16 +29,7 @@ func SetDir(dir string) {
 }
 
 func SetLocale(loc string) {
-	switch len(loc) {
-	case 2:
-		loc = strings.ToLower(loc[:2])
-		locale = Locale(loc)
-	case 5:
-		loc = strings.ToLower(loc[:2]) + "_" + strings.ToUpper(loc[3:5])
-		locale = Locale(loc)
-	default:
-		locale = Default
-	}
+	locale = NewLocale(loc)
 
 	gotext.Configure(localeDir, string(locale), "default")
 }
@@ -42,6 +42,8 @@ const (
 	ErrBusy
 	ErrWakeupInterval
 	ErrAccountConnect
+	ErrInvalidValues
+	ErrEntityRemoved
 
 	MsgChangesSaved
 	MsgAlbumCreated
@@ -132,6 +134,8 @@ var Messages = MessageMap{
 	ErrBusy:               gettext("Busy, please try again later"),
 	ErrWakeupInterval:     gettext("The wakeup interval is %s, but must be 1h or less"),
 	ErrAccountConnect:     gettext("Your account could not be connected"),
+	ErrInvalidValues:      gettext("Invalid values, please check your input"),
+	ErrEntityRemoved:      gettext("Entity has been removed"),
 
 	// Info and confirmation messages:
 	MsgChangesSaved:          gettext("Changes successfully saved"),
@@ -0,0 +1,82 @@
+package meta
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+// avifMaxMetaSize limits the size of the "meta" box that is read into memory.
+const avifMaxMetaSize = 1 << 20
+
+// AvifSize returns the width and height of the primary image stored in an AVIF file,
+// as specified by the "ispe" (image spatial extents) property, without decoding it.
+func AvifSize(fileName string) (width, height int, err error) {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	defer f.Close()
+
+	header := make([]byte, 8)
+
+	// Find the top-level "meta" box.
+	for {
+		if _, err = io.ReadFull(f, header); err != nil {
+			return 0, 0, fmt.Errorf("found no avif meta box")
+		}
+
+		size := int64(binary.BigEndian.Uint32(header[0:4]))
+
+		if size < 8 {
+			return 0, 0, fmt.Errorf("unsupported avif box size")
+		} else if string(header[4:8]) != "meta" {
+			if _, err = f.Seek(size-8, io.SeekCurrent); err != nil {
+				return 0, 0, err
+			}
+
+			continue
+		} else if size > avifMaxMetaSize {
+			return 0, 0, fmt.Errorf("avif meta box too large")
+		}
+
+		data := make([]byte, size-8)
+
+		if _, err = io.ReadFull(f, data); err != nil {
+			return 0, 0, err
+		}
+
+		// The "meta" box has a 4-byte version and flags field.
+		if len(data) < 4 {
+			return 0, 0, fmt.Errorf("invalid avif meta box")
+		}
+
+		ipco := avifBox(avifBox(data[4:], "iprp"), "ipco")
+
+		if ispe := avifBox(ipco, "ispe"); len(ispe) < 12 {
+			return 0, 0, fmt.Errorf("found no avif image size")
+		} else {
+			return int(binary.BigEndian.Uint32(ispe[4:8])), int(binary.BigEndian.Uint32(ispe[8:12])), nil
+		}
+	}
+}
+
+// avifBox returns the content of the first child box with the specified type, if any.
+func avifBox(data []byte, boxType string) []byte {
+	for len(data) >= 8 {
+		size := int(binary.BigEndian.Uint32(data[0:4]))
+
+		if size < 8 || size > len(data) {
+			return nil
+		} else if string(data[4:8]) == boxType {
+			return data[8:size]
+		}
+
+		data = data[size:]
+	}
+
+	return nil
+}
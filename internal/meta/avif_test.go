@@ -0,0 +1,40 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAvifSize(t *testing.T) {
+	t.Run("fox.avif", func(t *testing.T) {
+		width, height, err := AvifSize("testdata/fox.avif")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1204, width)
+		assert.Equal(t, 800, height)
+	})
+	t.Run("iphone_7.heic", func(t *testing.T) {
+		width, height, err := AvifSize("testdata/iphone_7.heic")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Greater(t, width, 0)
+		assert.Greater(t, height, 0)
+	})
+	t.Run("NotAvif", func(t *testing.T) {
+		_, _, err := AvifSize("testdata/example.gif")
+
+		assert.Error(t, err)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, _, err := AvifSize("testdata/xxx.avif")
+
+		assert.Error(t, err)
+	})
+}
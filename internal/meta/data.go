@@ -65,6 +65,7 @@ type Data struct {
 	Views         int           `meta:"-"`
 	Albums        []string      `meta:"-"`
 	Error         error         `meta:"-"`
+	Warning       error         `meta:"-"`
 	json          map[string]string
 	exif          map[string]string
 }
@@ -72,6 +72,19 @@ func (data *Data) Exif(fileName string, fileFormat fs.Type, bruteForce bool) (er
 	opt := exif.ScanOptions{}
 	entries, _, err := exif.GetFlatExifData(rawExif, &opt)
 
+	// Read as many tags as possible if the Exif block is partially corrupt.
+	if err != nil {
+		log.Debugf("metadata: %s in %s (exif flat data)", err, logName)
+
+		if tags, tagErr := ExifTagsTolerant(rawExif); len(tags) > 0 {
+			log.Warnf("metadata: corrupt exif data in %s, using %d readable tags", logName, len(tags))
+			data.Warning = fmt.Errorf("corrupt exif data (%s)", err)
+			entries = tags
+		} else if tagErr != nil {
+			log.Debugf("metadata: %s in %s (tolerant exif)", tagErr, logName)
+		}
+	}
+
 	// Create large enough map for values.
 	if data.exif == nil {
 		data.exif = make(map[string]string, len(entries))
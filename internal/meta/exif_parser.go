@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/dsoprea/go-exif/v3"
+	exifcommon "github.com/dsoprea/go-exif/v3/common"
 	heicexif "github.com/dsoprea/go-heic-exif-extractor/v2"
 	jpegstructure "github.com/dsoprea/go-jpeg-image-structure/v2"
 	pngstructure "github.com/dsoprea/go-png-image-structure/v2"
@@ -93,6 +94,18 @@ func RawExif(fileName string, fileFormat fs.Type, bruteForce bool) (rawExif []by
 				parsed = true
 			}
 		}
+	case fs.ImageWebP:
+		rawExif, err = WebpExif(fileName)
+
+		if err != nil {
+			if err.Error() == "found no exif header" {
+				return rawExif, err
+			} else {
+				log.Infof("metadata: %s in %s (parse webp)", err, logName)
+			}
+		} else {
+			parsed = true
+		}
 	case fs.ImageTIFF:
 		tiffMp := tiffstructure.NewTiffMediaParser()
 
@@ -129,3 +142,67 @@ func RawExif(fileName string, fileFormat fs.Type, bruteForce bool) (rawExif []by
 
 	return rawExif, nil
 }
+
+// ExifTagsTolerant returns the tags in a raw Exif block that can be read, skipping tags with invalid values,
+// so that the metadata of files with a partially corrupt Exif block can still be indexed.
+func ExifTagsTolerant(rawExif []byte) (tags []exif.ExifTag, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("%s (tolerant exif panic)", e)
+		}
+	}()
+
+	eh, err := exif.ParseExifHeader(rawExif)
+
+	if err != nil {
+		return tags, err
+	}
+
+	im, err := exifcommon.NewIfdMappingWithStandard()
+
+	if err != nil {
+		return tags, err
+	}
+
+	ie := exif.NewIfdEnumerate(im, exif.NewTagIndex(), exif.NewExifReadSeekerWithBytes(rawExif), eh.ByteOrder)
+
+	visitor := func(ite *exif.IfdTagEntry) (err error) {
+		// Skip tags that cannot be read.
+		defer func() {
+			if e := recover(); e != nil {
+				err = nil
+			}
+		}()
+
+		value, err := ite.Value()
+
+		if err != nil {
+			return nil
+		}
+
+		formatted, err := ite.FormatFirst()
+
+		if err != nil {
+			return nil
+		}
+
+		tags = append(tags, exif.ExifTag{
+			IfdPath:        ite.IfdPath(),
+			TagId:          ite.TagId(),
+			TagName:        ite.TagName(),
+			UnitCount:      ite.UnitCount(),
+			TagTypeId:      ite.TagType(),
+			TagTypeName:    ite.TagType().String(),
+			Value:          value,
+			ChildIfdPath:   ite.ChildIfdPath(),
+			FormattedFirst: formatted,
+		})
+
+		return nil
+	}
+
+	// Tags read before a corrupt IFD was found are returned along with the error.
+	_, err = ie.Scan(exifcommon.IfdStandardIfdIdentity, eh.FirstIfdOffset, visitor, nil)
+
+	return tags, err
+}
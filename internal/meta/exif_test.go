@@ -280,6 +280,33 @@ func TestExif(t *testing.T) {
 		assert.Equal(t, "", data.LensModel)
 	})
 
+	t.Run("corrupt-exif.jpg", func(t *testing.T) {
+		// Same as ladybug.jpg, except that the offset of the camera model value points outside the Exif block.
+		data, err := Exif("testdata/corrupt-exif.jpg", fs.ImageJPEG, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Error(t, data.Warning)
+		assert.Equal(t, "Photographer: TMB", data.Artist)
+		assert.Equal(t, "2011-07-10T17:34:28Z", data.TakenAt.Format("2006-01-02T15:04:05Z"))
+		assert.Equal(t, float32(51.254852), data.Lat)
+		assert.Equal(t, float32(7.389468), data.Lng)
+		assert.Equal(t, "Canon", data.CameraMake)
+		assert.Equal(t, "", data.CameraModel)
+	})
+
+	t.Run("ladybug.jpg/NoWarning", func(t *testing.T) {
+		data, err := Exif("testdata/ladybug.jpg", fs.ImageJPEG, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, data.Warning)
+	})
+
 	t.Run("no-exif-data.jpg", func(t *testing.T) {
 		_, err := Exif("testdata/no-exif-data.jpg", fs.ImageJPEG, false)
 
@@ -557,6 +584,7 @@ func TestExif(t *testing.T) {
 		assert.Equal(t, "", data.Title)
 		assert.Equal(t, "", data.Artist)
 		assert.Equal(t, Keywords{"flash"}, data.Keywords)
+		assert.True(t, data.Flash)
 		assert.Equal(t, "", data.Description)
 		assert.Equal(t, "", data.Copyright)
 		assert.Equal(t, "Canon", data.CameraMake)
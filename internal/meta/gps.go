@@ -26,8 +26,8 @@ func GpsToLatLng(s string) (lat, lng float64) {
 		return 0, 0
 	}
 
-	// Floating point numbers?
-	if fl := GpsFloatRegexp.FindAllString(s, -1); len(fl) == 2 {
+	// Floating point numbers, optionally followed by the altitude?
+	if fl := GpsFloatRegexp.FindAllString(s, -1); len(fl) == 2 || len(fl) == 3 {
 		if lat, err := strconv.ParseFloat(fl[0], 64); err != nil {
 			log.Infof("metadata: %s is not a valid gps position", clean.Log(fl[0]))
 		} else if lng, err := strconv.ParseFloat(fl[1], 64); err == nil {
@@ -39,7 +39,8 @@ func GpsToLatLng(s string) (lat, lng float64) {
 	co := GpsCoordsRegexp.FindAllString(s, -1)
 	re := GpsRefRegexp.FindAllString(s, -1)
 
-	if len(co) != 6 || len(re) != 2 {
+	// Ignore additional values, e.g. "34.121 m Above Sea Level".
+	if len(co) < 6 || len(re) < 2 {
 		return 0, 0
 	}
 
@@ -29,6 +29,21 @@ func TestGpsToLatLng(t *testing.T) {
 		assert.InEpsilon(t, lng, expLng, 0.1)
 	})
 
+	t.Run("with altitude", func(t *testing.T) {
+		lat, lng := GpsToLatLng("52 deg 27' 31.32\" N, 13 deg 27' 33.48\" E, 34.121 m Above Sea Level")
+		expLat, expLng := 52.458700, 13.459300
+
+		assert.InEpsilon(t, expLat, lat, 0.0001)
+		assert.InEpsilon(t, expLng, lng, 0.0001)
+	})
+
+	t.Run("numbers with altitude", func(t *testing.T) {
+		lat, lng := GpsToLatLng("52.4587 13.4593 34.121")
+
+		assert.Equal(t, 52.4587, lat)
+		assert.Equal(t, 13.4593, lng)
+	})
+
 	t.Run("empty string", func(t *testing.T) {
 		lat, lng := GpsToLatLng("")
 		assert.Equal(t, float64(0), lat)
@@ -0,0 +1,99 @@
+package meta
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ISO6709Regexp matches a location in ISO 6709 string format, e.g. "+37.3317-122.0307+010.000/"
+// as stored in the udta and mdta metadata atoms of videos recorded with Apple and Android devices.
+var ISO6709Regexp = regexp.MustCompile(`^([+\-]\d{2,6}(?:\.\d*)?)([+\-]\d{3,7}(?:\.\d*)?)([+\-]\d+(?:\.\d*)?)?(?:CRS[A-Za-z0-9_:]*)?/?$`)
+
+// ParseISO6709 returns the latitude, longitude, and altitude of a location in ISO 6709 string format.
+// Decimal degrees ("±DD.DDDD±DDD.DDDD"), degrees and minutes ("±DDMM.MM±DDDMM.MM"), and degrees,
+// minutes, and seconds ("±DDMMSS.SS±DDDMMSS.SS") are supported, each optionally followed by the altitude.
+func ParseISO6709(s string) (lat, lng, alt float64, err error) {
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return 0, 0, 0, fmt.Errorf("empty iso6709 location")
+	}
+
+	m := ISO6709Regexp.FindStringSubmatch(s)
+
+	if m == nil {
+		return 0, 0, 0, fmt.Errorf("invalid iso6709 location")
+	}
+
+	if lat, err = parseISO6709Degrees(m[1], 2); err != nil {
+		return 0, 0, 0, err
+	} else if lng, err = parseISO6709Degrees(m[2], 3); err != nil {
+		return 0, 0, 0, err
+	}
+
+	if lat < -LatMax || lat > LatMax || lng < -LngMax || lng > LngMax {
+		return 0, 0, 0, fmt.Errorf("iso6709 location out of range")
+	}
+
+	if m[3] != "" {
+		if alt, err = strconv.ParseFloat(m[3], 64); err != nil {
+			return 0, 0, 0, err
+		}
+	}
+
+	return lat, lng, alt, nil
+}
+
+// parseISO6709Degrees converts a signed ISO 6709 coordinate into decimal degrees,
+// where digits is the number of integer digits used for the degrees.
+func parseISO6709Degrees(s string, digits int) (float64, error) {
+	sign := 1.0
+
+	if s[0] == '-' {
+		sign = -1.0
+	}
+
+	s = s[1:]
+
+	// Number of integer digits determines the format.
+	intLen := strings.IndexByte(s, '.')
+
+	if intLen < 0 {
+		intLen = len(s)
+	}
+
+	var deg, min, sec float64
+	var err error
+
+	switch intLen {
+	case digits:
+		// Decimal degrees.
+		deg, err = strconv.ParseFloat(s, 64)
+	case digits + 2:
+		// Degrees and decimal minutes.
+		if deg, err = strconv.ParseFloat(s[:digits], 64); err == nil {
+			min, err = strconv.ParseFloat(s[digits:], 64)
+		}
+	case digits + 4:
+		// Degrees, minutes, and decimal seconds.
+		if deg, err = strconv.ParseFloat(s[:digits], 64); err != nil {
+			break
+		} else if min, err = strconv.ParseFloat(s[digits:digits+2], 64); err != nil {
+			break
+		}
+
+		sec, err = strconv.ParseFloat(s[digits+2:], 64)
+	default:
+		return 0, fmt.Errorf("invalid iso6709 coordinate")
+	}
+
+	if err != nil {
+		return 0, err
+	} else if min >= 60 || sec >= 60 {
+		return 0, fmt.Errorf("invalid iso6709 coordinate")
+	}
+
+	return sign * (deg + min/60 + sec/3600), nil
+}
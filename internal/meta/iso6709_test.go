@@ -0,0 +1,68 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseISO6709(t *testing.T) {
+	t.Run("Apple", func(t *testing.T) {
+		lat, lng, alt, err := ParseISO6709("+37.3317-122.0307+010.000/")
+
+		assert.NoError(t, err)
+		assert.InDelta(t, 37.3317, lat, 0.00001)
+		assert.InDelta(t, -122.0307, lng, 0.00001)
+		assert.InDelta(t, 10.0, alt, 0.00001)
+	})
+	t.Run("Android", func(t *testing.T) {
+		lat, lng, alt, err := ParseISO6709("+52.4587+013.4593/")
+
+		assert.NoError(t, err)
+		assert.InDelta(t, 52.4587, lat, 0.00001)
+		assert.InDelta(t, 13.4593, lng, 0.00001)
+		assert.Equal(t, 0.0, alt)
+	})
+	t.Run("NoSlash", func(t *testing.T) {
+		lat, lng, _, err := ParseISO6709("-33.8688+151.2093")
+
+		assert.NoError(t, err)
+		assert.InDelta(t, -33.8688, lat, 0.00001)
+		assert.InDelta(t, 151.2093, lng, 0.00001)
+	})
+	t.Run("DegreesMinutes", func(t *testing.T) {
+		lat, lng, _, err := ParseISO6709("+4027.5-07400.0/")
+
+		assert.NoError(t, err)
+		assert.InDelta(t, 40.45833, lat, 0.00001)
+		assert.InDelta(t, -74.0, lng, 0.00001)
+	})
+	t.Run("DegreesMinutesSeconds", func(t *testing.T) {
+		lat, lng, alt, err := ParseISO6709("+402800-0740000+2.5CRSWGS_84/")
+
+		assert.NoError(t, err)
+		assert.InDelta(t, 40.46667, lat, 0.00001)
+		assert.InDelta(t, -74.0, lng, 0.00001)
+		assert.InDelta(t, 2.5, alt, 0.00001)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, _, _, err := ParseISO6709("")
+
+		assert.Error(t, err)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, _, _, err := ParseISO6709("52.4587 13.4593")
+
+		assert.Error(t, err)
+	})
+	t.Run("OutOfRange", func(t *testing.T) {
+		_, _, _, err := ParseISO6709("+95.0000+013.4593/")
+
+		assert.Error(t, err)
+	})
+	t.Run("InvalidMinutes", func(t *testing.T) {
+		_, _, _, err := ParseISO6709("+4075.5-07400.0/")
+
+		assert.Error(t, err)
+	})
+}
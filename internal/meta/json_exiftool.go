@@ -178,6 +178,23 @@ func (data *Data) Exiftool(jsonData []byte, originalName string) (err error) {
 			data.Lat, data.Lng = NormalizeGPS(lat, lng)
 		} else if data.GPSLatitude != "" && data.GPSLongitude != "" {
 			data.Lat, data.Lng = NormalizeGPS(GpsToDecimal(data.GPSLatitude), GpsToDecimal(data.GPSLongitude))
+		} else if s := data.json["GPSCoordinates"]; s != "" {
+			// Videos recorded with Apple and Android devices store the location in QuickTime atoms.
+			if lat, lng, alt, err := ParseISO6709(s); err == nil {
+				data.Lat, data.Lng = NormalizeGPS(lat, lng)
+
+				if data.Altitude == 0 {
+					data.Altitude = alt
+				}
+			} else {
+				lat, lng := GpsToLatLng(s)
+				data.Lat, data.Lng = NormalizeGPS(lat, lng)
+
+				// Numeric values may include the altitude, e.g. "52.4587 13.4593 34.121".
+				if fl := GpsFloatRegexp.FindAllString(s, -1); len(fl) == 3 && data.Altitude == 0 {
+					data.Altitude, _ = strconv.ParseFloat(fl[2], 64)
+				}
+			}
 		}
 	}
 
@@ -1046,6 +1046,31 @@ func TestJSON(t *testing.T) {
 		assert.Equal(t, 1, data.Orientation)
 	})
 
+	t.Run("pxl-mp4-location.json", func(t *testing.T) {
+		data, err := JSON("testdata/pxl-mp4-location.json", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "2021-07-12T22:56:37Z", data.TakenAt.Format("2006-01-02T15:04:05Z"))
+		assert.InEpsilon(t, 52.4587, data.Lat, 0.00001)
+		assert.InEpsilon(t, 13.4593, data.Lng, 0.00001)
+		assert.Equal(t, 34.121, data.Altitude)
+	})
+
+	t.Run("pxl-mp4-iso6709.json", func(t *testing.T) {
+		data, err := JSON("testdata/pxl-mp4-iso6709.json", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.InEpsilon(t, 37.3317, data.Lat, 0.00001)
+		assert.InEpsilon(t, -122.0307, data.Lng, 0.00001)
+		assert.Equal(t, 10.0, data.Altitude)
+	})
+
 	t.Run("sony_mp4_exiftool.json", func(t *testing.T) {
 		data, err := JSON("testdata/sony_mp4_exiftool.json", "")
 
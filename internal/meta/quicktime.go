@@ -0,0 +1,242 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// quickTimeMaxMoovSize limits the size of the "moov" atom that is read into memory.
+const quickTimeMaxMoovSize = 64 << 20
+
+// quickTimeLocationKey is the mdta metadata key used by Apple devices to store the location.
+const quickTimeLocationKey = "com.apple.quicktime.location.ISO6709"
+
+// quickTimeXyz is the user data atom type used by Android and Apple devices to store the location.
+var quickTimeXyz = string([]byte{0xA9, 'x', 'y', 'z'})
+
+// QuickTimeLocation returns the location stored in the metadata atoms of a QuickTime or MP4 video,
+// as ISO 6709 string, without requiring external tools like ExifTool.
+func QuickTimeLocation(fileName string) (location string, err error) {
+	moov, err := quickTimeMoov(fileName)
+
+	if err != nil {
+		return "", err
+	}
+
+	// Apple devices store the location in the mdta metadata atom.
+	if location = quickTimeMetaValue(quickTimeBox(moov, "meta"), quickTimeLocationKey); location != "" {
+		return location, nil
+	}
+
+	udta := quickTimeBox(moov, "udta")
+
+	// Android and older Apple devices store the location in the ©xyz user data atom.
+	if xyz := quickTimeBox(udta, quickTimeXyz); len(xyz) > 4 {
+		size := int(binary.BigEndian.Uint16(xyz[0:2]))
+
+		if size > 0 && 4+size <= len(xyz) {
+			return strings.TrimSpace(string(xyz[4 : 4+size])), nil
+		}
+
+		return strings.TrimSpace(string(xyz[4:])), nil
+	}
+
+	if location = quickTimeMetaValue(quickTimeBox(udta, "meta"), quickTimeLocationKey); location != "" {
+		return location, nil
+	}
+
+	return "", fmt.Errorf("found no location")
+}
+
+// QuickTime sets the GPS position and altitude stored in the metadata atoms of a QuickTime or MP4 video
+// if they are not already known.
+func (data *Data) QuickTime(fileName string) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("metadata: %s in %s (quicktime panic)", e, clean.Log(filepath.Base(fileName)))
+		}
+	}()
+
+	location, err := QuickTimeLocation(fileName)
+
+	if err != nil {
+		return fmt.Errorf("metadata: %s in %s (quicktime)", err, clean.Log(filepath.Base(fileName)))
+	}
+
+	lat, lng, alt, err := ParseISO6709(location)
+
+	if err != nil {
+		return fmt.Errorf("metadata: %s in %s (quicktime)", err, clean.Log(filepath.Base(fileName)))
+	}
+
+	if data.Lat == 0 && data.Lng == 0 {
+		data.Lat, data.Lng = NormalizeGPS(lat, lng)
+	}
+
+	if data.Altitude == 0 {
+		data.Altitude = alt
+	}
+
+	return nil
+}
+
+// quickTimeMoov returns the content of the top-level "moov" atom.
+func quickTimeMoov(fileName string) ([]byte, error) {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	header := make([]byte, 8)
+
+	for {
+		if _, err = io.ReadFull(f, header); err != nil {
+			return nil, fmt.Errorf("found no moov atom")
+		}
+
+		size := int64(binary.BigEndian.Uint32(header[0:4]))
+		headerSize := int64(8)
+
+		switch size {
+		case 0:
+			// Atom extends to the end of the file.
+			if string(header[4:8]) != "moov" {
+				return nil, fmt.Errorf("found no moov atom")
+			}
+
+			return quickTimeReadAll(f, quickTimeMaxMoovSize)
+		case 1:
+			// Atom has a 64-bit extended size.
+			ext := make([]byte, 8)
+
+			if _, err = io.ReadFull(f, ext); err != nil {
+				return nil, err
+			}
+
+			size = int64(binary.BigEndian.Uint64(ext))
+			headerSize = 16
+		}
+
+		if size < headerSize {
+			return nil, fmt.Errorf("unsupported atom size")
+		} else if string(header[4:8]) != "moov" {
+			if _, err = f.Seek(size-headerSize, io.SeekCurrent); err != nil {
+				return nil, err
+			}
+
+			continue
+		} else if size-headerSize > quickTimeMaxMoovSize {
+			return nil, fmt.Errorf("moov atom too large")
+		}
+
+		data := make([]byte, size-headerSize)
+
+		if _, err = io.ReadFull(f, data); err != nil {
+			return nil, err
+		}
+
+		return data, nil
+	}
+}
+
+// quickTimeReadAll reads the remaining content of a file up to the specified limit.
+func quickTimeReadAll(r io.Reader, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+
+	if err != nil {
+		return nil, err
+	} else if int64(len(data)) > limit {
+		return nil, fmt.Errorf("moov atom too large")
+	}
+
+	return data, nil
+}
+
+// quickTimeBox returns the content of the first child atom with the specified type, if any.
+func quickTimeBox(data []byte, boxType string) []byte {
+	for len(data) >= 8 {
+		size := int(binary.BigEndian.Uint32(data[0:4]))
+
+		if size < 8 || size > len(data) {
+			return nil
+		} else if string(data[4:8]) == boxType {
+			return data[8:size]
+		}
+
+		data = data[size:]
+	}
+
+	return nil
+}
+
+// quickTimeMetaValue returns the string value of an mdta metadata key, if any.
+func quickTimeMetaValue(meta []byte, key string) string {
+	if len(meta) < 8 {
+		return ""
+	}
+
+	// The ISO base media "meta" box has a 4-byte version and flags field, unlike the QuickTime atom.
+	if bytes.Equal(meta[0:4], []byte{0, 0, 0, 0}) {
+		meta = meta[4:]
+	}
+
+	keys := quickTimeBox(meta, "keys")
+
+	if len(keys) < 8 {
+		return ""
+	}
+
+	index := uint32(0)
+	count := binary.BigEndian.Uint32(keys[4:8])
+	entries := keys[8:]
+
+	// Find the 1-based index of the key.
+	for i := uint32(1); i <= count && len(entries) >= 8; i++ {
+		size := int(binary.BigEndian.Uint32(entries[0:4]))
+
+		if size < 8 || size > len(entries) {
+			return ""
+		} else if string(entries[8:size]) == key {
+			index = i
+			break
+		}
+
+		entries = entries[size:]
+	}
+
+	if index == 0 {
+		return ""
+	}
+
+	// Item atoms in the "ilst" atom use the key index as type.
+	ilst := quickTimeBox(meta, "ilst")
+
+	for len(ilst) >= 8 {
+		size := int(binary.BigEndian.Uint32(ilst[0:4]))
+
+		if size < 8 || size > len(ilst) {
+			return ""
+		} else if binary.BigEndian.Uint32(ilst[4:8]) == index {
+			// The "data" atom has a 4-byte type and a 4-byte locale field.
+			if value := quickTimeBox(ilst[8:size], "data"); len(value) > 8 {
+				return strings.TrimSpace(string(value[8:]))
+			}
+
+			return ""
+		}
+
+		ilst = ilst[size:]
+	}
+
+	return ""
+}
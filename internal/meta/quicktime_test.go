@@ -0,0 +1,72 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuickTimeLocation(t *testing.T) {
+	t.Run("Android", func(t *testing.T) {
+		location, err := QuickTimeLocation("testdata/location-android.mp4")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "+52.4587+013.4593/", location)
+	})
+	t.Run("iPhone", func(t *testing.T) {
+		location, err := QuickTimeLocation("testdata/location-iphone.mov")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "+37.3317-122.0307+010.000/", location)
+	})
+	t.Run("NoLocation", func(t *testing.T) {
+		location, err := QuickTimeLocation("../../assets/examples/gopher-video.mp4")
+
+		assert.Error(t, err)
+		assert.Equal(t, "", location)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := QuickTimeLocation("testdata/notfound.mp4")
+
+		assert.Error(t, err)
+	})
+	t.Run("NotVideo", func(t *testing.T) {
+		_, err := QuickTimeLocation("testdata/iphone_7.json")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestData_QuickTime(t *testing.T) {
+	t.Run("Android", func(t *testing.T) {
+		data := Data{}
+
+		assert.NoError(t, data.QuickTime("testdata/location-android.mp4"))
+		assert.InDelta(t, 52.4587, data.Lat, 0.0001)
+		assert.InDelta(t, 13.4593, data.Lng, 0.0001)
+		assert.Equal(t, 0.0, data.Altitude)
+	})
+	t.Run("iPhone", func(t *testing.T) {
+		data := Data{}
+
+		assert.NoError(t, data.QuickTime("testdata/location-iphone.mov"))
+		assert.InDelta(t, 37.3317, data.Lat, 0.0001)
+		assert.InDelta(t, -122.0307, data.Lng, 0.0001)
+		assert.Equal(t, 10.0, data.Altitude)
+	})
+	t.Run("KeepExisting", func(t *testing.T) {
+		data := Data{Lat: 48.1, Lng: 11.5, Altitude: 520}
+
+		assert.NoError(t, data.QuickTime("testdata/location-iphone.mov"))
+		assert.Equal(t, float32(48.1), data.Lat)
+		assert.Equal(t, float32(11.5), data.Lng)
+		assert.Equal(t, 520.0, data.Altitude)
+	})
+	t.Run("NoLocation", func(t *testing.T) {
+		data := Data{}
+
+		assert.Error(t, data.QuickTime("../../assets/examples/gopher-video.mp4"))
+		assert.Equal(t, float32(0), data.Lat)
+		assert.Equal(t, float32(0), data.Lng)
+	})
+}
@@ -0,0 +1,62 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+// webpExifPrefix is written by some encoders before the TIFF header, although the spec does not require it.
+var webpExifPrefix = []byte("Exif\x00\x00")
+
+// WebpExif returns the raw Exif data embedded in the "EXIF" chunk of an extended WebP image file.
+func WebpExif(fileName string) (rawExif []byte, err error) {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return rawExif, err
+	}
+
+	defer f.Close()
+
+	header := make([]byte, 12)
+
+	if _, err = io.ReadFull(f, header); err != nil {
+		return rawExif, err
+	} else if string(header[0:4]) != "RIFF" || string(header[8:12]) != "WEBP" {
+		return rawExif, fmt.Errorf("invalid webp header")
+	}
+
+	chunk := make([]byte, 8)
+
+	for {
+		if _, err = io.ReadFull(f, chunk); err == io.EOF || err == io.ErrUnexpectedEOF {
+			return rawExif, fmt.Errorf("found no exif header")
+		} else if err != nil {
+			return rawExif, err
+		}
+
+		size := int64(binary.LittleEndian.Uint32(chunk[4:8]))
+
+		// Chunks are padded to an even size.
+		padded := size + size&1
+
+		if string(chunk[0:4]) != "EXIF" {
+			if _, err = f.Seek(padded, io.SeekCurrent); err != nil {
+				return rawExif, err
+			}
+
+			continue
+		}
+
+		rawExif = make([]byte, size)
+
+		if _, err = io.ReadFull(f, rawExif); err != nil {
+			return nil, err
+		}
+
+		return bytes.TrimPrefix(rawExif, webpExifPrefix), nil
+	}
+}
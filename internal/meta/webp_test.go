@@ -0,0 +1,47 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestWebpExif(t *testing.T) {
+	t.Run("yellow_rose.webp", func(t *testing.T) {
+		rawExif, err := WebpExif("testdata/yellow_rose.webp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "MM\x00*", string(rawExif[0:4]))
+	})
+	t.Run("NoExif", func(t *testing.T) {
+		_, err := WebpExif("testdata/example.gif")
+
+		assert.Error(t, err)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := WebpExif("testdata/xxx.webp")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestExif_WebP(t *testing.T) {
+	data, err := Exif("testdata/yellow_rose.webp", fs.ImageWebP, false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Apple", data.CameraMake)
+	assert.Equal(t, "iPhone SE", data.CameraModel)
+	assert.Equal(t, "2019-06-09T10:57:32Z", data.TakenAt.Format("2006-01-02T15:04:05Z"))
+	assert.Equal(t, "Europe/Berlin", data.TimeZone)
+	assert.InEpsilon(t, 50.047745, data.Lat, 0.00001)
+	assert.InEpsilon(t, 8.572355, data.Lng, 0.00001)
+	assert.Equal(t, 1, data.Orientation)
+}
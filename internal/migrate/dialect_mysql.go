@@ -165,4 +165,16 @@ var DialectMySQL = Migrations{
 		Stage:      "main",
 		Statements: []string{"UPDATE auth_users SET user_role = 'contributor' WHERE user_role = 'uploader';", "UPDATE auth_sessions SET auth_provider = 'link' WHERE auth_provider = 'token';"},
 	},
+	{
+		ID:         "20261014-000001",
+		Dialect:    "mysql",
+		Stage:      "main",
+		Statements: []string{"CREATE FULLTEXT INDEX ftx_photos_title_description ON photos (photo_title, photo_description);", "CREATE FULLTEXT INDEX ftx_details_notes_keywords ON details (notes, keywords);"},
+	},
+	{
+		ID:         "20261015-000001",
+		Dialect:    "mysql",
+		Stage:      "main",
+		Statements: []string{"UPDATE photos SET checked_at = NULL WHERE deleted_at IS NULL;"},
+	},
 }
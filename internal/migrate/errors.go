@@ -22,10 +22,11 @@ func (m QueryErr) Matches(query, err string) bool {
 }
 
 var IgnoreErr = QueryErr{
-	"rename":       {"no such", "already exists"},
-	"replace":      {"no such", "exist", "exists"},
-	" ignore ":     {"no such", "exist", "exists"},
-	"drop index ":  {"drop"},
-	"drop table ":  {"drop"},
-	"alter table ": {"duplicate"},
+	"rename":                 {"no such", "already exists"},
+	"replace":                {"no such", "exist", "exists"},
+	" ignore ":               {"no such", "exist", "exists"},
+	"drop index ":            {"drop"},
+	"drop table ":            {"drop"},
+	"alter table ":           {"duplicate"},
+	"create fulltext index ": {"duplicate"},
 }
@@ -0,0 +1,21 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryErr_Matches(t *testing.T) {
+	t.Run("DuplicateFulltextIndex", func(t *testing.T) {
+		query := "CREATE FULLTEXT INDEX ftx_photos_title_description ON photos (photo_title, photo_description);"
+		assert.True(t, IgnoreErr.Matches(query, "Error 1061: Duplicate key name 'ftx_photos_title_description'"))
+		assert.False(t, IgnoreErr.Matches(query, "Error 1214: The used table type doesn't support FULLTEXT indexes"))
+	})
+	t.Run("DuplicateColumn", func(t *testing.T) {
+		assert.True(t, IgnoreErr.Matches("ALTER TABLE files ADD media_id VARBINARY(32);", "Error 1060: Duplicate column name 'media_id'"))
+	})
+	t.Run("Other", func(t *testing.T) {
+		assert.False(t, IgnoreErr.Matches("UPDATE files SET media_id = NULL;", "Error 1146: Table 'files' doesn't exist"))
+	})
+}
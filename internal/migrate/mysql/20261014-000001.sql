@@ -0,0 +1,2 @@
+CREATE FULLTEXT INDEX ftx_photos_title_description ON photos (photo_title, photo_description);
+CREATE FULLTEXT INDEX ftx_details_notes_keywords ON details (notes, keywords);
@@ -0,0 +1 @@
+UPDATE photos SET checked_at = NULL WHERE deleted_at IS NULL;
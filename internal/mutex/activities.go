@@ -2,12 +2,14 @@ package mutex
 
 // Activities that can be started and stopped.
 var (
-	MainWorker   = Activity{}
-	SyncWorker   = Activity{}
-	ShareWorker  = Activity{}
-	MetaWorker   = Activity{}
-	FacesWorker  = Activity{}
-	UpdatePeople = Activity{}
+	MainWorker    = Activity{}
+	SyncWorker    = Activity{}
+	ShareWorker   = Activity{}
+	MetaWorker    = Activity{}
+	FacesWorker   = Activity{}
+	UpdatePeople  = Activity{}
+	SidecarWorker = Activity{}
+	TrashWorker   = Activity{}
 )
 
 // CancelAll requests to stop all activities.
@@ -18,6 +20,8 @@ func CancelAll() {
 	ShareWorker.Cancel()
 	MetaWorker.Cancel()
 	FacesWorker.Cancel()
+	SidecarWorker.Cancel()
+	TrashWorker.Cancel()
 }
 
 // IndexWorkersRunning checks if a worker is currently running.
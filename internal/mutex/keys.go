@@ -0,0 +1,58 @@
+package mutex
+
+import (
+	"sync"
+)
+
+// Keys provides mutual exclusion per key, e.g. for requests that modify the same file.
+type Keys struct {
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+// keyLock represents the lock of a single key and the number of goroutines holding or waiting for it.
+type keyLock struct {
+	sync.Mutex
+	refs int
+}
+
+// Lock locks the specified key and returns a function that must be called to unlock it.
+func (k *Keys) Lock(key string) (unlock func()) {
+	k.mu.Lock()
+
+	if k.locks == nil {
+		k.locks = make(map[string]*keyLock)
+	}
+
+	l, ok := k.locks[key]
+
+	if !ok {
+		l = &keyLock{}
+		k.locks[key] = l
+	}
+
+	l.refs++
+	k.mu.Unlock()
+
+	l.Lock()
+
+	return func() {
+		l.Unlock()
+
+		k.mu.Lock()
+		defer k.mu.Unlock()
+
+		// Remove the lock if it is no longer used.
+		if l.refs--; l.refs == 0 {
+			delete(k.locks, key)
+		}
+	}
+}
+
+// Len returns the number of keys that are currently locked or waited for.
+func (k *Keys) Len() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return len(k.locks)
+}
@@ -0,0 +1,48 @@
+package mutex
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeys_Lock(t *testing.T) {
+	t.Run("Concurrent", func(t *testing.T) {
+		var k Keys
+		var wg sync.WaitGroup
+
+		counter := 0
+
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				unlock := k.Lock("foo")
+				defer unlock()
+
+				counter++
+			}()
+		}
+
+		wg.Wait()
+
+		assert.Equal(t, 50, counter)
+		assert.Equal(t, 0, k.Len())
+	})
+	t.Run("Keys", func(t *testing.T) {
+		var k Keys
+
+		unlockFoo := k.Lock("foo")
+		unlockBar := k.Lock("bar")
+
+		assert.Equal(t, 2, k.Len())
+
+		unlockFoo()
+		unlockBar()
+
+		assert.Equal(t, 0, k.Len())
+	})
+}
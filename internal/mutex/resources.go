@@ -6,6 +6,7 @@ import (
 
 // Shared resources.
 var (
-	Db    = sync.Mutex{}
-	Index = sync.Mutex{}
+	Db          = sync.Mutex{}
+	Index       = sync.Mutex{}
+	UploadFiles = Keys{}
 )
@@ -60,6 +60,11 @@ func (c *Convert) ToImage(f *MediaFile, force bool) (*MediaFile, error) {
 			return nil, fmt.Errorf("convert: vector graphics support disabled (%s)", clean.Log(f.RootRelName()))
 		}
 		imageName = fs.FileName(f.FileName(), c.conf.SidecarPath(), c.conf.OriginalsPath(), fs.ExtPNG)
+	} else if f.IsDocument() {
+		if !c.conf.PdfEnabled() {
+			return nil, fmt.Errorf("convert: pdf support disabled (%s)", clean.Log(f.RootRelName()))
+		}
+		imageName = fs.FileName(f.FileName(), c.conf.SidecarPath(), c.conf.OriginalsPath(), fs.ExtJPEG)
 	} else {
 		imageName = fs.FileName(f.FileName(), c.conf.SidecarPath(), c.conf.OriginalsPath(), fs.ExtJPEG)
 	}
@@ -5,8 +5,10 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/photoprism/photoprism/internal/ffmpeg"
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 // JpegConvertCommands returns commands for converting a media file to JPEG, if possible.
@@ -29,7 +31,8 @@ func (c *Convert) JpegConvertCommands(f *MediaFile, jpegName string, xmpName str
 	// Extract a still image to be used as preview.
 	if f.IsAnimated() && !f.IsWebP() && c.conf.FFmpegEnabled() {
 		// Use "ffmpeg" to extract a JPEG still image from the video.
-		result = append(result, exec.Command(c.conf.FFmpegBin(), "-y", "-i", f.FileName(), "-ss", ffmpeg.PreviewTimeOffset(f.Duration()), "-vframes", "1", jpegName))
+		args := ffmpeg.PreviewImageArgs(f.FileName(), jpegName, f.Duration(), c.conf.FFmpegRotate(), f.MetaData().Rotation)
+		result = append(result, exec.Command(c.conf.FFmpegBin(), args...))
 	}
 
 	// Use heif-convert for HEIC/HEIF and AVIF image files.
@@ -37,6 +40,11 @@ func (c *Convert) JpegConvertCommands(f *MediaFile, jpegName string, xmpName str
 		result = append(result, exec.Command(c.conf.HeifConvertBin(), "-q", c.conf.JpegQuality().String(), f.FileName(), jpegName))
 	}
 
+	// Fall back to ffmpeg for decoding still AVIF images.
+	if f.IsAVIF() && !f.IsAnimated() && c.conf.FFmpegEnabled() {
+		result = append(result, exec.Command(c.conf.FFmpegBin(), "-y", "-i", f.FileName(), "-vframes", "1", jpegName))
+	}
+
 	// RAW files may be concerted with Darktable and RawTherapee.
 	if f.IsRaw() && c.conf.RawEnabled() {
 		if c.conf.DarktableEnabled() && c.darktableBlacklist.Allow(fileExt) {
@@ -88,6 +96,12 @@ func (c *Convert) JpegConvertCommands(f *MediaFile, jpegName string, xmpName str
 		result = append(result, exec.Command(c.conf.ExifToolBin(), "-q", "-q", "-b", "-PreviewImage", f.FileName()))
 	}
 
+	// Render the first page of PDF documents with Poppler, which appends the file extension to the output name.
+	if f.IsPDF() && c.conf.PdfEnabled() {
+		args := []string{"-jpeg", "-jpegopt", "quality=" + c.conf.JpegQuality().String(), "-f", "1", "-l", "1", "-singlefile", "-scale-to", maxSize, f.FileName(), strings.TrimSuffix(jpegName, fs.ExtJPEG)}
+		result = append(result, exec.Command(c.conf.PdfToPpmBin(), args...))
+	}
+
 	// Decode JPEG XL image if support is enabled.
 	if f.IsJpegXL() && c.conf.JpegXLEnabled() {
 		result = append(result, exec.Command(c.conf.JpegXLDecoderBin(), f.FileName(), jpegName))
@@ -95,7 +109,7 @@ func (c *Convert) JpegConvertCommands(f *MediaFile, jpegName string, xmpName str
 
 	// Try ImageMagick for other image file formats if allowed.
 	if c.conf.ImageMagickEnabled() && c.imagemagickBlacklist.Allow(fileExt) &&
-		(f.IsImage() && !f.IsJpegXL() && !f.IsRaw() && !f.IsHEIF() || f.IsVector() && c.conf.VectorEnabled()) {
+		(f.IsImage() && !f.IsJpegXL() && !f.IsRaw() && (!f.IsHEIF() || f.IsAVIF()) || f.IsVector() && c.conf.VectorEnabled()) {
 		quality := fmt.Sprintf("%d", c.conf.JpegQuality())
 		resize := fmt.Sprintf("%dx%d>", c.conf.JpegSize(), c.conf.JpegSize())
 		args := []string{f.FileName(), "-flatten", "-resize", resize, "-quality", quality, jpegName}
@@ -36,6 +36,11 @@ func (c *Convert) PngConvertCommands(f *MediaFile, pngName string) (result []*ex
 		result = append(result, exec.Command(c.conf.HeifConvertBin(), f.FileName(), pngName))
 	}
 
+	// Fall back to ffmpeg for decoding still AVIF images.
+	if f.IsAVIF() && !f.IsAnimated() && c.conf.FFmpegEnabled() {
+		result = append(result, exec.Command(c.conf.FFmpegBin(), "-y", "-i", f.FileName(), "-vframes", "1", pngName))
+	}
+
 	// Decode JPEG XL image if support is enabled.
 	if f.IsJpegXL() && c.conf.JpegXLEnabled() {
 		result = append(result, exec.Command(c.conf.JpegXLDecoderBin(), f.FileName(), pngName))
@@ -43,7 +48,7 @@ func (c *Convert) PngConvertCommands(f *MediaFile, pngName string) (result []*ex
 
 	// Try ImageMagick for other image file formats if allowed.
 	if c.conf.ImageMagickEnabled() && c.imagemagickBlacklist.Allow(fileExt) &&
-		(f.IsImage() && !f.IsJpegXL() && !f.IsRaw() && !f.IsHEIF() || f.IsVector() && c.conf.VectorEnabled()) {
+		(f.IsImage() && !f.IsJpegXL() && !f.IsRaw() && (!f.IsHEIF() || f.IsAVIF()) || f.IsVector() && c.conf.VectorEnabled()) {
 		resize := fmt.Sprintf("%dx%d>", c.conf.PngSize(), c.conf.PngSize())
 		args := []string{f.FileName(), "-flatten", "-resize", resize, pngName}
 		result = append(result, exec.Command(c.conf.ImageMagickBin(), args...))
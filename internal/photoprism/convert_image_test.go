@@ -1,11 +1,15 @@
 package photoprism
 
 import (
+	"image"
+	_ "image/jpeg"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/ffmpeg"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/stretchr/testify/assert"
 )
@@ -154,3 +158,137 @@ func TestConvert_PngConvertCommands(t *testing.T) {
 		t.Logf("commands: %#v", cmds)
 	})
 }
+
+func TestConvert_JpegConvertCommands(t *testing.T) {
+	cnf := config.TestConfig()
+
+	if !cnf.FFmpegEnabled() {
+		t.Skip("ffmpeg is not available")
+	}
+
+	convert := NewConvert(cnf)
+
+	videoFile := filepath.Join(cnf.ExamplesPath(), "blue-go-video.mp4")
+	jpegFile := filepath.Join(cnf.SidecarPath(), "blue-go-video.mp4.jpg")
+
+	findCmd := func(t *testing.T, rotate, imageName string) *exec.Cmd {
+		cnf.Options().FFmpegRotate = rotate
+		defer func() { cnf.Options().FFmpegRotate = "" }()
+
+		mediaFile, err := NewMediaFile(videoFile)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The example video is stored sideways with a rotation of 90 degrees.
+		assert.Equal(t, 90, mediaFile.MetaData().Rotation)
+
+		cmds, _, err := convert.JpegConvertCommands(mediaFile, imageName, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, cmd := range cmds {
+			if cmd.Path == cnf.FFmpegBin() {
+				return cmd
+			}
+		}
+
+		t.Fatal("ffmpeg command not found")
+
+		return nil
+	}
+
+	findArgs := func(t *testing.T, rotate string) []string {
+		return findCmd(t, rotate, jpegFile).Args[1:]
+	}
+
+	// imageSize extracts a frame and returns the width and height of the image.
+	imageSize := func(t *testing.T, rotate string) (int, int) {
+		imageName := filepath.Join(t.TempDir(), "frame.jpg")
+
+		if out, err := findCmd(t, rotate, imageName).CombinedOutput(); err != nil {
+			t.Fatalf("%s: %s", err, out)
+		}
+
+		f, err := os.Open(imageName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer f.Close()
+
+		cfg, _, err := image.DecodeConfig(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return cfg.Width, cfg.Height
+	}
+
+	t.Run("Auto", func(t *testing.T) {
+		args := findArgs(t, ffmpeg.RotateAuto)
+		assert.NotContains(t, args, "-noautorotate")
+		assert.NotContains(t, args, "-vf")
+	})
+	t.Run("Metadata", func(t *testing.T) {
+		args := findArgs(t, ffmpeg.RotateMetadata)
+		assert.Contains(t, args, "-noautorotate")
+		assert.Contains(t, args, "transpose=clock")
+	})
+	t.Run("None", func(t *testing.T) {
+		args := findArgs(t, ffmpeg.RotateNone)
+		assert.Contains(t, args, "-noautorotate")
+		assert.NotContains(t, args, "-vf")
+	})
+	t.Run("Upright", func(t *testing.T) {
+		storedWidth, storedHeight := imageSize(t, ffmpeg.RotateNone)
+		assert.NotEqual(t, storedWidth, storedHeight)
+
+		// Width and height of the sideways video must be swapped in the poster.
+		for _, rotate := range []string{ffmpeg.RotateAuto, ffmpeg.RotateMetadata} {
+			width, height := imageSize(t, rotate)
+			assert.Equalf(t, storedHeight, width, "width (%s)", rotate)
+			assert.Equalf(t, storedWidth, height, "height (%s)", rotate)
+		}
+	})
+}
+
+func TestConvert_JpegConvertCommandsPdf(t *testing.T) {
+	cnf := config.TestConfig()
+
+	if cnf.PdfToPpmBin() == "" {
+		t.Skip("pdftoppm is not available")
+	}
+
+	cnf.Options().IndexPdf = true
+	defer func() { cnf.Options().IndexPdf = false }()
+
+	convert := NewConvert(cnf)
+
+	pdfFile := filepath.Join(t.TempDir(), "document.pdf")
+
+	if err := os.WriteFile(pdfFile, []byte("%PDF-1.4\n%%EOF\n"), fs.ModeFile); err != nil {
+		t.Fatal(err)
+	}
+
+	mediaFile, err := NewMediaFile(pdfFile)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmds, _, err := convert.JpegConvertCommands(mediaFile, filepath.Join(cnf.SidecarPath(), "document.pdf.jpg"), "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, cnf.PdfToPpmBin(), cmds[0].Path)
+	assert.Contains(t, cmds[0].Args, "-singlefile")
+	assert.Equal(t, filepath.Join(cnf.SidecarPath(), "document.pdf"), cmds[0].Args[len(cmds[0].Args)-1])
+}
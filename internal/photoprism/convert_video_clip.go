@@ -0,0 +1,163 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/photoprism/photoprism/internal/ffmpeg"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/video"
+)
+
+// ClipPrecision is the precision to which clip offsets are rounded, so that similar requests share the same cache file.
+const ClipPrecision = 100 * time.Millisecond
+
+// clipGroup prevents concurrent requests from creating the same clip more than once.
+var clipGroup singleflight.Group
+
+// ClipRange rounds the start and end offsets to the clip precision and limits them to the video duration, if known.
+func ClipRange(start, end, duration time.Duration) (time.Duration, time.Duration, error) {
+	start = start.Truncate(ClipPrecision)
+	end = (end + ClipPrecision - 1).Truncate(ClipPrecision)
+
+	if duration > 0 && end > duration {
+		end = duration
+	}
+
+	if start < 0 || end <= start {
+		return start, end, fmt.Errorf("clip: invalid range %s-%s", ffmpeg.TimeOffset(start), ffmpeg.TimeOffset(end))
+	}
+
+	return start, end, nil
+}
+
+// ClipFileName returns the cache file name of a video clip between the start and end offsets.
+func ClipFileName(hash, cachePath string, start, end time.Duration) (string, error) {
+	if len(hash) < 4 {
+		return "", fmt.Errorf("clip: file hash is empty or too short (%s)", clean.Log(hash))
+	} else if cachePath == "" {
+		return "", errors.New("clip: folder is empty")
+	} else if start < 0 || end <= start {
+		return "", fmt.Errorf("clip: invalid range %s-%s", ffmpeg.TimeOffset(start), ffmpeg.TimeOffset(end))
+	}
+
+	p := path.Join(cachePath, hash[0:1], hash[1:2], hash[2:3])
+
+	if err := os.MkdirAll(p, fs.ModeDir); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s/%s_clip_%d-%d%s", p, hash, start.Milliseconds(), end.Milliseconds(), fs.ExtMP4), nil
+}
+
+// ClipStreamCopy checks if the video streams of a file can be copied into an MPEG-4 clip without re-encoding.
+func ClipStreamCopy(codec string) bool {
+	switch video.Codecs[strings.ToLower(clean.Codec(codec))] {
+	case video.CodecAVC, video.CodecHEVC, video.CodecAV1:
+		return true
+	default:
+		return false
+	}
+}
+
+// VideoClip creates a trimmed copy of the video between the start and end offsets and caches it in the media folder.
+// The offsets are rounded and limited to the video duration, if known. The streams are copied without re-encoding
+// if the codec is supported by MPEG-4 containers.
+func (c *Convert) VideoClip(f *MediaFile, hash, codec string, start, end, duration time.Duration) (fileName string, err error) {
+	if f == nil {
+		return "", fmt.Errorf("clip: file is nil - possible bug")
+	} else if !f.IsVideo() {
+		return "", fmt.Errorf("clip: %s is not a video", clean.Log(f.RootRelName()))
+	} else if !c.conf.FFmpegEnabled() {
+		return "", fmt.Errorf("clip: ffmpeg is disabled")
+	}
+
+	if hash == "" {
+		hash = f.Hash()
+	}
+
+	if start, end, err = ClipRange(start, end, duration); err != nil {
+		return "", err
+	} else if fileName, err = ClipFileName(hash, c.conf.MediaCachePath(), start, end); err != nil {
+		return "", err
+	} else if fs.FileExists(fileName) {
+		return fileName, nil
+	}
+
+	// Wait for and share the result if the same clip is already being created.
+	_, err, _ = clipGroup.Do(fileName, func() (interface{}, error) {
+		if fs.FileExists(fileName) {
+			return nil, nil
+		}
+
+		return nil, c.createClip(f, fileName, codec, start, end)
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+// createClip trims the video to a temporary file, which is renamed once complete so that
+// partially written clips are never served.
+func (c *Convert) createClip(f *MediaFile, fileName, codec string, start, end time.Duration) error {
+	tmpName := fileName + ".tmp"
+
+	streamCopy := ClipStreamCopy(codec)
+	cmd := exec.Command(c.conf.FFmpegBin(), ffmpeg.ClipArgs(f.FileName(), tmpName, start, end, streamCopy)...)
+
+	// Don't transcode more than one video at the same time.
+	if !streamCopy {
+		c.cmdMutex.Lock()
+		defer c.cmdMutex.Unlock()
+	}
+
+	// Fetch command output.
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	cmd.Env = []string{fmt.Sprintf("HOME=%s", c.conf.CmdCachePath())}
+
+	// Log exact command for debugging in trace mode.
+	log.Trace(cmd.String())
+
+	startTime := time.Now()
+
+	// Remove incomplete clip when done.
+	defer func() {
+		if fs.FileExists(tmpName) {
+			_ = os.Remove(tmpName)
+		}
+	}()
+
+	if err := cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			err = errors.New(stderr.String())
+		}
+
+		log.Debug(err)
+
+		return fmt.Errorf("clip: failed trimming %s", clean.Log(f.RootRelName()))
+	} else if !fs.FileExists(tmpName) {
+		return fmt.Errorf("clip: failed trimming %s", clean.Log(f.RootRelName()))
+	} else if err = os.Rename(tmpName, fileName); err != nil {
+		return err
+	}
+
+	log.Debugf("clip: created %s from %s [%s]", clean.Log(path.Base(fileName)), clean.Log(f.RootRelName()), time.Since(startTime))
+
+	return nil
+}
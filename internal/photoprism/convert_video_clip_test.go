@@ -0,0 +1,162 @@
+package photoprism
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// clipDuration returns the video duration reported by ffmpeg.
+func clipDuration(t *testing.T, bin, fileName string) time.Duration {
+	// ffmpeg exits with an error if no output file is specified, but still prints the input file info.
+	out, _ := exec.Command(bin, "-i", fileName).CombinedOutput()
+
+	m := regexp.MustCompile(`Duration: (\d+):(\d+):(\d+\.\d+)`).FindStringSubmatch(string(out))
+
+	if len(m) != 4 {
+		t.Fatalf("duration of %s not found", fileName)
+	}
+
+	h, _ := strconv.Atoi(m[1])
+	mins, _ := strconv.Atoi(m[2])
+	sec, _ := strconv.ParseFloat(m[3], 64)
+
+	return time.Duration(h)*time.Hour + time.Duration(mins)*time.Minute + time.Duration(sec*float64(time.Second))
+}
+
+func TestClipFileName(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		conf := config.TestConfig()
+		fileName, err := ClipFileName("8c1ae0e1de3ee37f0d3c9ed2b2f3e1e9b2d4fd6e", conf.MediaCachePath(), 500*time.Millisecond, 2*time.Second)
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(conf.MediaCachePath(), "8/c/1", "8c1ae0e1de3ee37f0d3c9ed2b2f3e1e9b2d4fd6e_clip_500-2000.mp4"), fileName)
+	})
+	t.Run("InvalidHash", func(t *testing.T) {
+		_, err := ClipFileName("8c1", "/tmp", 0, time.Second)
+		assert.Error(t, err)
+	})
+	t.Run("InvalidRange", func(t *testing.T) {
+		_, err := ClipFileName("8c1ae0e1de3ee37f0d3c9ed2b2f3e1e9b2d4fd6e", "/tmp", 2*time.Second, time.Second)
+		assert.Error(t, err)
+	})
+}
+
+func TestClipRange(t *testing.T) {
+	t.Run("Rounded", func(t *testing.T) {
+		start, end, err := ClipRange(512*time.Millisecond, 1488*time.Millisecond, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 500*time.Millisecond, start)
+		assert.Equal(t, 1500*time.Millisecond, end)
+	})
+	t.Run("EndAfterDuration", func(t *testing.T) {
+		start, end, err := ClipRange(time.Second, 5*time.Second, 2410*time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Second, start)
+		assert.Equal(t, 2410*time.Millisecond, end)
+	})
+	t.Run("StartAfterDuration", func(t *testing.T) {
+		_, _, err := ClipRange(3*time.Second, 5*time.Second, 2410*time.Millisecond)
+		assert.Error(t, err)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, _, err := ClipRange(510*time.Millisecond, 520*time.Millisecond, 0)
+		assert.NoError(t, err)
+		_, _, err = ClipRange(time.Second, time.Second, 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestClipStreamCopy(t *testing.T) {
+	assert.True(t, ClipStreamCopy("avc1"))
+	assert.True(t, ClipStreamCopy("HEVC"))
+	assert.False(t, ClipStreamCopy("vp8"))
+	assert.False(t, ClipStreamCopy(""))
+}
+
+func TestConvert_VideoClip(t *testing.T) {
+	conf := config.TestConfig()
+
+	if !conf.FFmpegEnabled() {
+		t.Skip("ffmpeg is not available")
+	}
+
+	convert := NewConvert(conf)
+
+	mf, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "gopher-video.mp4"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Transcode", func(t *testing.T) {
+		clipName, err := convert.VideoClip(mf, "", "", 500*time.Millisecond, 1500*time.Millisecond, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(clipName)
+
+		assert.InDelta(t, time.Second.Seconds(), clipDuration(t, conf.FFmpegBin(), clipName).Seconds(), 0.1)
+	})
+	t.Run("StreamCopy", func(t *testing.T) {
+		clipName, err := convert.VideoClip(mf, "", "avc1", 0, time.Second, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(clipName)
+
+		d := clipDuration(t, conf.FFmpegBin(), clipName)
+		assert.Greater(t, d, time.Duration(0))
+		assert.Less(t, d, 2*time.Second)
+	})
+	t.Run("Concurrent", func(t *testing.T) {
+		var wg sync.WaitGroup
+
+		names := make([]string, 3)
+		errs := make([]error, 3)
+
+		for i := range names {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+				names[i], errs[i] = convert.VideoClip(mf, "", "", 200*time.Millisecond, 1200*time.Millisecond, 0)
+			}(i)
+		}
+
+		wg.Wait()
+
+		for i := range names {
+			assert.NoError(t, errs[i])
+			assert.Equal(t, names[0], names[i])
+		}
+
+		defer os.Remove(names[0])
+
+		assert.False(t, fs.FileExists(names[0]+".tmp"))
+		assert.InDelta(t, time.Second.Seconds(), clipDuration(t, conf.FFmpegBin(), names[0]).Seconds(), 0.1)
+	})
+	t.Run("NoVideo", func(t *testing.T) {
+		img, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "elephants.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = convert.VideoClip(img, "", "", 0, time.Second, 0)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,59 @@
+package photoprism
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/video"
+)
+
+// ToEmbeddedVideo extracts the video embedded in a Google or Samsung motion photo,
+// and returns nil if the file does not contain a video.
+func (c *Convert) ToEmbeddedVideo(f *MediaFile, force bool) (*MediaFile, error) {
+	if f == nil {
+		return nil, fmt.Errorf("convert: file is nil - possible bug")
+	}
+
+	if !f.Exists() {
+		return nil, fmt.Errorf("convert: %s not found", clean.Log(f.RootRelName()))
+	} else if f.Empty() {
+		return nil, fmt.Errorf("convert: %s is empty", clean.Log(f.RootRelName()))
+	} else if !f.IsJpeg() {
+		return nil, nil
+	}
+
+	videoName := fs.FileName(f.FileName(), c.conf.SidecarPath(), c.conf.OriginalsPath(), fs.ExtMP4)
+
+	if !force && fs.FileExistsNotEmpty(videoName) {
+		return embeddedVideo(videoName)
+	} else if !c.conf.SidecarWritable() {
+		return nil, fmt.Errorf("convert: disabled in read-only mode (%s)", clean.Log(f.RootRelName()))
+	}
+
+	if found, err := video.ExtractEmbedded(f.FileName(), videoName); err != nil {
+		return nil, fmt.Errorf("convert: %s in %s", err, clean.Log(f.RootRelName()))
+	} else if !found {
+		return nil, nil
+	}
+
+	log.Infof("convert: extracted embedded video from %s", clean.Log(f.RootRelName()))
+
+	return embeddedVideo(videoName)
+}
+
+// embeddedVideo returns the video extracted from a motion photo as MediaFile.
+func embeddedVideo(fileName string) (*MediaFile, error) {
+	mediaFile, err := NewMediaFile(fileName)
+
+	if err != nil {
+		return nil, err
+	} else if !mediaFile.IsVideo() {
+		return nil, fmt.Errorf("convert: %s is not a video", clean.Log(mediaFile.RootRelName()))
+	}
+
+	// The motion photo itself is used as preview image.
+	mediaFile.hasPreviewImage = true
+
+	return mediaFile, nil
+}
@@ -0,0 +1,156 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/photoprism/photoprism/internal/ffmpeg"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// PosterPercent specifies the relative positions of video poster frames, e.g. for hover previews.
+var PosterPercent = []int{10, 50, 90}
+
+// posterGroup prevents concurrent requests from extracting the same poster frame more than once.
+var posterGroup singleflight.Group
+
+// Poster represents a video frame extracted at a relative position.
+type Poster struct {
+	Percent  int
+	Offset   time.Duration
+	FileName string
+}
+
+// Posters represents a list of video poster frames.
+type Posters []Poster
+
+// PosterFileName returns the cache file name of a video poster frame.
+// The folder is created when the frame is extracted, not when the name is requested.
+func PosterFileName(hash, thumbPath string, percent int) (string, error) {
+	if len(hash) < 4 {
+		return "", fmt.Errorf("poster: file hash is empty or too short (%s)", clean.Log(hash))
+	} else if thumbPath == "" {
+		return "", errors.New("poster: folder is empty")
+	} else if percent < 0 || percent > 100 {
+		return "", fmt.Errorf("poster: invalid position %d%%", percent)
+	}
+
+	p := path.Join(thumbPath, hash[0:1], hash[1:2], hash[2:3])
+
+	return fmt.Sprintf("%s/%s_poster_%d%s", p, hash, percent, fs.ExtJPEG), nil
+}
+
+// VideoPosters extracts poster frames at the default relative positions and caches them in the thumbnail folder.
+// The file hash and video duration are determined from the media file if they are not specified.
+func (c *Convert) VideoPosters(f *MediaFile, hash string, d time.Duration) (result Posters, err error) {
+	if f == nil {
+		return result, fmt.Errorf("poster: file is nil - possible bug")
+	} else if !f.IsVideo() {
+		return result, fmt.Errorf("poster: %s is not a video", clean.Log(f.RootRelName()))
+	} else if !c.conf.FFmpegEnabled() {
+		return result, fmt.Errorf("poster: ffmpeg is disabled")
+	}
+
+	if hash == "" {
+		hash = f.Hash()
+	}
+
+	if d <= 0 {
+		d = f.Duration()
+	}
+
+	offsets := ffmpeg.PosterOffsets(d, PosterPercent...)
+	result = make(Posters, 0, len(PosterPercent))
+
+	for i, percent := range PosterPercent {
+		fileName, err := PosterFileName(hash, c.conf.ThumbCachePath(), percent)
+
+		if err != nil {
+			return result, err
+		}
+
+		if !fs.FileExists(fileName) {
+			offset := offsets[i]
+
+			// Wait for and share the result if the same frame is already being extracted.
+			_, err, _ = posterGroup.Do(fileName, func() (interface{}, error) {
+				if fs.FileExists(fileName) {
+					return nil, nil
+				}
+
+				return nil, c.extractFrame(f, fileName, offset)
+			})
+
+			if err != nil {
+				return result, err
+			}
+		}
+
+		result = append(result, Poster{Percent: percent, Offset: offsets[i], FileName: fileName})
+	}
+
+	return result, nil
+}
+
+// extractFrame extracts a single video frame at the specified time offset and saves it as JPEG.
+// The frame is written to a temporary file, which is renamed once complete so that
+// partially written posters are never served.
+func (c *Convert) extractFrame(f *MediaFile, imageName string, offset time.Duration) error {
+	if err := os.MkdirAll(filepath.Dir(imageName), fs.ModeDir); err != nil {
+		return err
+	}
+
+	// Keep the extension, so that ffmpeg can determine the output format.
+	tmpName := strings.TrimSuffix(imageName, fs.ExtJPEG) + ".tmp" + fs.ExtJPEG
+
+	args := ffmpeg.FrameImageArgs(f.FileName(), tmpName, offset, c.conf.FFmpegRotate(), f.MetaData().Rotation)
+	cmd := exec.Command(c.conf.FFmpegBin(), args...)
+
+	// Fetch command output.
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	cmd.Env = []string{fmt.Sprintf("HOME=%s", c.conf.CmdCachePath())}
+
+	// Log exact command for debugging in trace mode.
+	log.Trace(cmd.String())
+
+	start := time.Now()
+
+	// Remove incomplete frame when done.
+	defer func() {
+		if fs.FileExists(tmpName) {
+			_ = os.Remove(tmpName)
+		}
+	}()
+
+	if err := cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			err = errors.New(stderr.String())
+		}
+
+		log.Debug(err)
+
+		return fmt.Errorf("poster: failed extracting frame at %s from %s", ffmpeg.TimeOffset(offset), clean.Log(f.RootRelName()))
+	} else if !fs.FileExists(tmpName) {
+		return fmt.Errorf("poster: no frame found at %s in %s", ffmpeg.TimeOffset(offset), clean.Log(f.RootRelName()))
+	} else if err = os.Rename(tmpName, imageName); err != nil {
+		return err
+	}
+
+	log.Debugf("poster: extracted frame at %s from %s [%s]", ffmpeg.TimeOffset(offset), clean.Log(f.RootRelName()), time.Since(start))
+
+	return nil
+}
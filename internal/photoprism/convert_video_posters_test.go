@@ -0,0 +1,134 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestPosterFileName(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		conf := config.TestConfig()
+		fileName, err := PosterFileName("8c1ae0e1de3ee37f0d3c9ed2b2f3e1e9b2d4fd6e", conf.ThumbCachePath(), 50)
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(conf.ThumbCachePath(), "8/c/1", "8c1ae0e1de3ee37f0d3c9ed2b2f3e1e9b2d4fd6e_poster_50.jpg"), fileName)
+	})
+	t.Run("NoFolder", func(t *testing.T) {
+		thumbPath := filepath.Join(os.TempDir(), "photoprism-poster-test")
+		_ = os.RemoveAll(thumbPath)
+
+		fileName, err := PosterFileName("ffff0e1de3ee37f0d3c9ed2b2f3e1e9b2d4fd6e", thumbPath, 50)
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(thumbPath, "f/f/f", "ffff0e1de3ee37f0d3c9ed2b2f3e1e9b2d4fd6e_poster_50.jpg"), fileName)
+		assert.NoDirExists(t, thumbPath)
+	})
+	t.Run("InvalidHash", func(t *testing.T) {
+		_, err := PosterFileName("8c1", "/tmp", 50)
+		assert.Error(t, err)
+	})
+	t.Run("InvalidPercent", func(t *testing.T) {
+		_, err := PosterFileName("8c1ae0e1de3ee37f0d3c9ed2b2f3e1e9b2d4fd6e", "/tmp", 110)
+		assert.Error(t, err)
+	})
+}
+
+func TestConvert_VideoPosters(t *testing.T) {
+	t.Run("gopher-video.mp4", func(t *testing.T) {
+		conf := config.TestConfig()
+
+		if !conf.FFmpegEnabled() {
+			t.Skip("ffmpeg is not available")
+		}
+
+		convert := NewConvert(conf)
+
+		mf, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "gopher-video.mp4"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		posters, err := convert.VideoPosters(mf, "", time.Second)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, posters, 3) {
+			assert.Equal(t, 10, posters[0].Percent)
+			assert.Equal(t, 100*time.Millisecond, posters[0].Offset)
+			assert.Equal(t, 50, posters[1].Percent)
+			assert.Equal(t, 500*time.Millisecond, posters[1].Offset)
+			assert.Equal(t, 90, posters[2].Percent)
+			assert.Equal(t, 900*time.Millisecond, posters[2].Offset)
+
+			for _, p := range posters {
+				assert.Truef(t, fs.FileExists(p.FileName), "poster does not exist: %s", p.FileName)
+				assert.False(t, fs.FileExists(strings.TrimSuffix(p.FileName, fs.ExtJPEG)+".tmp"+fs.ExtJPEG))
+				_ = os.Remove(p.FileName)
+			}
+		}
+	})
+	t.Run("Concurrent", func(t *testing.T) {
+		conf := config.TestConfig()
+
+		if !conf.FFmpegEnabled() {
+			t.Skip("ffmpeg is not available")
+		}
+
+		convert := NewConvert(conf)
+
+		mf, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "gopher-video.mp4"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var wg sync.WaitGroup
+
+		results := make([]Posters, 4)
+		errs := make([]error, 4)
+
+		for i := range results {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+				results[i], errs[i] = convert.VideoPosters(mf, "", time.Second)
+			}(i)
+		}
+
+		wg.Wait()
+
+		for i := range results {
+			assert.NoError(t, errs[i])
+			assert.Len(t, results[i], 3)
+		}
+
+		for _, p := range results[0] {
+			assert.Truef(t, fs.FileExists(p.FileName), "poster does not exist: %s", p.FileName)
+			_ = os.Remove(p.FileName)
+		}
+	})
+	t.Run("NotVideo", func(t *testing.T) {
+		conf := config.TestConfig()
+		convert := NewConvert(conf)
+
+		mf, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "elephants.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = convert.VideoPosters(mf, "", 0)
+		assert.Error(t, err)
+	})
+}
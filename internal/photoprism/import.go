@@ -90,10 +90,11 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 	}
 
 	jobs := make(chan ImportJob)
+	order := NewImportOrder()
 
 	// Start a fixed number of goroutines to import files.
 	var wg sync.WaitGroup
-	var numWorkers = imp.conf.Workers()
+	var numWorkers = imp.conf.ImportWorkers()
 	wg.Add(numWorkers)
 	for i := 0; i < numWorkers; i++ {
 		go func() {
@@ -208,6 +209,8 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 				IndexOpt:  indexOpt,
 				ImportOpt: opt,
 				Imp:       imp,
+				Seq:       order.Next(),
+				Order:     order,
 			}
 
 			return nil
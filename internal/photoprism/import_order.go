@@ -0,0 +1,58 @@
+package photoprism
+
+import (
+	"sync"
+)
+
+// ImportOrder makes sure that files imported in parallel are indexed in the order of their jobs,
+// while other tasks such as moving files and creating thumbnails are not blocked.
+type ImportOrder struct {
+	mutex sync.Mutex
+	cond  *sync.Cond
+	seq   int
+	next  int
+	done  map[int]bool
+}
+
+// NewImportOrder returns a new ImportOrder.
+func NewImportOrder() *ImportOrder {
+	o := &ImportOrder{done: make(map[int]bool)}
+	o.cond = sync.NewCond(&o.mutex)
+	return o
+}
+
+// Next returns the sequence number of the next job.
+func (o *ImportOrder) Next() int {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	seq := o.seq
+	o.seq++
+
+	return seq
+}
+
+// Wait blocks until all jobs with a lower sequence number are done.
+func (o *ImportOrder) Wait(seq int) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	for o.next < seq {
+		o.cond.Wait()
+	}
+}
+
+// Done marks the job with the sequence number as done.
+func (o *ImportOrder) Done(seq int) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.done[seq] = true
+
+	for o.done[o.next] {
+		delete(o.done, o.next)
+		o.next++
+	}
+
+	o.cond.Broadcast()
+}
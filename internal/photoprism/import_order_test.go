@@ -0,0 +1,131 @@
+package photoprism
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestImportOrder(t *testing.T) {
+	t.Run("Shuffled", func(t *testing.T) {
+		order := NewImportOrder()
+
+		var mutex sync.Mutex
+		var wg sync.WaitGroup
+		var result []int
+
+		seqs := make([]int, 8)
+
+		for i := range seqs {
+			seqs[i] = order.Next()
+		}
+
+		rand.Shuffle(len(seqs), func(i, j int) { seqs[i], seqs[j] = seqs[j], seqs[i] })
+
+		for _, seq := range seqs {
+			wg.Add(1)
+
+			go func(seq int) {
+				defer wg.Done()
+				defer order.Done(seq)
+
+				time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond)
+
+				order.Wait(seq)
+
+				mutex.Lock()
+				result = append(result, seq)
+				mutex.Unlock()
+			}(seq)
+		}
+
+		wg.Wait()
+
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, result)
+	})
+	t.Run("Stack", func(t *testing.T) {
+		names := []string{"IMG_4712.jpg", "IMG_4712.heic", "IMG_4712.dng"}
+
+		// stack indexes the files with the specified number of workers and returns the
+		// primary file name of the photo that is kept when merging them.
+		stack := func(n, workers int) string {
+			path := fmt.Sprintf("import-order/%d", n)
+			order := NewImportOrder()
+			jobs := make(chan int)
+			photos := make([]*entity.Photo, len(names))
+
+			var wg sync.WaitGroup
+
+			for w := 0; w < workers; w++ {
+				wg.Add(1)
+
+				go func() {
+					defer wg.Done()
+
+					for seq := range jobs {
+						// Simulate tasks that take a different amount of time, e.g. creating thumbnails.
+						time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond)
+
+						order.Wait(seq)
+
+						photo := &entity.Photo{PhotoName: "IMG_4712", PhotoPath: path, PhotoType: entity.MediaImage, PhotoQuality: 3}
+
+						if err := photo.Create(); err != nil {
+							t.Error(err)
+						} else if err = (&entity.File{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FileName: path + "/" + names[seq], FileHash: fmt.Sprintf("importorder%d%d", n, seq), FileType: "jpg", FilePrimary: true}).Create(); err != nil {
+							t.Error(err)
+						}
+
+						photos[seq] = photo
+						order.Done(seq)
+					}
+				}()
+			}
+
+			for range names {
+				jobs <- order.Next()
+			}
+
+			close(jobs)
+			wg.Wait()
+
+			defer func() {
+				for _, photo := range photos {
+					if photo != nil {
+						_, _ = photo.DeletePermanently()
+					}
+				}
+			}()
+
+			original, merged, err := photos[len(photos)-1].Merge(false, false)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Len(t, merged, len(names)-1)
+
+			for i, photo := range photos {
+				if photo.ID == original.ID {
+					return names[i]
+				}
+			}
+
+			return ""
+		}
+
+		serial := stack(0, 1)
+
+		assert.Equal(t, names[0], serial)
+
+		for n := 1; n <= 3; n++ {
+			assert.Equal(t, serial, stack(n, len(names)))
+		}
+	})
+}
@@ -1,12 +1,17 @@
 package photoprism
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -89,3 +94,85 @@ func TestImport_Start(t *testing.T) {
 
 	imp.Start(opt)
 }
+
+func TestImport_WebpAvif(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	conf := config.TestConfig()
+
+	conf.InitializeTestData()
+
+	// Image classification is not required for testing metadata extraction.
+	tf := classify.New(conf.AssetsPath(), true)
+	nd := nsfw.New(conf.NSFWModelPath())
+	fn := face.NewNet(conf.FaceNetModelPath(), "", conf.DisableTensorFlow())
+	convert := NewConvert(conf)
+
+	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+	imp := NewImport(conf, ind, convert)
+
+	importPath := filepath.Join(conf.ImportPath(), "webp-avif")
+
+	for _, name := range []string{"yellow_rose.webp", "fox.profile0.8bpc.yuv420.avif"} {
+		if err := fs.Copy(filepath.Join(conf.ExamplesPath(), name), filepath.Join(importPath, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defer os.RemoveAll(importPath)
+
+	imp.Start(ImportOptionsCopy(importPath, ""))
+
+	t.Run("yellow_rose.webp", func(t *testing.T) {
+		var file entity.File
+
+		if err := entity.Db().Where("original_name = ? AND file_type = ?", "yellow_rose.webp", fs.ImageWebP).First(&file).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 400, file.FileWidth)
+		assert.Equal(t, 301, file.FileHeight)
+		assert.Equal(t, fs.MimeTypeWebP, file.FileMime)
+
+		photo, err := query.PhotoPreloadByUID(file.PhotoUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "2019-06-09 10:57:32 +0000 UTC", photo.TakenAt.String())
+		assert.Equal(t, entity.SrcMeta, photo.TakenSrc)
+		assert.Equal(t, "Apple", photo.Camera.CameraMake)
+		assert.Equal(t, "iPhone SE", photo.Camera.CameraModel)
+		assert.InEpsilon(t, 50.047745, photo.PhotoLat, 0.00001)
+
+		// WebP images can be decoded natively, so a JPEG preview for creating thumbnails must exist.
+		primary, err := photo.PrimaryFile()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, fs.ImageJPEG.String(), primary.FileType)
+		assert.Equal(t, 400, primary.FileWidth)
+	})
+	t.Run("fox.profile0.8bpc.yuv420.avif", func(t *testing.T) {
+		// AVIF images cannot be decoded natively, so they are skipped if no converter is installed.
+		if !conf.HeifConvertEnabled() && !conf.FFmpegEnabled() && !conf.ImageMagickEnabled() {
+			t.Skip("no avif decoder available")
+		}
+
+		var file entity.File
+
+		if err := entity.Db().Where("original_name = ? AND file_type = ?", "fox.profile0.8bpc.yuv420.avif", fs.ImageAVIF).First(&file).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1204, file.FileWidth)
+		assert.Equal(t, 800, file.FileHeight)
+		assert.Equal(t, fs.MimeTypeAVIF, file.FileMime)
+		assert.Equal(t, entity.MediaImage, file.MediaType)
+	})
+}
@@ -17,240 +17,260 @@ type ImportJob struct {
 	IndexOpt  IndexOptions
 	ImportOpt ImportOptions
 	Imp       *Import
+	Seq       int
+	Order     *ImportOrder
 }
 
 func ImportWorker(jobs <-chan ImportJob) {
 	for job := range jobs {
-		var destMainFileName string
+		importJob(job)
+	}
+}
 
-		o := job.IndexOpt
+// importJob imports the main file of the job and its related files.
+func importJob(job ImportJob) {
+	// Let the next job index its files when done.
+	if job.Order != nil {
+		defer job.Order.Done(job.Seq)
+	}
 
-		imp := job.Imp
-		opt := job.ImportOpt
-		src := job.ImportOpt.Path
+	var destMainFileName string
 
-		related := job.Related
+	o := job.IndexOpt
 
-		// relatedOriginalNames contains the original filenames of related files.
-		relatedOriginalNames := make(map[string]string, len(related.Files))
+	imp := job.Imp
+	opt := job.ImportOpt
+	src := job.ImportOpt.Path
 
-		if related.Main == nil {
-			log.Warnf("import: %s belongs to no supported media file", clean.Log(fs.RelName(job.FileName, src)))
-			continue
-		}
+	related := job.Related
 
-		// Extract metadata to a JSON file with Exiftool.
-		if related.Main.NeedsExifToolJson() {
-			if jsonName, err := imp.convert.ToJson(related.Main, false); err != nil {
-				log.Tracef("exiftool: %s", clean.Log(err.Error()))
-				log.Debugf("exiftool: failed parsing %s", clean.Log(related.Main.RootRelName()))
-			} else if err := related.Main.ReadExifToolJson(); err != nil {
-				log.Errorf("import: %s in %s (read metadata)", clean.Log(err.Error()), clean.Log(related.Main.BaseName()))
-			} else {
-				log.Debugf("import: created %s", filepath.Base(jsonName))
-			}
+	// relatedOriginalNames contains the original filenames of related files.
+	relatedOriginalNames := make(map[string]string, len(related.Files))
+
+	if related.Main == nil {
+		log.Warnf("import: %s belongs to no supported media file", clean.Log(fs.RelName(job.FileName, src)))
+		return
+	}
+
+	// Extract metadata to a JSON file with Exiftool.
+	if related.Main.NeedsExifToolJson() {
+		if jsonName, err := imp.convert.ToJson(related.Main, false); err != nil {
+			log.Tracef("exiftool: %s", clean.Log(err.Error()))
+			log.Debugf("exiftool: failed parsing %s", clean.Log(related.Main.RootRelName()))
+		} else if err := related.Main.ReadExifToolJson(); err != nil {
+			log.Errorf("import: %s in %s (read metadata)", clean.Log(err.Error()), clean.Log(related.Main.BaseName()))
+		} else {
+			log.Debugf("import: created %s", filepath.Base(jsonName))
 		}
+	}
 
-		originalName := related.Main.RelName(src)
+	originalName := related.Main.RelName(src)
 
-		event.Publish("import.file", event.Data{
-			"fileName":  originalName,
-			"baseName":  filepath.Base(related.Main.FileName()),
-			"subFolder": opt.DestFolder,
-		})
+	event.Publish("import.file", event.Data{
+		"fileName":  originalName,
+		"baseName":  filepath.Base(related.Main.FileName()),
+		"subFolder": opt.DestFolder,
+	})
 
-		for _, f := range related.Files {
-			relFileName := f.RelName(src)
+	for _, f := range related.Files {
+		relFileName := f.RelName(src)
 
-			if destFileName, err := imp.DestinationFilename(related.Main, f, opt.DestFolder); err == nil {
-				destDir := filepath.Dir(destFileName)
+		if destFileName, err := imp.DestinationFilename(related.Main, f, opt.DestFolder); err == nil {
+			destDir := filepath.Dir(destFileName)
 
-				// Remember the original filenames of related files, so they can later be indexed and searched.
-				relatedOriginalNames[destFileName] = relFileName
+			// Remember the original filenames of related files, so they can later be indexed and searched.
+			relatedOriginalNames[destFileName] = relFileName
 
-				if fs.PathExists(destDir) {
-					// Do nothing.
-				} else if err := os.MkdirAll(destDir, fs.ModeDir); err != nil {
-					log.Errorf("import: failed creating folder for %s (%s)", clean.Log(f.BaseName()), err.Error())
-				} else {
-					destDirRel := fs.RelName(destDir, imp.originalsPath())
+			if fs.PathExists(destDir) {
+				// Do nothing.
+			} else if err := os.MkdirAll(destDir, fs.ModeDir); err != nil {
+				log.Errorf("import: failed creating folder for %s (%s)", clean.Log(f.BaseName()), err.Error())
+			} else {
+				destDirRel := fs.RelName(destDir, imp.originalsPath())
 
-					folder := entity.NewFolder(entity.RootOriginals, destDirRel, fs.BirthTime(destDir))
+				folder := entity.NewFolder(entity.RootOriginals, destDirRel, fs.BirthTime(destDir))
 
-					if err := folder.Create(); err == nil {
-						log.Infof("import: created folder /%s", folder.Path)
-					}
+				if err := folder.Create(); err == nil {
+					log.Infof("import: created folder /%s", folder.Path)
 				}
+			}
 
-				if related.Main.HasSameName(f) {
-					destMainFileName = destFileName
-					log.Infof("import: moving main %s file %s to %s", f.FileType(), clean.Log(relFileName), clean.Log(fs.RelName(destFileName, imp.originalsPath())))
-				} else {
-					log.Infof("import: moving related %s file %s to %s", f.FileType(), clean.Log(relFileName), clean.Log(fs.RelName(destFileName, imp.originalsPath())))
-				}
+			if related.Main.HasSameName(f) {
+				destMainFileName = destFileName
+				log.Infof("import: moving main %s file %s to %s", f.FileType(), clean.Log(relFileName), clean.Log(fs.RelName(destFileName, imp.originalsPath())))
+			} else {
+				log.Infof("import: moving related %s file %s to %s", f.FileType(), clean.Log(relFileName), clean.Log(fs.RelName(destFileName, imp.originalsPath())))
+			}
 
-				if opt.Move {
-					if err := f.Move(destFileName); err != nil {
-						logRelName := clean.Log(fs.RelName(destMainFileName, imp.originalsPath()))
-						log.Debugf("import: %s", err.Error())
-						log.Warnf("import: failed moving file to %s, is another import running at the same time?", logRelName)
-					}
-				} else {
-					if err := f.Copy(destFileName); err != nil {
-						logRelName := clean.Log(fs.RelName(destMainFileName, imp.originalsPath()))
-						log.Debugf("import: %s", err.Error())
-						log.Warnf("import: failed copying file to %s, is another import running at the same time?", logRelName)
-					}
+			if opt.Move {
+				if err := f.Move(destFileName); err != nil {
+					logRelName := clean.Log(fs.RelName(destMainFileName, imp.originalsPath()))
+					log.Debugf("import: %s", err.Error())
+					log.Warnf("import: failed moving file to %s, is another import running at the same time?", logRelName)
 				}
 			} else {
-				log.Infof("import: %s", err)
-
-				// Try to add duplicates to selected album(s) as well, see #991.
-				if fileHash := f.Hash(); fileHash == "" {
-					// Do nothing.
-				} else if file, err := entity.FirstFileByHash(fileHash); err != nil {
-					// Do nothing.
-				} else if err := entity.AddPhotoToUserAlbums(file.PhotoUID, opt.Albums, opt.UID); err != nil {
-					log.Warn(err)
+				if err := f.Copy(destFileName); err != nil {
+					logRelName := clean.Log(fs.RelName(destMainFileName, imp.originalsPath()))
+					log.Debugf("import: %s", err.Error())
+					log.Warnf("import: failed copying file to %s, is another import running at the same time?", logRelName)
 				}
+			}
+		} else {
+			log.Infof("import: %s", err)
+
+			// Try to add duplicates to selected album(s) as well, see #991.
+			if fileHash := f.Hash(); fileHash == "" {
+				// Do nothing.
+			} else if file, err := entity.FirstFileByHash(fileHash); err != nil {
+				// Do nothing.
+			} else if err := entity.AddPhotoToUserAlbums(file.PhotoUID, opt.Albums, opt.UID); err != nil {
+				log.Warn(err)
+			}
 
-				// Remove duplicates to save storage.
-				if opt.RemoveExistingFiles {
-					if err := f.Remove(); err != nil {
-						log.Errorf("import: failed deleting %s (%s)", clean.Log(f.BaseName()), err.Error())
-					} else {
-						log.Infof("import: deleted %s (already exists)", clean.Log(relFileName))
-					}
+			// Remove duplicates to save storage.
+			if opt.RemoveExistingFiles {
+				if err := f.Remove(); err != nil {
+					log.Errorf("import: failed deleting %s (%s)", clean.Log(f.BaseName()), err.Error())
+				} else {
+					log.Infof("import: deleted %s (already exists)", clean.Log(relFileName))
 				}
 			}
 		}
+	}
 
-		if destMainFileName != "" {
-			f, err := NewMediaFile(destMainFileName)
+	if destMainFileName != "" {
+		f, err := NewMediaFile(destMainFileName)
 
-			if err != nil {
-				log.Errorf("import: %s in %s", err.Error(), clean.Log(fs.RelName(destMainFileName, imp.originalsPath())))
-				continue
-			}
+		if err != nil {
+			log.Errorf("import: %s in %s", err.Error(), clean.Log(fs.RelName(destMainFileName, imp.originalsPath())))
+			return
+		}
 
-			// Extract metadata to a JSON file with Exiftool.
-			if f.NeedsExifToolJson() {
-				if jsonName, err := imp.convert.ToJson(f, false); err != nil {
-					log.Tracef("exiftool: %s", clean.Log(err.Error()))
-					log.Debugf("exiftool: failed parsing %s", clean.Log(f.RootRelName()))
-				} else {
-					log.Debugf("import: created %s", filepath.Base(jsonName))
-				}
+		// Extract metadata to a JSON file with Exiftool.
+		if f.NeedsExifToolJson() {
+			if jsonName, err := imp.convert.ToJson(f, false); err != nil {
+				log.Tracef("exiftool: %s", clean.Log(err.Error()))
+				log.Debugf("exiftool: failed parsing %s", clean.Log(f.RootRelName()))
+			} else {
+				log.Debugf("import: created %s", filepath.Base(jsonName))
 			}
+		}
 
-			// Create JPEG sidecar for media files in other formats so that thumbnails can be created.
-			if o.Convert && f.IsMedia() && !f.HasPreviewImage() {
-				if jpegFile, err := imp.convert.ToImage(f, false); err != nil {
-					log.Errorf("import: %s in %s (convert to jpeg)", err.Error(), clean.Log(f.RootRelName()))
-					continue
-				} else {
-					log.Debugf("import: created %s", clean.Log(jpegFile.BaseName()))
-				}
+		// Create JPEG sidecar for media files in other formats so that thumbnails can be created.
+		if o.Convert && f.IsMedia() && !f.HasPreviewImage() {
+			if jpegFile, err := imp.convert.ToImage(f, false); err != nil {
+				log.Errorf("import: %s in %s (convert to jpeg)", err.Error(), clean.Log(f.RootRelName()))
+				return
+			} else {
+				log.Debugf("import: created %s", clean.Log(jpegFile.BaseName()))
 			}
+		}
 
-			// Ensure that a JPEG and the configured default thumbnail sizes exist.
-			if jpg, err := f.PreviewImage(); err != nil {
-				log.Error(err)
-			} else if limitErr, _ := jpg.ExceedsResolution(o.ResolutionLimit); limitErr != nil {
-				log.Errorf("index: %s", limitErr)
-				continue
-			} else if err := jpg.CreateThumbnails(imp.thumbPath(), false); err != nil {
-				log.Errorf("import: failed creating thumbnails for %s (%s)", clean.Log(f.RootRelName()), err.Error())
-				continue
-			}
+		// Ensure that a JPEG and the configured default thumbnail sizes exist.
+		if jpg, err := f.PreviewImage(); err != nil {
+			log.Error(err)
+		} else if limitErr, _ := jpg.ExceedsResolution(o.ResolutionLimit); limitErr != nil {
+			log.Errorf("index: %s", limitErr)
+			return
+		} else if imp.conf.ThumbLazy() {
+			// Thumbnails will be created when first requested.
+		} else if err := jpg.CreateThumbnails(imp.thumbPath(), false); err != nil {
+			log.Errorf("import: failed creating thumbnails for %s (%s)", clean.Log(f.RootRelName()), err.Error())
+			return
+		}
 
-			// Find related files.
-			related, err := f.RelatedFiles(imp.conf.Settings().StackSequences())
+		// Find related files.
+		related, err := f.RelatedFiles(imp.conf.Settings().StackSequences())
 
-			// Skip import if the finding related files results in an error.
-			if err != nil {
-				log.Errorf("import: %s in %s (find related files)", err.Error(), clean.Log(fs.RelName(destMainFileName, imp.originalsPath())))
-				continue
-			}
+		// Skip import if the finding related files results in an error.
+		if err != nil {
+			log.Errorf("import: %s in %s (find related files)", err.Error(), clean.Log(fs.RelName(destMainFileName, imp.originalsPath())))
+			return
+		}
 
-			done := make(map[string]bool)
-			ind := imp.index
-			photoUID := ""
+		// Index files in the same order as a single worker would, so that the results,
+		// e.g. which photo is kept when stacking, don't depend on the number of workers.
+		if job.Order != nil {
+			job.Order.Wait(job.Seq)
+		}
 
-			if related.Main != nil {
-				f := related.Main
+		done := make(map[string]bool)
+		ind := imp.index
+		photoUID := ""
 
-				// Enforce file size and resolution limits.
-				if limitErr, _ := f.ExceedsBytes(o.ByteLimit); limitErr != nil {
-					log.Warnf("import: %s", limitErr)
-					continue
-				} else if limitErr, _ = f.ExceedsResolution(o.ResolutionLimit); limitErr != nil {
-					log.Warnf("import: %s", limitErr)
-					continue
-				}
+		if related.Main != nil {
+			f := related.Main
 
-				// Index main MediaFile.
-				res := ind.UserMediaFile(f, o, originalName, "", opt.UID)
+			// Enforce file size and resolution limits.
+			if limitErr, _ := f.ExceedsBytes(o.ByteLimit); limitErr != nil {
+				log.Warnf("import: %s", limitErr)
+				return
+			} else if limitErr, _ = f.ExceedsResolution(o.ResolutionLimit); limitErr != nil {
+				log.Warnf("import: %s", limitErr)
+				return
+			}
+
+			// Index main MediaFile.
+			res := ind.UserMediaFile(f, o, originalName, "", opt.UID)
 
-				// Log result.
-				log.Infof("import: %s main %s file %s", res, f.FileType(), clean.Log(f.RootRelName()))
-				done[f.FileName()] = true
+			// Log result.
+			log.Infof("import: %s main %s file %s", res, f.FileType(), clean.Log(f.RootRelName()))
+			done[f.FileName()] = true
 
-				if !res.Success() {
-					// Skip importing related files if the main file was not indexed successfully.
-					continue
-				} else if res.PhotoUID != "" {
-					photoUID = res.PhotoUID
+			if !res.Success() {
+				// Skip importing related files if the main file was not indexed successfully.
+				return
+			} else if res.PhotoUID != "" {
+				photoUID = res.PhotoUID
 
-					// Add photo to album if a list of albums was provided when importing.
-					if err := entity.AddPhotoToUserAlbums(photoUID, opt.Albums, opt.UID); err != nil {
-						log.Warn(err)
-					}
+				// Add photo to album if a list of albums was provided when importing.
+				if err := entity.AddPhotoToUserAlbums(photoUID, opt.Albums, opt.UID); err != nil {
+					log.Warn(err)
 				}
-			} else {
-				log.Warnf("import: found no main file for %s, conversion to jpeg may have failed", clean.Log(f.RootRelName()))
 			}
+		} else {
+			log.Warnf("import: found no main file for %s, conversion to jpeg may have failed", clean.Log(f.RootRelName()))
+		}
 
-			for _, f := range related.Files {
-				if f == nil {
-					continue
-				}
+		for _, f := range related.Files {
+			if f == nil {
+				continue
+			}
 
-				if done[f.FileName()] {
-					continue
-				}
+			if done[f.FileName()] {
+				continue
+			}
 
-				done[f.FileName()] = true
+			done[f.FileName()] = true
 
-				// Show warning if sidecar file exceeds size or resolution limit.
-				if limitErr, _ := f.ExceedsBytes(o.ByteLimit); limitErr != nil {
-					log.Warnf("import: %s", limitErr)
-				} else if limitErr, _ = f.ExceedsResolution(o.ResolutionLimit); limitErr != nil {
-					log.Warnf("import: %s", limitErr)
-				}
+			// Show warning if sidecar file exceeds size or resolution limit.
+			if limitErr, _ := f.ExceedsBytes(o.ByteLimit); limitErr != nil {
+				log.Warnf("import: %s", limitErr)
+			} else if limitErr, _ = f.ExceedsResolution(o.ResolutionLimit); limitErr != nil {
+				log.Warnf("import: %s", limitErr)
+			}
 
-				// Extract metadata to a JSON file with Exiftool.
-				if f.NeedsExifToolJson() {
-					if jsonName, err := imp.convert.ToJson(f, false); err != nil {
-						log.Tracef("exiftool: %s", clean.Log(err.Error()))
-						log.Debugf("exiftool: failed parsing %s", clean.Log(f.RootRelName()))
-					} else {
-						log.Debugf("import: created %s", filepath.Base(jsonName))
-					}
+			// Extract metadata to a JSON file with Exiftool.
+			if f.NeedsExifToolJson() {
+				if jsonName, err := imp.convert.ToJson(f, false); err != nil {
+					log.Tracef("exiftool: %s", clean.Log(err.Error()))
+					log.Debugf("exiftool: failed parsing %s", clean.Log(f.RootRelName()))
+				} else {
+					log.Debugf("import: created %s", filepath.Base(jsonName))
 				}
+			}
 
-				// Index related media file including its original filename.
-				res := ind.UserMediaFile(f, o, relatedOriginalNames[f.FileName()], photoUID, opt.UID)
+			// Index related media file including its original filename.
+			res := ind.UserMediaFile(f, o, relatedOriginalNames[f.FileName()], photoUID, opt.UID)
 
-				// Save file error.
-				if fileUid, err := res.FileError(); err != nil {
-					query.SetFileError(fileUid, err.Error())
-				}
-
-				// Log result.
-				log.Infof("import: %s related %s file %s", res, f.FileType(), clean.Log(f.RootRelName()))
+			// Save file error.
+			if fileUid, err := res.FileError(); err != nil {
+				query.SetFileError(fileUid, err.Error())
 			}
 
+			// Log result.
+			log.Infof("import: %s related %s file %s", res, f.FileType(), clean.Log(f.RootRelName()))
 		}
+
 	}
 }
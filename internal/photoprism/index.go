@@ -69,6 +69,28 @@ func (ind *Index) thumbPath() string {
 	return ind.conf.ThumbCachePath()
 }
 
+// createThumbnails creates the default thumbnail sizes for a media file, unless they
+// should be created when first requested.
+func (ind *Index) createThumbnails(m *MediaFile) error {
+	if ind.conf.ThumbLazy() {
+		return nil
+	}
+
+	return m.CreateThumbnails(ind.thumbPath(), false)
+}
+
+// smartCropThumbnails recreates the square thumbnails of a media file so that text and watermarks
+// are not cut off, if enabled for the photo type and thumbnails are not created lazily.
+func (ind *Index) smartCropThumbnails(m *MediaFile, photoType string) {
+	if ind.conf.ThumbLazy() || !ind.conf.ThumbSmartCropType(photoType) || !m.IsPreviewImage() {
+		return
+	}
+
+	if _, err := m.SmartCropThumbnails(ind.thumbPath()); err != nil {
+		log.Warnf("index: %s in %s (smart crop)", err, clean.Log(m.RootRelName()))
+	}
+}
+
 // Cancel stops the current indexing operation.
 func (ind *Index) Cancel() {
 	mutex.MainWorker.Cancel()
@@ -143,6 +165,19 @@ func (ind *Index) Start(o IndexOptions) (found fs.Done, updated int) {
 		log.Infof(`index: ignored "%s"`, fs.RelName(fileName, originalsPath))
 	}
 
+	// Make sure parent folders have an album as well when indexing a subfolder.
+	if o.FolderAlbums {
+		for dir := filepath.Dir(fs.RelName(optionsPath, originalsPath)); dir != "." && dir != "/" && dir != ""; dir = filepath.Dir(dir) {
+			folder := entity.NewFolder(entity.RootOriginals, dir, fs.BirthTime(filepath.Join(originalsPath, dir)))
+
+			if parent := entity.FirstOrCreateFolder(&folder); parent == nil {
+				continue
+			} else if err := parent.UpdateAlbum(); err != nil {
+				log.Errorf("index: %s (update album for /%s)", err, parent.Path)
+			}
+		}
+	}
+
 	err := godirwalk.Walk(optionsPath, &godirwalk.Options{
 		ErrorCallback: func(fileName string, err error) godirwalk.ErrorAction {
 			return godirwalk.SkipNode
@@ -173,6 +208,10 @@ func (ind *Index) Start(o IndexOptions) (found fs.Done, updated int) {
 
 					if err := folder.Create(); err == nil {
 						log.Infof("index: added folder /%s", folder.Path)
+					} else if o.FolderAlbums {
+						if err = folder.UpdateAlbum(); err != nil {
+							log.Errorf("index: %s (update album for /%s)", err, folder.Path)
+						}
 					}
 				}
 
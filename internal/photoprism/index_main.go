@@ -53,7 +53,7 @@ func IndexMain(related *RelatedFiles, ind *Index, o IndexOptions) (result IndexR
 		} else {
 			log.Debugf("index: created %s", clean.Log(jpg.BaseName()))
 
-			if err := jpg.CreateThumbnails(ind.thumbPath(), false); err != nil {
+			if err := ind.createThumbnails(jpg); err != nil {
 				result.Err = fmt.Errorf("index: failed creating thumbnails for %s (%s)", clean.Log(f.RootRelName()), err.Error())
 				result.Status = IndexFailed
 				return result
@@ -63,6 +63,15 @@ func IndexMain(related *RelatedFiles, ind *Index, o IndexOptions) (result IndexR
 		}
 	}
 
+	// Extract the video embedded in motion photos so that they can be played as live photos.
+	if o.Convert && f.IsJpeg() && !related.HasVideo() {
+		if video, err := ind.convert.ToEmbeddedVideo(f, false); err != nil {
+			log.Warnf("index: %s", err)
+		} else if video != nil {
+			related.Files = append(related.Files, video)
+		}
+	}
+
 	// Index main MediaFile.
 	exists := ind.files.Exists(f.RootRelName(), f.Root())
 	result = ind.MediaFile(f, o, "", "")
@@ -7,13 +7,16 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize/english"
 	"github.com/jinzhu/gorm"
 
 	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/meta"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/rules"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/media"
@@ -231,7 +234,7 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 	}
 
 	// Create default thumbnails if needed.
-	if err := m.CreateThumbnails(ind.thumbPath(), false); err != nil {
+	if err := ind.createThumbnails(m); err != nil {
 		result.Status = IndexFailed
 		result.Err = fmt.Errorf("index: failed creating thumbnails for %s (%s)", clean.Log(m.RootRelName()), err.Error())
 		return result
@@ -256,6 +259,15 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 				log.Infof("index: uid %s restored from %s", photo.PhotoUID, clean.Log(filepath.Base(yamlName)))
 			}
 		}
+	} else if !m.IsSidecar() {
+		// Merge values edited in the YAML sidecar file, so that they are not overwritten by file metadata.
+		if yamlName := fs.SidecarYAML.FindFirst(m.FileName(), []string{Config().SidecarPath(), fs.HiddenPath}, Config().OriginalsPath(), stripSequence); yamlName != "" {
+			if merged, err := photo.MergeYaml(yamlName, Config().YamlPrecedence() == config.PrecedenceSidecar); err != nil {
+				log.Errorf("index: %s in %s (merge yaml)", err.Error(), logName)
+			} else if merged > 0 {
+				log.Infof("index: merged %s from %s", english.Plural(merged, "edited value", "edited values"), clean.Log(filepath.Base(yamlName)))
+			}
+		}
 	}
 
 	// Calculate SHA1 file hash if not exists.
@@ -359,6 +371,13 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 	file.FileDiff = -1
 	file.FileChroma = -1
 
+	// Flag files with partially corrupt metadata, see query.MetaErrorFiles.
+	if metaData := m.MetaData(); metaData.Warning != nil {
+		file.FileMetaError = metaData.Warning.Error()
+	} else {
+		file.FileMetaError = ""
+	}
+
 	// Handle file types.
 	switch {
 	case m.IsPreviewImage():
@@ -379,6 +398,16 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			}
 		}
 
+		// ThumbHash and BlurHash placeholders
+		if file.FilePrimary {
+			if thumbHash, blurHash, err := m.Placeholders(Config().ThumbCachePath()); err != nil {
+				log.Debugf("index: %s while creating placeholders for %s", err, logName)
+			} else {
+				photo.PhotoThumbHash = thumbHash
+				photo.PhotoBlurHash = blurHash
+			}
+		}
+
 		if m.Width() > 0 && m.Height() > 0 {
 			file.FileWidth = m.Width()
 			file.FileHeight = m.Height()
@@ -400,6 +429,7 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			file.SetFrames(metaData.Frames)
 			file.SetProjection(metaData.Projection)
 			file.SetHDR(metaData.IsHDR())
+			file.SetFlash(metaData.Flash)
 			file.SetColorProfile(metaData.ColorProfile)
 			file.SetSoftware(metaData.Software)
 
@@ -489,6 +519,7 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			file.SetFrames(metaData.Frames)
 			file.SetProjection(metaData.Projection)
 			file.SetHDR(metaData.IsHDR())
+			file.SetFlash(metaData.Flash)
 			file.SetColorProfile(metaData.ColorProfile)
 			file.SetSoftware(metaData.Software)
 
@@ -559,6 +590,7 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			file.SetFrames(metaData.Frames)
 			file.SetProjection(metaData.Projection)
 			file.SetHDR(metaData.IsHDR())
+			file.SetFlash(metaData.Flash)
 			file.SetColorProfile(metaData.ColorProfile)
 			file.SetSoftware(metaData.Software)
 
@@ -571,6 +603,11 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 		if photo.TypeSrc == entity.SrcAuto {
 			photo.PhotoType = entity.MediaVector
 		}
+	case m.IsDocument():
+		// Update photo type if not manually modified.
+		if photo.TypeSrc == entity.SrcAuto {
+			photo.PhotoType = entity.MediaDocument
+		}
 	case m.IsVideo():
 		if metaData := m.MetaData(); metaData.Error == nil {
 			photo.SetTitle(metaData.Title, entity.SrcMeta)
@@ -618,6 +655,7 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			file.SetFrames(metaData.Frames)
 			file.SetProjection(metaData.Projection)
 			file.SetHDR(metaData.IsHDR())
+			file.SetFlash(metaData.Flash)
 			file.SetColorProfile(metaData.ColorProfile)
 			file.SetSoftware(metaData.Software)
 
@@ -688,6 +726,11 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 				labels = append(labels, extraLabels...)
 			}
 
+			// Flag new pictures with only low-confidence labels for review.
+			if !photoExists {
+				photo.ReviewNeeded = labels.Uncertain()
+			}
+
 			if !photoExists && Config().Settings().Features.Private && Config().DetectNSFW() {
 				photo.PhotoPrivate = ind.NSFW(m)
 			}
@@ -798,6 +841,13 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 		event.EntitiesCreated("photos", []entity.Photo{photo})
 	}
 
+	// Apply custom rules that map metadata to keywords and labels.
+	ruleKeywords, ruleLabels := rules.Cached(Config().RulesYaml()).Apply(&photo)
+
+	if len(ruleLabels) > 0 {
+		labels = append(labels, ruleLabels...)
+	}
+
 	photo.AddLabels(labels)
 
 	file.PhotoID = photo.ID
@@ -832,6 +882,7 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 		w = append(w, locKeywords...)
 		w = append(w, file.FileMainColor)
 		w = append(w, labels.Keywords()...)
+		w = append(w, ruleKeywords...)
 
 		details.Keywords = strings.Join(txt.UniqueWords(w), ", ")
 
@@ -902,6 +953,10 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 		}
 	}
 
+	if file.FilePrimary {
+		ind.smartCropThumbnails(m, photo.PhotoType)
+	}
+
 	result.FileID = file.ID
 	result.FileUID = file.FileUID
 
@@ -10,6 +10,7 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/internal/query"
 )
 
 func TestIndex_MediaFile(t *testing.T) {
@@ -77,6 +78,62 @@ func TestIndex_MediaFile(t *testing.T) {
 		assert.Equal(t, "Blue Gopher", mediaFile.metaData.Title)
 		assert.Equal(t, IndexStatus("added"), result.Status)
 	})
+	t.Run("corrupt-exif.jpg", func(t *testing.T) {
+		cfg := config.TestConfig()
+
+		cfg.InitializeTestData()
+
+		tf := classify.New(cfg.AssetsPath(), cfg.DisableTensorFlow())
+		nd := nsfw.New(cfg.NSFWModelPath())
+		fn := face.NewNet(cfg.FaceNetModelPath(), "", cfg.DisableTensorFlow())
+		convert := NewConvert(cfg)
+
+		ind := NewIndex(cfg, tf, nd, fn, convert, NewFiles(), NewPhotos())
+		indexOpt := IndexOptionsAll()
+		mediaFile, err := NewMediaFile("../meta/testdata/corrupt-exif.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result := ind.MediaFile(mediaFile, indexOpt, "corrupt-exif.jpg", "")
+
+		assert.Equal(t, IndexStatus("added"), result.Status)
+
+		file, err := entity.FirstFileByHash(mediaFile.Hash())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, file.FileMetaError, "corrupt exif data")
+
+		photo, err := query.PhotoByUID(result.PhotoUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "2011-07-10T17:34:28Z", photo.TakenAt.Format("2006-01-02T15:04:05Z"))
+		assert.Equal(t, entity.SrcMeta, photo.TakenSrc)
+		assert.Equal(t, "Photographer: TMB", photo.Details.Artist)
+
+		files, err := query.MetaErrorFiles(100, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var found bool
+
+		for _, f := range files {
+			if f.FileUID == file.FileUID {
+				found = true
+			}
+		}
+
+		assert.True(t, found)
+	})
 	t.Run("error", func(t *testing.T) {
 		cfg := config.TestConfig()
 
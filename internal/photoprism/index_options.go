@@ -14,6 +14,7 @@ type IndexOptions struct {
 	SkipArchived    bool
 	ByteLimit       int64
 	ResolutionLimit int
+	FolderAlbums    bool
 }
 
 // NewIndexOptions returns new index options instance.
@@ -29,6 +30,7 @@ func NewIndexOptions(path string, rescan, convert, stack, facesOnly, skipArchive
 		SkipArchived:    skipArchived,
 		ByteLimit:       Config().OriginalsByteLimit(),
 		ResolutionLimit: Config().ResolutionLimit(),
+		FolderAlbums:    Config().FolderAlbums(),
 	}
 
 	return result
@@ -76,7 +76,7 @@ func IndexRelated(related RelatedFiles, ind *Index, o IndexOptions) (result Inde
 			} else {
 				log.Debugf("index: created %s", clean.Log(jpg.BaseName()))
 
-				if err := jpg.CreateThumbnails(ind.thumbPath(), false); err != nil {
+				if err := ind.createThumbnails(jpg); err != nil {
 					result.Err = fmt.Errorf("index: failed creating thumbnails for %s (%s)", clean.Log(f.RootRelName()), err.Error())
 					result.Status = IndexFailed
 					return result
@@ -1,6 +1,8 @@
 package photoprism
 
 import (
+	"bytes"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -8,10 +10,13 @@ import (
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/video"
 )
 
 func TestIndexRelated(t *testing.T) {
@@ -143,4 +148,80 @@ func TestIndexRelated(t *testing.T) {
 			assert.Equal(t, "xmp", photo.TakenSrc)
 		}
 	})
+	t.Run("motion-photo.jpg", func(t *testing.T) {
+		conf := config.TestConfig()
+
+		testToken := rnd.GenerateToken(8)
+		testPath := filepath.Join(conf.OriginalsPath(), testToken)
+		testName := filepath.Join(testPath, "motion-photo.jpg")
+
+		jpegData, err := os.ReadFile(filepath.Join(conf.ExamplesPath(), "cat_brown.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		videoData, err := os.ReadFile(filepath.Join(conf.ExamplesPath(), "christmas.mp4"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Create a Samsung motion photo with an embedded video.
+		if err = os.MkdirAll(testPath, fs.ModeDir); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(testName, bytes.Join([][]byte{jpegData, video.SamsungMotionPhotoMarker, videoData}, nil), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		mainFile, err := NewMediaFile(testName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		related, err := mainFile.RelatedFiles(true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tf := classify.New(conf.AssetsPath(), conf.DisableTensorFlow())
+		nd := nsfw.New(conf.NSFWModelPath())
+		fn := face.NewNet(conf.FaceNetModelPath(), "", conf.DisableTensorFlow())
+		convert := NewConvert(conf)
+
+		ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+		opt := IndexOptionsAll()
+
+		result := IndexRelated(related, ind, opt)
+
+		assert.Nil(t, result.Err)
+		assert.True(t, result.Success())
+		assert.Equal(t, IndexAdded, result.Status)
+
+		videoName := filepath.Join(conf.SidecarPath(), testToken, "motion-photo.jpg.mp4")
+
+		if data, err := os.ReadFile(videoName); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, videoData, data)
+		}
+
+		if photo, err := query.PhotoPreloadByUID(result.PhotoUID); err != nil {
+			t.Fatal(err)
+		} else {
+			var videos int
+
+			for _, f := range photo.Files {
+				if f.FileVideo {
+					videos++
+					assert.Equal(t, filepath.Join(testToken, "motion-photo.jpg.mp4"), f.FileName)
+					assert.Equal(t, entity.RootSidecar, f.FileRoot)
+				}
+			}
+
+			assert.Equal(t, 1, videos)
+		}
+	})
 }
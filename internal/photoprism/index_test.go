@@ -1,6 +1,8 @@
 package photoprism
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -9,8 +11,10 @@ import (
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/internal/thumb"
 )
 
 func TestIndex_Start(t *testing.T) {
@@ -82,3 +86,38 @@ func TestIndex_File(t *testing.T) {
 
 	assert.Equal(t, IndexFailed, err.Status)
 }
+
+func TestIndex_SmartCropThumbnails(t *testing.T) {
+	conf := config.TestConfig()
+
+	ind := NewIndex(conf, nil, nil, nil, NewConvert(conf), NewFiles(), NewPhotos())
+
+	m, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "elephants.jpg"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fileName, err := thumb.Sizes[thumb.Tile224].FileName(m.Hash(), ind.thumbPath())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = os.Remove(fileName)
+
+	t.Run("Disabled", func(t *testing.T) {
+		ind.smartCropThumbnails(m, entity.MediaImage)
+		assert.NoFileExists(t, fileName)
+	})
+	t.Run("Enabled", func(t *testing.T) {
+		conf.Options().ThumbSmartCrop = "document,image"
+		defer func() { conf.Options().ThumbSmartCrop = "" }()
+
+		ind.smartCropThumbnails(m, entity.MediaVideo)
+		assert.NoFileExists(t, fileName)
+
+		ind.smartCropThumbnails(m, entity.MediaImage)
+		assert.FileExists(t, fileName)
+	})
+}
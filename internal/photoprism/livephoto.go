@@ -4,3 +4,6 @@ import "time"
 
 // LivePhotoDurationLimit is the maximum duration of a live photo.
 var LivePhotoDurationLimit = time.Millisecond * 3100
+
+// LivePhotoTimeTolerance is the maximum time difference between the image and video of a live photo.
+var LivePhotoTimeTolerance = time.Second * 3
@@ -815,6 +815,16 @@ func (m *MediaFile) IsVector() bool {
 	return m.HasMediaType(media.Vector) || m.IsSVG()
 }
 
+// IsDocument returns true if this is a document, e.g. a PDF file.
+func (m *MediaFile) IsDocument() bool {
+	return m.HasMediaType(media.Document)
+}
+
+// IsPDF returns true if this is a PDF document.
+func (m *MediaFile) IsPDF() bool {
+	return m.FileType() == fs.DocumentPDF
+}
+
 // IsSidecar checks if the file is a metadata sidecar file, independent of the storage location.
 func (m *MediaFile) IsSidecar() bool {
 	return m.Media() == media.Sidecar
@@ -862,12 +872,8 @@ func (m *MediaFile) IsImageNative() bool {
 
 // IsLive checks if the file is a live photo.
 func (m *MediaFile) IsLive() bool {
-	if m.IsHEIC() {
-		return fs.VideoMOV.FindFirst(m.FileName(), []string{}, Config().OriginalsPath(), false) != ""
-	}
-
-	if m.IsVideo() {
-		return fs.ImageHEIC.FindFirst(m.FileName(), []string{}, Config().OriginalsPath(), false) != ""
+	if m.IsHEIF() || m.IsVideo() {
+		return fs.LivePhotoPair(m.FileName()) != ""
 	}
 
 	return false
@@ -875,12 +881,12 @@ func (m *MediaFile) IsLive() bool {
 
 // ExifSupported returns true if parsing exif metadata is supported for the media file type.
 func (m *MediaFile) ExifSupported() bool {
-	return m.IsJpeg() || m.IsRaw() || m.IsHEIF() || m.IsPNG() || m.IsTIFF()
+	return m.IsJpeg() || m.IsRaw() || m.IsHEIF() || m.IsPNG() || m.IsTIFF() || m.IsWebP()
 }
 
-// IsMedia returns true if this is a media file (photo or video, not sidecar or other).
+// IsMedia returns true if this is a media file (photo, video, or document, not sidecar or other).
 func (m *MediaFile) IsMedia() bool {
-	return m.IsImage() || m.IsRaw() || m.IsVideo() || m.IsVector()
+	return m.IsImage() || m.IsRaw() || m.IsVideo() || m.IsVector() || m.IsDocument()
 }
 
 // PreviewImage returns a PNG or JPEG version of the media file, if exists.
@@ -968,6 +974,18 @@ func (m *MediaFile) decodeDimensions() error {
 		return nil
 	}
 
+	// Read the width and height from the container for AVIF images, as they cannot be decoded natively.
+	if m.IsAVIF() {
+		if w, h, err := meta.AvifSize(m.FileName()); err != nil {
+			log.Debugf("media: %s in %s", err, clean.Log(m.BaseName()))
+		} else if w > 0 && h > 0 {
+			m.width = w
+			m.height = h
+
+			return nil
+		}
+	}
+
 	// Extract the width and height from metadata for other formats.
 	if data := m.MetaData(); data.Error != nil {
 		return data.Error
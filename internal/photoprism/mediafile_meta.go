@@ -104,6 +104,18 @@ func (m *MediaFile) MetaData() (result meta.Data) {
 			}
 		}
 
+		// Read location from QuickTime atoms if it is missing, e.g. because ExifTool is disabled.
+		if m.IsVideo() && m.metaData.Lat == 0 && m.metaData.Lng == 0 {
+			if qtErr := m.metaData.QuickTime(m.FileName()); qtErr != nil {
+				log.Trace(qtErr)
+			}
+		}
+
+		// Ignore partially corrupt Exif data in strict mode.
+		if err == nil && m.metaData.Warning != nil && Config().ExifStrict() {
+			err = m.metaData.Warning
+		}
+
 		if err != nil {
 			m.metaData.Error = err
 			log.Debugf("metadata: %s in %s", err, clean.Log(m.BaseName()))
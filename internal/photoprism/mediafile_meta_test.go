@@ -386,3 +386,67 @@ func TestMediaFile_Exif_HEIC(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestMediaFile_MetaData_VideoLocation(t *testing.T) {
+	t.Run("location-android.mp4", func(t *testing.T) {
+		mediaFile, err := NewMediaFile("testdata/location-android.mp4")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, mediaFile.IsVideo())
+
+		data := mediaFile.MetaData()
+
+		// The location is read from QuickTime atoms, but the Exif error is kept.
+		assert.EqualError(t, data.Error, "exif not supported")
+		assert.InDelta(t, 52.4587, data.Lat, 0.0001)
+		assert.InDelta(t, 13.4593, data.Lng, 0.0001)
+	})
+	t.Run("gopher-video.mp4", func(t *testing.T) {
+		conf := config.TestConfig()
+
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/gopher-video.mp4")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data := mediaFile.MetaData()
+
+		assert.Equal(t, float32(0), data.Lat)
+		assert.Equal(t, float32(0), data.Lng)
+	})
+}
+
+func TestMediaFile_MetaData_CorruptExif(t *testing.T) {
+	t.Run("Tolerant", func(t *testing.T) {
+		mediaFile, err := NewMediaFile("../meta/testdata/corrupt-exif.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data := mediaFile.MetaData()
+
+		assert.NoError(t, data.Error)
+		assert.Error(t, data.Warning)
+		assert.Equal(t, "Canon", data.CameraMake)
+	})
+	t.Run("Strict", func(t *testing.T) {
+		Config().Options().ExifStrict = true
+		defer func() { Config().Options().ExifStrict = false }()
+
+		mediaFile, err := NewMediaFile("../meta/testdata/corrupt-exif.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data := mediaFile.MetaData()
+
+		assert.Error(t, data.Error)
+		assert.Error(t, data.Warning)
+	})
+}
@@ -0,0 +1,36 @@
+package photoprism
+
+// OrientationMismatch checks if the Exif orientation of a JPEG or PNG file is inconsistent with its
+// pixel data, e.g. because an app has rotated the pixels without resetting the orientation flag, so that
+// thumbnails would be rotated twice. For this, the aspect ratio of the thumbnails is compared with the
+// aspect ratio expected from the image size stored in the Exif header. If a mismatch is found, the
+// suggested orientation is returned.
+func (m *MediaFile) OrientationMismatch() (suggested int, mismatch bool) {
+	if m == nil || !m.IsPreviewImage() {
+		return 0, false
+	}
+
+	data := m.MetaData()
+
+	// Only files with an orientation that swaps width and height can be detected.
+	if data.Error != nil || data.Orientation <= 4 || data.Width <= 0 || data.Height <= 0 || data.Width == data.Height {
+		return 0, false
+	}
+
+	cfg, err := m.DecodeConfig()
+
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width == cfg.Height {
+		return 0, false
+	}
+
+	// Thumbnails are rotated according to the orientation flag, so width and height are swapped.
+	thumbPortrait := cfg.Height < cfg.Width
+	expectedPortrait := data.ActualWidth() < data.ActualHeight()
+
+	if thumbPortrait == expectedPortrait {
+		return 0, false
+	}
+
+	// The pixels already appear to be upright.
+	return 1, true
+}
@@ -0,0 +1,64 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestMediaFile_OrientationMismatch(t *testing.T) {
+	t.Run("Mismatch", func(t *testing.T) {
+		// Pixels are already upright, but the Exif orientation is still 6.
+		mediaFile, err := NewMediaFile("testdata/orientation_mismatch.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 6, mediaFile.Orientation())
+
+		suggested, mismatch := mediaFile.OrientationMismatch()
+
+		assert.True(t, mismatch)
+		assert.Equal(t, 1, suggested)
+	})
+	t.Run("Rotated", func(t *testing.T) {
+		// Pixels are stored sideways and need to be rotated.
+		mediaFile, err := NewMediaFile("testdata/orientation_rotated.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 6, mediaFile.Orientation())
+
+		suggested, mismatch := mediaFile.OrientationMismatch()
+
+		assert.False(t, mismatch)
+		assert.Equal(t, 0, suggested)
+	})
+	t.Run("NotRotated", func(t *testing.T) {
+		c := config.TestConfig()
+
+		mediaFile, err := NewMediaFile(c.ExamplesPath() + "/cat_black.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		suggested, mismatch := mediaFile.OrientationMismatch()
+
+		assert.False(t, mismatch)
+		assert.Equal(t, 0, suggested)
+	})
+	t.Run("Nil", func(t *testing.T) {
+		var mediaFile *MediaFile
+
+		suggested, mismatch := mediaFile.OrientationMismatch()
+
+		assert.False(t, mismatch)
+		assert.Equal(t, 0, suggested)
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/list"
 )
 
 // RelatedFiles returns files which are related to this file.
@@ -29,6 +30,9 @@ func (m *MediaFile) RelatedFiles(stripSequence bool) (result RelatedFiles, err e
 	// Ignore vector graphics?
 	skipVectors := Config().DisableVectors()
 
+	// Ignore PDF documents?
+	skipDocuments := !Config().PdfEnabled()
+
 	// Replace sidecar with originals path in search prefix.
 	if len(sidecarPrefix) > 1 && sidecarPrefix != originalsPrefix && strings.HasPrefix(prefix, sidecarPrefix) {
 		prefix = strings.Replace(prefix, sidecarPrefix, originalsPrefix, 1)
@@ -55,6 +59,11 @@ func (m *MediaFile) RelatedFiles(stripSequence bool) (result RelatedFiles, err e
 		matches = append(matches, name)
 	}
 
+	// Add the other file of a Live Photo, e.g. if its base name differs in case.
+	if name := fs.LivePhotoPair(m.FileName()); name != "" && !list.Contains(matches, name) {
+		matches = append(matches, name)
+	}
+
 	isHEIC := false
 
 	for _, fileName := range matches {
@@ -75,6 +84,9 @@ func (m *MediaFile) RelatedFiles(stripSequence bool) (result RelatedFiles, err e
 		case skipVectors && f.IsVector():
 			log.Debugf("media: skipped related vector graphic %s", clean.Log(f.RootRelName()))
 			continue
+		case skipDocuments && f.IsDocument():
+			log.Debugf("media: skipped related document %s", clean.Log(f.RootRelName()))
+			continue
 		}
 
 		// Set main file.
@@ -84,6 +96,8 @@ func (m *MediaFile) RelatedFiles(stripSequence bool) (result RelatedFiles, err e
 			result.Main = f
 		} else if f.IsVector() {
 			result.Main = f
+		} else if f.IsDocument() {
+			result.Main = f
 		} else if f.IsHEIC() {
 			isHEIC = true
 			result.Main = f
@@ -629,6 +629,41 @@ func TestMediaFile_RelatedFiles(t *testing.T) {
 	})
 }
 
+func TestMediaFile_RelatedFiles_LivePhoto(t *testing.T) {
+	conf := config.TestConfig()
+	dir := t.TempDir()
+
+	stillName := filepath.Join(dir, "IMG_1234.HEIC")
+	videoName := filepath.Join(dir, "img_1234.mov")
+
+	if err := fs.Copy(conf.ExamplesPath()+"/iphone_7.heic", stillName); err != nil {
+		t.Fatal(err)
+	} else if err = fs.Copy(conf.ExamplesPath()+"/earth.mov", videoName); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, fileName := range []string{stillName, videoName} {
+		t.Run(filepath.Base(fileName), func(t *testing.T) {
+			mediaFile, err := NewMediaFile(fileName)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.True(t, mediaFile.IsLive())
+
+			related, err := mediaFile.RelatedFiles(false)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Len(t, related.Files, 2)
+			assert.Equal(t, stillName, related.Main.FileName())
+		})
+	}
+}
+
 func TestMediaFile_RelatedFiles_Ordering(t *testing.T) {
 	conf := config.TestConfig()
 
@@ -1522,7 +1557,7 @@ func TestMediaFile_IsAnimated(t *testing.T) {
 			assert.Equal(t, true, f.IsWebP())
 			assert.Equal(t, true, f.IsAnimated())
 			assert.Equal(t, true, f.IsAnimatedImage())
-			assert.Equal(t, false, f.ExifSupported())
+			assert.Equal(t, true, f.ExifSupported())
 			assert.Equal(t, false, f.IsVideo())
 			assert.Equal(t, false, f.IsGIF())
 			assert.Equal(t, false, f.IsAVIF())
@@ -1800,6 +1835,25 @@ func TestMediaFile_Width(t *testing.T) {
 		width := mediaFile.Width()
 		assert.Equal(t, 416, width)
 	})
+	t.Run("yellow_rose.webp", func(t *testing.T) {
+		cfg := config.TestConfig()
+
+		mediaFile, err := NewMediaFile(cfg.ExamplesPath() + "/yellow_rose.webp")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 400, mediaFile.Width())
+		assert.True(t, mediaFile.ExifSupported())
+	})
+	t.Run("fox.profile0.8bpc.yuv420.avif", func(t *testing.T) {
+		cfg := config.TestConfig()
+
+		mediaFile, err := NewMediaFile(cfg.ExamplesPath() + "/fox.profile0.8bpc.yuv420.avif")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 1204, mediaFile.Width())
+	})
 }
 
 func TestMediaFile_Height(t *testing.T) {
@@ -1827,6 +1881,18 @@ func TestMediaFile_Height(t *testing.T) {
 		height := mediaFile.Height()
 		assert.Equal(t, 331, height)
 	})
+	t.Run("fox.profile0.8bpc.yuv420.avif", func(t *testing.T) {
+		conf := config.TestConfig()
+
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/fox.profile0.8bpc.yuv420.avif")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		height := mediaFile.Height()
+		assert.Equal(t, 800, height)
+	})
 }
 
 func TestMediaFile_Megapixels(t *testing.T) {
@@ -2548,3 +2614,35 @@ func TestMediaFile_Duration(t *testing.T) {
 		}
 	})
 }
+
+func TestMediaFile_IsDocument(t *testing.T) {
+	pdfFile := filepath.Join(t.TempDir(), "document.pdf")
+
+	if err := os.WriteFile(pdfFile, []byte("%PDF-1.4\n%%EOF\n"), fs.ModeFile); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("PDF", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(pdfFile)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, mediaFile.IsDocument())
+		assert.True(t, mediaFile.IsPDF())
+		assert.True(t, mediaFile.IsMedia())
+		assert.False(t, mediaFile.IsImage())
+		assert.False(t, mediaFile.IsPreviewImage())
+	})
+	t.Run("JPEG", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(filepath.Join(config.TestConfig().ExamplesPath(), "elephants.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, mediaFile.IsDocument())
+		assert.False(t, mediaFile.IsPDF())
+	})
+}
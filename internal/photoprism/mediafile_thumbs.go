@@ -2,6 +2,7 @@ package photoprism
 
 import (
 	"bytes"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"image"
@@ -61,23 +62,51 @@ func (m *MediaFile) Resample(path string, sizeName thumb.Name) (img image.Image,
 	return imaging.Open(thumbName)
 }
 
+// ThumbHash returns the ThumbHash placeholder of the image, base64 encoded with the URL-safe alphabet and without padding.
+func (m *MediaFile) ThumbHash(thumbPath string) (string, error) {
+	hash, _, err := m.Placeholders(thumbPath)
+	return hash, err
+}
+
+// Placeholders returns the URL-safe base64 encoded ThumbHash and the BlurHash placeholder of the image.
+// Both are computed from the same resampled image, so it only needs to be decoded once.
+func (m *MediaFile) Placeholders(thumbPath string) (thumbHash, blurHash string, err error) {
+	if !m.IsPreviewImage() {
+		return "", "", fmt.Errorf("%s is not a jpeg", clean.Log(m.BaseName()))
+	}
+
+	img, err := m.Resample(thumbPath, thumb.Fit720)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(thumb.ThumbHash(img)), thumb.BlurHash(img), nil
+}
+
 // CreateThumbnails creates the default thumbnail sizes if the media file
 // is a JPEG and they don't exist yet (except force is true).
 func (m *MediaFile) CreateThumbnails(thumbPath string, force bool) (err error) {
+	_, _, err = m.WarmThumbnails(thumbPath, force)
+	return err
+}
+
+// WarmThumbnails creates the default thumbnail sizes like CreateThumbnails, and returns
+// the number of thumbnails that were created and that already existed in the cache.
+func (m *MediaFile) WarmThumbnails(thumbPath string, force bool) (created, cached int, err error) {
 	if !m.IsPreviewImage() {
 		// Skip.
-		return
+		return 0, 0, nil
 	}
 
-	count := 0
 	start := time.Now()
 
 	defer func() {
-		switch count {
+		switch created {
 		case 0:
 			log.Debug(capture.Time(start, fmt.Sprintf("media: created no new thumbnails for %s", clean.Log(m.RootRelName()))))
 		default:
-			log.Info(capture.Time(start, fmt.Sprintf("media: created %s for %s", english.Plural(count, "thumbnail", "thumbnails"), clean.Log(m.RootRelName()))))
+			log.Info(capture.Time(start, fmt.Sprintf("media: created %s for %s", english.Plural(created, "thumbnail", "thumbnails"), clean.Log(m.RootRelName()))))
 		}
 	}()
 
@@ -97,8 +126,10 @@ func (m *MediaFile) CreateThumbnails(thumbPath string, force bool) (err error) {
 			continue
 		} else if fileName, err = size.FileName(hash, thumbPath); err != nil {
 			log.Errorf("media: failed creating %s (%s)", clean.Log(string(name)), err)
-			return err
-		} else if force || !fs.FileExists(fileName) {
+			return created, cached, err
+		} else if !force && fs.FileExists(fileName) {
+			cached++
+		} else {
 			// Open original if needed.
 			if original == nil {
 				img, err := thumb.Open(m.FileName(), m.Orientation())
@@ -107,13 +138,13 @@ func (m *MediaFile) CreateThumbnails(thumbPath string, force bool) (err error) {
 				if err != nil {
 					if !strings.HasPrefix(err.Error(), "invalid JPEG format") {
 						log.Debugf("media: %s in %s", err.Error(), clean.Log(m.RootRelName()))
-						return err
+						return created, cached, err
 					}
 
 					if fixed, err := NewConvert(conf).FixJpeg(m, false); err != nil {
-						return err
+						return created, cached, err
 					} else if fixedImg, err := thumb.Open(fixed.FileName(), m.Orientation()); err != nil {
-						return err
+						return created, cached, err
 					} else {
 						img = fixedImg
 					}
@@ -146,14 +177,77 @@ func (m *MediaFile) CreateThumbnails(thumbPath string, force bool) (err error) {
 			// Failed?
 			if err != nil {
 				log.Errorf("media: failed creating %s (%s)", name.String(), err)
-				return err
+				return created, cached, err
 			}
 
-			count++
+			created++
 		}
 	}
 
-	return nil
+	return created, cached, nil
+}
+
+// ReframeThumbnails creates copies of the center-filled thumbnails using the specified fill method, e.g. top-left for
+// documents. They are saved with the method in their file name, so the cached center-filled thumbnails remain valid.
+func (m *MediaFile) ReframeThumbnails(thumbPath string, method thumb.ResampleOption) (count int, err error) {
+	return m.reframeThumbnails(thumbPath, method, true)
+}
+
+// SmartCropThumbnails recreates the center-filled thumbnails so that text and watermarks are not cut off. The
+// regular file names are kept, as this is done while indexing and clients request them without a crop parameter.
+func (m *MediaFile) SmartCropThumbnails(thumbPath string) (count int, err error) {
+	return m.reframeThumbnails(thumbPath, thumb.ResampleFillSmart, false)
+}
+
+// reframeThumbnails recreates the center-filled thumbnails using the specified fill method and either saves
+// them with the method in their file name or replaces the existing files.
+func (m *MediaFile) reframeThumbnails(thumbPath string, method thumb.ResampleOption, rename bool) (count int, err error) {
+	if !m.IsPreviewImage() {
+		return 0, fmt.Errorf("%s is not a jpeg", clean.Log(m.BaseName()))
+	}
+
+	original, err := thumb.Open(m.FileName(), m.Orientation())
+
+	if err != nil {
+		log.Debugf("media: %s in %s", err.Error(), clean.Log(m.RootRelName()))
+		return 0, err
+	}
+
+	hash := m.Hash()
+
+	for _, name := range thumb.Names {
+		size := thumb.Sizes[name]
+
+		if size.Uncached() || !size.FillCenter() {
+			continue
+		}
+
+		reframed := size.Reframe(method)
+
+		var fileName string
+
+		if rename {
+			fileName, err = reframed.FileName(hash, thumbPath)
+		} else {
+			fileName, err = size.FileName(hash, thumbPath)
+		}
+
+		if err != nil {
+			log.Errorf("media: failed reframing %s (%s)", clean.Log(string(name)), err)
+			return count, err
+		}
+
+		if _, err = reframed.Create(original, fileName); err != nil {
+			log.Errorf("media: failed reframing %s (%s)", name.String(), err)
+			return count, err
+		}
+
+		count++
+	}
+
+	log.Infof("media: reframed %s for %s", english.Plural(count, "thumbnail", "thumbnails"), clean.Log(m.RootRelName()))
+
+	return count, nil
 }
 
 // ChangeOrientation changes the file orientation.
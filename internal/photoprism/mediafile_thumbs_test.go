@@ -1,10 +1,14 @@
 package photoprism
 
 import (
+	"encoding/base64"
+	"image"
+	"math"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/disintegration/imaging"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/photoprism/photoprism/internal/config"
@@ -113,6 +117,107 @@ func TestMediaFile_Resample(t *testing.T) {
 
 }
 
+func TestMediaFile_ThumbHash(t *testing.T) {
+	conf := config.TestConfig()
+
+	thumbsPath := conf.CachePath() + "/.test_mediafile_thumbhash"
+
+	defer func(path string) {
+		_ = os.RemoveAll(path)
+	}(thumbsPath)
+
+	t.Run("elephants.jpg", func(t *testing.T) {
+		image, err := NewMediaFile(conf.ExamplesPath() + "/elephants.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hash, err := image.ThumbHash(thumbsPath)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, hash)
+		assert.NotContains(t, hash, "=")
+
+		if data, err := base64.RawURLEncoding.DecodeString(hash); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.NotEmpty(t, data)
+		}
+
+		again, err := image.ThumbHash(thumbsPath)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, hash, again)
+	})
+	t.Run("video", func(t *testing.T) {
+		video, err := NewMediaFile(conf.ExamplesPath() + "/gopher-video.mp4")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hash, err := video.ThumbHash(thumbsPath)
+
+		assert.Error(t, err)
+		assert.Empty(t, hash)
+	})
+}
+
+func TestMediaFile_Placeholders(t *testing.T) {
+	conf := config.TestConfig()
+
+	thumbsPath := conf.CachePath() + "/.test_mediafile_placeholders"
+
+	defer func(path string) {
+		_ = os.RemoveAll(path)
+	}(thumbsPath)
+
+	t.Run("elephants.jpg", func(t *testing.T) {
+		image, err := NewMediaFile(conf.ExamplesPath() + "/elephants.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		thumbHash, blurHash, err := image.Placeholders(thumbsPath)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, thumbHash)
+		assert.Len(t, blurHash, 28)
+
+		hash, err := image.ThumbHash(thumbsPath)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, thumbHash, hash)
+	})
+	t.Run("video", func(t *testing.T) {
+		video, err := NewMediaFile(conf.ExamplesPath() + "/gopher-video.mp4")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		thumbHash, blurHash, err := video.Placeholders(thumbsPath)
+
+		assert.Error(t, err)
+		assert.Empty(t, thumbHash)
+		assert.Empty(t, blurHash)
+	})
+}
+
 func TestMediaFile_CreateThumbnails(t *testing.T) {
 	c := config.TestConfig()
 
@@ -202,6 +307,127 @@ func TestMediaFile_CreateThumbnails(t *testing.T) {
 	})
 }
 
+func TestMediaFile_ReframeThumbnails(t *testing.T) {
+	c := config.TestConfig()
+
+	thumbsPath := "./.test_mediafile_reframethumbnails"
+
+	if p, err := filepath.Abs(thumbsPath); err != nil {
+		t.Fatal(err)
+	} else {
+		thumbsPath = p
+	}
+
+	defer func(path string) {
+		_ = os.RemoveAll(path)
+	}(thumbsPath)
+
+	if err := c.CreateDirectories(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("elephants.jpg", func(t *testing.T) {
+		m, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "elephants.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = m.CreateThumbnails(thumbsPath, true); err != nil {
+			t.Fatal(err)
+		}
+
+		size := thumb.Sizes[thumb.Tile224]
+		fileName, err := size.FileName(m.Hash(), thumbsPath)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		centered, err := imaging.Open(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		count, err := m.ReframeThumbnails(thumbsPath, thumb.ResampleFillTopLeft)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 4, count)
+
+		// The center-filled thumbnail must remain unchanged.
+		unchanged, err := imaging.Open(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0.0, imageDistance(unchanged, centered))
+
+		reframedName, err := size.Reframe(thumb.ResampleFillTopLeft).FileName(m.Hash(), thumbsPath)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEqual(t, fileName, reframedName)
+
+		reframed, err := imaging.Open(reframedName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		original, err := thumb.Open(m.FileName(), m.Orientation())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The reframed thumbnail must show the top left instead of the center of the landscape image.
+		topLeft, err := thumb.Resample(original, size.Width, size.Height, thumb.ResampleFillTopLeft)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, size.Bounds(), reframed.Bounds())
+		assert.Less(t, imageDistance(reframed, topLeft), imageDistance(centered, topLeft))
+	})
+	t.Run("NoJpeg", func(t *testing.T) {
+		m, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "canon_eos_6d.dng"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		count, err := m.ReframeThumbnails(thumbsPath, thumb.ResampleFillTopLeft)
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, count)
+	})
+}
+
+// imageDistance returns the mean absolute difference of the red channel of two images with the same size.
+func imageDistance(a, b image.Image) float64 {
+	var sum float64
+
+	bounds := a.Bounds()
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r1, _, _, _ := a.At(x, y).RGBA()
+			r2, _, _, _ := b.At(x, y).RGBA()
+			sum += math.Abs(float64(r1) - float64(r2))
+		}
+	}
+
+	return sum / float64(bounds.Dx()*bounds.Dy())
+}
+
 func TestMediaFile_ChangeOrientation(t *testing.T) {
 	t.Run("JPEG", func(t *testing.T) {
 		m, err := NewMediaFile("testdata/orientation.jpg")
@@ -238,3 +464,57 @@ func TestMediaFile_ChangeOrientation(t *testing.T) {
 		}
 	})
 }
+
+func TestMediaFile_WarmThumbnails(t *testing.T) {
+	thumbsPath, err := filepath.Abs("./.test_mediafile_warmthumbnails")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(thumbsPath)
+
+	t.Run("elephants.jpg", func(t *testing.T) {
+		m, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "elephants.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		created, cached, err := m.WarmThumbnails(thumbsPath, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Greater(t, created, 0)
+		assert.Equal(t, 0, cached)
+
+		// Thumbnails that already exist must not be created again.
+		created2, cached2, err := m.WarmThumbnails(thumbsPath, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, created2)
+		assert.Equal(t, created, cached2)
+
+		// Unless force is true.
+		created3, cached3, err := m.WarmThumbnails(thumbsPath, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, created, created3)
+		assert.Equal(t, 0, cached3)
+	})
+	t.Run("NoPreviewImage", func(t *testing.T) {
+		m, err := NewMediaFile(conf.ExamplesPath() + "/Random.docx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		created, cached, err := m.WarmThumbnails(thumbsPath, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, created)
+		assert.Equal(t, 0, cached)
+	})
+}
@@ -27,6 +27,17 @@ func (m RelatedFiles) HasPreview() bool {
 	return m.Main.IsPreviewImage()
 }
 
+// HasVideo checks if the list of files contains a video.
+func (m RelatedFiles) HasVideo() bool {
+	for _, f := range m.Files {
+		if f.IsVideo() {
+			return true
+		}
+	}
+
+	return false
+}
+
 // String returns file names as string.
 func (m RelatedFiles) String() string {
 	names := make([]string, len(m.Files))
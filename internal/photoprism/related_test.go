@@ -9,6 +9,34 @@ import (
 	"github.com/photoprism/photoprism/pkg/fs"
 )
 
+func TestRelatedFiles_HasVideo(t *testing.T) {
+	cfg := config.TestConfig()
+
+	mediaFile, err := NewMediaFile(cfg.ExamplesPath() + "/telegram_2020-01-30_09-57-18.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mediaFile2, err2 := NewMediaFile(cfg.ExamplesPath() + "/gopher-video.mp4")
+	if err2 != nil {
+		t.Fatal(err2)
+	}
+
+	t.Run("True", func(t *testing.T) {
+		relatedFiles := RelatedFiles{
+			Files: MediaFiles{mediaFile, mediaFile2},
+			Main:  mediaFile,
+		}
+		assert.True(t, relatedFiles.HasVideo())
+	})
+	t.Run("False", func(t *testing.T) {
+		relatedFiles := RelatedFiles{
+			Files: MediaFiles{mediaFile},
+			Main:  mediaFile,
+		}
+		assert.False(t, relatedFiles.HasVideo())
+	})
+}
+
 func TestRelatedFiles_HasPreview(t *testing.T) {
 	cfg := config.TestConfig()
 
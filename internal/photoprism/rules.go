@@ -0,0 +1,46 @@
+package photoprism
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/rules"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ApplyRules adds the keywords and labels of matching metadata rules to an indexed photo,
+// and returns true if any rule matched.
+func ApplyRules(r rules.Rules, p *entity.Photo) (matched bool, err error) {
+	if p == nil || !p.HasID() || len(r) == 0 {
+		return false, nil
+	}
+
+	keywords, labels := r.Apply(p)
+
+	if len(keywords) == 0 && len(labels) == 0 {
+		return false, nil
+	}
+
+	if len(labels) > 0 {
+		p.AddLabels(labels)
+	}
+
+	if len(keywords) > 0 {
+		details := p.GetDetails()
+		w := append(txt.Words(details.Keywords), keywords...)
+
+		if merged := strings.Join(txt.UniqueWords(w), ", "); merged != details.Keywords {
+			details.Keywords = merged
+
+			if err = details.Save(); err != nil {
+				return true, err
+			}
+		}
+	}
+
+	if err = p.SyncKeywordLabels(); err != nil {
+		return true, err
+	}
+
+	return true, p.IndexKeywords()
+}
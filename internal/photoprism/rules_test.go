@@ -0,0 +1,66 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/rules"
+)
+
+func TestApplyRules(t *testing.T) {
+	r, err := rules.Parse([]byte("- When: iso > 3200\n  Keywords: [highiso]\n- When: focal_length >= 200\n  Labels: [Telephoto]\n"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	takenAt := time.Date(2021, 5, 4, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Match", func(t *testing.T) {
+		p := &entity.Photo{PhotoName: "rules-match", PhotoIso: 6400, PhotoFocalLength: 400, TakenAt: takenAt, TakenAtLocal: takenAt}
+
+		if err := p.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _, _ = p.DeletePermanently() }()
+
+		matched, err := ApplyRules(r, p)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, matched)
+		assert.Contains(t, p.GetDetails().Keywords, "highiso")
+
+		found := false
+
+		for _, l := range p.Labels {
+			if l.Label != nil && l.Label.LabelName == "Telephoto" {
+				found = true
+				assert.Equal(t, entity.SrcRule, l.LabelSrc)
+			}
+		}
+
+		assert.True(t, found)
+	})
+	t.Run("NoMatch", func(t *testing.T) {
+		p := &entity.Photo{PhotoName: "rules-nomatch", PhotoIso: 100, PhotoFocalLength: 35, TakenAt: takenAt, TakenAtLocal: takenAt}
+
+		if err := p.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _, _ = p.DeletePermanently() }()
+
+		matched, err := ApplyRules(r, p)
+
+		assert.NoError(t, err)
+		assert.False(t, matched)
+		assert.NotContains(t, p.GetDetails().Keywords, "highiso")
+	})
+}
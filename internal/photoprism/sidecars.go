@@ -0,0 +1,169 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// SidecarsBatchSize is the default number of photos that are loaded from the index at once.
+const SidecarsBatchSize = 500
+
+// Sidecars represents a worker that rewrites YAML sidecar files, e.g. after a schema change.
+type Sidecars struct {
+	conf *config.Config
+}
+
+// NewSidecars returns a new sidecar files worker.
+func NewSidecars(conf *config.Config) *Sidecars {
+	return &Sidecars{conf: conf}
+}
+
+// Rebuild rewrites the YAML sidecar files of all photos taken within the specified date range
+// and returns the number of files written.
+func (w *Sidecars) Rebuild(opt SidecarsOptions) (written int, err error) {
+	if err = mutex.SidecarWorker.Start(); err != nil {
+		log.Warnf("sidecars: %s (start)", err)
+		return written, err
+	}
+
+	defer mutex.SidecarWorker.Stop()
+
+	return w.rebuild(opt)
+}
+
+// Start rewrites the YAML sidecar files of all photos taken within the specified date range in the
+// background. It returns an error if a rebuild is already running.
+func (w *Sidecars) Start(opt SidecarsOptions) error {
+	if err := mutex.SidecarWorker.Start(); err != nil {
+		return err
+	}
+
+	go func() {
+		defer mutex.SidecarWorker.Stop()
+
+		if _, err := w.rebuild(opt); err != nil {
+			log.Debugf("sidecars: %s (rebuild)", err)
+		}
+	}()
+
+	return nil
+}
+
+// rebuild rewrites the YAML sidecar files, the caller must hold the worker lock.
+func (w *Sidecars) rebuild(opt SidecarsOptions) (written int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("sidecars: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	start := time.Now()
+
+	limit := opt.BatchSize
+
+	if limit <= 0 {
+		limit = SidecarsBatchSize
+	}
+
+	originalsPath := w.conf.OriginalsPath()
+	sidecarPath := w.conf.SidecarPath()
+
+	// Start a fixed number of goroutines to write the files.
+	jobs := make(chan *entity.Photo)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var numWorkers = w.conf.Workers()
+
+	wg.Add(numWorkers)
+
+	for i := 0; i < numWorkers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for p := range jobs {
+				fileName := p.YamlFileName(originalsPath, sidecarPath)
+
+				if err := p.SaveAsYaml(fileName); err != nil {
+					log.Errorf("sidecars: %s while writing yaml file of %s", err, p.String())
+					continue
+				}
+
+				mu.Lock()
+				written++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	offset := 0
+	found := 0
+
+	for {
+		if mutex.SidecarWorker.Canceled() {
+			err = errors.New("rebuild canceled")
+			break
+		}
+
+		photos, queryErr := query.PhotosTakenBetween(opt.From, opt.To, limit, offset)
+
+		if queryErr != nil {
+			err = queryErr
+			break
+		}
+
+		for i := range photos {
+			if mutex.SidecarWorker.Canceled() {
+				break
+			}
+
+			jobs <- &photos[i]
+		}
+
+		found += len(photos)
+
+		event.Publish("sidecars.updating", event.Data{
+			"step":  "yaml",
+			"found": found,
+		})
+
+		if len(photos) < limit {
+			break
+		}
+
+		offset += limit
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	if err != nil {
+		log.Warnf("sidecars: %s", err)
+	}
+
+	log.Infof("sidecars: rewrote %s [%s]", english.Plural(written, "yaml file", "yaml files"), time.Since(start))
+
+	event.Publish("sidecars.completed", event.Data{
+		"found":   found,
+		"written": written,
+	})
+
+	return written, err
+}
+
+// Cancel stops the current rebuild, if any.
+func (w *Sidecars) Cancel() {
+	mutex.SidecarWorker.Cancel()
+}
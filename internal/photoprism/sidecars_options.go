@@ -0,0 +1,10 @@
+package photoprism
+
+import "time"
+
+// SidecarsOptions specifies the photos for which YAML sidecar files should be rewritten.
+type SidecarsOptions struct {
+	From      time.Time
+	To        time.Time
+	BatchSize int
+}
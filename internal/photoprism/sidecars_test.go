@@ -0,0 +1,117 @@
+package photoprism
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestSidecars_Rebuild(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("Range", func(t *testing.T) {
+		photo := entity.PhotoFixtures.Get("Photo01")
+		fileName := photo.YamlFileName(conf.OriginalsPath(), conf.SidecarPath())
+
+		_ = os.Remove(fileName)
+
+		defer func() { _ = os.Remove(fileName) }()
+
+		w := NewSidecars(conf)
+
+		written, err := w.Rebuild(SidecarsOptions{
+			From:      time.Date(2006, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:        time.Date(2006, 1, 1, 23, 59, 59, 0, time.UTC),
+			BatchSize: 1,
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, written, 1)
+		assert.True(t, fs.FileExists(fileName))
+
+		loaded := entity.Photo{}
+
+		if err = loaded.LoadFromYaml(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, photo.PhotoUID, loaded.PhotoUID)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		w := NewSidecars(conf)
+
+		written, err := w.Rebuild(SidecarsOptions{
+			From: time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(1800, 12, 31, 0, 0, 0, 0, time.UTC),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, written)
+	})
+	t.Run("Running", func(t *testing.T) {
+		if err := mutex.SidecarWorker.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer mutex.SidecarWorker.Stop()
+
+		w := NewSidecars(conf)
+
+		written, err := w.Rebuild(SidecarsOptions{})
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, written)
+	})
+}
+
+func TestSidecars_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("Range", func(t *testing.T) {
+		photo := entity.PhotoFixtures.Get("Photo01")
+		fileName := photo.YamlFileName(conf.OriginalsPath(), conf.SidecarPath())
+
+		_ = os.Remove(fileName)
+
+		defer func() { _ = os.Remove(fileName) }()
+
+		w := NewSidecars(conf)
+
+		err := w.Start(SidecarsOptions{
+			From: time.Date(2006, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2006, 1, 1, 23, 59, 59, 0, time.UTC),
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; i < 100 && mutex.SidecarWorker.Running(); i++ {
+			time.Sleep(50 * time.Millisecond)
+		}
+
+		assert.False(t, mutex.SidecarWorker.Running())
+		assert.True(t, fs.FileExists(fileName))
+	})
+	t.Run("Running", func(t *testing.T) {
+		if err := mutex.SidecarWorker.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer mutex.SidecarWorker.Stop()
+
+		w := NewSidecars(conf)
+
+		assert.Error(t, w.Start(SidecarsOptions{}))
+	})
+}
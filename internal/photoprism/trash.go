@@ -0,0 +1,117 @@
+package photoprism
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// TrashBatchSize is the maximum number of archived photos that are loaded from the index at once.
+const TrashBatchSize = 500
+
+// Trash represents a worker that permanently deletes archived pictures after the retention period.
+type Trash struct {
+	conf *config.Config
+}
+
+// NewTrash returns a new trash worker.
+func NewTrash(conf *config.Config) *Trash {
+	return &Trash{conf: conf}
+}
+
+// Disabled checks if archived pictures should not be deleted automatically, e.g. because
+// no retention period is configured or deleting pictures is disabled in the settings.
+func (w *Trash) Disabled() bool {
+	return w.conf.TrashRetention() <= 0 || w.conf.ReadOnly() || !w.conf.Settings().Features.Delete
+}
+
+// Expired returns archived pictures whose retention period has ended, without deleting them.
+func (w *Trash) Expired(limit int) (photos entity.Photos, err error) {
+	if w.Disabled() {
+		return entity.Photos{}, nil
+	}
+
+	if limit <= 0 || limit > TrashBatchSize {
+		limit = TrashBatchSize
+	}
+
+	return query.PhotosArchivedBefore(time.Now().Add(-1*w.conf.TrashRetention()), limit)
+}
+
+// Purge permanently deletes archived pictures whose retention period has ended, including their files,
+// and returns the deleted pictures.
+func (w *Trash) Purge() (deleted entity.Photos, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("trash: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if w.Disabled() {
+		return deleted, nil
+	}
+
+	if err = mutex.TrashWorker.Start(); err != nil {
+		return deleted, err
+	}
+
+	defer mutex.TrashWorker.Stop()
+
+	start := time.Now()
+	numFiles := 0
+
+	for {
+		photos, err := w.Expired(TrashBatchSize)
+
+		if err != nil {
+			return deleted, err
+		} else if len(photos) == 0 {
+			break
+		}
+
+		numDeleted := len(deleted)
+
+		for _, p := range photos {
+			if mutex.TrashWorker.Canceled() {
+				return deleted, nil
+			}
+
+			n, err := DeletePhoto(p, true, true)
+
+			numFiles += n
+
+			if err != nil {
+				log.Errorf("trash: %s", err)
+			} else {
+				deleted = append(deleted, p)
+			}
+		}
+
+		// Stop if no more photos could be deleted, to prevent an endless loop.
+		if len(deleted) == numDeleted {
+			break
+		}
+	}
+
+	if len(deleted) > 0 {
+		log.Infof("trash: deleted %s and %s [%s]", english.Plural(len(deleted), "picture", "pictures"), english.Plural(numFiles, "file", "files"), time.Since(start))
+
+		// Update precalculated photo and file counts.
+		if err := entity.UpdateCounts(); err != nil {
+			log.Warnf("trash: %s (update counts)", err)
+		}
+
+		event.EntitiesDeleted("photos", deleted.UIDs())
+	}
+
+	return deleted, nil
+}
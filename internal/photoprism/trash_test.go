@@ -0,0 +1,119 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func TestTrash(t *testing.T) {
+	conf := config.TestConfig()
+
+	archive := func(name string, archivedAt time.Time) entity.Photo {
+		p := entity.Photo{
+			PhotoUID:  rnd.GenerateUID(entity.PhotoUID),
+			PhotoPath: "trash",
+			PhotoName: name,
+			PhotoType: entity.MediaImage,
+		}
+
+		if err := p.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := entity.UnscopedDb().Model(&p).UpdateColumn("deleted_at", archivedAt).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		return p
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		conf.Options().TrashRetention = 0
+
+		w := NewTrash(conf)
+
+		assert.True(t, w.Disabled())
+
+		expired, err := w.Expired(10)
+
+		assert.NoError(t, err)
+		assert.Empty(t, expired)
+
+		deleted, err := w.Purge()
+
+		assert.NoError(t, err)
+		assert.Empty(t, deleted)
+	})
+	t.Run("DeleteDisabled", func(t *testing.T) {
+		conf.Options().TrashRetention = 30
+		defer func() { conf.Options().TrashRetention = 0 }()
+
+		enabled := conf.Settings().Features.Delete
+		conf.Settings().Features.Delete = false
+		defer func() { conf.Settings().Features.Delete = enabled }()
+
+		assert.True(t, NewTrash(conf).Disabled())
+	})
+	t.Run("Retention", func(t *testing.T) {
+		conf.Options().TrashRetention = 30
+		defer func() { conf.Options().TrashRetention = 0 }()
+
+		enabled := conf.Settings().Features.Delete
+		conf.Settings().Features.Delete = true
+		defer func() { conf.Settings().Features.Delete = enabled }()
+
+		retention := conf.TrashRetention()
+
+		assert.Equal(t, 30*24*time.Hour, retention)
+
+		inside := archive("inside", time.Now().Add(-1*retention).Add(time.Hour))
+		outside := archive("outside", time.Now().Add(-1*retention).Add(-1*time.Hour))
+
+		defer inside.DeletePermanently()
+
+		w := NewTrash(conf)
+
+		assert.False(t, w.Disabled())
+
+		// Dry run.
+		expired, err := w.Expired(TrashBatchSize)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, expired.UIDs(), outside.PhotoUID)
+		assert.NotContains(t, expired.UIDs(), inside.PhotoUID)
+
+		// Nothing must have been deleted yet.
+		assert.NotNil(t, entity.FindPhoto(entity.Photo{PhotoUID: outside.PhotoUID}))
+
+		s := event.Subscribe("photos.deleted")
+		defer event.Unsubscribe(s)
+
+		deleted, err := w.Purge()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, deleted.UIDs(), outside.PhotoUID)
+		assert.NotContains(t, deleted.UIDs(), inside.PhotoUID)
+		assert.Nil(t, entity.FindPhoto(entity.Photo{PhotoUID: outside.PhotoUID}))
+		assert.NotNil(t, entity.FindPhoto(entity.Photo{PhotoUID: inside.PhotoUID}))
+
+		select {
+		case msg := <-s.Receiver:
+			assert.Contains(t, msg.Fields["entities"], outside.PhotoUID)
+		case <-time.After(5 * time.Second):
+			t.Error("expected photos.deleted event")
+		}
+	})
+}
@@ -30,7 +30,7 @@ func UpdateAlbumDefaultCovers() (err error) {
     	SELECT p2.album_uid, f.file_hash FROM files f, (
         	SELECT pa.album_uid, max(p.id) AS photo_id FROM photos p
             JOIN photos_albums pa ON pa.photo_uid = p.photo_uid AND pa.hidden = 0 AND pa.missing = 0
-        	WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.deleted_at IS NULL
+        	WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.photo_type <> 'document' AND p.deleted_at IS NULL
         	GROUP BY pa.album_uid) p2 WHERE p2.photo_id = f.photo_id AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?)
 			) b ON b.album_uid = albums.album_uid
 		SET thumb = b.file_hash WHERE ?`, media.PreviewExpr, condition)
@@ -39,7 +39,7 @@ func UpdateAlbumDefaultCovers() (err error) {
 			UpdateColumn("thumb", gorm.Expr(`(
 		SELECT f.file_hash FROM files f 
 			JOIN photos_albums pa ON pa.album_uid = albums.album_uid AND pa.photo_uid = f.photo_uid AND pa.hidden = 0 AND pa.missing = 0
-			JOIN photos p ON p.id = f.photo_id AND p.photo_private = 0 AND p.deleted_at IS NULL AND p.photo_quality > 0
+			JOIN photos p ON p.id = f.photo_id AND p.photo_private = 0 AND p.photo_type <> 'document' AND p.deleted_at IS NULL AND p.photo_quality > 0
 			WHERE f.deleted_at IS NULL AND f.file_missing = 0 AND f.file_hash <> '' AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?)
 			ORDER BY p.taken_at DESC LIMIT 1
 		) WHERE ?`, media.PreviewExpr, condition))
@@ -77,7 +77,7 @@ func UpdateAlbumFolderCovers() (err error) {
 		res = Db().Exec(`UPDATE albums LEFT JOIN (
 		SELECT p2.photo_path, f.file_hash FROM files f, (
 			SELECT p.photo_path, max(p.id) AS photo_id FROM photos p
-			WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.deleted_at IS NULL
+			WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.photo_type <> 'document' AND p.deleted_at IS NULL
 			GROUP BY p.photo_path) p2 WHERE p2.photo_id = f.photo_id AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?)
 			) b ON b.photo_path = albums.album_path
 		SET thumb = b.file_hash WHERE ?`, media.PreviewExpr, condition)
@@ -85,7 +85,7 @@ func UpdateAlbumFolderCovers() (err error) {
 		res = Db().Table(entity.Album{}.TableName()).UpdateColumn("thumb", gorm.Expr(`(
 		SELECT f.file_hash FROM files f,(
 			SELECT p.photo_path, max(p.id) AS photo_id FROM photos p
-			  WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.deleted_at IS NULL
+			  WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.photo_type <> 'document' AND p.deleted_at IS NULL
 			  GROUP BY p.photo_path
 			) b
 		WHERE f.photo_id = b.photo_id  AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?)
@@ -125,7 +125,7 @@ func UpdateAlbumMonthCovers() (err error) {
 		res = Db().Exec(`UPDATE albums LEFT JOIN (
 		SELECT p2.photo_year, p2.photo_month, f.file_hash FROM files f, (
 			SELECT p.photo_year, p.photo_month, max(p.id) AS photo_id FROM photos p
-			WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.deleted_at IS NULL
+			WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.photo_type <> 'document' AND p.deleted_at IS NULL
 			GROUP BY p.photo_year, p.photo_month) p2 WHERE p2.photo_id = f.photo_id AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?)
 			) b ON b.photo_year = albums.album_year AND b.photo_month = albums.album_month
 		SET thumb = b.file_hash WHERE ?`, media.PreviewExpr, condition)
@@ -133,7 +133,7 @@ func UpdateAlbumMonthCovers() (err error) {
 		res = Db().Table(entity.Album{}.TableName()).UpdateColumn("thumb", gorm.Expr(`(
 		SELECT f.file_hash FROM files f,(
 			SELECT p.photo_year, p.photo_month, max(p.id) AS photo_id FROM photos p
-			  WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.deleted_at IS NULL
+			  WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.photo_type <> 'document' AND p.deleted_at IS NULL
 			  GROUP BY p.photo_year, p.photo_month
 			) b
 		WHERE f.photo_id = b.photo_id AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?)
@@ -174,9 +174,38 @@ func UpdateAlbumCovers() (err error) {
 		return err
 	}
 
+	// Update Cover Placeholders.
+	if err = UpdateAlbumThumbHashes(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// UpdateAlbumThumbHashes updates the cached ThumbHash placeholders of album covers, so that
+// clients can display them while the cover images are loading.
+func UpdateAlbumThumbHashes() (err error) {
+	mutex.Index.Lock()
+	defer mutex.Index.Unlock()
+
+	start := time.Now()
+
+	res := Db().Table(entity.Album{}.TableName()).
+		UpdateColumn("thumb_hash", gorm.Expr(`COALESCE((
+		SELECT p.photo_thumb_hash FROM files f
+			JOIN photos p ON p.id = f.photo_id AND p.photo_thumb_hash <> ''
+			WHERE f.file_hash = albums.thumb AND f.deleted_at IS NULL
+			LIMIT 1
+		), '')`))
+
+	if err = res.Error; err == nil {
+		entity.FlushAlbumCache()
+		log.Debugf("covers: updated placeholders of %s [%s]", english.Plural(int(res.RowsAffected), "album", "albums"), time.Since(start))
+	}
+
+	return err
+}
+
 // UpdateLabelCovers updates label cover thumbs.
 func UpdateLabelCovers() (err error) {
 	mutex.Index.Lock()
@@ -194,13 +223,13 @@ func UpdateLabelCovers() (err error) {
 		SELECT p2.label_id, f.file_hash FROM files f, (
 			SELECT pl.label_id as label_id, max(p.id) AS photo_id FROM photos p
 				JOIN photos_labels pl ON pl.photo_id = p.id AND pl.uncertainty < 100
-			WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.deleted_at IS NULL
+			WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.photo_type <> 'document' AND p.deleted_at IS NULL
 			GROUP BY pl.label_id
 			UNION
 			SELECT c.category_id as label_id, max(p.id) AS photo_id FROM photos p
 				JOIN photos_labels pl ON pl.photo_id = p.id AND pl.uncertainty < 100
 				JOIN categories c ON c.label_id = pl.label_id
-			WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.deleted_at IS NULL
+			WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.photo_type <> 'document' AND p.deleted_at IS NULL
 			GROUP BY c.category_id
 			) p2 WHERE p2.photo_id = f.photo_id AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?) AND f.file_missing = 0
 		) b ON b.label_id = labels.id
@@ -209,7 +238,7 @@ func UpdateLabelCovers() (err error) {
 		res = Db().Table(entity.Label{}.TableName()).UpdateColumn("thumb", gorm.Expr(`(
 		SELECT f.file_hash FROM files f 
 			JOIN photos_labels pl ON pl.label_id = labels.id AND pl.photo_id = f.photo_id AND pl.uncertainty < 100
-			JOIN photos p ON p.id = f.photo_id AND p.photo_private = 0 AND p.deleted_at IS NULL AND p.photo_quality > 0
+			JOIN photos p ON p.id = f.photo_id AND p.photo_private = 0 AND p.photo_type <> 'document' AND p.deleted_at IS NULL AND p.photo_quality > 0
 			WHERE f.deleted_at IS NULL AND f.file_hash <> '' AND f.file_missing = 0 AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?)
 			ORDER BY p.photo_quality DESC, pl.uncertainty ASC, p.taken_at DESC LIMIT 1
 		) WHERE ?`, media.PreviewExpr, condition))
@@ -219,7 +248,7 @@ func UpdateLabelCovers() (err error) {
 			SELECT f.file_hash FROM files f 
 			JOIN photos_labels pl ON pl.photo_id = f.photo_id AND pl.uncertainty < 100
 			JOIN categories c ON c.label_id = pl.label_id AND c.category_id = labels.id
-			JOIN photos p ON p.id = f.photo_id AND p.photo_private = 0 AND p.deleted_at IS NULL AND p.photo_quality > 0
+			JOIN photos p ON p.id = f.photo_id AND p.photo_private = 0 AND p.photo_type <> 'document' AND p.deleted_at IS NULL AND p.photo_quality > 0
 			WHERE f.deleted_at IS NULL AND f.file_hash <> '' AND f.file_missing = 0 AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?)
 			ORDER BY p.photo_quality DESC, pl.uncertainty ASC, p.taken_at DESC LIMIT 1
 			) WHERE thumb IS NULL`, media.PreviewExpr))
@@ -4,6 +4,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/search"
 )
 
 func TestUpdateAlbumDefaultCovers(t *testing.T) {
@@ -33,3 +37,58 @@ func TestUpdateSubjectCovers(t *testing.T) {
 func TestUpdateCovers(t *testing.T) {
 	assert.NoError(t, UpdateCovers())
 }
+
+func TestUpdateAlbumThumbHashes(t *testing.T) {
+	album := entity.AlbumFixtures.Get("christmas2030")
+	first := entity.FileFixtures.Get("bridge.jpg")
+	second := entity.FileFixtures.Get("reunion.jpg")
+
+	if err := Db().Model(&entity.Photo{}).Where("id = ?", first.PhotoID).UpdateColumn("photo_thumb_hash", "HBkSHYSIeHiPiHh8eJd4eTN0EEQG").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Db().Model(&entity.Photo{}).Where("id = ?", second.PhotoID).UpdateColumn("photo_thumb_hash", "1QcSHQRnh493V4dIh4eXh1h4kJUI").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	defer Db().Model(&entity.Photo{}).Where("id IN (?)", []uint{first.PhotoID, second.PhotoID}).UpdateColumn("photo_thumb_hash", "")
+	defer Db().Model(&entity.Album{}).Where("id = ?", album.ID).UpdateColumns(entity.Values{"thumb": album.Thumb, "thumb_src": album.ThumbSrc, "thumb_hash": ""})
+
+	setCover := func(fileHash string) entity.Album {
+		if err := Db().Model(&entity.Album{}).Where("id = ?", album.ID).UpdateColumns(entity.Values{"thumb": fileHash, "thumb_src": entity.SrcManual}).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		if err := UpdateAlbumThumbHashes(); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := AlbumByUID(album.AlbumUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return result
+	}
+
+	t.Run("Cover", func(t *testing.T) {
+		assert.Equal(t, "HBkSHYSIeHiPiHh8eJd4eTN0EEQG", setCover(first.FileHash).ThumbHash)
+	})
+	t.Run("CoverChanged", func(t *testing.T) {
+		assert.Equal(t, "1QcSHQRnh493V4dIh4eXh1h4kJUI", setCover(second.FileHash).ThumbHash)
+
+		results, err := search.Albums(form.SearchAlbums{UID: album.AlbumUID})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, results, 1) {
+			assert.Equal(t, "1QcSHQRnh493V4dIh4eXh1h4kJUI", results[0].ThumbHash)
+		}
+	})
+	t.Run("NoCover", func(t *testing.T) {
+		assert.Equal(t, "", setCover("").ThumbHash)
+	})
+}
@@ -126,7 +126,8 @@ func SelectedFiles(f form.Selection, o FileSelection) (results entity.Files, err
 		Joins("JOIN photos ON photos.id = files.photo_id").
 		Where("files.file_missing = 0 AND files.file_name <> '' AND files.file_hash <> ''").
 		Where(where, f.Photos, f.Places, f.Files, f.Files, f.Files, f.Albums, f.Subjects, f.Labels, f.Labels).
-		Group("files.id")
+		Group("files.id").
+		Order("files.id")
 
 	// File size limit?
 	if o.MaxSize > 0 {
@@ -76,6 +76,19 @@ func FileByPhotoUID(photoUID string) (*entity.File, error) {
 	return &f, err
 }
 
+// FilesByPhotoUID returns all files of a photo including missing files, with the primary file first.
+func FilesByPhotoUID(photoUID string) (files entity.Files, err error) {
+	if photoUID == "" {
+		return files, fmt.Errorf("photo uid required")
+	}
+
+	err = UnscopedDb().Where("photo_uid = ? AND (deleted_at IS NULL OR file_missing = 1)", photoUID).
+		Order("file_primary DESC, file_missing ASC, file_sidecar ASC, file_name, id").
+		Find(&files).Error
+
+	return files, err
+}
+
 // VideoByPhotoUID finds a video for the given photo UID.
 func VideoByPhotoUID(photoUID string) (*entity.File, error) {
 	f := entity.File{}
@@ -216,3 +229,40 @@ func OrphanFiles() (files entity.Files, err error) {
 
 	return files, err
 }
+
+// OrphanFilesRange finds files without a photo in the range of limit and offset sorted by id,
+// and returns the total number of orphaned files.
+func OrphanFilesRange(limit, offset int) (files entity.Files, total int, err error) {
+	stmt := UnscopedDb().Model(&entity.File{}).Where("photo_id NOT IN (SELECT id FROM photos)")
+
+	if err = stmt.Count(&total).Error; err != nil {
+		return files, total, err
+	}
+
+	err = stmt.Order("id").Limit(limit).Offset(offset).Find(&files).Error
+
+	return files, total, err
+}
+
+// RotatedFiles finds primary JPEG and PNG files with an orientation that swaps width and height,
+// in the range of limit and offset sorted by id.
+func RotatedFiles(limit, offset int) (files entity.Files, err error) {
+	err = Db().
+		Where("file_primary = 1 AND file_missing = 0 AND file_orientation > 4").
+		Where("file_type IN (?)", []string{fs.ImageJPEG.String(), fs.ImagePNG.String()}).
+		Order("id").Limit(limit).Offset(offset).
+		Find(&files).Error
+
+	return files, err
+}
+
+// MetaErrorFiles finds files with partially corrupt metadata, e.g. a malformed Exif block,
+// in the range of limit and offset sorted by id.
+func MetaErrorFiles(limit, offset int) (files entity.Files, err error) {
+	err = Db().
+		Where("file_meta_error <> '' AND file_missing = 0").
+		Order("id").Limit(limit).Offset(offset).
+		Find(&files).Error
+
+	return files, err
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -287,3 +288,182 @@ func TestOrphanFiles(t *testing.T) {
 
 	assert.IsType(t, entity.Files{}, files)
 }
+
+func TestOrphanFilesRange(t *testing.T) {
+	orphans := make([]*entity.File, 3)
+
+	for i := range orphans {
+		orphans[i] = &entity.File{
+			PhotoID:  9999980,
+			PhotoUID: rnd.GenerateUID(entity.PhotoUID),
+			FileRoot: entity.RootOriginals,
+			FileName: "orphans/" + rnd.GenerateUID('h') + ".jpg",
+			FileHash: rnd.GenerateUID('h'),
+			FileType: "jpg",
+		}
+
+		if err := orphans[i].Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defer func() {
+		for _, f := range orphans {
+			_ = f.DeletePermanently()
+		}
+	}()
+
+	all, err := OrphanFiles()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, total, err := OrphanFilesRange(2, 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(all), total)
+	assert.Len(t, files, 2)
+
+	files, total, err = OrphanFilesRange(10, total)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(all), total)
+	assert.Empty(t, files)
+}
+
+func TestRotatedFiles(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		rotated := entity.File{
+			FileUID:         "fs6sg6bw1rotat01",
+			PhotoID:         1000000,
+			PhotoUID:        "ps6sg6be2lvl0yh7",
+			FileRoot:        entity.RootOriginals,
+			FileName:        "rotated/sideways.jpg",
+			FileHash:        "c3c2d0ae1c5f8a0b25ad45e9b99aa02b1ff2d8e2",
+			FileType:        "jpg",
+			FilePrimary:     true,
+			FileOrientation: 6,
+		}
+
+		if err := rotated.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer rotated.DeletePermanently()
+
+		files, err := RotatedFiles(10000, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		found := false
+
+		for _, f := range files {
+			assert.Greater(t, f.FileOrientation, 4)
+			assert.True(t, f.FilePrimary)
+
+			if f.FileUID == rotated.FileUID {
+				found = true
+			}
+		}
+
+		assert.True(t, found)
+	})
+	t.Run("Offset", func(t *testing.T) {
+		files, err := RotatedFiles(10, 100000)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, files)
+	})
+}
+
+func TestMetaErrorFiles(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		corrupt := entity.File{
+			FileUID:       "fs6sg6bw1corr001",
+			PhotoID:       1000000,
+			PhotoUID:      "ps6sg6be2lvl0yh7",
+			FileRoot:      entity.RootOriginals,
+			FileName:      "corrupt/exif.jpg",
+			FileHash:      "d4c2d0ae1c5f8a0b25ad45e9b99aa02b1ff2d8e2",
+			FileType:      "jpg",
+			FileMetaError: "corrupt exif data (EOF)",
+		}
+
+		if err := corrupt.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer corrupt.DeletePermanently()
+
+		files, err := MetaErrorFiles(10000, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		found := false
+
+		for _, f := range files {
+			assert.NotEmpty(t, f.FileMetaError)
+
+			if f.FileUID == corrupt.FileUID {
+				found = true
+			}
+		}
+
+		assert.True(t, found)
+	})
+	t.Run("Offset", func(t *testing.T) {
+		files, err := MetaErrorFiles(10, 100000)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, files)
+	})
+}
+
+func TestFilesByPhotoUID(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		files, err := FilesByPhotoUID("pt9jtdre2lvl0y11")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.NotEmpty(t, files) {
+			assert.True(t, files[0].FilePrimary)
+			assert.Equal(t, "Germany/bridge.jpg", files[0].FileName)
+		}
+	})
+	t.Run("Missing", func(t *testing.T) {
+		files, err := FilesByPhotoUID("pt9jtdre2lvl0y22")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, files, 1) {
+			assert.True(t, files[0].FileMissing)
+		}
+	})
+	t.Run("EmptyUID", func(t *testing.T) {
+		files, err := FilesByPhotoUID("")
+
+		assert.Error(t, err)
+		assert.Empty(t, files)
+	})
+}
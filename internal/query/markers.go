@@ -76,6 +76,19 @@ func FaceMarkers(limit, offset int) (result entity.Markers, err error) {
 	return result, err
 }
 
+// BestSubjectMarker returns the valid face marker of a subject with the highest quality and size.
+func BestSubjectMarker(subjUid string) (*entity.Marker, error) {
+	m := entity.Marker{}
+
+	err := Db().
+		Where("subj_uid = ? AND marker_type = ?", subjUid, entity.MarkerFace).
+		Where("marker_invalid = 0 AND thumb <> ''").
+		Order("q DESC, size DESC, marker_uid").
+		First(&m).Error
+
+	return &m, err
+}
+
 // Embeddings returns existing face embeddings.
 func Embeddings(single, unclustered bool, size, score int) (result face.Embeddings, err error) {
 	var col []string
@@ -109,6 +109,25 @@ func TestFaceMarkers(t *testing.T) {
 	})
 }
 
+func TestBestSubjectMarker(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		m, err := BestSubjectMarker("jqu0xs11qekk9jx8")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "jqu0xs11qekk9jx8", m.SubjUID)
+		assert.Equal(t, entity.MarkerFace, m.MarkerType)
+		assert.False(t, m.MarkerInvalid)
+		assert.NotEmpty(t, m.Thumb)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := BestSubjectMarker("jqu0xs11qekk9xxx")
+		assert.Error(t, err)
+	})
+}
+
 func TestEmbeddings(t *testing.T) {
 	t.Run("all", func(t *testing.T) {
 		results, err := Embeddings(false, false, 0, 0)
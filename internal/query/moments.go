@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/maps"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -135,11 +136,17 @@ func (m Moment) Slug() (s string) {
 
 // TitleSlug returns an identifier string based on the title.
 func (m Moment) TitleSlug() string {
-	return txt.Slug(m.Title())
+	// Slugs must not depend on the language, so that existing albums are found when it changes.
+	return txt.Slug(m.LocaleTitle(i18n.English))
 }
 
-// Title returns an english title for the moment.
+// Title returns a title for the moment in the default language.
 func (m Moment) Title() string {
+	return m.LocaleTitle(i18n.CurrentLocale())
+}
+
+// LocaleTitle returns a title for the moment with localized month names.
+func (m Moment) LocaleTitle(loc i18n.Locale) string {
 	state := clean.State(m.State, m.Country)
 
 	if m.Year == 0 && m.Month == 0 {
@@ -165,18 +172,18 @@ func (m Moment) Title() string {
 		date := time.Date(m.Year, time.Month(m.Month), 1, 0, 0, 0, 0, time.UTC)
 
 		if state != "" {
-			return fmt.Sprintf("%s / %s", state, date.Format("January 2006"))
+			return fmt.Sprintf("%s / %s", state, loc.MonthYear(date))
 		}
 
 		if m.Country == "" {
-			return date.Format("January 2006")
+			return loc.MonthYear(date)
 		}
 
-		return fmt.Sprintf("%s / %s", m.CountryName(), date.Format("January 2006"))
+		return fmt.Sprintf("%s / %s", m.CountryName(), loc.MonthYear(date))
 	}
 
 	if m.Month > 0 && m.Month <= 12 {
-		return time.Month(m.Month).String()
+		return txt.UpperFirst(loc.MonthName(time.Month(m.Month)))
 	}
 
 	return m.CountryName()
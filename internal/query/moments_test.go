@@ -6,6 +6,8 @@ import (
 	"github.com/dustin/go-humanize/english"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/i18n"
 )
 
 func TestMomentsTime(t *testing.T) {
@@ -333,3 +335,35 @@ func TestRemoveDuplicateMoments(t *testing.T) {
 		}
 	})
 }
+
+func TestMoment_LocaleTitle(t *testing.T) {
+	t.Run("MonthYear", func(t *testing.T) {
+		moment := Moment{Year: 2021, Month: 3}
+
+		assert.Equal(t, "March 2021", moment.LocaleTitle(i18n.English))
+		assert.Equal(t, "März 2021", moment.LocaleTitle(i18n.German))
+		assert.Equal(t, "Mars 2021", moment.LocaleTitle(i18n.French))
+	})
+	t.Run("StateMonthYear", func(t *testing.T) {
+		moment := Moment{Country: "de", State: "Bayern", Year: 2021, Month: 3}
+
+		assert.Equal(t, "Bayern / March 2021", moment.LocaleTitle(i18n.English))
+		assert.Equal(t, "Bayern / März 2021", moment.LocaleTitle(i18n.German))
+	})
+	t.Run("Month", func(t *testing.T) {
+		moment := Moment{Month: 12}
+
+		assert.Equal(t, "December", moment.LocaleTitle(i18n.English))
+		assert.Equal(t, "Dezember", moment.LocaleTitle(i18n.German))
+		assert.Equal(t, "Diciembre", moment.LocaleTitle(i18n.Spanish))
+	})
+	t.Run("SlugIndependentOfLocale", func(t *testing.T) {
+		moment := Moment{Year: 2021, Month: 3}
+
+		i18n.SetLocale("de")
+		defer i18n.SetLocale("")
+
+		assert.Equal(t, "März 2021", moment.Title())
+		assert.Equal(t, "march-2021", moment.TitleSlug())
+	})
+}
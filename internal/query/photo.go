@@ -1,6 +1,7 @@
 package query
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/dustin/go-humanize/english"
@@ -50,6 +51,26 @@ func PhotoByUID(photoUID string) (photo entity.Photo, err error) {
 	return photo, nil
 }
 
+// PhotoRemoved checks if the photo with the UID has been removed from the index, e.g. because its files
+// were deleted or purged, and returns the time of removal. Archived photos are not considered removed
+// as long as they have files.
+func PhotoRemoved(photoUID string) (deletedAt time.Time, removed bool) {
+	if photoUID == "" {
+		return deletedAt, false
+	}
+
+	photo := entity.Photo{}
+
+	if err := UnscopedDb().Select("id, photo_uid, deleted_at").
+		Where("photo_uid = ? AND deleted_at IS NOT NULL", photoUID).
+		Where("NOT EXISTS (SELECT 1 FROM files f WHERE f.photo_id = photos.id AND f.deleted_at IS NULL)").
+		First(&photo).Error; err != nil || photo.DeletedAt == nil {
+		return deletedAt, false
+	}
+
+	return *photo.DeletedAt, true
+}
+
 // PhotoPreloadByUID returns a Photo based on the UID with all dependencies preloaded.
 func PhotoPreloadByUID(photoUID string) (photo entity.Photo, err error) {
 	if err := UnscopedDb().Where("photo_uid = ?", photoUID).
@@ -72,6 +93,80 @@ func PhotoPreloadByUID(photoUID string) (photo entity.Photo, err error) {
 	return photo, nil
 }
 
+// PhotoBasic represents a lightweight photo projection, e.g. for previews.
+type PhotoBasic struct {
+	PhotoUID     string     `json:"UID"`
+	PhotoType    string     `json:"Type"`
+	PhotoTitle   string     `json:"Title"`
+	TakenAt      time.Time  `json:"TakenAt"`
+	TakenAtLocal time.Time  `json:"TakenAtLocal"`
+	FileHash     string     `json:"Hash"`
+	DeletedAt    *time.Time `json:"DeletedAt,omitempty"`
+}
+
+// PhotoBasicByUID returns a lightweight projection of the photo with the UID, including the primary
+// file hash, without preloading any dependencies.
+func PhotoBasicByUID(photoUID string) (photo PhotoBasic, err error) {
+	if photoUID == "" {
+		return photo, fmt.Errorf("photo uid required")
+	}
+
+	if err = UnscopedDb().Table("photos p").
+		Select("p.photo_uid, p.photo_type, p.photo_title, p.taken_at, p.taken_at_local, p.deleted_at, f.file_hash").
+		Joins("LEFT JOIN files f ON f.photo_id = p.id AND f.file_primary = 1 AND f.deleted_at IS NULL").
+		Where("p.photo_uid = ?", photoUID).
+		Limit(1).
+		Scan(&photo).Error; err != nil {
+		return photo, err
+	} else if photo.PhotoUID == "" {
+		return photo, gorm.ErrRecordNotFound
+	}
+
+	return photo, nil
+}
+
+// PhotoByFileHash returns the Photo with all dependencies preloaded that the file with the hash belongs to.
+func PhotoByFileHash(fileHash string) (photo entity.Photo, err error) {
+	if fileHash == "" {
+		return photo, fmt.Errorf("file hash required")
+	}
+
+	f := entity.File{}
+
+	if err = Db().Where("file_hash = ? AND photo_uid <> ''", fileHash).
+		Order("file_primary DESC, id").
+		First(&f).Error; err != nil {
+		return photo, err
+	}
+
+	return PhotoPreloadByUID(f.PhotoUID)
+}
+
+// PhotoShared checks if a photo is visible in one of the specified shared albums or has been published.
+func PhotoShared(photoUID string, albumUIDs []string) bool {
+	if photoUID == "" {
+		return false
+	}
+
+	stmt := Db().Model(&entity.Photo{}).Where("photo_uid = ? AND photo_private = 0", photoUID)
+
+	if len(albumUIDs) > 0 {
+		stmt = stmt.Where("photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = 0 AND missing = 0 AND album_uid IN (?)) OR published_at > ?",
+			albumUIDs, entity.TimeStamp())
+	} else {
+		stmt = stmt.Where("published_at > ?", entity.TimeStamp())
+	}
+
+	var count int
+
+	if err := stmt.Count(&count).Error; err != nil {
+		log.Errorf("query: %s (find shared photo)", err)
+		return false
+	}
+
+	return count > 0
+}
+
 // PhotosMissing returns photo entities without existing files.
 func PhotosMissing(limit int, offset int) (entities entity.Photos, err error) {
 	err = Db().
@@ -104,6 +199,46 @@ func PhotosMetadataUpdate(limit, offset int, delay, interval time.Duration) (ent
 	return entities, err
 }
 
+// PhotosWithMetadata returns indexed photos with camera, lens, and details preloaded, e.g. to apply metadata rules.
+func PhotosWithMetadata(limit, offset int) (entities entity.Photos, err error) {
+	err = Db().
+		Preload("Camera").
+		Preload("Lens").
+		Preload("Details").
+		Where("photo_quality > -1").
+		Order("photos.ID ASC").Limit(limit).Offset(offset).Find(&entities).Error
+
+	return entities, err
+}
+
+// PhotosTakenBetween returns photos taken within the specified time range, e.g. to rewrite their YAML sidecar files.
+// A zero time means that the range is open on this side.
+func PhotosTakenBetween(from, to time.Time, limit, offset int) (entities entity.Photos, err error) {
+	stmt := Db().
+		Preload("Labels", func(db *gorm.DB) *gorm.DB {
+			return db.Order("photos_labels.uncertainty ASC, photos_labels.label_id DESC")
+		}).
+		Preload("Labels.Label").
+		Preload("Camera").
+		Preload("Lens").
+		Preload("Details").
+		Preload("Place").
+		Preload("Cell").
+		Preload("Cell.Place")
+
+	if !from.IsZero() {
+		stmt = stmt.Where("photos.taken_at >= ?", from)
+	}
+
+	if !to.IsZero() {
+		stmt = stmt.Where("photos.taken_at <= ?", to)
+	}
+
+	err = stmt.Order("photos.ID ASC").Limit(limit).Offset(offset).Find(&entities).Error
+
+	return entities, err
+}
+
 // OrphanPhotos finds orphan index entries that may be removed.
 func OrphanPhotos() (photos entity.Photos, err error) {
 	err = UnscopedDb().
@@ -190,3 +325,12 @@ func FlagHiddenPhotos() (err error) {
 
 	return nil
 }
+
+// PhotosArchivedBefore returns photos that have been archived before the specified time, oldest first.
+func PhotosArchivedBefore(before time.Time, limit int) (entities entity.Photos, err error) {
+	err = UnscopedDb().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at ASC, id ASC").Limit(limit).Find(&entities).Error
+
+	return entities, err
+}
@@ -0,0 +1,70 @@
+package query
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// LivePair represents the image and video of a live photo that were indexed as separate photos.
+type LivePair struct {
+	Image entity.Photo
+	Video entity.Photo
+}
+
+// LivePairs represents a list of live photo image and video pairs.
+type LivePairs []LivePair
+
+// LivePhotoPairs finds images and short videos with the same base name in the same folder that were taken
+// within the specified time tolerance, so that they can be stacked as live photos.
+func LivePhotoPairs(maxDuration, tolerance time.Duration) (result LivePairs, err error) {
+	var videos entity.Photos
+
+	if err = Db().
+		Where("photo_type = ? AND photo_stack > -1 AND photo_name <> ''", entity.MediaVideo).
+		Where("photo_duration <= ?", maxDuration).
+		Order("photo_name, photo_path, taken_at, id").
+		Find(&videos).Error; err != nil || len(videos) == 0 {
+		return result, err
+	}
+
+	names := make([]string, 0, len(videos))
+
+	for _, v := range videos {
+		names = append(names, v.PhotoName)
+	}
+
+	var images entity.Photos
+
+	if err = Db().
+		Where("photo_type = ? AND photo_stack > -1 AND photo_name IN (?)", entity.MediaImage, names).
+		Order("photo_name, photo_path, taken_at, id").
+		Find(&images).Error; err != nil {
+		return result, err
+	}
+
+	// Each image and video can only be part of one pair.
+	paired := make(map[uint]bool, len(images))
+
+	for _, v := range videos {
+		for _, i := range images {
+			if paired[i.ID] || i.PhotoName != v.PhotoName || i.PhotoPath != v.PhotoPath {
+				continue
+			}
+
+			diff := i.TakenAt.Sub(v.TakenAt)
+
+			if diff < 0 {
+				diff = -diff
+			}
+
+			if diff <= tolerance {
+				paired[i.ID] = true
+				result = append(result, LivePair{Image: i, Video: v})
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,58 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestLivePhotoPairs(t *testing.T) {
+	takenAt := time.Date(2022, 7, 3, 12, 30, 0, 0, time.UTC)
+
+	photos := []*entity.Photo{
+		{PhotoName: "IMG_8001", PhotoPath: "2022/07", PhotoType: entity.MediaImage, TakenAt: takenAt, TakenAtLocal: takenAt},
+		{PhotoName: "IMG_8001", PhotoPath: "2022/07", PhotoType: entity.MediaVideo, PhotoDuration: 2 * time.Second, TakenAt: takenAt.Add(time.Second), TakenAtLocal: takenAt},
+		{PhotoName: "IMG_8002", PhotoPath: "2022/07", PhotoType: entity.MediaImage, TakenAt: takenAt, TakenAtLocal: takenAt},
+		{PhotoName: "IMG_8002", PhotoPath: "2022/07", PhotoType: entity.MediaVideo, PhotoDuration: 2 * time.Second, TakenAt: takenAt.Add(time.Hour), TakenAtLocal: takenAt},
+		{PhotoName: "IMG_8003", PhotoPath: "2022/07", PhotoType: entity.MediaImage, TakenAt: takenAt, TakenAtLocal: takenAt},
+		{PhotoName: "IMG_8003", PhotoPath: "2022/07", PhotoType: entity.MediaVideo, PhotoDuration: time.Minute, TakenAt: takenAt, TakenAtLocal: takenAt},
+		{PhotoName: "IMG_8004", PhotoPath: "2022/07", PhotoType: entity.MediaImage, TakenAt: takenAt, TakenAtLocal: takenAt},
+		{PhotoName: "IMG_8004", PhotoPath: "import", PhotoType: entity.MediaVideo, PhotoDuration: 2 * time.Second, TakenAt: takenAt, TakenAtLocal: takenAt},
+	}
+
+	for _, p := range photos {
+		if err := p.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func(p *entity.Photo) { _, _ = p.DeletePermanently() }(p)
+	}
+
+	result, err := LivePhotoPairs(3100*time.Millisecond, 3*time.Second)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := make(map[string]string)
+
+	for _, pair := range result {
+		found[pair.Image.PhotoUID] = pair.Video.PhotoUID
+	}
+
+	t.Run("Match", func(t *testing.T) {
+		assert.Equal(t, photos[1].PhotoUID, found[photos[0].PhotoUID])
+	})
+	t.Run("TimeDiff", func(t *testing.T) {
+		assert.NotContains(t, found, photos[2].PhotoUID)
+	})
+	t.Run("Duration", func(t *testing.T) {
+		assert.NotContains(t, found, photos[4].PhotoUID)
+	})
+	t.Run("Path", func(t *testing.T) {
+		assert.NotContains(t, found, photos[6].PhotoUID)
+	})
+}
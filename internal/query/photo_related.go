@@ -0,0 +1,135 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// RelatedPhotosMaxCount is the maximum number of related photos returned.
+const RelatedPhotosMaxCount = 100
+
+// RelatedPhotosDays is the maximum number of days between the dates of related photos.
+const RelatedPhotosDays = 3
+
+// Weights of the attributes related photos can have in common.
+const (
+	RelatedSubjectWeight = 3
+	RelatedPlaceWeight   = 2
+	RelatedDateWeight    = 1
+)
+
+// RelatedPhoto represents a photo that has subjects, a place, or a close date in common with another photo.
+type RelatedPhoto struct {
+	PhotoUID   string    `json:"UID"`
+	PhotoType  string    `json:"Type"`
+	PhotoTitle string    `json:"Title"`
+	TakenAt    time.Time `json:"TakenAt"`
+	PlaceID    string    `json:"PlaceID"`
+	Subjects   int       `json:"Subjects"`
+	SamePlace  bool      `json:"SamePlace"`
+	CloseDate  bool      `json:"CloseDate"`
+	Score      int       `json:"Score"`
+}
+
+// RelatedPhotos represents a list of related photos, ranked by the number of shared attributes.
+type RelatedPhotos []RelatedPhoto
+
+// UIDs returns the photo UIDs of the related photos.
+func (r RelatedPhotos) UIDs() []string {
+	result := make([]string, len(r))
+
+	for i, p := range r {
+		result[i] = p.PhotoUID
+	}
+
+	return result
+}
+
+// PhotoSubjectUIDs returns the UIDs of the subjects with valid markers in the files of a photo.
+func PhotoSubjectUIDs(photoID uint) (subjects []string, err error) {
+	err = Db().Table("markers").
+		Joins("JOIN files ON files.file_uid = markers.file_uid").
+		Where("files.photo_id = ? AND files.deleted_at IS NULL", photoID).
+		Where("markers.subj_uid <> '' AND markers.marker_invalid = 0").
+		Order("markers.subj_uid").
+		Pluck("DISTINCT markers.subj_uid", &subjects).Error
+
+	return subjects, err
+}
+
+// Related returns up to limit photos that have subjects, the place, or a close date in common with the photo,
+// ranked by the number of shared attributes. Private, archived, and low quality photos are excluded.
+func Related(photo entity.Photo, limit int) (results RelatedPhotos, err error) {
+	if !photo.HasID() {
+		return results, fmt.Errorf("photo id must not be empty")
+	}
+
+	if limit <= 0 || limit > RelatedPhotosMaxCount {
+		limit = RelatedPhotosMaxCount
+	}
+
+	subjects, err := PhotoSubjectUIDs(photo.ID)
+
+	if err != nil {
+		return results, err
+	}
+
+	var cols, where []string
+	var colValues, whereValues []interface{}
+
+	// Number of shared subjects.
+	if len(subjects) > 0 {
+		cols = append(cols, "(SELECT COUNT(DISTINCT m.subj_uid) FROM markers m JOIN files f ON f.file_uid = m.file_uid "+
+			"WHERE f.photo_id = p.id AND f.deleted_at IS NULL AND m.marker_invalid = 0 AND m.subj_uid IN (?)) AS subjects")
+		colValues = append(colValues, subjects)
+		where = append(where, "p.id IN (SELECT f.photo_id FROM files f JOIN markers m ON m.file_uid = f.file_uid "+
+			"WHERE f.deleted_at IS NULL AND m.marker_invalid = 0 AND m.subj_uid IN (?))")
+		whereValues = append(whereValues, subjects)
+	} else {
+		cols = append(cols, "0 AS subjects")
+	}
+
+	// Same place, unless it is unknown.
+	if photo.PlaceID != "" && photo.PlaceID != entity.UnknownID {
+		cols = append(cols, "CASE WHEN p.place_id = ? THEN 1 ELSE 0 END AS same_place")
+		colValues = append(colValues, photo.PlaceID)
+		where = append(where, "p.place_id = ?")
+		whereValues = append(whereValues, photo.PlaceID)
+	} else {
+		cols = append(cols, "0 AS same_place")
+	}
+
+	// Close date, unless it is unknown.
+	if !photo.TakenAt.IsZero() && photo.TakenSrc != entity.SrcAuto {
+		from := photo.TakenAt.Add(-1 * RelatedPhotosDays * 24 * time.Hour)
+		to := photo.TakenAt.Add(RelatedPhotosDays * 24 * time.Hour)
+		cols = append(cols, "CASE WHEN p.taken_at BETWEEN ? AND ? THEN 1 ELSE 0 END AS close_date")
+		colValues = append(colValues, from, to)
+		where = append(where, "p.taken_at BETWEEN ? AND ?")
+		whereValues = append(whereValues, from, to)
+	} else {
+		cols = append(cols, "0 AS close_date")
+	}
+
+	if len(where) == 0 {
+		return results, nil
+	}
+
+	sql := fmt.Sprintf("SELECT r.*, (r.subjects * %d + r.same_place * %d + r.close_date * %d) AS score FROM "+
+		"(SELECT p.photo_uid, p.photo_type, p.photo_title, p.taken_at, p.place_id, %s FROM photos p "+
+		"WHERE p.id <> ? AND p.deleted_at IS NULL AND p.photo_private = 0 AND p.photo_quality > -1 AND (%s)) r "+
+		"ORDER BY score DESC, r.taken_at DESC, r.photo_uid LIMIT ?",
+		RelatedSubjectWeight, RelatedPlaceWeight, RelatedDateWeight,
+		strings.Join(cols, ", "), strings.Join(where, " OR "))
+
+	values := append(colValues, photo.ID)
+	values = append(values, whereValues...)
+	values = append(values, limit)
+
+	err = Db().Raw(sql, values...).Scan(&results).Error
+
+	return results, err
+}
@@ -0,0 +1,133 @@
+package query
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/crop"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestPhotoSubjectUIDs(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		photo := entity.PhotoFixtures.Get("19800101_000002_D640C559")
+
+		subjects, err := PhotoSubjectUIDs(photo.ID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, subjects, entity.SubjectFixtures.Get("actress-1").SubjUID)
+		assert.Contains(t, subjects, entity.SubjectFixtures.Get("actor-1").SubjUID)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		subjects, err := PhotoSubjectUIDs(99999)
+
+		assert.NoError(t, err)
+		assert.Empty(t, subjects)
+	})
+}
+
+func TestRelated(t *testing.T) {
+	t.Run("Ranking", func(t *testing.T) {
+		takenAt := time.Date(2003, 4, 5, 12, 0, 0, 0, time.UTC)
+		placeID := entity.PlaceFixtures.Get("mexico").ID
+		subjA := entity.SubjectFixtures.Get("actress-1").SubjUID
+		subjB := entity.SubjectFixtures.Get("actor-1").SubjUID
+
+		// Photo with subjects, place, and date in common with the source, followed by photos with fewer attributes in common.
+		tests := []struct {
+			name     string
+			subjects []string
+			placeID  string
+			takenAt  time.Time
+		}{
+			{"source", []string{subjA, subjB}, placeID, takenAt},
+			{"all", []string{subjA, subjB}, placeID, takenAt.Add(time.Hour)},
+			{"subjects", []string{subjA, subjB}, entity.UnknownID, takenAt.AddDate(-5, 0, 0)},
+			{"subject-place", []string{subjA}, placeID, takenAt.AddDate(5, 0, 0)},
+			{"place-date", nil, placeID, takenAt.Add(-24 * time.Hour)},
+			{"date", nil, entity.UnknownID, takenAt.Add(48 * time.Hour)},
+			{"none", nil, entity.UnknownID, takenAt.AddDate(-7, 0, 0)},
+		}
+
+		photos := make([]entity.Photo, len(tests))
+
+		for i, tt := range tests {
+			photo := entity.Photo{PhotoTitle: "Related " + tt.name, PhotoType: entity.MediaImage, PlaceID: tt.placeID, TakenAt: tt.takenAt, TakenAtLocal: tt.takenAt, TakenSrc: entity.SrcMeta, PhotoQuality: 3}
+
+			if err := photo.Create(); err != nil {
+				t.Fatal(err)
+			}
+
+			photos[i] = photo
+
+			defer func() { _, _ = photo.DeletePermanently() }()
+
+			file := entity.File{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FileName: fmt.Sprintf("related/%s.jpg", tt.name), FileHash: "related" + tt.name, FileType: "jpg", FilePrimary: true}
+
+			if err := file.Create(); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, subjUID := range tt.subjects {
+				if err := entity.NewMarker(file, crop.Area{X: 0.1, Y: 0.1, W: 0.2, H: 0.2}, subjUID, entity.SrcManual, entity.MarkerFace, 100, 100).Create(); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+
+		results, err := Related(photos[0], RelatedPhotosMaxCount)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		index := make(map[string]int)
+
+		for i, r := range results {
+			index[r.PhotoUID] = i
+
+			if i > 0 {
+				assert.GreaterOrEqual(t, results[i-1].Score, r.Score)
+			}
+		}
+
+		assert.NotContains(t, index, photos[0].PhotoUID)
+		assert.NotContains(t, index, photos[6].PhotoUID)
+
+		for i := 1; i < 5; i++ {
+			if assert.Contains(t, index, photos[i].PhotoUID) && assert.Contains(t, index, photos[i+1].PhotoUID) {
+				assert.Less(t, index[photos[i].PhotoUID], index[photos[i+1].PhotoUID], tests[i].name)
+			}
+		}
+
+		all := results[index[photos[1].PhotoUID]]
+
+		assert.Equal(t, 2, all.Subjects)
+		assert.True(t, all.SamePlace)
+		assert.True(t, all.CloseDate)
+		assert.Equal(t, 2*RelatedSubjectWeight+RelatedPlaceWeight+RelatedDateWeight, all.Score)
+	})
+	t.Run("Limit", func(t *testing.T) {
+		photo := entity.PhotoFixtures.Get("19800101_000002_D640C559")
+
+		results, err := Related(photo, 2)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, len(results), 2)
+	})
+	t.Run("NoID", func(t *testing.T) {
+		results, err := Related(entity.Photo{}, 10)
+
+		assert.Error(t, err)
+		assert.Empty(t, results)
+	})
+}
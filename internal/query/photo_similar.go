@@ -0,0 +1,146 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// SimilarPhotosMaxCount is the maximum number of similar photos returned.
+const SimilarPhotosMaxCount = 100
+
+// SimilarCandidatesMaxCount is the maximum number of photos that are compared with a photo.
+const SimilarCandidatesMaxCount = 1000
+
+// SimilarMaxDistance is the maximum distance of photos that are considered similar.
+const SimilarMaxDistance = 40
+
+// SimilarColorWeight is the distance added for each cell in which the dominant colors differ.
+const SimilarColorWeight = 4
+
+// SimilarPhoto represents a photo that looks similar to another photo.
+type SimilarPhoto struct {
+	PhotoUID   string    `json:"UID"`
+	PhotoType  string    `json:"Type"`
+	PhotoTitle string    `json:"Title"`
+	TakenAt    time.Time `json:"TakenAt"`
+	FileUID    string    `json:"FileUID"`
+	FileHash   string    `json:"Hash"`
+	Distance   int       `json:"Distance"`
+}
+
+// SimilarPhotos represents a list of similar photos, ranked by distance.
+type SimilarPhotos []SimilarPhoto
+
+// UIDs returns the photo UIDs of the similar photos.
+func (r SimilarPhotos) UIDs() []string {
+	result := make([]string, len(r))
+
+	for i, p := range r {
+		result[i] = p.PhotoUID
+	}
+
+	return result
+}
+
+// ImageDistance returns the visual distance of two images based on their 3x3 luminance and color maps,
+// as stored in the files table. It returns -1 if the maps are missing or cannot be compared.
+func ImageDistance(colorsA, luminanceA, colorsB, luminanceB string) int {
+	if len(colorsA) != len(colorsB) || len(luminanceA) != len(luminanceB) || colorsA == "" || luminanceA == "" {
+		return -1
+	}
+
+	distance := 0
+
+	// Sum of the luminance differences of each cell.
+	for i := 0; i < len(luminanceA); i++ {
+		a, errA := strconv.ParseUint(luminanceA[i:i+1], 16, 8)
+		b, errB := strconv.ParseUint(luminanceB[i:i+1], 16, 8)
+
+		if errA != nil || errB != nil {
+			return -1
+		} else if a > b {
+			distance += int(a - b)
+		} else {
+			distance += int(b - a)
+		}
+	}
+
+	// Hamming distance of the color indexes.
+	for i := 0; i < len(colorsA); i++ {
+		if colorsA[i] != colorsB[i] {
+			distance += SimilarColorWeight
+		}
+	}
+
+	return distance
+}
+
+// Similar returns up to limit photos that look similar to the photo, ranked by the distance of their primary
+// files' color and luminance maps. Only the most recent photos with the same main color are compared, and
+// private, archived, and low quality photos are excluded. If visitor is true, only photos in the specified
+// shared albums and published photos are returned.
+func Similar(photo entity.Photo, limit int, visitor bool, albumUIDs []string) (results SimilarPhotos, err error) {
+	if !photo.HasID() {
+		return results, fmt.Errorf("photo id must not be empty")
+	}
+
+	if limit <= 0 || limit > SimilarPhotosMaxCount {
+		limit = SimilarPhotosMaxCount
+	}
+
+	var source entity.File
+
+	if err = Db().Where("photo_id = ? AND file_primary = 1 AND deleted_at IS NULL", photo.ID).First(&source).Error; err != nil {
+		return results, err
+	} else if source.FileColors == "" || source.FileLuminance == "" {
+		return results, nil
+	}
+
+	var candidates []struct {
+		SimilarPhoto
+		FileColors    string
+		FileLuminance string
+	}
+
+	stmt := Db().Table("photos p").
+		Select("p.photo_uid, p.photo_type, p.photo_title, p.taken_at, f.file_uid, f.file_hash, f.file_colors, f.file_luminance").
+		Joins("JOIN files f ON f.photo_id = p.id AND f.file_primary = 1 AND f.deleted_at IS NULL").
+		Where("p.id <> ? AND p.deleted_at IS NULL AND p.photo_private = 0 AND p.photo_quality > -1", photo.ID).
+		Where("f.file_main_color = ? AND f.file_colors <> '' AND f.file_luminance <> ''", source.FileMainColor)
+
+	// Visitors can only see photos in shared albums and published photos.
+	if visitor && len(albumUIDs) > 0 {
+		stmt = stmt.Where("p.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = 0 AND missing = 0 AND album_uid IN (?)) OR p.published_at > ?",
+			albumUIDs, entity.TimeStamp())
+	} else if visitor {
+		stmt = stmt.Where("p.published_at > ?", entity.TimeStamp())
+	}
+
+	if err = stmt.Order("p.taken_at DESC, p.photo_uid").
+		Limit(SimilarCandidatesMaxCount).
+		Scan(&candidates).Error; err != nil {
+		return results, err
+	}
+
+	for _, c := range candidates {
+		if d := ImageDistance(source.FileColors, source.FileLuminance, c.FileColors, c.FileLuminance); d >= 0 && d <= SimilarMaxDistance {
+			c.SimilarPhoto.Distance = d
+			results = append(results, c.SimilarPhoto)
+		}
+	}
+
+	// Photos with the same distance remain sorted by date.
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Distance < results[j].Distance
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
@@ -0,0 +1,136 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestImageDistance(t *testing.T) {
+	assert.Equal(t, 0, ImageDistance("225221C1E", "DC42844C8", "225221C1E", "DC42844C8"))
+	assert.Equal(t, 3, ImageDistance("225221C1E", "DC42844C8", "225221C1E", "DC42844CB"))
+	assert.Equal(t, 3, ImageDistance("225221C1E", "DC42844CB", "225221C1E", "DC42844C8"))
+	assert.Equal(t, 2*SimilarColorWeight, ImageDistance("225221C1E", "DC42844C8", "125221C1F", "DC42844C8"))
+	assert.Equal(t, 9*15+9*SimilarColorWeight, ImageDistance("000000000", "000000000", "111111111", "FFFFFFFFF"))
+	assert.Equal(t, -1, ImageDistance("", "", "", ""))
+	assert.Equal(t, -1, ImageDistance("225221C1E", "DC42844C8", "225221C1", "DC42844C8"))
+	assert.Equal(t, -1, ImageDistance("225221C1E", "DC42844C8", "225221C1E", "DC42844CX"))
+}
+
+func TestSimilar(t *testing.T) {
+	t.Run("Ranking", func(t *testing.T) {
+		takenAt := time.Date(2002, 3, 4, 12, 0, 0, 0, time.UTC)
+
+		// Source photo, followed by photos with increasing distance.
+		tests := []struct {
+			name      string
+			colors    string
+			luminance string
+			mainColor string
+			private   bool
+		}{
+			{"source", "ABABABABA", "123456789", "blue", false},
+			{"same", "ABABABABA", "123456789", "blue", false},
+			{"luminance", "ABABABABA", "223456788", "blue", false},
+			{"colors", "BBABABABB", "123456789", "blue", false},
+			{"both", "BBABABABB", "323456787", "blue", false},
+			{"private", "ABABABABA", "123456789", "blue", true},
+			{"different", "000000000", "FEDCBA987", "blue", false},
+			{"maincolor", "ABABABABA", "123456789", "red", false},
+		}
+
+		photos := make([]entity.Photo, len(tests))
+
+		for i, tt := range tests {
+			photo := entity.Photo{PhotoTitle: "Similar " + tt.name, PhotoType: entity.MediaImage, PhotoPrivate: tt.private, TakenAt: takenAt, TakenAtLocal: takenAt, PhotoQuality: 3}
+
+			// Published photos can be seen by visitors.
+			if tt.name == "luminance" {
+				publishedAt := entity.TimeStamp().Add(time.Hour)
+				photo.PublishedAt = &publishedAt
+			}
+
+			if err := photo.Create(); err != nil {
+				t.Fatal(err)
+			}
+
+			photos[i] = photo
+
+			defer func() { _, _ = photo.DeletePermanently() }()
+
+			file := entity.File{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FileName: "similar/" + tt.name + ".jpg", FileHash: "similar" + tt.name, FileType: "jpg", FilePrimary: true, FileColors: tt.colors, FileLuminance: tt.luminance, FileMainColor: tt.mainColor}
+
+			if err := file.Create(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		results, err := Similar(photos[0], SimilarPhotosMaxCount, false, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		index := make(map[string]int)
+
+		for i, r := range results {
+			index[r.PhotoUID] = i
+
+			assert.LessOrEqual(t, r.Distance, SimilarMaxDistance)
+
+			if i > 0 {
+				assert.LessOrEqual(t, results[i-1].Distance, r.Distance)
+			}
+		}
+
+		assert.NotContains(t, index, photos[0].PhotoUID)
+		assert.NotContains(t, index, photos[5].PhotoUID)
+		assert.NotContains(t, index, photos[6].PhotoUID)
+		assert.NotContains(t, index, photos[7].PhotoUID)
+
+		for i := 1; i < 4; i++ {
+			if assert.Contains(t, index, photos[i].PhotoUID) && assert.Contains(t, index, photos[i+1].PhotoUID) {
+				assert.Less(t, index[photos[i].PhotoUID], index[photos[i+1].PhotoUID], tests[i].name)
+			}
+		}
+
+		same := results[index[photos[1].PhotoUID]]
+
+		assert.Equal(t, 0, same.Distance)
+		assert.Equal(t, "similarsame", same.FileHash)
+		assert.Equal(t, "Similar same", same.PhotoTitle)
+		assert.Equal(t, 2, results[index[photos[2].PhotoUID]].Distance)
+		assert.Equal(t, 2*SimilarColorWeight, results[index[photos[3].PhotoUID]].Distance)
+
+		// Visitors without shared albums only see published photos.
+		visible, err := Similar(photos[0], SimilarPhotosMaxCount, true, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, visible, 1) {
+			assert.Equal(t, photos[2].PhotoUID, visible[0].PhotoUID)
+		}
+	})
+	t.Run("Limit", func(t *testing.T) {
+		photo := entity.PhotoFixtures.Get("Photo04")
+
+		results, err := Similar(photo, 2, false, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, len(results), 2)
+	})
+	t.Run("NoID", func(t *testing.T) {
+		results, err := Similar(entity.Photo{}, 10, false, nil)
+
+		assert.Error(t, err)
+		assert.Empty(t, results)
+	})
+}
@@ -57,6 +57,79 @@ func TestPreloadPhotoByUID(t *testing.T) {
 	})
 }
 
+func TestPhotoBasicByUID(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		takenAt := time.Date(2003, 4, 5, 6, 7, 8, 0, time.UTC)
+		photo := entity.Photo{PhotoTitle: "Basic Projection", PhotoType: entity.MediaImage, TakenAt: takenAt, TakenAtLocal: takenAt}
+
+		if err := photo.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _, _ = photo.DeletePermanently() }()
+
+		file := entity.File{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FileName: "basic/projection.jpg", FileHash: "basicprojection", FileType: "jpg", FilePrimary: true}
+
+		if err := file.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := PhotoBasicByUID(photo.PhotoUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, photo.PhotoUID, result.PhotoUID)
+		assert.Equal(t, "Basic Projection", result.PhotoTitle)
+		assert.Equal(t, entity.MediaImage, result.PhotoType)
+		assert.True(t, takenAt.Equal(result.TakenAt))
+		assert.Equal(t, "basicprojection", result.FileHash)
+		assert.Nil(t, result.DeletedAt)
+	})
+	t.Run("Fixture", func(t *testing.T) {
+		result, err := PhotoBasicByUID("pt9jtdre2lvl0y12")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Reunion", result.PhotoTitle)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		result, err := PhotoBasicByUID("pt9jtdre2lvl0xxx")
+		assert.Error(t, err)
+		assert.Empty(t, result.PhotoUID)
+	})
+	t.Run("EmptyUID", func(t *testing.T) {
+		_, err := PhotoBasicByUID("")
+		assert.Error(t, err)
+	})
+}
+
+func TestPhotoByFileHash(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		result, err := PhotoByFileHash("2cad9168fa6acc5c5c2965ddf6ec465ca42fd818")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "pt9jtdre2lvl0yh7", result.PhotoUID)
+		assert.NotEmpty(t, result.Labels)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		result, err := PhotoByFileHash("0000000000000000000000000000000000000000")
+		assert.Error(t, err)
+		assert.Empty(t, result.PhotoUID)
+	})
+	t.Run("EmptyHash", func(t *testing.T) {
+		result, err := PhotoByFileHash("")
+		assert.Error(t, err)
+		assert.Empty(t, result.PhotoUID)
+	})
+}
+
 func TestMissingPhotos(t *testing.T) {
 	result, err := PhotosMissing(15, 0)
 
@@ -78,6 +151,55 @@ func TestPhotosMetadataUpdate(t *testing.T) {
 	assert.IsType(t, entity.Photos{}, result)
 }
 
+func TestPhotosWithMetadata(t *testing.T) {
+	result, err := PhotosWithMetadata(10, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, result, 10)
+}
+
+func TestPhotosTakenBetween(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		result, err := PhotosTakenBetween(time.Time{}, time.Time{}, 10, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 10)
+	})
+	t.Run("Range", func(t *testing.T) {
+		from := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2000, 12, 31, 23, 59, 59, 0, time.UTC)
+
+		result, err := PhotosTakenBetween(from, to, 1000, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range result {
+			assert.False(t, p.TakenAt.Before(from))
+			assert.False(t, p.TakenAt.After(to))
+		}
+	})
+	t.Run("Empty", func(t *testing.T) {
+		from := time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(1800, 12, 31, 0, 0, 0, 0, time.UTC)
+
+		result, err := PhotosTakenBetween(from, to, 10, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 0)
+	})
+}
+
 func TestOrphanPhotos(t *testing.T) {
 	result, err := OrphanPhotos()
 
@@ -104,3 +226,90 @@ func TestFlagHiddenPhotos(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestPhotoShared(t *testing.T) {
+	t.Run("SharedAlbum", func(t *testing.T) {
+		assert.True(t, PhotoShared("pt9jtdre2lvl0yh7", []string{"at9lxuqxpogaaba8"}))
+	})
+	t.Run("OtherAlbum", func(t *testing.T) {
+		assert.False(t, PhotoShared("pt9jtdre2lvl0yh8", []string{"at9lxuqxpogaaba8"}))
+	})
+	t.Run("NoAlbums", func(t *testing.T) {
+		assert.False(t, PhotoShared("pt9jtdre2lvl0yh7", nil))
+	})
+	t.Run("EmptyUID", func(t *testing.T) {
+		assert.False(t, PhotoShared("", []string{"at9lxuqxpogaaba8"}))
+	})
+}
+
+func TestPhotosArchivedBefore(t *testing.T) {
+	t.Run("Archived", func(t *testing.T) {
+		photos, err := PhotosArchivedBefore(time.Now(), 100)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			if assert.NotNil(t, p.DeletedAt) {
+				assert.True(t, p.DeletedAt.Before(time.Now()))
+			}
+		}
+	})
+	t.Run("None", func(t *testing.T) {
+		photos, err := PhotosArchivedBefore(time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), 100)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, photos)
+	})
+}
+
+func TestPhotoRemoved(t *testing.T) {
+	t.Run("Removed", func(t *testing.T) {
+		photo := entity.Photo{PhotoTitle: "Removed", PhotoType: entity.MediaImage, TakenSrc: entity.SrcAuto}
+
+		if err := photo.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _, _ = photo.DeletePermanently() }()
+
+		file := entity.File{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FileName: "removed/" + photo.PhotoUID + ".jpg", FileHash: "removed" + photo.PhotoUID, FileType: "jpg", FilePrimary: true}
+
+		if err := file.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		_, removed := PhotoRemoved(photo.PhotoUID)
+		assert.False(t, removed)
+
+		if _, err := photo.Delete(false); err != nil {
+			t.Fatal(err)
+		}
+
+		deletedAt, removed := PhotoRemoved(photo.PhotoUID)
+		assert.True(t, removed)
+		assert.False(t, deletedAt.IsZero())
+	})
+	t.Run("Archived", func(t *testing.T) {
+		_, removed := PhotoRemoved("pt9jtdre2lvl0y25")
+		assert.False(t, removed)
+	})
+	t.Run("Exists", func(t *testing.T) {
+		_, removed := PhotoRemoved("pt9jtdre2lvl0y12")
+		assert.False(t, removed)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, removed := PhotoRemoved("pt9jtdre2lvl0xxx")
+		assert.False(t, removed)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, removed := PhotoRemoved("")
+		assert.False(t, removed)
+	})
+}
@@ -0,0 +1,97 @@
+package query
+
+import (
+	"math"
+	"sort"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// NearbyPlacesDist is the default search radius for nearby places in km.
+const NearbyPlacesDist = 20
+
+// NearbyPlacesMaxDist is the maximum search radius for nearby places in km.
+const NearbyPlacesMaxDist = 1000
+
+// NearbyPlacesMaxCount is the maximum number of nearby places returned.
+const NearbyPlacesMaxCount = 100
+
+// NearbyPlace represents a named place close to a location.
+type NearbyPlace struct {
+	PlaceID    string  `json:"PlaceID"`
+	Label      string  `json:"Label"`
+	City       string  `json:"City"`
+	State      string  `json:"State"`
+	Country    string  `json:"Country"`
+	Lat        float64 `json:"Lat"`
+	Lng        float64 `json:"Lng"`
+	PhotoCount int     `json:"PhotoCount"`
+	Distance   float64 `json:"Distance"`
+}
+
+// NearbyPlaces represents a list of nearby places, ordered by distance.
+type NearbyPlaces []NearbyPlace
+
+// NearbyPlacesByLatLng returns up to limit named places within the distance in km around the coordinates,
+// ordered by distance. Since places have no coordinates of their own, their location is estimated
+// based on the average position of the photos taken there.
+func NearbyPlacesByLatLng(lat, lng, dist float64, limit int) (results NearbyPlaces, err error) {
+	results = NearbyPlaces{}
+
+	if lat == 0 && lng == 0 {
+		return results, nil
+	}
+
+	if dist <= 0 {
+		dist = NearbyPlacesDist
+	} else if dist > NearbyPlacesMaxDist {
+		dist = NearbyPlacesMaxDist
+	}
+
+	if limit <= 0 || limit > NearbyPlacesMaxCount {
+		limit = NearbyPlacesMaxCount
+	}
+
+	// Approximate bounding box in degrees.
+	latRange := dist / (geo.EarthRadiusKm * math.Pi / 180)
+	lngRange := 180.0
+
+	if c := math.Cos(geo.DegToRad(lat)); c > 0.01 {
+		lngRange = latRange / c
+	}
+
+	var places NearbyPlaces
+
+	if err = Db().Table("photos p").
+		Select("pl.id AS place_id, pl.place_label AS label, pl.place_city AS city, pl.place_state AS state, "+
+			"pl.place_country AS country, AVG(p.photo_lat) AS lat, AVG(p.photo_lng) AS lng, COUNT(*) AS photo_count").
+		Joins("JOIN places pl ON pl.id = p.place_id").
+		Where("p.place_id <> ? AND p.deleted_at IS NULL AND p.photo_private = 0", entity.UnknownPlace.ID).
+		Where("p.photo_lat BETWEEN ? AND ?", lat-latRange, lat+latRange).
+		Where("p.photo_lng BETWEEN ? AND ?", lng-lngRange, lng+lngRange).
+		Group("pl.id, pl.place_label, pl.place_city, pl.place_state, pl.place_country").
+		Scan(&places).Error; err != nil {
+		return results, err
+	}
+
+	origin := geo.Position{Lat: lat, Lng: lng}
+
+	for _, p := range places {
+		p.Distance = origin.Km(geo.Position{Lat: p.Lat, Lng: p.Lng})
+
+		if p.Distance <= dist {
+			results = append(results, p)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Distance < results[j].Distance
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
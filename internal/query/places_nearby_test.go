@@ -0,0 +1,63 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestNearbyPlacesByLatLng(t *testing.T) {
+	t.Run("Germany", func(t *testing.T) {
+		results, err := NearbyPlacesByLatLng(48.519234, 9.057997, 20, 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ids := make([]string, len(results))
+
+		for i, r := range results {
+			ids[i] = r.PlaceID
+
+			assert.LessOrEqual(t, r.Distance, 20.0)
+			assert.Greater(t, r.PhotoCount, 0)
+
+			if i > 0 {
+				assert.LessOrEqual(t, results[i-1].Distance, r.Distance)
+			}
+		}
+
+		assert.Contains(t, ids, entity.PlaceFixtures.Get("Germany").ID)
+		assert.NotContains(t, ids, entity.UnknownPlace.ID)
+	})
+	t.Run("FarAway", func(t *testing.T) {
+		results, err := NearbyPlacesByLatLng(-77.846323, 166.668235, 1, 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+	t.Run("NoCoordinates", func(t *testing.T) {
+		results, err := NearbyPlacesByLatLng(0, 0, 20, 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotNil(t, results)
+		assert.Empty(t, results)
+	})
+	t.Run("Limit", func(t *testing.T) {
+		results, err := NearbyPlacesByLatLng(48.519234, 9.057997, NearbyPlacesMaxDist, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+	})
+}
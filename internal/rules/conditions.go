@@ -0,0 +1,151 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Operators supported in rule conditions.
+const (
+	OpEqual        = "="
+	OpNotEqual     = "!="
+	OpGreater      = ">"
+	OpGreaterEqual = ">="
+	OpLess         = "<"
+	OpLessEqual    = "<="
+	OpContains     = "~"
+)
+
+// conditionRegexp matches a single condition, e.g. "iso > 3200" or "camera_make = 'Canon'".
+var conditionRegexp = regexp.MustCompile(`^\s*([a-z_]+)\s*(!=|>=|<=|=|>|<|~)\s*(.*?)\s*$`)
+
+// andRegexp matches the keyword used to combine multiple conditions.
+var andRegexp = regexp.MustCompile(`(?i)\s+and\s+`)
+
+// Condition represents a comparison of a metadata field with a value.
+type Condition struct {
+	Field  string
+	Op     string
+	Value  string
+	Number float64
+}
+
+// Conditions represents a list of conditions that must all match.
+type Conditions []Condition
+
+// ParseConditions parses conditions combined with "and", e.g. "iso > 3200 and camera_make = Canon".
+func ParseConditions(s string) (result Conditions, err error) {
+	if s = strings.TrimSpace(s); s == "" {
+		return result, fmt.Errorf("condition is empty")
+	}
+
+	for _, part := range andRegexp.Split(s, -1) {
+		c, err := ParseCondition(part)
+
+		if err != nil {
+			return Conditions{}, err
+		}
+
+		result = append(result, c)
+	}
+
+	return result, nil
+}
+
+// ParseCondition parses a single condition and validates field name, operator, and value.
+func ParseCondition(s string) (c Condition, err error) {
+	m := conditionRegexp.FindStringSubmatch(strings.ToLower(s))
+
+	if len(m) != 4 {
+		return c, fmt.Errorf("invalid condition %s", clean.Log(s))
+	}
+
+	c.Field, c.Op, c.Value = m[1], m[2], strings.Trim(m[3], `"'`)
+
+	f, ok := Fields[c.Field]
+
+	if !ok {
+		return c, fmt.Errorf("unknown field %s", clean.Log(c.Field))
+	} else if c.Value == "" {
+		return c, fmt.Errorf("missing value for %s", clean.Log(c.Field))
+	}
+
+	if f.Number != nil {
+		if c.Op == OpContains {
+			return c, fmt.Errorf("operator %s not supported for %s", c.Op, clean.Log(c.Field))
+		} else if c.Number, err = strconv.ParseFloat(c.Value, 64); err != nil {
+			return c, fmt.Errorf("%s must be a number", clean.Log(c.Field))
+		}
+	} else if c.Op != OpEqual && c.Op != OpNotEqual && c.Op != OpContains {
+		return c, fmt.Errorf("operator %s not supported for %s", c.Op, clean.Log(c.Field))
+	}
+
+	return c, nil
+}
+
+// Match checks if the photo metadata matches the condition.
+func (c Condition) Match(p *entity.Photo) bool {
+	f, ok := Fields[c.Field]
+
+	if !ok {
+		return false
+	}
+
+	if f.Number != nil {
+		v, known := f.Number(p)
+
+		if !known {
+			return false
+		}
+
+		switch c.Op {
+		case OpEqual:
+			return v == c.Number
+		case OpNotEqual:
+			return v != c.Number
+		case OpGreater:
+			return v > c.Number
+		case OpGreaterEqual:
+			return v >= c.Number
+		case OpLess:
+			return v < c.Number
+		case OpLessEqual:
+			return v <= c.Number
+		}
+
+		return false
+	}
+
+	v := strings.ToLower(f.Text(p))
+
+	switch c.Op {
+	case OpEqual:
+		return v == c.Value
+	case OpNotEqual:
+		return v != c.Value
+	case OpContains:
+		return strings.Contains(v, c.Value)
+	}
+
+	return false
+}
+
+// Match checks if the photo metadata matches all conditions.
+func (c Conditions) Match(p *entity.Photo) bool {
+	if p == nil || len(c) == 0 {
+		return false
+	}
+
+	for i := range c {
+		if !c[i].Match(p) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,129 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestParseCondition(t *testing.T) {
+	t.Run("Number", func(t *testing.T) {
+		c, err := ParseCondition("ISO > 3200")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "iso", c.Field)
+		assert.Equal(t, OpGreater, c.Op)
+		assert.Equal(t, float64(3200), c.Number)
+	})
+	t.Run("Text", func(t *testing.T) {
+		c, err := ParseCondition(`camera_model ~ "EOS R5"`)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "camera_model", c.Field)
+		assert.Equal(t, OpContains, c.Op)
+		assert.Equal(t, "eos r5", c.Value)
+	})
+	t.Run("UnknownField", func(t *testing.T) {
+		_, err := ParseCondition("speed > 100")
+		assert.EqualError(t, err, "unknown field speed")
+	})
+	t.Run("NotNumber", func(t *testing.T) {
+		_, err := ParseCondition("iso >= high")
+		assert.EqualError(t, err, "iso must be a number")
+	})
+	t.Run("TextOperator", func(t *testing.T) {
+		_, err := ParseCondition("camera_make > Canon")
+		assert.EqualError(t, err, "operator > not supported for camera_make")
+	})
+	t.Run("NumberOperator", func(t *testing.T) {
+		_, err := ParseCondition("iso ~ 100")
+		assert.EqualError(t, err, "operator ~ not supported for iso")
+	})
+	t.Run("MissingValue", func(t *testing.T) {
+		_, err := ParseCondition("iso >")
+		assert.EqualError(t, err, "missing value for iso")
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseCondition("iso")
+		assert.Error(t, err)
+	})
+}
+
+func TestParseConditions(t *testing.T) {
+	t.Run("And", func(t *testing.T) {
+		c, err := ParseConditions("camera_make = Canon AND focal_length >= 200")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, c, 2)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := ParseConditions("  ")
+		assert.EqualError(t, err, "condition is empty")
+	})
+}
+
+func TestConditions_Match(t *testing.T) {
+	p := &entity.Photo{
+		PhotoIso:         6400,
+		PhotoFocalLength: 300,
+		PhotoType:        entity.MediaImage,
+		Camera:           &entity.Camera{CameraMake: "Canon", CameraModel: "EOS R5"},
+	}
+
+	t.Run("Match", func(t *testing.T) {
+		c, err := ParseConditions("iso > 3200 and camera_model ~ r5 and type = image")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, c.Match(p))
+	})
+	t.Run("NoMatch", func(t *testing.T) {
+		c, err := ParseConditions("iso > 3200 and focal_length < 50")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, c.Match(p))
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		c, err := ParseConditions("fnumber < 2.8")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, c.Match(p))
+	})
+	t.Run("NoLens", func(t *testing.T) {
+		c, err := ParseConditions("lens_model != xyz")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, c.Match(p))
+	})
+	t.Run("Nil", func(t *testing.T) {
+		c, err := ParseConditions("iso > 100")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, c.Match(nil))
+	})
+}
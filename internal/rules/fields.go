@@ -0,0 +1,102 @@
+package rules
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// Field reads a metadata property of a photo, either as number or as text.
+type Field struct {
+	Number func(p *entity.Photo) (v float64, known bool)
+	Text   func(p *entity.Photo) string
+}
+
+// positive returns the value as float64 if it is greater than zero, as zero indicates unknown metadata.
+func positive(v float64) (float64, bool) {
+	return v, v > 0
+}
+
+// Fields maps the field names that can be used in rule conditions.
+var Fields = map[string]Field{
+	"iso": {Number: func(p *entity.Photo) (float64, bool) {
+		return positive(float64(p.PhotoIso))
+	}},
+	"fnumber": {Number: func(p *entity.Photo) (float64, bool) {
+		return positive(float64(p.PhotoFNumber))
+	}},
+	"focal_length": {Number: func(p *entity.Photo) (float64, bool) {
+		return positive(float64(p.PhotoFocalLength))
+	}},
+	"resolution": {Number: func(p *entity.Photo) (float64, bool) {
+		return positive(float64(p.PhotoResolution))
+	}},
+	"duration": {Number: func(p *entity.Photo) (float64, bool) {
+		return positive(p.PhotoDuration.Seconds())
+	}},
+	"year": {Number: func(p *entity.Photo) (float64, bool) {
+		return positive(float64(p.PhotoYear))
+	}},
+	"month": {Number: func(p *entity.Photo) (float64, bool) {
+		return positive(float64(p.PhotoMonth))
+	}},
+	"day": {Number: func(p *entity.Photo) (float64, bool) {
+		return positive(float64(p.PhotoDay))
+	}},
+	"altitude": {Number: func(p *entity.Photo) (float64, bool) {
+		return float64(p.PhotoAltitude), p.HasLatLng()
+	}},
+	"lat": {Number: func(p *entity.Photo) (float64, bool) {
+		return float64(p.PhotoLat), p.HasLatLng()
+	}},
+	"lng": {Number: func(p *entity.Photo) (float64, bool) {
+		return float64(p.PhotoLng), p.HasLatLng()
+	}},
+	"faces": {Number: func(p *entity.Photo) (float64, bool) {
+		return float64(p.PhotoFaces), true
+	}},
+	"type": {Text: func(p *entity.Photo) string {
+		return p.PhotoType
+	}},
+	"exposure": {Text: func(p *entity.Photo) string {
+		return p.PhotoExposure
+	}},
+	"country": {Text: func(p *entity.Photo) string {
+		return p.PhotoCountry
+	}},
+	"camera_serial": {Text: func(p *entity.Photo) string {
+		return p.CameraSerial
+	}},
+	"camera_make": {Text: func(p *entity.Photo) string {
+		if p.Camera == nil {
+			return ""
+		}
+
+		return p.Camera.CameraMake
+	}},
+	"camera_model": {Text: func(p *entity.Photo) string {
+		if p.Camera == nil {
+			return ""
+		}
+
+		return p.Camera.CameraModel
+	}},
+	"lens_make": {Text: func(p *entity.Photo) string {
+		if p.Lens == nil {
+			return ""
+		}
+
+		return p.Lens.LensMake
+	}},
+	"lens_model": {Text: func(p *entity.Photo) string {
+		if p.Lens == nil {
+			return ""
+		}
+
+		return p.Lens.LensModel
+	}},
+	"path": {Text: func(p *entity.Photo) string {
+		return p.PhotoPath
+	}},
+	"name": {Text: func(p *entity.Photo) string {
+		return p.PhotoName
+	}},
+}
@@ -0,0 +1,177 @@
+/*
+Package rules maps metadata conditions to custom keywords and labels, e.g. "iso > 3200" to "highiso".
+
+Copyright (c) 2018 - 2023 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"gopkg.in/yaml.v2"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+var log = event.Log
+
+// Rule adds keywords and labels to pictures with metadata matching all conditions.
+type Rule struct {
+	Name     string     `yaml:"Name,omitempty" json:"Name"`
+	When     string     `yaml:"When" json:"When"`
+	Keywords []string   `yaml:"Keywords,omitempty" json:"Keywords"`
+	Labels   []string   `yaml:"Labels,omitempty" json:"Labels"`
+	cond     Conditions `yaml:"-" json:"-"`
+}
+
+// Compile parses the rule conditions and returns an error if the syntax is invalid.
+func (r *Rule) Compile() (err error) {
+	if r.cond, err = ParseConditions(r.When); err != nil {
+		return err
+	} else if len(r.Keywords) == 0 && len(r.Labels) == 0 {
+		return fmt.Errorf("no keywords or labels specified")
+	}
+
+	return nil
+}
+
+// Match checks if the photo metadata matches all rule conditions.
+func (r *Rule) Match(p *entity.Photo) bool {
+	if len(r.cond) == 0 {
+		return false
+	}
+
+	return r.cond.Match(p)
+}
+
+// Rules represents a list of metadata rules.
+type Rules []Rule
+
+// Load reads and compiles the rules from a YAML file, an empty list is returned if the file does not exist.
+func Load(fileName string) (result Rules, err error) {
+	if !fs.FileExists(fileName) {
+		return result, nil
+	}
+
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return result, err
+	}
+
+	return Parse(data)
+}
+
+// cache holds the rules last loaded with Cached.
+var cache = struct {
+	sync.Mutex
+	fileName string
+	modTime  time.Time
+	rules    Rules
+}{}
+
+// Cached returns the rules from a YAML file and only reloads them if the file has been modified.
+// Errors are logged, so that invalid rules do not prevent indexing.
+func Cached(fileName string) Rules {
+	cache.Lock()
+	defer cache.Unlock()
+
+	info, err := os.Stat(fileName)
+
+	if err != nil {
+		cache.fileName, cache.modTime, cache.rules = "", time.Time{}, Rules{}
+		return cache.rules
+	} else if cache.fileName == fileName && cache.modTime.Equal(info.ModTime()) {
+		return cache.rules
+	}
+
+	cache.fileName, cache.modTime = fileName, info.ModTime()
+
+	if cache.rules, err = Load(fileName); err != nil {
+		log.Errorf("%s in %s", err, clean.Log(filepath.Base(fileName)))
+	} else if n := len(cache.rules); n > 0 {
+		log.Debugf("rules: loaded %s from %s", english.Plural(n, "rule", "rules"), clean.Log(filepath.Base(fileName)))
+	}
+
+	return cache.rules
+}
+
+// Parse parses and compiles the rules from YAML data.
+func Parse(data []byte) (result Rules, err error) {
+	if err = yaml.Unmarshal(data, &result); err != nil {
+		return Rules{}, fmt.Errorf("rules: %s", err)
+	}
+
+	for i := range result {
+		if err = result[i].Compile(); err != nil {
+			if result[i].Name != "" {
+				return Rules{}, fmt.Errorf("rules: %s in rule %d (%s)", err, i+1, clean.Log(result[i].Name))
+			}
+
+			return Rules{}, fmt.Errorf("rules: %s in rule %d", err, i+1)
+		}
+	}
+
+	return result, nil
+}
+
+// Apply returns the keywords and labels of all rules matching the photo metadata.
+func (r Rules) Apply(p *entity.Photo) (keywords []string, labels classify.Labels) {
+	if p == nil || len(r) == 0 {
+		return keywords, labels
+	}
+
+	for i := range r {
+		if !r[i].Match(p) {
+			continue
+		}
+
+		log.Tracef("rules: %s matches %s", p.String(), clean.Log(r[i].When))
+
+		for _, k := range r[i].Keywords {
+			if k = strings.TrimSpace(k); k != "" {
+				keywords = append(keywords, k)
+			}
+		}
+
+		for _, name := range r[i].Labels {
+			if name = strings.TrimSpace(name); name == "" {
+				continue
+			}
+
+			labels = append(labels, classify.Label{Name: name, Source: classify.SrcRule, Uncertainty: 0, Priority: 0})
+		}
+	}
+
+	return txt.UniqueWords(keywords), labels
+}
@@ -0,0 +1,104 @@
+package rules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r, err := Load("testdata/rules.yml")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, r, 3)
+		assert.Equal(t, "High ISO", r[0].Name)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		r, err := Load("testdata/invalid.yml")
+
+		assert.EqualError(t, err, "rules: unknown field speed in rule 1 ('Unknown Field')")
+		assert.Len(t, r, 0)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		r, err := Load("testdata/missing.yml")
+
+		assert.NoError(t, err)
+		assert.Len(t, r, 0)
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("NoKeywords", func(t *testing.T) {
+		_, err := Parse([]byte("- When: iso > 100\n"))
+		assert.EqualError(t, err, "rules: no keywords or labels specified in rule 1")
+	})
+	t.Run("InvalidYaml", func(t *testing.T) {
+		_, err := Parse([]byte("When: [iso"))
+		assert.Error(t, err)
+	})
+}
+
+func TestCached(t *testing.T) {
+	r := Cached("testdata/rules.yml")
+	assert.Len(t, r, 3)
+	assert.Len(t, Cached("testdata/invalid.yml"), 0)
+	assert.Len(t, Cached("testdata/missing.yml"), 0)
+}
+
+func TestRules_Apply(t *testing.T) {
+	r, err := Load("testdata/rules.yml")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("HighIso", func(t *testing.T) {
+		p := &entity.Photo{PhotoIso: 6400, PhotoFocalLength: 35, PhotoType: entity.MediaImage}
+
+		keywords, labels := r.Apply(p)
+
+		assert.Equal(t, []string{"highiso"}, keywords)
+		assert.Len(t, labels, 0)
+	})
+	t.Run("Telephoto", func(t *testing.T) {
+		p := &entity.Photo{
+			PhotoIso:         6400,
+			PhotoFocalLength: 400,
+			PhotoType:        entity.MediaImage,
+			Camera:           &entity.Camera{CameraMake: "Canon", CameraModel: "EOS R3"},
+		}
+
+		keywords, labels := r.Apply(p)
+
+		assert.Equal(t, []string{"highiso", "telephoto"}, keywords)
+
+		if assert.Len(t, labels, 1) {
+			assert.Equal(t, "Telephoto", labels[0].Name)
+			assert.Equal(t, classify.SrcRule, labels[0].Source)
+		}
+	})
+	t.Run("Video", func(t *testing.T) {
+		p := &entity.Photo{PhotoType: entity.MediaVideo, PhotoDuration: 3 * time.Second}
+
+		keywords, labels := r.Apply(p)
+
+		assert.Equal(t, []string{"clip"}, keywords)
+		assert.Len(t, labels, 0)
+	})
+	t.Run("NoMatch", func(t *testing.T) {
+		p := &entity.Photo{PhotoIso: 100, PhotoType: entity.MediaImage}
+
+		keywords, labels := r.Apply(p)
+
+		assert.Len(t, keywords, 0)
+		assert.Len(t, labels, 0)
+	})
+}
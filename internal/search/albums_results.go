@@ -11,6 +11,7 @@ type Album struct {
 	ParentUID        string    `json:"ParentUID"`
 	Thumb            string    `json:"Thumb"`
 	ThumbSrc         string    `json:"ThumbSrc,omitempty"`
+	ThumbHash        string    `json:"ThumbHash,omitempty"`
 	AlbumSlug        string    `json:"Slug"`
 	AlbumType        string    `json:"Type"`
 	AlbumTitle       string    `json:"Title"`
@@ -2,8 +2,11 @@ package search
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/txt"
 
@@ -45,6 +48,9 @@ func LikeAny(col, s string, keywords, exact bool) (wheres []string) {
 
 		if len(words) == 0 {
 			continue
+		} else if !exact {
+			// Also match words without diacritics, e.g. "cafe" for "café".
+			words = txt.FoldWords(words)
 		}
 
 		for _, w := range words {
@@ -234,6 +240,399 @@ func AnyInt(col, numbers, sep string, min, max int) (where string) {
 	return strings.Join(wheres, " OR ")
 }
 
+// ParseTimeOfDay parses a local time range like "18:00-20:00" and returns the start and end in minutes after midnight.
+func ParseTimeOfDay(s string) (start, end int, ok bool) {
+	v := strings.Split(strings.TrimSpace(s), "-")
+
+	if len(v) != 2 {
+		return 0, 0, false
+	}
+
+	if start, ok = parseClock(v[0]); !ok {
+		return 0, 0, false
+	}
+
+	if end, ok = parseClock(v[1]); !ok {
+		return 0, 0, false
+	}
+
+	return start, end, true
+}
+
+// parseClock parses a time of day in the format "HH:MM", "HHMM", or "HH" and returns the minutes after midnight.
+func parseClock(s string) (minutes int, ok bool) {
+	s = strings.TrimSpace(s)
+
+	var h, m string
+
+	if i := strings.Index(s, ":"); i >= 0 {
+		h, m = s[:i], s[i+1:]
+	} else if len(s) > 2 {
+		h, m = s[:len(s)-2], s[len(s)-2:]
+	} else {
+		h, m = s, "0"
+	}
+
+	if !txt.IsUInt(h) || !txt.IsUInt(m) || len(h) > 2 || len(m) > 2 {
+		return 0, false
+	}
+
+	hours, mins := txt.Int(h), txt.Int(m)
+
+	// Allow 24:00 as end of the day.
+	if hours == 24 && mins == 0 {
+		return 24*60 - 1, true
+	} else if hours > 23 || mins > 59 {
+		return 0, false
+	}
+
+	return hours*60 + mins, true
+}
+
+// TimeOfDay returns a where condition that matches a local time range, which may wrap past midnight.
+func TimeOfDay(col, s string) (where string) {
+	start, end, ok := ParseTimeOfDay(s)
+
+	if !ok {
+		return ""
+	}
+
+	var minutes string
+
+	switch entity.DbDialect() {
+	case entity.MySQL:
+		minutes = fmt.Sprintf("(HOUR(%s) * 60 + MINUTE(%s))", col, col)
+	default:
+		minutes = fmt.Sprintf("(CAST(strftime('%%H', %s) AS INTEGER) * 60 + CAST(strftime('%%M', %s) AS INTEGER))", col, col)
+	}
+
+	if start <= end {
+		return fmt.Sprintf("%s BETWEEN %d AND %d", minutes, start, end)
+	}
+
+	return fmt.Sprintf("(%s >= %d OR %s <= %d)", minutes, start, minutes, end)
+}
+
+// ByteUnits maps the supported file size units to their number of bytes.
+var ByteUnits = map[string]int64{
+	"":   1,
+	"b":  1,
+	"k":  1 << 10,
+	"kb": 1 << 10,
+	"m":  1 << 20,
+	"mb": 1 << 20,
+	"g":  1 << 30,
+	"gb": 1 << 30,
+}
+
+// ParseByteSize parses a file size like "20MB", "1.5 GB", or "4096" and returns the number of bytes.
+func ParseByteSize(s string) (bytes int64, ok bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+
+	if i < 0 {
+		i = len(s)
+	}
+
+	if i == 0 {
+		return 0, false
+	}
+
+	n, err := strconv.ParseFloat(s[:i], 64)
+
+	if err != nil || n < 0 {
+		return 0, false
+	}
+
+	unit, found := ByteUnits[strings.TrimSpace(s[i:])]
+
+	if !found {
+		return 0, false
+	}
+
+	return int64(n * float64(unit)), true
+}
+
+// FileSize returns a where condition that matches a file size expression like ">20MB", "<=100KB", or "1MB-5MB".
+// Sizes without an operator match files of at least this size.
+func FileSize(col, s string) (where string) {
+	s = strings.TrimSpace(s)
+
+	if v := strings.Split(s, "-"); len(v) == 2 {
+		from, fromOk := ParseByteSize(v[0])
+		to, toOk := ParseByteSize(v[1])
+
+		if !fromOk || !toOk || from > to {
+			return ""
+		}
+
+		return fmt.Sprintf("%s BETWEEN %d AND %d", col, from, to)
+	}
+
+	op := ">="
+
+	for _, o := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(s, o) {
+			op, s = o, s[len(o):]
+			break
+		}
+	}
+
+	size, ok := ParseByteSize(s)
+
+	if !ok {
+		return ""
+	}
+
+	return fmt.Sprintf("%s %s %d", col, op, size)
+}
+
+// NumRange represents a numeric filter expression like ">1600", "<=2.8", "35-85", or "100".
+type NumRange struct {
+	Op   string
+	From float64
+	To   float64
+}
+
+// ParseNumRange parses a numeric filter expression using the given value parser.
+// Values without an operator match exactly.
+func ParseNumRange(s string, parse func(string) (float64, bool)) (r NumRange, ok bool) {
+	s = strings.TrimSpace(s)
+
+	if v := strings.Split(s, "-"); len(v) == 2 {
+		from, fromOk := parse(v[0])
+		to, toOk := parse(v[1])
+
+		if !fromOk || !toOk || from > to {
+			return r, false
+		}
+
+		return NumRange{Op: "-", From: from, To: to}, true
+	}
+
+	r.Op = "="
+
+	for _, o := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(s, o) {
+			r.Op, s = o, s[len(o):]
+			break
+		}
+	}
+
+	if r.From, ok = parse(s); !ok {
+		return NumRange{}, false
+	}
+
+	r.To = r.From
+
+	return r, true
+}
+
+// Match tests if the value is in range.
+func (r NumRange) Match(v float64) bool {
+	switch r.Op {
+	case ">":
+		return v > r.From
+	case ">=":
+		return v >= r.From
+	case "<":
+		return v < r.From
+	case "<=":
+		return v <= r.From
+	default:
+		return v >= r.From && v <= r.To
+	}
+}
+
+// Where returns a where condition for the column, allowing the specified rounding tolerance
+// when comparing floating point values.
+func (r NumRange) Where(col string, tolerance float64) string {
+	f := func(v float64) string {
+		return strconv.FormatFloat(math.Round(v*1e6)/1e6, 'f', -1, 64)
+	}
+
+	switch r.Op {
+	case ">":
+		return fmt.Sprintf("%s > %s", col, f(r.From+tolerance))
+	case ">=":
+		return fmt.Sprintf("%s >= %s", col, f(r.From-tolerance))
+	case "<":
+		return fmt.Sprintf("%s < %s", col, f(r.From-tolerance))
+	case "<=":
+		return fmt.Sprintf("%s <= %s", col, f(r.From+tolerance))
+	case "=":
+		if tolerance == 0 {
+			return fmt.Sprintf("%s = %s", col, f(r.From))
+		}
+	}
+
+	return fmt.Sprintf("%s BETWEEN %s AND %s", col, f(r.From-tolerance), f(r.To+tolerance))
+}
+
+// ParseNumber parses a positive number, ignoring the specified unit prefix or suffix, e.g. "f/" or "mm".
+func ParseNumber(s, unit string) (n float64, ok bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if unit != "" {
+		s = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(s, unit), unit))
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+
+	if err != nil || n < 0 {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// ParseExposure parses an exposure time like "1/60", "1/250s", or "2" and returns it in seconds.
+func ParseExposure(s string) (seconds float64, ok bool) {
+	s = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), "s")
+
+	if i := strings.Index(s, "/"); i >= 0 {
+		num, numOk := ParseNumber(s[:i], "")
+		den, denOk := ParseNumber(s[i+1:], "")
+
+		if !numOk || !denOk || den == 0 {
+			return 0, false
+		}
+
+		return num / den, true
+	}
+
+	return ParseNumber(s, "")
+}
+
+// IsoRange returns a where condition that matches an ISO speed expression like ">1600" or "100-400".
+func IsoRange(col, s string) (where string) {
+	r, ok := ParseNumRange(s, func(v string) (float64, bool) { return ParseNumber(v, "iso") })
+
+	if !ok {
+		return ""
+	}
+
+	return r.Where(col, 0)
+}
+
+// ApertureRange returns a where condition that matches an f-number expression like "<2.8" or "f/4-f/8".
+func ApertureRange(col, s string) (where string) {
+	r, ok := ParseNumRange(s, func(v string) (float64, bool) { return ParseNumber(strings.Replace(v, "/", "", 1), "f") })
+
+	if !ok {
+		return ""
+	}
+
+	// F-numbers are stored as single precision floats.
+	return r.Where(col, 0.01)
+}
+
+// FocalRange returns a where condition that matches a focal length expression like "35-85" or ">=200mm".
+func FocalRange(col, s string) (where string) {
+	r, ok := ParseNumRange(s, func(v string) (float64, bool) { return ParseNumber(v, "mm") })
+
+	if !ok {
+		return ""
+	}
+
+	return r.Where(col, 0)
+}
+
+// ShutterValues returns the exposure times that match an expression like ">1/60" or "1/250-1/60".
+// Exposure times are stored as strings, so they must be compared after parsing them.
+func ShutterValues(values []string, s string) (matches []string, ok bool) {
+	r, ok := ParseNumRange(s, ParseExposure)
+
+	if !ok {
+		return nil, false
+	}
+
+	matches = make([]string, 0, len(values))
+
+	for _, v := range values {
+		if seconds, valid := ParseExposure(v); valid && r.Match(seconds) {
+			matches = append(matches, v)
+		}
+	}
+
+	return matches, true
+}
+
+// DateSourceNames maps date source filter values to the source names stored in the index.
+var DateSourceNames = map[string][]string{
+	"exif":     {entity.SrcMeta},
+	"meta":     {entity.SrcMeta},
+	"name":     {entity.SrcName},
+	"sidecar":  {entity.SrcXmp, entity.SrcYaml},
+	"xmp":      {entity.SrcXmp},
+	"yaml":     {entity.SrcYaml},
+	"manual":   {entity.SrcManual},
+	"estimate": {entity.SrcEstimate},
+	"auto":     {entity.SrcAuto, entity.SrcDefault},
+}
+
+// DateSources returns the source names stored in the index for date source filter values like "exif|name",
+// or false if a value is not supported.
+func DateSources(s string) (sources []string, ok bool) {
+	values := SplitOr(strings.ToLower(s))
+
+	if len(values) == 0 {
+		return nil, false
+	}
+
+	for _, v := range values {
+		if src, found := DateSourceNames[strings.TrimSpace(v)]; !found {
+			return nil, false
+		} else {
+			sources = append(sources, src...)
+		}
+	}
+
+	return sources, true
+}
+
+// FulltextRank returns an expression that ranks photos by how well their title, description, notes, and keywords
+// match the search words, using full-text indexes with MySQL and MariaDB, and weighted LIKE conditions otherwise.
+func FulltextRank(s string) (expr string, values []interface{}) {
+	words := txt.UniqueKeywords(s)
+
+	if len(words) == 0 {
+		return "", values
+	}
+
+	switch entity.DbDialect() {
+	case entity.MySQL:
+		q := strings.Join(words, " ")
+		expr = "(MATCH(photos.photo_title, photos.photo_description) AGAINST (? IN NATURAL LANGUAGE MODE) * 2 + " +
+			"MATCH(details.notes, details.keywords) AGAINST (? IN NATURAL LANGUAGE MODE))"
+		return expr, []interface{}{q, q}
+	default:
+		cols := []struct {
+			name   string
+			weight int
+		}{
+			{"photos.photo_title", 4},
+			{"details.keywords", 3},
+			{"photos.photo_description", 2},
+			{"details.notes", 1},
+		}
+
+		sums := make([]string, 0, len(words)*len(cols))
+
+		for _, w := range words {
+			for _, col := range cols {
+				sums = append(sums, fmt.Sprintf("(CASE WHEN %s LIKE ? THEN %d ELSE 0 END)", col.name, col.weight))
+				values = append(values, "%"+Like(w)+"%")
+			}
+		}
+
+		return "(" + strings.Join(sums, " + ") + ")", values
+	}
+}
+
 // OrLike returns a where condition and values for finding multiple terms combined with OR.
 func OrLike(col, s string) (where string, values []interface{}) {
 	if txt.Empty(col) || txt.Empty(s) {
@@ -99,6 +99,30 @@ func TestLikeAny(t *testing.T) {
 	})
 }
 
+func TestLikeAny_Diacritics(t *testing.T) {
+	t.Run("Keywords", func(t *testing.T) {
+		if w := LikeAny("k.keyword", "Café", true, false); len(w) != 1 {
+			t.Fatal("one where condition expected")
+		} else {
+			assert.Equal(t, "k.keyword LIKE 'café%' OR k.keyword LIKE 'cafe%'", w[0])
+		}
+	})
+	t.Run("Exact", func(t *testing.T) {
+		if w := LikeAny("k.keyword", "Café", true, true); len(w) != 1 {
+			t.Fatal("one where condition expected")
+		} else {
+			assert.Equal(t, "k.keyword LIKE 'café'", w[0])
+		}
+	})
+	t.Run("Unaccented", func(t *testing.T) {
+		if w := LikeAny("k.keyword", "cafe", true, false); len(w) != 1 {
+			t.Fatal("one where condition expected")
+		} else {
+			assert.Equal(t, "k.keyword LIKE 'cafe%'", w[0])
+		}
+	})
+}
+
 func TestLikeAnyKeyword(t *testing.T) {
 	t.Run("and_or_search", func(t *testing.T) {
 		if w := LikeAnyKeyword("k.keyword", "table spoon & usa | img json"); len(w) != 2 {
@@ -325,6 +349,134 @@ func TestAnyInt(t *testing.T) {
 	})
 }
 
+func TestParseTimeOfDay(t *testing.T) {
+	t.Run("Range", func(t *testing.T) {
+		start, end, ok := ParseTimeOfDay("18:00-20:30")
+		assert.True(t, ok)
+		assert.Equal(t, 1080, start)
+		assert.Equal(t, 1230, end)
+	})
+	t.Run("Wrap", func(t *testing.T) {
+		start, end, ok := ParseTimeOfDay("22:15-02:00")
+		assert.True(t, ok)
+		assert.Equal(t, 1335, start)
+		assert.Equal(t, 120, end)
+	})
+	t.Run("Hours", func(t *testing.T) {
+		start, end, ok := ParseTimeOfDay("8-24")
+		assert.True(t, ok)
+		assert.Equal(t, 480, start)
+		assert.Equal(t, 1439, end)
+	})
+	t.Run("NoColon", func(t *testing.T) {
+		start, end, ok := ParseTimeOfDay("1800-2000")
+		assert.True(t, ok)
+		assert.Equal(t, 1080, start)
+		assert.Equal(t, 1200, end)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, _, ok := ParseTimeOfDay("18:00")
+		assert.False(t, ok)
+		_, _, ok = ParseTimeOfDay("18:60-20:00")
+		assert.False(t, ok)
+		_, _, ok = ParseTimeOfDay("abc-def")
+		assert.False(t, ok)
+		_, _, ok = ParseTimeOfDay("")
+		assert.False(t, ok)
+	})
+}
+
+func TestTimeOfDay(t *testing.T) {
+	t.Run("Range", func(t *testing.T) {
+		where := TimeOfDay("photos.taken_at_local", "18:00-20:00")
+		assert.Equal(t, "(CAST(strftime('%H', photos.taken_at_local) AS INTEGER) * 60 + CAST(strftime('%M', photos.taken_at_local) AS INTEGER)) BETWEEN 1080 AND 1200", where)
+	})
+	t.Run("Wrap", func(t *testing.T) {
+		where := TimeOfDay("photos.taken_at_local", "23:00-01:00")
+		assert.Equal(t, "((CAST(strftime('%H', photos.taken_at_local) AS INTEGER) * 60 + CAST(strftime('%M', photos.taken_at_local) AS INTEGER)) >= 1380 OR (CAST(strftime('%H', photos.taken_at_local) AS INTEGER) * 60 + CAST(strftime('%M', photos.taken_at_local) AS INTEGER)) <= 60)", where)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, "", TimeOfDay("photos.taken_at_local", "foo"))
+	})
+}
+
+func TestParseByteSize(t *testing.T) {
+	t.Run("Bytes", func(t *testing.T) {
+		n, ok := ParseByteSize("4096")
+		assert.True(t, ok)
+		assert.Equal(t, int64(4096), n)
+	})
+	t.Run("KB", func(t *testing.T) {
+		n, ok := ParseByteSize("100KB")
+		assert.True(t, ok)
+		assert.Equal(t, int64(102400), n)
+	})
+	t.Run("MB", func(t *testing.T) {
+		n, ok := ParseByteSize("20mb")
+		assert.True(t, ok)
+		assert.Equal(t, int64(20971520), n)
+	})
+	t.Run("GB", func(t *testing.T) {
+		n, ok := ParseByteSize("1.5 GB")
+		assert.True(t, ok)
+		assert.Equal(t, int64(1610612736), n)
+	})
+	t.Run("InvalidUnit", func(t *testing.T) {
+		_, ok := ParseByteSize("20XB")
+		assert.False(t, ok)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, ok := ParseByteSize("MB")
+		assert.False(t, ok)
+	})
+}
+
+func TestFileSize(t *testing.T) {
+	t.Run("Greater", func(t *testing.T) {
+		assert.Equal(t, "files.file_size > 20971520", FileSize("files.file_size", ">20MB"))
+	})
+	t.Run("Less", func(t *testing.T) {
+		assert.Equal(t, "files.file_size < 102400", FileSize("files.file_size", "<100KB"))
+	})
+	t.Run("GreaterEqual", func(t *testing.T) {
+		assert.Equal(t, "files.file_size >= 1073741824", FileSize("files.file_size", ">=1GB"))
+	})
+	t.Run("LessEqual", func(t *testing.T) {
+		assert.Equal(t, "files.file_size <= 500", FileSize("files.file_size", "<= 500B"))
+	})
+	t.Run("Equal", func(t *testing.T) {
+		assert.Equal(t, "files.file_size = 900", FileSize("files.file_size", "=900"))
+	})
+	t.Run("NoOperator", func(t *testing.T) {
+		assert.Equal(t, "files.file_size >= 5242880", FileSize("files.file_size", "5MB"))
+	})
+	t.Run("Range", func(t *testing.T) {
+		assert.Equal(t, "files.file_size BETWEEN 1048576 AND 5242880", FileSize("files.file_size", "1MB-5MB"))
+	})
+	t.Run("InvalidRange", func(t *testing.T) {
+		assert.Equal(t, "", FileSize("files.file_size", "5MB-1MB"))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, "", FileSize("files.file_size", ">big"))
+	})
+}
+
+func TestFulltextRank(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		expr, values := FulltextRank("")
+		assert.Equal(t, "", expr)
+		assert.Len(t, values, 0)
+	})
+	t.Run("Words", func(t *testing.T) {
+		expr, values := FulltextRank("Beach sunset")
+		assert.Contains(t, expr, "(CASE WHEN photos.photo_title LIKE ? THEN 4 ELSE 0 END)")
+		assert.Contains(t, expr, "(CASE WHEN details.notes LIKE ? THEN 1 ELSE 0 END)")
+		assert.Len(t, values, 8)
+		assert.Contains(t, values, "%beach%")
+		assert.Contains(t, values, "%sunset%")
+	})
+}
+
 func TestOrLike(t *testing.T) {
 	t.Run("Empty", func(t *testing.T) {
 		where, values := OrLike("k.keyword", "")
@@ -433,3 +585,126 @@ func TestSplitAnd(t *testing.T) {
 		assert.Equal(t, []string{"foo", "Bar", "BAZ"}, values)
 	})
 }
+
+func TestParseNumRange(t *testing.T) {
+	parse := func(s string) (float64, bool) { return ParseNumber(s, "") }
+
+	t.Run("Greater", func(t *testing.T) {
+		r, ok := ParseNumRange(">1600", parse)
+		assert.True(t, ok)
+		assert.Equal(t, NumRange{Op: ">", From: 1600, To: 1600}, r)
+	})
+	t.Run("Exact", func(t *testing.T) {
+		r, ok := ParseNumRange(" 100 ", parse)
+		assert.True(t, ok)
+		assert.Equal(t, NumRange{Op: "=", From: 100, To: 100}, r)
+	})
+	t.Run("Range", func(t *testing.T) {
+		r, ok := ParseNumRange("35-85", parse)
+		assert.True(t, ok)
+		assert.Equal(t, NumRange{Op: "-", From: 35, To: 85}, r)
+		assert.True(t, r.Match(50))
+		assert.False(t, r.Match(100))
+	})
+	t.Run("InvalidRange", func(t *testing.T) {
+		_, ok := ParseNumRange("85-35", parse)
+		assert.False(t, ok)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, ok := ParseNumRange(">", parse)
+		assert.False(t, ok)
+	})
+}
+
+func TestParseExposure(t *testing.T) {
+	t.Run("Fraction", func(t *testing.T) {
+		seconds, ok := ParseExposure("1/250")
+		assert.True(t, ok)
+		assert.Equal(t, 0.004, seconds)
+	})
+	t.Run("Seconds", func(t *testing.T) {
+		seconds, ok := ParseExposure("2s")
+		assert.True(t, ok)
+		assert.Equal(t, 2.0, seconds)
+	})
+	t.Run("Decimal", func(t *testing.T) {
+		seconds, ok := ParseExposure("0.5")
+		assert.True(t, ok)
+		assert.Equal(t, 0.5, seconds)
+	})
+	t.Run("DivisionByZero", func(t *testing.T) {
+		_, ok := ParseExposure("1/0")
+		assert.False(t, ok)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, ok := ParseExposure("fast")
+		assert.False(t, ok)
+	})
+}
+
+func TestIsoRange(t *testing.T) {
+	assert.Equal(t, "photos.photo_iso > 1600", IsoRange("photos.photo_iso", ">1600"))
+	assert.Equal(t, "photos.photo_iso = 100", IsoRange("photos.photo_iso", "ISO100"))
+	assert.Equal(t, "photos.photo_iso BETWEEN 100 AND 400", IsoRange("photos.photo_iso", "100-400"))
+	assert.Equal(t, "", IsoRange("photos.photo_iso", ">high"))
+}
+
+func TestApertureRange(t *testing.T) {
+	assert.Equal(t, "photos.photo_f_number < 2.79", ApertureRange("photos.photo_f_number", "<2.8"))
+	assert.Equal(t, "photos.photo_f_number >= 7.99", ApertureRange("photos.photo_f_number", ">=f/8"))
+	assert.Equal(t, "photos.photo_f_number BETWEEN 2.59 AND 2.61", ApertureRange("photos.photo_f_number", "f2.6"))
+	assert.Equal(t, "photos.photo_f_number BETWEEN 3.99 AND 8.01", ApertureRange("photos.photo_f_number", "4-8"))
+	assert.Equal(t, "", ApertureRange("photos.photo_f_number", "wide"))
+}
+
+func TestFocalRange(t *testing.T) {
+	assert.Equal(t, "photos.photo_focal_length BETWEEN 35 AND 85", FocalRange("photos.photo_focal_length", "35-85"))
+	assert.Equal(t, "photos.photo_focal_length >= 200", FocalRange("photos.photo_focal_length", ">=200mm"))
+	assert.Equal(t, "", FocalRange("photos.photo_focal_length", "85-35"))
+}
+
+func TestShutterValues(t *testing.T) {
+	values := []string{"1/4000", "1/80", "1/50", "1/2", "2", "invalid"}
+
+	t.Run("Greater", func(t *testing.T) {
+		matches, ok := ShutterValues(values, ">1/60")
+		assert.True(t, ok)
+		assert.Equal(t, []string{"1/50", "1/2", "2"}, matches)
+	})
+	t.Run("Range", func(t *testing.T) {
+		matches, ok := ShutterValues(values, "1/100-1/60")
+		assert.True(t, ok)
+		assert.Equal(t, []string{"1/80"}, matches)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		matches, ok := ShutterValues(values, ">slow")
+		assert.False(t, ok)
+		assert.Nil(t, matches)
+	})
+}
+
+func TestDateSources(t *testing.T) {
+	t.Run("Exif", func(t *testing.T) {
+		sources, ok := DateSources("exif")
+		assert.True(t, ok)
+		assert.Equal(t, []string{entity.SrcMeta}, sources)
+	})
+	t.Run("Sidecar", func(t *testing.T) {
+		sources, ok := DateSources("Sidecar")
+		assert.True(t, ok)
+		assert.Equal(t, []string{entity.SrcXmp, entity.SrcYaml}, sources)
+	})
+	t.Run("Or", func(t *testing.T) {
+		sources, ok := DateSources("name|manual")
+		assert.True(t, ok)
+		assert.Equal(t, []string{entity.SrcName, entity.SrcManual}, sources)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, ok := DateSources("exif|camera")
+		assert.False(t, ok)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, ok := DateSources("")
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,58 @@
+package search
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// CategoryLabelIds returns the IDs of the labels matching the category names, including all labels that
+// belong to them directly or through subcategories, e.g. "beagle" for "animal" if it is a "dog".
+func CategoryLabelIds(names string) (labelIds []uint) {
+	if names == "" {
+		return labelIds
+	}
+
+	var labels []entity.Label
+
+	if err := Db().Where(AnySlug("label_slug", names, txt.Or)).Or(AnySlug("custom_slug", names, txt.Or)).Find(&labels).Error; err != nil {
+		log.Warnf("search: %s (find categories)", err)
+		return labelIds
+	} else if len(labels) == 0 {
+		return labelIds
+	}
+
+	found := make(map[uint]bool, len(labels))
+	parents := make([]uint, 0, len(labels))
+
+	for _, l := range labels {
+		if !found[l.ID] {
+			found[l.ID] = true
+			parents = append(parents, l.ID)
+		}
+	}
+
+	labelIds = append(labelIds, parents...)
+
+	// Expand the hierarchy level by level until no further subcategories are found.
+	for len(parents) > 0 {
+		var categories []entity.Category
+
+		if err := Db().Where("category_id IN (?)", parents).Find(&categories).Error; err != nil {
+			log.Warnf("search: %s (find subcategories)", err)
+			break
+		}
+
+		parents = make([]uint, 0, len(categories))
+
+		for _, c := range categories {
+			if !found[c.LabelID] {
+				found[c.LabelID] = true
+				parents = append(parents, c.LabelID)
+			}
+		}
+
+		labelIds = append(labelIds, parents...)
+	}
+
+	return labelIds
+}
@@ -0,0 +1,30 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestCategoryLabelIds(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Empty(t, CategoryLabelIds(""))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Empty(t, CategoryLabelIds("xxx-category-not-found"))
+	})
+	t.Run("Landscape", func(t *testing.T) {
+		result := CategoryLabelIds("landscape")
+
+		assert.Contains(t, result, entity.LabelFixtures.Get("landscape").ID)
+		assert.Contains(t, result, entity.LabelFixtures.Get("flower").ID)
+	})
+	t.Run("Flower", func(t *testing.T) {
+		result := CategoryLabelIds("flower")
+
+		assert.Contains(t, result, entity.LabelFixtures.Get("flower").ID)
+		assert.NotContains(t, result, entity.LabelFixtures.Get("landscape").ID)
+	})
+}
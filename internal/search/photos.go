@@ -38,6 +38,12 @@ func UserPhotos(f form.SearchPhotos, sess *entity.Session) (results PhotoResults
 	return searchPhotos(f, sess, PhotosColsAll)
 }
 
+// UserPhotosTotal finds PhotoResults based on the search form and user session, and also returns the
+// total number of matching results regardless of count and offset if f.Total is set.
+func UserPhotosTotal(f form.SearchPhotos, sess *entity.Session) (results PhotoResults, count, total int, err error) {
+	return searchPhotosTotal(f, sess, PhotosColsAll)
+}
+
 // PhotoIds finds photo and file ids based on the search form provided and returns them as PhotoResults.
 func PhotoIds(f form.SearchPhotos) (files PhotoResults, count int, err error) {
 	f.Merged = false
@@ -47,12 +53,19 @@ func PhotoIds(f form.SearchPhotos) (files PhotoResults, count int, err error) {
 
 // searchPhotos finds photos based on the search form and user session then returns them as PhotoResults.
 func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string) (results PhotoResults, count int, err error) {
+	results, count, _, err = searchPhotosTotal(f, sess, resultCols)
+	return results, count, err
+}
+
+// searchPhotosTotal finds photos like searchPhotos and, if requested with f.Total, also returns
+// the total number of matching results regardless of count and offset.
+func searchPhotosTotal(f form.SearchPhotos, sess *entity.Session, resultCols string) (results PhotoResults, count, total int, err error) {
 	start := time.Now()
 
 	// Parse query string and filter.
 	if err = f.ParseQueryString(); err != nil {
 		log.Debugf("search: %s", err)
-		return PhotoResults{}, 0, ErrBadRequest
+		return PhotoResults{}, 0, 0, ErrBadRequest
 	}
 
 	// Specify table names and joins.
@@ -76,14 +89,14 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		f.Scope = strings.ToLower(f.Scope)
 
 		if idType, idPrefix := rnd.IdType(f.Scope); idType != rnd.TypeUID || idPrefix != entity.AlbumUID {
-			return PhotoResults{}, 0, ErrInvalidId
+			return PhotoResults{}, 0, 0, ErrInvalidId
 		} else if a, err := entity.CachedAlbumByUID(f.Scope); err != nil || a.AlbumUID == "" {
-			return PhotoResults{}, 0, ErrInvalidId
+			return PhotoResults{}, 0, 0, ErrInvalidId
 		} else if a.AlbumFilter == "" {
 			s = s.Joins("JOIN photos_albums ON photos_albums.photo_uid = files.photo_uid").
 				Where("photos_albums.hidden = 0 AND photos_albums.album_uid = ?", a.AlbumUID)
 		} else if err = form.Unserialize(&f, a.AlbumFilter); err != nil {
-			return PhotoResults{}, 0, ErrBadFilter
+			return PhotoResults{}, 0, 0, ErrBadFilter
 		} else {
 			f.Filter = a.AlbumFilter
 			s = s.Where("files.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa WHERE pa.hidden = 1 AND pa.album_uid = ?)", a.AlbumUID)
@@ -118,7 +131,7 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		if f.Scope != "" && !sess.HasShare(f.Scope) && (sess.IsVisitor() || sess.NotRegistered()) ||
 			f.Scope == "" && acl.Resources.Deny(acl.ResourcePhotos, aclRole, acl.ActionSearch) {
 			event.AuditErr([]string{sess.IP(), "session %s", "%s %s as %s", "denied"}, sess.RefID, acl.ActionSearch.String(), string(acl.ResourcePhotos), aclRole)
-			return PhotoResults{}, 0, ErrForbidden
+			return PhotoResults{}, 0, 0, ErrForbidden
 		}
 
 		// Limit results for external users.
@@ -161,10 +174,20 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		s = s.Order("photos.photo_path, photos.photo_name, files.time_index")
 	case sortby.Random:
 		s = s.Order(sortby.RandomExpr(s.Dialect()))
+	case sortby.Manual:
+		// Only photos in the manual sort order of the current user are returned.
+		var userUid string
+
+		if sess != nil {
+			userUid = sess.UserUID
+		}
+
+		s = s.Joins("JOIN photos_orders ON photos_orders.photo_uid = photos.photo_uid AND photos_orders.user_uid = ?", userUid).
+			Order("photos_orders.position, files.media_id")
 	case sortby.Default, sortby.Imported, sortby.Added:
 		s = s.Order("files.media_id")
 	default:
-		return PhotoResults{}, 0, ErrBadSortOrder
+		return PhotoResults{}, 0, 0, ErrBadSortOrder
 	}
 
 	// Limit the result file types if hidden images/videos should not be found.
@@ -190,7 +213,7 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		idType, prefix := rnd.ContainsType(ids)
 
 		if idType == rnd.TypeUnknown {
-			return PhotoResults{}, 0, fmt.Errorf("%s ids specified", idType)
+			return PhotoResults{}, 0, 0, fmt.Errorf("%s ids specified", idType)
 		} else if idType.SHA() {
 			s = s.Where("files.file_hash IN (?)", ids)
 		} else if idType == rnd.TypeUID {
@@ -200,23 +223,24 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 			case entity.FileUID:
 				s = s.Where("files.file_uid IN (?)", ids)
 			default:
-				return PhotoResults{}, 0, fmt.Errorf("invalid ids specified")
+				return PhotoResults{}, 0, 0, fmt.Errorf("invalid ids specified")
 			}
 		}
 
 		// Find UIDs only to improve performance.
 		if sess == nil && f.FindUidOnly() {
 			if result := s.Scan(&results); result.Error != nil {
-				return results, 0, result.Error
+				return results, 0, 0, result.Error
 			}
 
 			log.Debugf("photos: found %s for %s [%s]", english.Plural(len(results), "result", "results"), f.SerializeAll(), time.Since(start))
 
 			if f.Merged {
-				return results.Merge()
+				results, count, err = results.Merge()
+				return results, count, count, err
 			}
 
-			return results, len(results), nil
+			return results, len(results), len(results), nil
 		}
 	}
 
@@ -236,7 +260,7 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 	if txt.NotEmpty(f.Label) {
 		if err := Db().Where(AnySlug("label_slug", f.Label, txt.Or)).Or(AnySlug("custom_slug", f.Label, txt.Or)).Find(&labels).Error; len(labels) == 0 || err != nil {
 			log.Debugf("search: label %s not found", txt.LogParamLower(f.Label))
-			return PhotoResults{}, 0, nil
+			return PhotoResults{}, 0, 0, nil
 		} else {
 			for _, l := range labels {
 				labelIds = append(labelIds, l.ID)
@@ -326,6 +350,15 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		for _, where := range LikeAnyKeyword("k.keyword", f.Query) {
 			s = s.Where("files.photo_id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(where))
 		}
+	} else if f.Query != "" && f.Fulltext {
+		if rank, values := FulltextRank(f.Query); rank != "" {
+			s = s.Joins("LEFT JOIN details ON details.photo_id = photos.id").Where(rank+" > 0", values...)
+
+			// Show the best matches first unless another sort order was requested.
+			if f.Order == sortby.Default || f.Order == sortby.Relevance {
+				s = s.Order(gorm.Expr(rank+" DESC, files.time_index", values...), true)
+			}
+		}
 	} else if f.Query != "" {
 		if err := Db().Where(AnySlug("custom_slug", f.Query, " ")).Find(&labels).Error; len(labels) == 0 || err != nil {
 			log.Debugf("search: label %s not found, using fuzzy search", txt.LogParamLower(f.Query))
@@ -369,7 +402,7 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		// Do nothing.
 	} else if txt.IsUInt(f.Faces) {
 		s = s.Where("photos.photo_faces >= ?", txt.Int(f.Faces))
-	} else if txt.New(f.Faces) && f.Face == "" {
+	} else if (txt.New(f.Faces) || txt.Unnamed(f.Faces)) && f.Face == "" {
 		f.Face = f.Faces
 		f.Faces = ""
 	} else if txt.Yes(f.Faces) {
@@ -386,7 +419,8 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 			s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 WHERE face_id IN (?))",
 				entity.Marker{}.TableName()), SplitOr(f))
 		}
-	} else if txt.New(f.Face) {
+	} else if txt.New(f.Face) || txt.Unnamed(f.Face) {
+		// Find pictures with detected faces that have not been assigned to a subject yet.
 		s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = ? WHERE subj_uid IS NULL OR subj_uid = '')",
 			entity.Marker{}.TableName()), entity.MarkerFace)
 	} else if txt.No(f.Face) {
@@ -472,6 +506,24 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		s = s.Where(AnyInt("photos.photo_day", f.Day, txt.Or, entity.UnknownDay, txt.DayMax))
 	}
 
+	// Filter by local time of day.
+	if f.TimeOfDay != "" {
+		if where := TimeOfDay("photos.taken_at_local", f.TimeOfDay); where != "" {
+			s = s.Where(where)
+		} else {
+			return PhotoResults{}, 0, 0, ErrBadFilter
+		}
+	}
+
+	// Filter by file size, matching any file of a picture.
+	if f.Size != "" {
+		if where := FileSize("f.file_size", f.Size); where != "" {
+			s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT f.photo_id FROM files f WHERE f.deleted_at IS NULL AND f.file_missing = 0 AND %s)", where))
+		} else {
+			return PhotoResults{}, 0, 0, ErrBadFilter
+		}
+	}
+
 	// Filter by main color.
 	if f.Color != "" {
 		s = s.Where("files.file_main_color IN (?)", SplitOr(strings.ToLower(f.Color)))
@@ -492,6 +544,21 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		s = s.Where("photos.photo_panorama = 1")
 	}
 
+	// Find pictures taken with flash only.
+	if f.Flash {
+		s = s.Where("photos.id IN (SELECT f.photo_id FROM files f WHERE f.file_flash = 1 AND f.deleted_at IS NULL)")
+	}
+
+	// Find high dynamic range pictures only.
+	if f.HDR {
+		s = s.Where("photos.id IN (SELECT f.photo_id FROM files f WHERE f.file_hdr = 1 AND f.deleted_at IS NULL)")
+	}
+
+	// Find pictures with only low-confidence labels that need to be reviewed.
+	if f.Uncertain {
+		s = s.Where("photos.review_needed = 1")
+	}
+
 	// Find portrait/landscape/square pictures only.
 	if f.Portrait {
 		s = s.Where("files.file_portrait = 1")
@@ -522,10 +589,16 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		s = s.Where("places.place_city IN (?)", SplitOr(f.City))
 	}
 
-	// Filter by location category.
+	// Filter by location or label category, including all labels in subcategories.
 	if txt.NotEmpty(f.Category) {
-		s = s.Joins("JOIN cells ON photos.cell_id = cells.id").
-			Where("cells.cell_category IN (?)", SplitOr(strings.ToLower(f.Category)))
+		if labelIds := CategoryLabelIds(f.Category); len(labelIds) > 0 {
+			s = s.Where("photos.cell_id IN (SELECT c.id FROM cells c WHERE c.cell_category IN (?)) OR "+
+				"photos.id IN (SELECT pl.photo_id FROM photos_labels pl WHERE pl.uncertainty < 100 AND pl.label_id IN (?))",
+				SplitOr(strings.ToLower(f.Category)), labelIds)
+		} else {
+			s = s.Joins("JOIN cells ON photos.cell_id = cells.id").
+				Where("cells.cell_category IN (?)", SplitOr(strings.ToLower(f.Category)))
+		}
 	}
 
 	// Filter by media type.
@@ -617,6 +690,60 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		s = s.Where("photos.photo_f_number <= ?", f.Fmax)
 	}
 
+	// Filter by ISO speed.
+	if f.Iso != "" {
+		if where := IsoRange("photos.photo_iso", f.Iso); where != "" {
+			s = s.Where(where)
+		} else {
+			return PhotoResults{}, 0, 0, ErrBadFilter
+		}
+	}
+
+	// Filter by aperture.
+	if f.Aperture != "" {
+		if where := ApertureRange("photos.photo_f_number", f.Aperture); where != "" {
+			s = s.Where(where)
+		} else {
+			return PhotoResults{}, 0, 0, ErrBadFilter
+		}
+	}
+
+	// Filter by focal length.
+	if f.Focal != "" {
+		if where := FocalRange("photos.photo_focal_length", f.Focal); where != "" {
+			s = s.Where(where)
+		} else {
+			return PhotoResults{}, 0, 0, ErrBadFilter
+		}
+	}
+
+	// Filter by shutter speed, comparing the parsed exposure times stored as strings.
+	if f.Shutter != "" {
+		var exposures []string
+
+		if err = Db().Table(entity.Photo{}.TableName()).Where("photo_exposure <> ''").
+			Pluck("DISTINCT photo_exposure", &exposures).Error; err != nil {
+			return PhotoResults{}, 0, 0, err
+		}
+
+		if matches, ok := ShutterValues(exposures, f.Shutter); !ok {
+			return PhotoResults{}, 0, 0, ErrBadFilter
+		} else if len(matches) == 0 {
+			return PhotoResults{}, 0, 0, nil
+		} else {
+			s = s.Where("photos.photo_exposure IN (?)", matches)
+		}
+	}
+
+	// Filter by source of the date taken, e.g. Exif metadata, file name, or sidecar file.
+	if txt.NotEmpty(f.DateSrc) {
+		if sources, ok := DateSources(f.DateSrc); !ok {
+			return PhotoResults{}, 0, 0, ErrBadFilter
+		} else {
+			s = s.Where("photos.taken_src IN (?)", sources)
+		}
+	}
+
 	if f.Dist == 0 {
 		f.Dist = 20
 	} else if f.Dist > 5000 {
@@ -635,7 +762,10 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		s = s.Where("photos.photo_lng BETWEEN ? AND ?", lngMin, lngMax)
 	}
 
-	if !f.Before.IsZero() {
+	// Find photos taken before date, or on the date if inclusive.
+	if !f.Before.IsZero() && f.Inclusive {
+		s = s.Where("photos.taken_at < ?", f.Before.AddDate(0, 0, 1).Format("2006-01-02"))
+	} else if !f.Before.IsZero() {
 		s = s.Where("photos.taken_at <= ?", f.Before.Format("2006-01-02"))
 	}
 
@@ -662,6 +792,19 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		}
 	}
 
+	// Count all matching results regardless of count and offset, e.g. for paginated timeline views.
+	if f.Total {
+		countExpr := "COUNT(*)"
+
+		if f.Merged {
+			countExpr = "COUNT(DISTINCT photos.id)"
+		}
+
+		if err = s.Select(countExpr).Order("", true).Row().Scan(&total); err != nil {
+			return results, 0, 0, err
+		}
+	}
+
 	// Limit offset and count.
 	if f.Count > 0 && f.Count <= MaxResults {
 		s = s.Limit(f.Count).Offset(f.Offset)
@@ -671,7 +814,7 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 
 	// Query database.
 	if err = s.Scan(&results).Error; err != nil {
-		return results, 0, err
+		return results, 0, 0, err
 	}
 
 	// Log number of results.
@@ -680,9 +823,10 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 	// Merge files that belong to the same photo.
 	if f.Merged {
 		// Return merged files.
-		return results.Merge()
+		results, count, err = results.Merge()
+		return results, count, total, err
 	}
 
 	// Return unmerged files.
-	return results, len(results), nil
+	return results, len(results), total, nil
 }
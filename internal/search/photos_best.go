@@ -0,0 +1,135 @@
+package search
+
+import (
+	"sort"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/sortby"
+)
+
+// BestOfWeights represents the scoring weights for automatic best-of selections.
+// Each score component is normalized to a value between 0 and 1 before it is weighted.
+type BestOfWeights struct {
+	Quality    float64 `json:"Quality"`
+	Resolution float64 `json:"Resolution"`
+	Faces      float64 `json:"Faces"`
+	Favorite   float64 `json:"Favorite"`
+}
+
+// NewBestOfWeights returns scoring weights based on the values with the specified names,
+// e.g. "quality", "resolution", "faces", and "favorite".
+func NewBestOfWeights(values map[string]float64) BestOfWeights {
+	return BestOfWeights{
+		Quality:    values["quality"],
+		Resolution: values["resolution"],
+		Faces:      values["faces"],
+		Favorite:   values["favorite"],
+	}
+}
+
+// Merge returns a copy of the weights, with the values with the specified names replaced.
+func (w BestOfWeights) Merge(values map[string]float64) BestOfWeights {
+	for name, val := range values {
+		if val < 0 {
+			continue
+		}
+
+		switch name {
+		case "quality":
+			w.Quality = val
+		case "resolution":
+			w.Resolution = val
+		case "faces":
+			w.Faces = val
+		case "favorite":
+			w.Favorite = val
+		}
+	}
+
+	return w
+}
+
+// Limits used to normalize the score components.
+const (
+	bestOfQualityMax    = 7
+	bestOfResolutionMax = 24
+	bestOfFacesMax      = 4
+)
+
+// Score returns the weighted composite score of a photo search result.
+func (w BestOfWeights) Score(p Photo) (score float64) {
+	score += w.Quality * bestOfRatio(p.PhotoQuality, bestOfQualityMax)
+	score += w.Resolution * bestOfRatio(p.PhotoResolution, bestOfResolutionMax)
+	score += w.Faces * bestOfRatio(p.PhotoFaces, bestOfFacesMax)
+
+	if p.PhotoFavorite {
+		score += w.Favorite
+	}
+
+	return score
+}
+
+// bestOfRatio returns the value divided by max, clipped to the range 0-1.
+func bestOfRatio(val, max int) float64 {
+	if val <= 0 {
+		return 0
+	} else if val >= max {
+		return 1
+	}
+
+	return float64(val) / float64(max)
+}
+
+// BestOf returns the pictures with the highest composite score taken within the specified date range,
+// optionally limited to an album. Pictures with the same score are ordered by date, newest first.
+func BestOf(from, to time.Time, album string, count int, w BestOfWeights, sess *entity.Session) (results PhotoResults, err error) {
+	f := form.SearchPhotos{
+		Album:   album,
+		Primary: true,
+		Count:   MaxResults,
+		Order:   sortby.Newest,
+	}
+
+	// Search filters compare dates only, so the exact range is checked below.
+	if !from.IsZero() {
+		f.After = from
+	}
+
+	if !to.IsZero() {
+		f.Before = to.AddDate(0, 0, 1)
+	}
+
+	candidates, _, err := UserPhotos(f, sess)
+
+	if err != nil {
+		return results, err
+	}
+
+	results = make(PhotoResults, 0, len(candidates))
+
+	for _, p := range candidates {
+		if !from.IsZero() && p.TakenAt.Before(from) || !to.IsZero() && p.TakenAt.After(to) {
+			continue
+		}
+
+		results = append(results, p)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := w.Score(results[i]), w.Score(results[j])
+
+		if a != b {
+			return a > b
+		}
+
+		return results[i].TakenAt.After(results[j].TakenAt)
+	})
+
+	if count > 0 && len(results) > count {
+		results = results[:count]
+	}
+
+	return results, nil
+}
@@ -0,0 +1,112 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBestOfWeights(t *testing.T) {
+	w := NewBestOfWeights(map[string]float64{"quality": 2, "resolution": 1, "faces": 0.5, "favorite": 3})
+
+	assert.Equal(t, BestOfWeights{Quality: 2, Resolution: 1, Faces: 0.5, Favorite: 3}, w)
+}
+
+func TestBestOfWeights_Merge(t *testing.T) {
+	w := BestOfWeights{Quality: 2, Resolution: 1, Faces: 1, Favorite: 3}
+
+	result := w.Merge(map[string]float64{"faces": 4, "favorite": -1, "sharpness": 2})
+
+	assert.Equal(t, BestOfWeights{Quality: 2, Resolution: 1, Faces: 4, Favorite: 3}, result)
+	assert.Equal(t, 1.0, w.Faces)
+}
+
+func TestBestOfWeights_Score(t *testing.T) {
+	w := BestOfWeights{Quality: 2, Resolution: 1, Faces: 1, Favorite: 3}
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, 0.0, w.Score(Photo{}))
+	})
+	t.Run("Max", func(t *testing.T) {
+		p := Photo{PhotoQuality: 7, PhotoResolution: 50, PhotoFaces: 10, PhotoFavorite: true}
+		assert.Equal(t, 7.0, w.Score(p))
+	})
+	t.Run("Composite", func(t *testing.T) {
+		p := Photo{PhotoQuality: 7, PhotoResolution: 12, PhotoFaces: 2}
+		assert.Equal(t, 3.0, w.Score(p))
+	})
+	t.Run("Favorite", func(t *testing.T) {
+		fav := Photo{PhotoQuality: 3, PhotoFavorite: true}
+		faces := Photo{PhotoQuality: 3, PhotoResolution: 24, PhotoFaces: 4}
+
+		assert.Greater(t, w.Score(fav), w.Score(faces))
+		assert.Less(t, BestOfWeights{Quality: 2, Resolution: 1, Faces: 1}.Score(fav), BestOfWeights{Quality: 2, Resolution: 1, Faces: 1}.Score(faces))
+	})
+}
+
+func TestBestOf(t *testing.T) {
+	w := BestOfWeights{Quality: 2, Resolution: 1, Faces: 1, Favorite: 3}
+
+	t.Run("OrderedByScore", func(t *testing.T) {
+		results, err := BestOf(time.Time{}, time.Time{}, "", 0, w, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 2)
+
+		for i := 1; i < len(results); i++ {
+			assert.GreaterOrEqual(t, w.Score(results[i-1]), w.Score(results[i]))
+		}
+	})
+	t.Run("FavoritesFirst", func(t *testing.T) {
+		favorites := BestOfWeights{Favorite: 1}
+
+		results, err := BestOf(time.Time{}, time.Time{}, "", 3, favorites, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 3)
+
+		for _, p := range results {
+			assert.True(t, p.PhotoFavorite)
+		}
+	})
+	t.Run("Count", func(t *testing.T) {
+		results, err := BestOf(time.Time{}, time.Time{}, "", 2, w, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 2)
+	})
+	t.Run("DateRange", func(t *testing.T) {
+		from := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2015, 12, 31, 23, 59, 59, 0, time.UTC)
+
+		results, err := BestOf(from, to, "", 0, w, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range results {
+			assert.False(t, p.TakenAt.Before(from))
+			assert.False(t, p.TakenAt.After(to))
+		}
+	})
+	t.Run("Album", func(t *testing.T) {
+		results, err := BestOf(time.Time{}, time.Time{}, "at9lxuqxpogaaba8", 0, w, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+	})
+}
@@ -0,0 +1,81 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosFilterCategory_Labels(t *testing.T) {
+	animal := entity.NewLabel("Animal Category Test", 0)
+	dog := entity.NewLabel("Dog Category Test", 0)
+	beagle := entity.NewLabel("Beagle Category Test", 0)
+
+	for _, l := range []*entity.Label{animal, dog, beagle} {
+		if err := l.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	photo := entity.PhotoFixtures.Get("Photo19")
+
+	rows := []interface{}{
+		&entity.Category{LabelID: dog.ID, CategoryID: animal.ID},
+		&entity.Category{LabelID: beagle.ID, CategoryID: dog.ID},
+		entity.NewPhotoLabel(photo.ID, beagle.ID, 10, entity.SrcManual),
+	}
+
+	for _, row := range rows {
+		if err := entity.Db().Create(row).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Cleanup(func() {
+		for _, l := range []*entity.Label{animal, dog, beagle} {
+			entity.UnscopedDb().Where("label_id = ? OR category_id = ?", l.ID, l.ID).Delete(&entity.Category{})
+			entity.UnscopedDb().Where("label_id = ?", l.ID).Delete(&entity.PhotoLabel{})
+			entity.UnscopedDb().Delete(l)
+		}
+	})
+
+	search := func(t *testing.T, category string) []string {
+		var f form.SearchPhotos
+
+		f.Category = category
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		uids := make([]string, len(photos))
+
+		for i, p := range photos {
+			uids[i] = p.PhotoUID
+		}
+
+		return uids
+	}
+
+	t.Run("Parent", func(t *testing.T) {
+		assert.Equal(t, []string{photo.PhotoUID}, search(t, animal.LabelSlug))
+	})
+	t.Run("Child", func(t *testing.T) {
+		assert.Equal(t, []string{photo.PhotoUID}, search(t, dog.LabelSlug))
+	})
+	t.Run("Leaf", func(t *testing.T) {
+		assert.Equal(t, []string{photo.PhotoUID}, search(t, beagle.LabelSlug))
+	})
+	t.Run("LocationOrLabel", func(t *testing.T) {
+		uids := search(t, "botanical garden|"+animal.LabelSlug)
+
+		assert.Contains(t, uids, photo.PhotoUID)
+		assert.Greater(t, len(uids), 1)
+	})
+}
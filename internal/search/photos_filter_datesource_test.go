@@ -0,0 +1,115 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosFilterDateSource(t *testing.T) {
+	// Fixtures only use some sources, so others are set temporarily.
+	setTakenSrc := func(uid, src string) {
+		if err := entity.Db().Model(&entity.Photo{}).Where("photo_uid = ?", uid).UpdateColumn("taken_src", src).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	setTakenSrc("pt9jtxrexxvl0y22", entity.SrcName)
+	setTakenSrc("pt9jtxrexxvl0y21", entity.SrcXmp)
+	setTakenSrc("pt9jtxrexxvl0y20", entity.SrcYaml)
+
+	defer func() {
+		setTakenSrc("pt9jtxrexxvl0y22", entity.SrcAuto)
+		setTakenSrc("pt9jtxrexxvl0y21", entity.SrcAuto)
+		setTakenSrc("pt9jtxrexxvl0y20", entity.SrcAuto)
+	}()
+
+	search := func(q string) PhotoResults {
+		var f form.SearchPhotos
+
+		f.Query = q
+		f.Merged = true
+
+		if err := f.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return photos
+	}
+
+	t.Run("Exif", func(t *testing.T) {
+		photos := search("datesource:exif")
+
+		assert.GreaterOrEqual(t, len(photos), 5)
+
+		for _, p := range photos {
+			assert.Equal(t, entity.SrcMeta, p.TakenSrc)
+		}
+	})
+	t.Run("Name", func(t *testing.T) {
+		photos := search("datesource:name")
+
+		assert.Len(t, photos, 1)
+
+		for _, p := range photos {
+			assert.Equal(t, "pt9jtxrexxvl0y22", p.PhotoUID)
+			assert.Equal(t, entity.SrcName, p.TakenSrc)
+		}
+	})
+	t.Run("Sidecar", func(t *testing.T) {
+		photos := search("datesource:sidecar")
+
+		assert.Len(t, photos, 2)
+
+		for _, p := range photos {
+			assert.Contains(t, []string{entity.SrcXmp, entity.SrcYaml}, p.TakenSrc)
+		}
+	})
+	t.Run("Xmp", func(t *testing.T) {
+		photos := search("datesource:xmp")
+
+		assert.Len(t, photos, 1)
+	})
+	t.Run("Manual", func(t *testing.T) {
+		photos := search("datesource:manual")
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.Equal(t, entity.SrcManual, p.TakenSrc)
+		}
+	})
+	t.Run("Auto", func(t *testing.T) {
+		photos := search("datesource:auto")
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.Contains(t, []string{entity.SrcAuto, entity.SrcDefault}, p.TakenSrc)
+		}
+	})
+	t.Run("Or", func(t *testing.T) {
+		photos := search("datesource:\"name|sidecar\"")
+
+		assert.Len(t, photos, 3)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.DateSrc = "camera"
+		f.Merged = true
+
+		_, _, err := Photos(f)
+
+		assert.ErrorIs(t, err, ErrBadFilter)
+	})
+}
@@ -0,0 +1,91 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosQueryDiacritics(t *testing.T) {
+	photo := entity.PhotoFixtures.Get("Photo02")
+	title := photo.PhotoTitle
+
+	photo.PhotoTitle = "Crème Brûlée at Café Zürich"
+
+	if err := photo.IndexKeywords(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		photo.PhotoTitle = title
+		_ = photo.IndexKeywords()
+	}()
+
+	contains := func(t *testing.T, photos PhotoResults) {
+		for _, p := range photos {
+			if p.PhotoUID == photo.PhotoUID {
+				return
+			}
+		}
+
+		t.Errorf("photo %s not found", photo.PhotoUID)
+	}
+
+	t.Run("Unaccented", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "creme brulee"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		contains(t, photos)
+	})
+	t.Run("Uppercase", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "ZURICH"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		contains(t, photos)
+	})
+	t.Run("Accented", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "Café"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		contains(t, photos)
+	})
+	t.Run("Keywords", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Keywords = "brulee"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		contains(t, photos)
+	})
+}
@@ -0,0 +1,245 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosFilterIso(t *testing.T) {
+	t.Run("Greater", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Iso = ">150"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.Greater(t, p.PhotoIso, 150)
+		}
+	})
+	t.Run("Query", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "iso:150-250"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.GreaterOrEqual(t, p.PhotoIso, 150)
+			assert.LessOrEqual(t, p.PhotoIso, 250)
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Iso = ">fast"
+		f.Merged = true
+
+		_, _, err := Photos(f)
+
+		assert.ErrorIs(t, err, ErrBadFilter)
+	})
+}
+
+func TestPhotosFilterAperture(t *testing.T) {
+	t.Run("Less", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Aperture = "<5.6"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.Less(t, p.PhotoFNumber, float32(5.6))
+		}
+	})
+	t.Run("Exact", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "aperture:f/5"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.Equal(t, float32(5), p.PhotoFNumber)
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Aperture = "8-4"
+		f.Merged = true
+
+		_, _, err := Photos(f)
+
+		assert.ErrorIs(t, err, ErrBadFilter)
+	})
+}
+
+func TestPhotosFilterShutter(t *testing.T) {
+	t.Run("Greater", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Shutter = ">1/100"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			seconds, ok := ParseExposure(p.PhotoExposure)
+			assert.True(t, ok)
+			assert.Greater(t, seconds, 1.0/100)
+		}
+	})
+	t.Run("Range", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "shutter:1/100-1/60"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.Equal(t, "1/80", p.PhotoExposure)
+		}
+	})
+	t.Run("NoMatches", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Shutter = ">1/60"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 0)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Shutter = ">1/0"
+		f.Merged = true
+
+		_, _, err := Photos(f)
+
+		assert.ErrorIs(t, err, ErrBadFilter)
+	})
+}
+
+func TestPhotosFilterFocal(t *testing.T) {
+	t.Run("Range", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Focal = "35-85"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.GreaterOrEqual(t, p.PhotoFocalLength, 35)
+			assert.LessOrEqual(t, p.PhotoFocalLength, 85)
+		}
+	})
+	t.Run("Query", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "focal:<=50mm"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.LessOrEqual(t, p.PhotoFocalLength, 50)
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Focal = "wide"
+		f.Merged = true
+
+		_, _, err := Photos(f)
+
+		assert.ErrorIs(t, err, ErrBadFilter)
+	})
+}
+
+func TestPhotosFilterExifCombined(t *testing.T) {
+	var f form.SearchPhotos
+
+	f.Query = "iso:>=200 aperture:>4 shutter:<1/60 focal:35-85"
+	f.Merged = true
+
+	photos, _, err := Photos(f)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, len(photos), 1)
+
+	for _, p := range photos {
+		assert.GreaterOrEqual(t, p.PhotoIso, 200)
+		assert.Greater(t, p.PhotoFNumber, float32(4))
+		assert.Equal(t, "1/80", p.PhotoExposure)
+		assert.Equal(t, 50, p.PhotoFocalLength)
+	}
+}
@@ -65,6 +65,34 @@ func TestPhotosFilterFaces(t *testing.T) {
 		}
 		assert.Equal(t, len(photos), 0)
 	})
+	t.Run("Unnamed", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Faces = "unnamed"
+		f.Primary = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, len(photos), 4)
+
+		uids := make([]string, len(photos))
+
+		for i, p := range photos {
+			uids[i] = p.PhotoUID
+		}
+
+		// Has unnamed face markers.
+		assert.Contains(t, uids, "pt9jtdre2lvl0y50")
+		assert.Contains(t, uids, "pt9jtdre2lvl0yh0")
+
+		// Has named face markers only.
+		assert.NotContains(t, uids, "pt9jtdre2lvl0y17")
+		assert.NotContains(t, uids, "pt9jtdre2lvl0yh9")
+	})
 	t.Run("StartsWithPercent", func(t *testing.T) {
 		var f form.SearchPhotos
 
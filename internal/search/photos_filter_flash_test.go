@@ -0,0 +1,82 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosFilterFlash(t *testing.T) {
+	file := entity.FileFixtures.Get("exampleFileName.jpg")
+
+	if err := file.Update("FileFlash", true); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = file.Update("FileFlash", false) }()
+
+	t.Run("True", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Flash = true
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+
+		for _, p := range photos {
+			assert.Equal(t, file.PhotoUID, p.PhotoUID)
+		}
+	})
+	t.Run("Query", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "flash:true"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+	})
+	t.Run("False", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "flash:false"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Greater(t, len(photos), 1)
+	})
+	t.Run("Geo", func(t *testing.T) {
+		var f form.SearchPhotosGeo
+
+		f.Query = "flash:true"
+
+		photos, err := PhotosGeo(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range photos {
+			assert.Equal(t, file.PhotoUID, p.PhotoUID)
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/sortby"
+)
+
+func TestPhotosFilterFulltext(t *testing.T) {
+	seed := func(title, description, keywords, notes string) *entity.Photo {
+		mediaID := rnd.GenerateUID('m')
+		takenAt := time.Date(2019, 3, 14, 15, 9, 26, 0, time.UTC)
+
+		photo := &entity.Photo{
+			TakenAt:          takenAt,
+			TakenAtLocal:     takenAt,
+			PhotoTitle:       title,
+			PhotoDescription: description,
+			PhotoQuality:     3,
+			Details:          &entity.Details{Keywords: keywords, Notes: notes},
+		}
+
+		if err := photo.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		file := &entity.File{
+			PhotoID:     photo.ID,
+			PhotoUID:    photo.PhotoUID,
+			FileRoot:    entity.RootOriginals,
+			FileName:    "fulltext/" + photo.PhotoUID + ".jpg",
+			FileHash:    rnd.GenerateUID('h'),
+			FileType:    "jpg",
+			FilePrimary: true,
+			MediaID:     &mediaID,
+		}
+
+		if err := file.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		return photo
+	}
+
+	title := seed("Zebrafinch at Dawn", "", "zebrafinch, bird", "")
+	description := seed("Garden", "A zebrafinch sitting on a branch", "", "")
+	notes := seed("Nest", "", "", "Found a zebrafinch nest at dawn")
+	other := seed("Sparrow", "Small bird", "bird", "dawn")
+
+	defer func() {
+		for _, p := range []*entity.Photo{title, description, notes, other} {
+			_, _ = p.DeletePermanently()
+		}
+	}()
+
+	uids := func(results PhotoResults) (result []string) {
+		for _, r := range results {
+			result = append(result, r.PhotoUID)
+		}
+
+		return result
+	}
+
+	t.Run("Ranking", func(t *testing.T) {
+		f := form.SearchPhotos{Query: "zebrafinch", Fulltext: true, Merged: true}
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{title.PhotoUID, description.PhotoUID, notes.PhotoUID}, uids(photos))
+	})
+	t.Run("MultipleFields", func(t *testing.T) {
+		f := form.SearchPhotos{Query: "zebrafinch dawn", Fulltext: true, Merged: true}
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Matches in the title and keywords rank higher than matches in the notes only.
+		assert.Equal(t, []string{title.PhotoUID, notes.PhotoUID, description.PhotoUID, other.PhotoUID}, uids(photos))
+	})
+	t.Run("Keywords", func(t *testing.T) {
+		f := form.SearchPhotos{Query: "bird", Fulltext: true, Merged: true}
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Subset(t, uids(photos), []string{title.PhotoUID, other.PhotoUID})
+		assert.NotContains(t, uids(photos), notes.PhotoUID)
+	})
+	t.Run("SortOrder", func(t *testing.T) {
+		f := form.SearchPhotos{Query: "zebrafinch", Fulltext: true, Merged: true, Order: sortby.Name}
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.ElementsMatch(t, []string{title.PhotoUID, description.PhotoUID, notes.PhotoUID}, uids(photos))
+	})
+	t.Run("QueryString", func(t *testing.T) {
+		f := form.SearchPhotos{Query: "fulltext:yes zebrafinch", Merged: true}
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{title.PhotoUID, description.PhotoUID, notes.PhotoUID}, uids(photos))
+	})
+}
@@ -0,0 +1,82 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosFilterHDR(t *testing.T) {
+	file := entity.FileFixtures.Get("exampleFileName.jpg")
+
+	if err := file.Update("FileHDR", true); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = file.Update("FileHDR", false) }()
+
+	t.Run("True", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.HDR = true
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+
+		for _, p := range photos {
+			assert.Equal(t, file.PhotoUID, p.PhotoUID)
+		}
+	})
+	t.Run("Query", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "hdr:true"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+	})
+	t.Run("False", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "hdr:false"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Greater(t, len(photos), 1)
+	})
+	t.Run("Geo", func(t *testing.T) {
+		var f form.SearchPhotosGeo
+
+		f.Query = "hdr:true"
+
+		photos, err := PhotosGeo(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range photos {
+			assert.Equal(t, file.PhotoUID, p.PhotoUID)
+		}
+	})
+}
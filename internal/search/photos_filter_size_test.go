@@ -0,0 +1,77 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosFilterSize(t *testing.T) {
+	t.Run("Greater", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Size = ">7MB"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			found := false
+
+			for _, file := range p.Files {
+				if file.FileSize > 7<<20 {
+					found = true
+				}
+			}
+
+			assert.True(t, found, p.PhotoUID)
+		}
+	})
+	t.Run("Range", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Size = "100KB-200KB"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+	})
+	t.Run("Query", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "size:<1KB"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Size = ">20XB"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		assert.Equal(t, ErrBadFilter, err)
+		assert.Len(t, photos, 0)
+	})
+}
@@ -0,0 +1,96 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosFilterTimeOfDay(t *testing.T) {
+	// Add a second photo taken between 17:00 and 18:00, as the fixtures only contain one.
+	takenAt := time.Date(2016, 11, 11, 17, 15, 0, 0, time.UTC)
+	photo := entity.Photo{PhotoTitle: "Time of Day", PhotoType: entity.MediaImage, TakenAt: takenAt, TakenAtLocal: takenAt, PhotoQuality: 3}
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _, _ = photo.DeletePermanently() }()
+
+	file := entity.File{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FileName: "timeofday/17.jpg", FileHash: "timeofday17", FileType: "jpg", FilePrimary: true}
+
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("17:00-18:00", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.TimeOfDay = "17:00-18:00"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 2)
+
+		for _, p := range photos {
+			assert.Equal(t, 17, p.TakenAtLocal.Hour())
+		}
+	})
+	t.Run("23:00-01:00", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.TimeOfDay = "23:00-01:00"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 3)
+
+		for _, p := range photos {
+			minutes := p.TakenAtLocal.Hour()*60 + p.TakenAtLocal.Minute()
+			assert.True(t, minutes >= 23*60 || minutes <= 60, p.TakenAtLocal.String())
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.TimeOfDay = "25:00-26:00"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		assert.Equal(t, ErrBadFilter, err)
+		assert.Len(t, photos, 0)
+	})
+	t.Run("QueryTimeOfDay", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "timeofday:17:00-18:00"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 2)
+
+		for _, p := range photos {
+			assert.Equal(t, 17, p.TakenAtLocal.Hour())
+		}
+	})
+}
@@ -61,7 +61,8 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 		Joins(`JOIN files ON files.photo_id = photos.id AND files.file_primary = 1 AND files.media_id IS NOT NULL`).
 		Joins("LEFT JOIN places ON photos.place_id = places.id").
 		Where("photos.deleted_at IS NULL").
-		Where("photos.photo_lat <> 0")
+		Where("photos.photo_lat <> 0").
+		Where("photos.photo_type <> ?", entity.MediaDocument)
 
 	// Accept the album UID as scope for backward compatibility.
 	if rnd.IsUID(f.Album, entity.AlbumUID) {
@@ -293,7 +294,7 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 		// Do nothing.
 	} else if txt.IsUInt(f.Faces) {
 		s = s.Where("photos.photo_faces >= ?", txt.Int(f.Faces))
-	} else if txt.New(f.Faces) && f.Face == "" {
+	} else if (txt.New(f.Faces) || txt.Unnamed(f.Faces)) && f.Face == "" {
 		f.Face = f.Faces
 		f.Faces = ""
 	} else if txt.Yes(f.Faces) {
@@ -310,7 +311,7 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 			s = s.Where(fmt.Sprintf("photos.id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 WHERE face_id IN (?))",
 				entity.Marker{}.TableName()), SplitOr(f))
 		}
-	} else if txt.New(f.Face) {
+	} else if txt.New(f.Face) || txt.Unnamed(f.Face) {
 		s = s.Where(fmt.Sprintf("photos.id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = ? WHERE subj_uid IS NULL OR subj_uid = '')",
 			entity.Marker{}.TableName()), entity.MarkerFace)
 	} else if txt.No(f.Face) {
@@ -381,6 +382,24 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 		s = s.Where(AnyInt("photos.photo_day", f.Day, txt.Or, entity.UnknownDay, txt.DayMax))
 	}
 
+	// Filter by local time of day.
+	if f.TimeOfDay != "" {
+		if where := TimeOfDay("photos.taken_at_local", f.TimeOfDay); where != "" {
+			s = s.Where(where)
+		} else {
+			return GeoResults{}, ErrBadFilter
+		}
+	}
+
+	// Filter by file size, matching any file of a picture.
+	if f.Size != "" {
+		if where := FileSize("f.file_size", f.Size); where != "" {
+			s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT f.photo_id FROM files f WHERE f.deleted_at IS NULL AND f.file_missing = 0 AND %s)", where))
+		} else {
+			return GeoResults{}, ErrBadFilter
+		}
+	}
+
 	// Filter by main color.
 	if f.Color != "" {
 		s = s.Where("files.file_main_color IN (?)", SplitOr(strings.ToLower(f.Color)))
@@ -401,6 +420,16 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 		s = s.Where("photos.photo_panorama = 1")
 	}
 
+	// Find pictures taken with flash only.
+	if f.Flash {
+		s = s.Where("photos.id IN (SELECT f.photo_id FROM files f WHERE f.file_flash = 1 AND f.deleted_at IS NULL)")
+	}
+
+	// Find high dynamic range pictures only.
+	if f.HDR {
+		s = s.Where("photos.id IN (SELECT f.photo_id FROM files f WHERE f.file_hdr = 1 AND f.deleted_at IS NULL)")
+	}
+
 	// Find portrait/landscape/square pictures only.
 	if f.Portrait {
 		s = s.Where("files.file_portrait = 1")
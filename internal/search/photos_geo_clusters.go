@@ -0,0 +1,60 @@
+package search
+
+import (
+	"sort"
+
+	"github.com/photoprism/photoprism/pkg/s2"
+)
+
+// GeoClusterLevel is the default S2 cell level used to group map results.
+const GeoClusterLevel = 10
+
+// GeoCluster represents a group of geo search results in the same S2 cell.
+type GeoCluster struct {
+	Cell  string  `json:"Cell"`
+	Lat   float64 `json:"Lat"`
+	Lng   float64 `json:"Lng"`
+	Count int     `json:"Count"`
+}
+
+// GeoClusters represents a list of geo result clusters.
+type GeoClusters []GeoCluster
+
+// Clusters groups the results by S2 cell at the specified level, the cluster position is the average of its results.
+func (photos GeoResults) Clusters(level int) GeoClusters {
+	if level < 1 || level > s2.MaxLevel {
+		level = GeoClusterLevel
+	}
+
+	index := make(map[string]int)
+	result := GeoClusters{}
+
+	for _, p := range photos {
+		token := s2.TokenLevel(p.Lat(), p.Lng(), level)
+
+		if token == "" {
+			continue
+		}
+
+		if i, ok := index[token]; ok {
+			result[i].Lat += p.Lat()
+			result[i].Lng += p.Lng()
+			result[i].Count++
+		} else {
+			index[token] = len(result)
+			result = append(result, GeoCluster{Cell: s2.Prefix(token), Lat: p.Lat(), Lng: p.Lng(), Count: 1})
+		}
+	}
+
+	for i := range result {
+		result[i].Lat /= float64(result[i].Count)
+		result[i].Lng /= float64(result[i].Count)
+	}
+
+	// Show the largest clusters first.
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Count > result[j].Count
+	})
+
+	return result
+}
@@ -0,0 +1,115 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/s2"
+)
+
+func TestGeoResults_Clusters(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Len(t, GeoResults{}.Clusters(0), 0)
+	})
+	t.Run("SameCell", func(t *testing.T) {
+		results := GeoResults{
+			{PhotoUID: "a", PhotoLat: 52.5200, PhotoLng: 13.4050},
+			{PhotoUID: "b", PhotoLat: 52.5210, PhotoLng: 13.4060},
+			{PhotoUID: "c", PhotoLat: -33.8688, PhotoLng: 151.2093},
+			{PhotoUID: "d"},
+		}
+
+		clusters := results.Clusters(GeoClusterLevel)
+
+		assert.Len(t, clusters, 2)
+		assert.Equal(t, 2, clusters[0].Count)
+		assert.Equal(t, s2.Prefix(s2.TokenLevel(52.52, 13.405, GeoClusterLevel)), clusters[0].Cell)
+		assert.InDelta(t, 52.5205, clusters[0].Lat, 0.0001)
+		assert.InDelta(t, 13.4055, clusters[0].Lng, 0.0001)
+		assert.Equal(t, 1, clusters[1].Count)
+	})
+	t.Run("InvalidLevel", func(t *testing.T) {
+		results := GeoResults{{PhotoUID: "a", PhotoLat: 52.5200, PhotoLng: 13.4050}}
+
+		clusters := results.Clusters(99)
+
+		assert.Len(t, clusters, 1)
+		assert.Equal(t, s2.Prefix(s2.TokenLevel(52.52, 13.405, GeoClusterLevel)), clusters[0].Cell)
+	})
+}
+
+func TestPhotosGeo_Clusters(t *testing.T) {
+	const lat, lng = 64.1466, -21.9426
+
+	seed := func(private bool) *entity.Photo {
+		mediaID := rnd.GenerateUID('m')
+		takenAt := time.Date(2021, 6, 21, 12, 0, 0, 0, time.UTC)
+
+		photo := &entity.Photo{
+			TakenAt:      takenAt,
+			TakenAtLocal: takenAt,
+			PhotoLat:     lat,
+			PhotoLng:     lng,
+			PhotoPrivate: private,
+			PhotoQuality: 3,
+		}
+
+		if err := photo.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		file := &entity.File{
+			PhotoID:     photo.ID,
+			PhotoUID:    photo.PhotoUID,
+			FileRoot:    entity.RootOriginals,
+			FileName:    "clusters/" + photo.PhotoUID + ".jpg",
+			FileHash:    rnd.GenerateUID('h'),
+			FilePrimary: true,
+			MediaID:     &mediaID,
+		}
+
+		if err := file.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		return photo
+	}
+
+	public := seed(false)
+	private := seed(true)
+
+	defer func() {
+		_, _ = public.DeletePermanently()
+		_, _ = private.DeletePermanently()
+	}()
+
+	cell := s2.Prefix(s2.TokenLevel(lat, lng, GeoClusterLevel))
+
+	count := func(f form.SearchPhotosGeo) int {
+		photos, err := PhotosGeo(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, c := range photos.Clusters(GeoClusterLevel) {
+			if c.Cell == cell {
+				return c.Count
+			}
+		}
+
+		return 0
+	}
+
+	t.Run("All", func(t *testing.T) {
+		assert.Equal(t, 2, count(form.SearchPhotosGeo{}))
+	})
+	t.Run("PublicOnly", func(t *testing.T) {
+		assert.Equal(t, 1, count(form.SearchPhotosGeo{Public: true}))
+	})
+}
@@ -0,0 +1,71 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/sortby"
+)
+
+func TestPhotosOrderManual(t *testing.T) {
+	alice := entity.SessionFixtures.Pointer("alice")
+	bob := entity.SessionFixtures.Pointer("bob")
+
+	var f form.SearchPhotos
+
+	f.Count = 10
+	f.Merged = true
+
+	photos, _, err := UserPhotos(f, alice)
+
+	if err != nil {
+		t.Fatal(err)
+	} else if len(photos) < 3 {
+		t.Fatalf("expected at least 3 photos, got %d", len(photos))
+	}
+
+	// Store the photos in a custom order.
+	expected := []string{photos[2].PhotoUID, photos[0].PhotoUID, photos[1].PhotoUID}
+
+	if _, err = entity.SetPhotoOrders(alice.UserUID, expected); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		entity.UnscopedDb().Where("user_uid = ?", alice.UserUID).Delete(&entity.PhotoOrder{})
+	})
+
+	search := func(t *testing.T, sess *entity.Session) []string {
+		var f form.SearchPhotos
+
+		f.Order = sortby.Manual
+		f.Merged = true
+
+		results, _, err := UserPhotos(f, sess)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return results.UIDs()
+	}
+
+	t.Run("Stored", func(t *testing.T) {
+		assert.Equal(t, expected, search(t, alice))
+	})
+	t.Run("Reordered", func(t *testing.T) {
+		reordered := []string{expected[1], expected[2], expected[0]}
+
+		if _, err = entity.SetPhotoOrders(alice.UserUID, reordered); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, reordered, search(t, alice))
+	})
+	t.Run("OtherUser", func(t *testing.T) {
+		assert.Empty(t, search(t, bob))
+	})
+}
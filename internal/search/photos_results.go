@@ -44,8 +44,11 @@ type Photo struct {
 	PhotoResolution  int           `json:"Resolution" select:"photos.photo_resolution"`
 	PhotoDuration    time.Duration `json:"Duration,omitempty" yaml:"photos.photo_duration"`
 	PhotoColor       int16         `json:"Color" select:"photos.photo_color"`
+	PhotoThumbHash   string        `json:"ThumbHash,omitempty" select:"photos.photo_thumb_hash"`
+	PhotoBlurHash    string        `json:"BlurHash,omitempty" select:"photos.photo_blur_hash"`
 	PhotoScan        bool          `json:"Scan" select:"photos.photo_scan"`
 	PhotoPanorama    bool          `json:"Panorama" select:"photos.photo_panorama"`
+	ReviewNeeded     bool          `json:"ReviewNeeded,omitempty" select:"photos.review_needed"`
 	CameraID         uint          `json:"CameraID" select:"photos.camera_id"` // Camera
 	CameraSrc        string        `json:"CameraSrc,omitempty" select:"photos.camera_src"`
 	CameraSerial     string        `json:"CameraSerial,omitempty" select:"photos.camera_serial"`
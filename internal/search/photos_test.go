@@ -42,6 +42,23 @@ func TestPhotos(t *testing.T) {
 
 		assert.LessOrEqual(t, 2, len(photos))
 	})
+	t.Run("Placeholders", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.UID = "pt9jtdre2lvl0yh7"
+		frm.Count = 1
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, photos, 1) {
+			assert.Equal(t, "WigKNBqAF3h4iHeId4eAcQjoiA", photos[0].PhotoThumbHash)
+			assert.Equal(t, "LKO2?U%2Tw=w]~RBVZRi};RPxuwH", photos[0].PhotoBlurHash)
+		}
+	})
 	t.Run("OrderInvalid", func(t *testing.T) {
 		var frm form.SearchPhotos
 
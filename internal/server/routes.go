@@ -46,6 +46,8 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	// Profile and Uploads.
 	api.UploadUserFiles(APIv1)
 	api.ProcessUserUpload(APIv1)
+	api.GetUserUploadOffset(APIv1)
+	api.ResumeUserUpload(APIv1)
 	api.UploadUserAvatar(APIv1)
 	api.UpdateUserPassword(APIv1)
 	api.UpdateUser(APIv1)
@@ -61,9 +63,11 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 
 	// Thumbnail Images.
 	api.GetThumb(APIv1)
+	api.GetThumbHash(APIv1)
 
 	// Video Streaming.
 	api.GetVideo(APIv1)
+	api.GetVideoPoster(APIv1)
 
 	// Downloads.
 	api.GetDownload(APIv1)
@@ -80,14 +84,47 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.SearchPhotos(APIv1)
 	api.SearchGeo(APIv1)
 	api.GetPhoto(APIv1)
+	api.GetPhotoByHash(APIv1)
 	api.GetPhotoYaml(APIv1)
 	api.UpdatePhoto(APIv1)
+	api.PatchPhoto(APIv1)
 	api.GetPhotoDownload(APIv1)
+	api.GetPhotoFileDownload(APIv1)
+	api.GetPhotoDownloadName(APIv1)
+	api.GetPhotoRenditions(APIv1)
+	api.GetPhotoStatus(APIv1)
+	api.ReindexPhoto(APIv1)
+	api.WarmPhotoThumbs(APIv1)
+	api.RegeneratePhotoThumb(APIv1)
+	api.BestOfPhotos(APIv1)
+	api.ExportPhotosCsv(APIv1)
+	api.ExportPhotosYaml(APIv1)
+	api.DownloadPhotos(APIv1)
+	api.GetPhotoOrder(APIv1)
+	api.AddPhotoOrder(APIv1)
+	api.UpdatePhotoOrder(APIv1)
+	api.RemovePhotoOrder(APIv1)
+	api.ComparePhotos(APIv1)
+	api.PhotosDocument(APIv1)
+	api.GetPhotoJsonLd(APIv1)
+	api.GetPhotoPosters(APIv1)
+	api.GetPhotoRelated(APIv1)
+	api.GetPhotoSimilar(APIv1)
+	api.GetPhotoMap(APIv1)
+	api.GetPhotoFiles(APIv1)
+	api.GetPhotoClip(APIv1)
+	api.GetPhotosReview(APIv1)
+	api.ClearPhotosReview(APIv1)
+	api.RelinkLivePhotos(APIv1)
+	api.ApplyPhotoRules(APIv1)
 	// api.GetPhotoLinks(APIv1)
 	// api.CreatePhotoLink(APIv1)
 	// api.UpdatePhotoLink(APIv1)
 	// api.DeletePhotoLink(APIv1)
 	api.ApprovePhoto(APIv1)
+	api.RestorePhoto(APIv1)
+	api.ApprovePhotos(APIv1)
+	api.PhotosPrivate(APIv1)
 	api.LikePhoto(APIv1)
 	api.DislikePhoto(APIv1)
 	api.AddPhotoLabel(APIv1)
@@ -96,10 +133,20 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.GetMomentsTime(APIv1)
 	api.GetFile(APIv1)
 	api.DeleteFile(APIv1)
+	api.GetFilePath(APIv1)
+	api.GetOrphanFiles(APIv1)
+	api.CleanupOrphanFiles(APIv1)
+	api.RebuildSidecars(APIv1)
+	api.CancelRebuildSidecars(APIv1)
 	api.ChangeFileOrientation(APIv1)
+	api.GetPhotoOrientationIssues(APIv1)
+	api.FixPhotoOrientation(APIv1)
+	api.RotatePhoto(APIv1)
+	api.SetPhotoTime(APIv1)
 	api.UpdateMarker(APIv1)
 	api.ClearMarkerSubject(APIv1)
 	api.PhotoPrimary(APIv1)
+	api.PhotoPrimaryAuto(APIv1)
 	api.PhotoUnstack(APIv1)
 
 	// Photo Albums.
@@ -139,6 +186,7 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	// People.
 	api.SearchSubjects(APIv1)
 	api.GetSubject(APIv1)
+	api.GetSubjectThumb(APIv1)
 	api.UpdateSubject(APIv1)
 	api.LikeSubject(APIv1)
 	api.DislikeSubject(APIv1)
@@ -157,6 +205,10 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.BatchAlbumsDelete(APIv1)
 	api.BatchLabelsDelete(APIv1)
 
+	// Trash.
+	api.GetTrashExpired(APIv1)
+	api.PurgeTrash(APIv1)
+
 	// Technical Endpoints.
 	api.GetSvg(APIv1)
 	api.GetStatus(APIv1)
@@ -0,0 +1,49 @@
+package thumb
+
+import (
+	"image"
+	"os/exec"
+	"strconv"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// AvifEncoderBin is the name of the AVIF encoder executable, see https://github.com/AOMediaCodec/libavif.
+var AvifEncoderBin = "avifenc"
+
+// AVIF encoder speed settings, from 0 (slowest, smallest files) to 10 (fastest, largest files).
+const (
+	AvifSpeedSlowest = 0
+	AvifSpeedDefault = 6
+	AvifSpeedFastest = 10
+)
+
+// AvifSpeed is the current AVIF encoder speed, since encoding is CPU-heavy compared to JPEG.
+var AvifSpeed = AvifSpeedDefault
+
+// AvifEncoderAvailable checks if the AVIF encoder executable can be found.
+func AvifEncoderAvailable() bool {
+	_, err := exec.LookPath(AvifEncoderBin)
+	return err == nil
+}
+
+// SaveAvif encodes an image as AVIF with the specified quality and saves it.
+func SaveAvif(img image.Image, fileName string, quality Quality) error {
+	encoderBin, err := exec.LookPath(AvifEncoderBin)
+
+	if err != nil {
+		return ErrAvifEncoderNotFound
+	}
+
+	speed := AvifSpeed
+
+	if speed < AvifSpeedSlowest {
+		speed = AvifSpeedSlowest
+	} else if speed > AvifSpeedFastest {
+		speed = AvifSpeedFastest
+	}
+
+	return saveEncoded(img, fileName, encoderBin, fs.ImageAVIF, func(src, dst string) []string {
+		return []string{"-q", quality.String(), "-s", strconv.Itoa(speed), src, dst}
+	})
+}
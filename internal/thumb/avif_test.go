@@ -0,0 +1,130 @@
+package thumb
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestSaveAvif(t *testing.T) {
+	t.Run("EncoderNotFound", func(t *testing.T) {
+		encoderBin := AvifEncoderBin
+		AvifEncoderBin = "avifenc-not-found"
+		defer func() { AvifEncoderBin = encoderBin }()
+
+		img, err := imaging.Open("testdata/example.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fileName := filepath.Join(t.TempDir(), "example.avif")
+
+		assert.False(t, AvifEncoderAvailable())
+		assert.ErrorIs(t, SaveAvif(img, fileName, AvifQuality), ErrAvifEncoderNotFound)
+		assert.False(t, fs.FileExists(fileName))
+	})
+	t.Run("Ok", func(t *testing.T) {
+		if !AvifEncoderAvailable() {
+			t.Skip("avif encoder not found")
+		}
+
+		img, err := imaging.Open("testdata/example.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dir := t.TempDir()
+		fileName := filepath.Join(dir, "example.avif")
+
+		if err = SaveAvif(img, fileName, AvifQuality); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, fs.ImageAVIF, fs.FileType(fileName))
+
+		// The temporary PNG file must be removed.
+		files, err := os.ReadDir(dir)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, files, 1)
+	})
+}
+
+func TestCreate_Avif(t *testing.T) {
+	if !AvifEncoderAvailable() {
+		t.Skip("avif encoder not found")
+	}
+
+	img, err := imaging.Open("testdata/example.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fileName, err := FileName("193456789098765432", t.TempDir(), 100, 100, ResampleFillCenter, ResampleAvif)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, ".avif", filepath.Ext(fileName))
+
+	if _, err = Create(img, fileName, 100, 100, ResampleFillCenter, ResampleAvif); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, fs.FileExists(fileName))
+}
+
+// benchmarkCreate measures the time it takes to create a thumbnail in the specified format.
+func benchmarkCreate(b *testing.B, opts ...ResampleOption) {
+	img, err := imaging.Open("testdata/example.jpg")
+
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	dir := b.TempDir()
+
+	fileName, err := FileName("193456789098765432", dir, 720, 720, opts...)
+
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		if _, err = Create(img, fileName, 720, 720, opts...); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.StopTimer()
+
+	if info, err := os.Stat(fileName); err == nil {
+		b.ReportMetric(float64(info.Size()), "bytes")
+	}
+}
+
+func BenchmarkCreate_Jpeg(b *testing.B) {
+	benchmarkCreate(b, ResampleFit)
+}
+
+func BenchmarkCreate_Avif(b *testing.B) {
+	if !AvifEncoderAvailable() {
+		b.Skip("avif encoder not found")
+	}
+
+	benchmarkCreate(b, ResampleFit, ResampleAvif)
+}
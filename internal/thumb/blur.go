@@ -0,0 +1,68 @@
+package thumb
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/disintegration/imaging"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// BlurExtendScale is the factor by which the background is downscaled before blurring it,
+// so that a strong blur effect can be achieved with little computational effort.
+const BlurExtendScale = 8
+
+// BlurExtendSigma is the standard deviation of the Gaussian blur applied to the downscaled background.
+const BlurExtendSigma = 4.0
+
+// BlurExtend scales an image to fit into the specified box and fills the remaining area
+// with a blurred copy of the image instead of cropping it or adding solid color bars.
+func BlurExtend(img image.Image, width, height int, filter imaging.ResampleFilter) image.Image {
+	fg := imaging.Fit(img, width, height, filter)
+
+	// Nothing to fill if the aspect ratios match.
+	if b := fg.Bounds(); b.Dx() >= width && b.Dy() >= height {
+		return fg
+	}
+
+	bgWidth := width/BlurExtendScale + 1
+	bgHeight := height/BlurExtendScale + 1
+
+	bg := imaging.Fill(img, bgWidth, bgHeight, imaging.Center, imaging.Box)
+	bg = imaging.Blur(bg, BlurExtendSigma)
+
+	return imaging.PasteCenter(imaging.Resize(bg, width, height, imaging.Linear), fg)
+}
+
+// PadTransparent scales an image to fit into the specified box and centers it on a transparent
+// canvas, so that the padding area does not hide the transparency of the original image.
+func PadTransparent(img image.Image, width, height int, filter imaging.ResampleFilter) image.Image {
+	fg := imaging.Fit(img, width, height, filter)
+
+	// Nothing to fill if the aspect ratios match.
+	if b := fg.Bounds(); b.Dx() >= width && b.Dy() >= height {
+		return fg
+	}
+
+	return imaging.OverlayCenter(imaging.New(width, height, color.Transparent), fg, 1.0)
+}
+
+// Transparent checks if the image may contain transparent pixels.
+func Transparent(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return !o.Opaque()
+	}
+
+	return false
+}
+
+// TransparentFormat checks if the thumbnail file format supports transparency.
+func TransparentFormat(format fs.Type) bool {
+	switch format {
+	case fs.ImagePNG, fs.ImageWebP, fs.ImageAVIF, fs.ImageGIF:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,208 @@
+package thumb
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// blurTestImage returns an image with a red left half and a blue right half.
+func blurTestImage(width, height int) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			if x < width/2 {
+				img.Set(x, y, color.NRGBA{R: 220, G: 30, B: 30, A: 255})
+			} else {
+				img.Set(x, y, color.NRGBA{R: 30, G: 30, B: 220, A: 255})
+			}
+		}
+	}
+
+	return img
+}
+
+// transparentTestImage returns an image with a transparent background and an opaque red square in the center.
+func transparentTestImage(width, height int) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	img.Set(0, 0, color.Transparent)
+
+	for x := width / 4; x < width*3/4; x++ {
+		for y := height / 4; y < height*3/4; y++ {
+			img.Set(x, y, color.NRGBA{R: 220, G: 30, B: 30, A: 255})
+		}
+	}
+
+	return img
+}
+
+// assertTransparentCorners checks that the corner pixels of the image are fully transparent.
+func assertTransparentCorners(t *testing.T, img image.Image) {
+	b := img.Bounds()
+
+	for _, p := range []image.Point{{b.Min.X, b.Min.Y}, {b.Max.X - 1, b.Min.Y}, {b.Min.X, b.Max.Y - 1}, {b.Max.X - 1, b.Max.Y - 1}} {
+		assert.Equal(t, uint8(0), color.NRGBAModel.Convert(img.At(p.X, p.Y)).(color.NRGBA).A, p.String())
+	}
+}
+
+func TestBlurExtend(t *testing.T) {
+	t.Run("Landscape", func(t *testing.T) {
+		result := BlurExtend(blurTestImage(400, 200), 100, 100, imaging.Lanczos)
+
+		assert.Equal(t, 100, result.Bounds().Dx())
+		assert.Equal(t, 100, result.Bounds().Dy())
+
+		// Corners must contain image content instead of a solid fill color.
+		topLeft := color.NRGBAModel.Convert(result.At(0, 0)).(color.NRGBA)
+		topRight := color.NRGBAModel.Convert(result.At(99, 0)).(color.NRGBA)
+		bottomLeft := color.NRGBAModel.Convert(result.At(0, 99)).(color.NRGBA)
+		bottomRight := color.NRGBAModel.Convert(result.At(99, 99)).(color.NRGBA)
+
+		assert.Greater(t, topLeft.R, topLeft.B)
+		assert.Greater(t, bottomLeft.R, bottomLeft.B)
+		assert.Greater(t, topRight.B, topRight.R)
+		assert.Greater(t, bottomRight.B, bottomRight.R)
+		assert.Equal(t, uint8(255), topLeft.A)
+
+		// The background must be blurred, so the colors blend near the center line.
+		top := color.NRGBAModel.Convert(result.At(50, 5)).(color.NRGBA)
+		assert.Greater(t, top.R, uint8(30))
+		assert.Greater(t, top.B, uint8(30))
+
+		// The image itself is scaled to fit and pasted in the center.
+		center := color.NRGBAModel.Convert(result.At(10, 50)).(color.NRGBA)
+		assert.Equal(t, color.NRGBA{R: 220, G: 30, B: 30, A: 255}, center)
+	})
+	t.Run("Portrait", func(t *testing.T) {
+		result := BlurExtend(blurTestImage(100, 300), 200, 150, imaging.Lanczos)
+
+		assert.Equal(t, 200, result.Bounds().Dx())
+		assert.Equal(t, 150, result.Bounds().Dy())
+
+		topLeft := color.NRGBAModel.Convert(result.At(0, 0)).(color.NRGBA)
+		bottomRight := color.NRGBAModel.Convert(result.At(199, 149)).(color.NRGBA)
+
+		assert.Greater(t, topLeft.R, topLeft.B)
+		assert.Greater(t, bottomRight.B, bottomRight.R)
+	})
+	t.Run("SameAspectRatio", func(t *testing.T) {
+		result := BlurExtend(blurTestImage(200, 100), 100, 50, imaging.Lanczos)
+
+		assert.Equal(t, 100, result.Bounds().Dx())
+		assert.Equal(t, 50, result.Bounds().Dy())
+	})
+}
+
+func TestResample_BlurExtend(t *testing.T) {
+	result, err := Resample(blurTestImage(300, 100), 100, 100, ResampleBlurExtend, ResampleDefault)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 100, result.Bounds().Dx())
+	assert.Equal(t, 100, result.Bounds().Dy())
+
+	method, _, _ := ResampleOptions(ResampleBlurExtend, ResampleDefault)
+	assert.Equal(t, ResampleBlurExtend, method)
+	assert.Equal(t, "blur", ResampleMethods[method])
+}
+
+func TestPadTransparent(t *testing.T) {
+	t.Run("Landscape", func(t *testing.T) {
+		result := PadTransparent(transparentTestImage(400, 200), 100, 100, imaging.Lanczos)
+
+		assert.Equal(t, 100, result.Bounds().Dx())
+		assert.Equal(t, 100, result.Bounds().Dy())
+		assertTransparentCorners(t, result)
+
+		// The padding above and below the image must be transparent, the image itself unchanged.
+		assert.Equal(t, uint8(0), color.NRGBAModel.Convert(result.At(50, 10)).(color.NRGBA).A)
+		assert.Equal(t, color.NRGBA{R: 220, G: 30, B: 30, A: 255}, color.NRGBAModel.Convert(result.At(50, 50)).(color.NRGBA))
+	})
+	t.Run("SameAspectRatio", func(t *testing.T) {
+		result := PadTransparent(transparentTestImage(200, 100), 100, 50, imaging.Lanczos)
+
+		assert.Equal(t, 100, result.Bounds().Dx())
+		assert.Equal(t, 50, result.Bounds().Dy())
+	})
+}
+
+func TestTransparent(t *testing.T) {
+	assert.True(t, Transparent(transparentTestImage(10, 10)))
+	assert.False(t, Transparent(blurTestImage(10, 10)))
+	assert.False(t, Transparent(image.NewYCbCr(image.Rect(0, 0, 10, 10), image.YCbCrSubsampleRatio420)))
+}
+
+func TestTransparentFormat(t *testing.T) {
+	assert.True(t, TransparentFormat(fs.ImagePNG))
+	assert.True(t, TransparentFormat(fs.ImageWebP))
+	assert.True(t, TransparentFormat(fs.ImageAVIF))
+	assert.False(t, TransparentFormat(fs.ImageJPEG))
+}
+
+func TestResample_BlurExtendTransparent(t *testing.T) {
+	src := transparentTestImage(300, 100)
+
+	t.Run("Png", func(t *testing.T) {
+		result, err := Resample(src, 100, 100, ResampleBlurExtend, ResamplePng)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertTransparentCorners(t, result)
+	})
+	t.Run("Webp", func(t *testing.T) {
+		result, err := Resample(src, 100, 100, ResampleBlurExtend, ResampleWebp)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertTransparentCorners(t, result)
+	})
+	t.Run("Jpeg", func(t *testing.T) {
+		result, err := Resample(src, 100, 100, ResampleBlurExtend)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, BlurExtend(src, 100, 100, imaging.Lanczos), result)
+	})
+	t.Run("Opaque", func(t *testing.T) {
+		result, err := Resample(blurTestImage(300, 100), 100, 100, ResampleBlurExtend, ResamplePng)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, uint8(255), color.NRGBAModel.Convert(result.At(0, 0)).(color.NRGBA).A)
+	})
+	t.Run("CreatePng", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "transparent.png")
+
+		if _, err := Create(src, fileName, 100, 100, ResampleBlurExtend, ResamplePng); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := imaging.Open(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertTransparentCorners(t, result)
+
+		_ = os.Remove(fileName)
+	})
+}
@@ -0,0 +1,158 @@
+package thumb
+
+import (
+	"image"
+	"math"
+	"strings"
+
+	"github.com/disintegration/imaging"
+)
+
+// BlurHashMaxSize is the maximum image width and height used to compute a BlurHash.
+const BlurHashMaxSize = 32
+
+// BlurHashComponentsX is the number of horizontal BlurHash components.
+const BlurHashComponentsX = 4
+
+// BlurHashComponentsY is the number of vertical BlurHash components.
+const BlurHashComponentsY = 3
+
+// blurHashChars contains the characters of the base 83 encoding used by BlurHash.
+const blurHashChars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+// BlurHash returns the BlurHash placeholder of an image, see https://blurha.sh/.
+func BlurHash(img image.Image) string {
+	if img == nil {
+		return ""
+	}
+
+	small := imaging.Fit(img, BlurHashMaxSize, BlurHashMaxSize, imaging.Linear)
+	w, h := small.Bounds().Dx(), small.Bounds().Dy()
+
+	if w == 0 || h == 0 {
+		return ""
+	}
+
+	// Convert pixels to linear RGB.
+	r := make([]float64, w*h)
+	g := make([]float64, w*h)
+	b := make([]float64, w*h)
+
+	for i := 0; i < w*h; i++ {
+		r[i] = srgbToLinear(small.Pix[i*4])
+		g[i] = srgbToLinear(small.Pix[i*4+1])
+		b[i] = srgbToLinear(small.Pix[i*4+2])
+	}
+
+	// Compute the DCT factors, starting with the DC component.
+	factors := make([][3]float64, 0, BlurHashComponentsX*BlurHashComponentsY)
+
+	for cy := 0; cy < BlurHashComponentsY; cy++ {
+		for cx := 0; cx < BlurHashComponentsX; cx++ {
+			var f [3]float64
+
+			norm := 2.0
+
+			if cx == 0 && cy == 0 {
+				norm = 1.0
+			}
+
+			for y := 0; y < h; y++ {
+				fy := math.Cos(math.Pi * float64(cy) * float64(y) / float64(h))
+
+				for x := 0; x < w; x++ {
+					basis := fy * math.Cos(math.Pi*float64(cx)*float64(x)/float64(w))
+					f[0] += basis * r[y*w+x]
+					f[1] += basis * g[y*w+x]
+					f[2] += basis * b[y*w+x]
+				}
+			}
+
+			scale := norm / float64(w*h)
+
+			factors = append(factors, [3]float64{f[0] * scale, f[1] * scale, f[2] * scale})
+		}
+	}
+
+	dc, ac := factors[0], factors[1:]
+
+	var sb strings.Builder
+
+	// Number of components.
+	sb.WriteString(encode83((BlurHashComponentsX-1)+(BlurHashComponentsY-1)*9, 1))
+
+	// Quantized maximum AC component value.
+	maxVal := 1.0
+
+	if len(ac) > 0 {
+		actualMax := 0.0
+
+		for _, f := range ac {
+			actualMax = math.Max(actualMax, math.Max(math.Abs(f[0]), math.Max(math.Abs(f[1]), math.Abs(f[2]))))
+		}
+
+		quantMax := int(math.Max(0, math.Min(82, math.Floor(actualMax*166-0.5))))
+		maxVal = float64(quantMax+1) / 166
+		sb.WriteString(encode83(quantMax, 1))
+	} else {
+		sb.WriteString(encode83(0, 1))
+	}
+
+	// Average color.
+	sb.WriteString(encode83(linearToSrgb(dc[0])<<16+linearToSrgb(dc[1])<<8+linearToSrgb(dc[2]), 4))
+
+	// AC components.
+	for _, f := range ac {
+		quantR := quantizeAC(f[0], maxVal)
+		quantG := quantizeAC(f[1], maxVal)
+		quantB := quantizeAC(f[2], maxVal)
+
+		sb.WriteString(encode83(quantR*19*19+quantG*19+quantB, 2))
+	}
+
+	return sb.String()
+}
+
+// encode83 returns the base 83 representation of value with the specified number of digits.
+func encode83(value, length int) string {
+	result := make([]byte, length)
+
+	for i := length - 1; i >= 0; i-- {
+		result[i] = blurHashChars[value%83]
+		value /= 83
+	}
+
+	return string(result)
+}
+
+// quantizeAC returns the quantized value of an AC component in the range from 0 to 18.
+func quantizeAC(v, maxVal float64) int {
+	return int(math.Max(0, math.Min(18, math.Floor(signPow(v/maxVal, 0.5)*9+9.5))))
+}
+
+// signPow raises the absolute value of v to the power of exp and keeps its sign.
+func signPow(v, exp float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(v), exp), v)
+}
+
+// srgbToLinear converts an sRGB color value to linear RGB.
+func srgbToLinear(c uint8) float64 {
+	v := float64(c) / 255
+
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// linearToSrgb converts a linear RGB color value to sRGB.
+func linearToSrgb(v float64) int {
+	v = math.Max(0, math.Min(1, v))
+
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
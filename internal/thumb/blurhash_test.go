@@ -0,0 +1,65 @@
+package thumb
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlurHash(t *testing.T) {
+	t.Run("Deterministic", func(t *testing.T) {
+		img, err := Open("testdata/example.jpg", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hash := BlurHash(img)
+
+		assert.Len(t, hash, 4+2*BlurHashComponentsX*BlurHashComponentsY)
+		assert.True(t, strings.HasPrefix(hash, "L"))
+		assert.Equal(t, hash, BlurHash(img))
+	})
+	t.Run("Red", func(t *testing.T) {
+		img := image.NewNRGBA(image.Rect(0, 0, 60, 40))
+
+		for y := 0; y < 40; y++ {
+			for x := 0; x < 60; x++ {
+				img.Set(x, y, color.NRGBA{R: 255, A: 255})
+			}
+		}
+
+		hash := BlurHash(img)
+
+		if assert.Len(t, hash, 28) {
+			assert.Equal(t, "L", hash[:1])
+			assert.Equal(t, "TI:j", hash[2:6])
+		}
+	})
+	t.Run("Reference", func(t *testing.T) {
+		// Expected value computed with the reference encoder algorithm, see https://github.com/woltapp/blurhash.
+		img := image.NewNRGBA(image.Rect(0, 0, 16, 12))
+
+		for y := 0; y < 12; y++ {
+			for x := 0; x < 16; x++ {
+				img.Set(x, y, color.NRGBA{R: uint8(x * 255 / 15), G: uint8(y * 255 / 11), B: uint8((x + y) * 255 / 26), A: 255})
+			}
+		}
+
+		assert.Equal(t, "L$Hx+w2%wyoyqKR.jue:f}fkfQfj", BlurHash(img))
+	})
+	t.Run("Nil", func(t *testing.T) {
+		assert.Equal(t, "", BlurHash(nil))
+	})
+}
+
+func TestEncode83(t *testing.T) {
+	assert.Equal(t, "0", encode83(0, 1))
+	assert.Equal(t, "L", encode83(21, 1))
+	assert.Equal(t, "~", encode83(82, 1))
+	assert.Equal(t, "10", encode83(83, 2))
+	assert.Equal(t, "TI:j", encode83(0xFF0000, 4))
+}
@@ -4,12 +4,15 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"image/gif"
 	"image/png"
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/disintegration/imaging"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -17,9 +20,9 @@ import (
 
 // Suffix returns the thumb cache file suffix.
 func Suffix(width, height int, opts ...ResampleOption) (result string) {
-	method, _, format := ResampleOptions(opts...)
+	_, _, format := ResampleOptions(opts...)
 
-	result = fmt.Sprintf("%dx%d_%s.%s", width, height, ResampleMethods[method], format)
+	result = fmt.Sprintf("%dx%d_%s.%s", width, height, ResampleName(opts...), format)
 
 	return result
 }
@@ -63,6 +66,31 @@ func ResolvedName(hash, thumbPath string, width, height int, opts ...ResampleOpt
 	}
 }
 
+// RemoveCached removes all cached thumbnails of the file with the specified hash, and returns their number.
+func RemoveCached(hash, thumbPath string) (removed int, err error) {
+	if len(hash) < 4 {
+		return 0, fmt.Errorf("thumb: invalid file hash %s", clean.Log(hash))
+	} else if len(thumbPath) == 0 {
+		return 0, errors.New("thumb: folder is empty")
+	}
+
+	matches, err := filepath.Glob(path.Join(thumbPath, hash[0:1], hash[1:2], hash[2:3], hash+"_*"))
+
+	if err != nil {
+		return 0, err
+	}
+
+	for _, fileName := range matches {
+		if err = os.Remove(fileName); err != nil {
+			return removed, err
+		}
+
+		removed++
+	}
+
+	return removed, nil
+}
+
 // FromCache returns the filename if a thumbnail image with the matching size is in the cache.
 func FromCache(imageFilename, hash, thumbPath string, width, height int, opts ...ResampleOption) (fileName string, err error) {
 	if len(hash) < 4 {
@@ -73,6 +101,13 @@ func FromCache(imageFilename, hash, thumbPath string, width, height int, opts ..
 		return "", fmt.Errorf("thumb: invalid file name %s", clean.Log(imageFilename))
 	}
 
+	// Animated thumbnails are cached as GIF, see ResampleAnimated.
+	if animated := animatedOptions(imageFilename, opts...); animated != nil && !HasOption(ResampleGif, opts...) {
+		if fileName, err = FromCache(imageFilename, hash, thumbPath, width, height, animated...); err == nil {
+			return fileName, nil
+		}
+	}
+
 	if fileName, err = FileName(hash, thumbPath, width, height, opts...); err != nil {
 		log.Debugf("thumb: %s in %s (get filename)", err, clean.Log(imageFilename))
 		return "", err
@@ -93,6 +128,15 @@ func FromFile(imageFilename, hash, thumbPath string, width, height, orientation
 		return "", err
 	}
 
+	// Keep all frames of animated GIFs?
+	if animated := animatedOptions(imageFilename, opts...); animated != nil {
+		if g, gifErr := OpenGif(imageFilename); gifErr != nil {
+			log.Debugf("thumb: %s in %s (open gif)", gifErr, clean.Log(filepath.Base(imageFilename)))
+		} else if len(g.Image) > 1 {
+			return fromGif(g, hash, thumbPath, width, height, animated...)
+		}
+	}
+
 	// Generate thumb cache filename.
 	fileName, err = FileName(hash, thumbPath, width, height, opts...)
 
@@ -101,24 +145,64 @@ func FromFile(imageFilename, hash, thumbPath string, width, height, orientation
 		return "", err
 	}
 
-	// Load image from storage.
-	img, err := Open(imageFilename, orientation)
+	// Create thumb from image unless another request is already creating it.
+	if _, err = createOnce(fileName, func() error {
+		// Load image from storage.
+		img, err := Open(imageFilename, orientation)
 
-	if err != nil {
-		log.Debugf("thumb: %s in %s", err, clean.Log(filepath.Base(imageFilename)))
+		if err != nil {
+			log.Debugf("thumb: %s in %s", err, clean.Log(filepath.Base(imageFilename)))
+			return err
+		}
+
+		_, err = Create(img, fileName, width, height, opts...)
+
+		return err
+	}); err != nil {
 		return "", err
 	}
 
-	// Create thumb from image.
-	if _, err = Create(img, fileName, width, height, opts...); err != nil {
+	return fileName, nil
+}
+
+// fromGif creates an animated thumbnail with the specified size, and returns the filename.
+func fromGif(g *gif.GIF, hash, thumbPath string, width, height int, opts ...ResampleOption) (fileName string, err error) {
+	if fileName, err = FileName(hash, thumbPath, width, height, opts...); err != nil {
+		log.Error(err)
+		return "", err
+	}
+
+	if _, err = createOnce(fileName, func() error {
+		_, createErr := CreateAnimated(g, fileName, width, height, opts...)
+		return createErr
+	}); err != nil {
 		return "", err
 	}
 
 	return fileName, nil
 }
 
+// createGroup prevents concurrent requests from creating the same thumbnail more than once.
+var createGroup singleflight.Group
+
+// createOnce runs the create function for the given thumbnail filename, waiting for and
+// sharing the result of a call that is already in progress, if any.
+func createOnce(fileName string, create func() error) (shared bool, err error) {
+	_, err, shared = createGroup.Do(fileName, func() (interface{}, error) {
+		return nil, create()
+	})
+
+	return shared, err
+}
+
 // Create creates an image thumbnail.
 func Create(img image.Image, fileName string, width, height int, opts ...ResampleOption) (result image.Image, err error) {
+	return CreateQuality(img, fileName, width, height, 0, opts...)
+}
+
+// CreateQuality creates an image thumbnail with the specified encoding quality (1-100),
+// or the current quality setting of the output format if it is 0.
+func CreateQuality(img image.Image, fileName string, width, height int, quality Quality, opts ...ResampleOption) (result image.Image, err error) {
 	if InvalidSize(width) {
 		return img, fmt.Errorf("thumb: width has an invalid value (%d)", width)
 	}
@@ -127,19 +211,30 @@ func Create(img image.Image, fileName string, width, height int, opts ...Resampl
 		return img, fmt.Errorf("thumb: height has an invalid value (%d)", height)
 	}
 
-	result = Resample(img, width, height, opts...)
+	if result, err = Resample(img, width, height, opts...); err != nil {
+		return result, err
+	}
 
-	var quality imaging.EncodeOption
+	format := fs.Type(strings.TrimPrefix(filepath.Ext(fileName), "."))
 
-	if filepath.Ext(fileName) == "."+string(fs.ImagePNG) {
-		quality = imaging.PNGCompressionLevel(png.DefaultCompression)
-	} else if width <= 150 && height <= 150 {
-		quality = JpegQualitySmall.EncodeOption()
-	} else {
-		quality = JpegQuality.EncodeOption()
+	if quality <= 0 {
+		quality = EncodeQuality(format, width, height)
+	} else if quality > 100 {
+		quality = 100
 	}
 
-	err = imaging.Save(result, fileName, quality)
+	switch format {
+	case fs.ImagePNG:
+		err = imaging.Save(result, fileName, imaging.PNGCompressionLevel(png.DefaultCompression))
+	case fs.ImageWebP:
+		err = SaveWebp(result, fileName, quality)
+	case fs.ImageAVIF:
+		err = SaveAvif(result, fileName, quality)
+	case fs.ImageJPEG:
+		err = SaveJpeg(result, fileName, quality.EncodeOption())
+	default:
+		err = imaging.Save(result, fileName, quality.EncodeOption())
+	}
 
 	if err != nil {
 		log.Debugf("thumb: failed to save %s", clean.Log(filepath.Base(fileName)))
@@ -1,9 +1,16 @@
 package thumb
 
 import (
+	"fmt"
+	"image"
+	"image/color"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/disintegration/imaging"
 	"github.com/stretchr/testify/assert"
@@ -33,6 +40,94 @@ func TestResampleOptions(t *testing.T) {
 		assert.Equal(t, imaging.NearestNeighbor.Support, filter.Support)
 		assert.Equal(t, fs.ImageJPEG, format)
 	})
+	t.Run("ResampleWebp, FillCenter", func(t *testing.T) {
+		method, filter, format := ResampleOptions(ResampleWebp, ResampleFillCenter, ResampleDefault)
+
+		assert.Equal(t, ResampleFillCenter, method)
+		assert.Equal(t, imaging.Lanczos.Support, filter.Support)
+		assert.Equal(t, fs.ImageWebP, format)
+	})
+	t.Run("ResamplePng, ResampleWebp", func(t *testing.T) {
+		_, _, format := ResampleOptions(ResamplePng, ResampleFit, ResampleWebp)
+		assert.Equal(t, fs.ImageWebP, format)
+	})
+	t.Run("ResampleWebp, ResamplePng", func(t *testing.T) {
+		_, _, format := ResampleOptions(ResampleWebp, ResampleFit, ResamplePng)
+		assert.Equal(t, fs.ImagePNG, format)
+	})
+	t.Run("ResampleAvif, FillCenter", func(t *testing.T) {
+		method, _, format := ResampleOptions(ResampleAvif, ResampleFillCenter)
+
+		assert.Equal(t, ResampleFillCenter, method)
+		assert.Equal(t, fs.ImageAVIF, format)
+	})
+	t.Run("ResampleWebp, ResampleAvif", func(t *testing.T) {
+		_, _, format := ResampleOptions(ResampleWebp, ResampleFit, ResampleAvif)
+		assert.Equal(t, fs.ImageAVIF, format)
+	})
+	t.Run("ResampleFilterLinear", func(t *testing.T) {
+		method, filter, format := ResampleOptions(ResampleFit, ResampleFilterLinear)
+
+		assert.Equal(t, ResampleFit, method)
+		assert.Equal(t, imaging.Linear.Support, filter.Support)
+		assert.Equal(t, fs.ImageJPEG, format)
+	})
+	t.Run("ResampleFilterCubic", func(t *testing.T) {
+		_, filter, _ := ResampleOptions(ResampleFillCenter, ResampleFilterCubic)
+		assert.Equal(t, imaging.CatmullRom.Support, filter.Support)
+	})
+	t.Run("ResampleFilterLanczos", func(t *testing.T) {
+		_, filter, _ := ResampleOptions(ResampleNearestNeighbor, ResampleFilterLanczos)
+		assert.Equal(t, imaging.Lanczos.Support, filter.Support)
+	})
+	t.Run("ResampleFilterLinear, ResampleFilterCubic", func(t *testing.T) {
+		_, filter, _ := ResampleOptions(ResampleFilterLinear, ResampleFit, ResampleFilterCubic)
+		assert.Equal(t, imaging.CatmullRom.Support, filter.Support)
+	})
+	t.Run("ResampleDefault, ResampleFilterLinear", func(t *testing.T) {
+		_, filter, _ := ResampleOptions(ResampleDefault, ResampleFilterLinear)
+		assert.Equal(t, imaging.Linear.Support, filter.Support)
+	})
+}
+
+func TestResampleAspect(t *testing.T) {
+	t.Run("FillConstants", func(t *testing.T) {
+		for option, expected := range fillAnchors {
+			square, anchor := ResampleAspect(option, ResampleDefault)
+
+			assert.True(t, square, ResampleMethods[option])
+			assert.Equal(t, expected, anchor, ResampleMethods[option])
+		}
+	})
+	t.Run("Fit", func(t *testing.T) {
+		square, anchor := ResampleAspect(ResampleFit, ResampleDefault)
+
+		assert.False(t, square)
+		assert.Equal(t, imaging.Center, anchor)
+	})
+	t.Run("FitSquare", func(t *testing.T) {
+		square, anchor := ResampleAspect(ResampleFit, ResampleSquare, ResampleAnchorBottomRight)
+
+		assert.True(t, square)
+		assert.Equal(t, imaging.BottomRight, anchor)
+	})
+	t.Run("FillCenterAnchorTopLeft", func(t *testing.T) {
+		square, anchor := ResampleAspect(ResampleFillCenter, ResampleAnchorTopLeft)
+
+		assert.True(t, square)
+		assert.Equal(t, imaging.TopLeft, anchor)
+	})
+}
+
+func TestResampleName(t *testing.T) {
+	assert.Equal(t, "center", ResampleName(ResampleFillCenter, ResampleDefault))
+	assert.Equal(t, "left", ResampleName(ResampleFillTopLeft, ResampleDefault))
+	assert.Equal(t, "right", ResampleName(ResampleFillBottomRight, ResampleDefault))
+	assert.Equal(t, "left", ResampleName(ResampleFillCenter, ResampleAnchorTopLeft))
+	assert.Equal(t, "fit", ResampleName(ResampleFit, ResampleAnchorTopLeft))
+	assert.Equal(t, "fit_square", ResampleName(ResampleFit, ResampleSquare))
+	assert.Equal(t, "resize_square_right", ResampleName(ResampleResize, ResampleSquare, ResampleAnchorBottomRight))
+	assert.Equal(t, "smart", ResampleName(ResampleFillSmart, ResampleSquare))
 }
 
 func TestResample(t *testing.T) {
@@ -54,7 +149,11 @@ func TestResample(t *testing.T) {
 		assert.Equal(t, 750, bounds.Max.X)
 		assert.Equal(t, 500, bounds.Max.Y)
 
-		result := Resample(img, tile50.Width, tile50.Height, tile50.Options...)
+		result, err := Resample(img, tile50.Width, tile50.Height, tile50.Options...)
+
+		if err != nil {
+			t.Fatal(err)
+		}
 
 		boundsNew := result.Bounds()
 
@@ -79,7 +178,11 @@ func TestResample(t *testing.T) {
 		assert.Equal(t, 750, bounds.Max.X)
 		assert.Equal(t, 500, bounds.Max.Y)
 
-		result := Resample(img, left224.Width, left224.Height, left224.Options...)
+		result, err := Resample(img, left224.Width, left224.Height, left224.Options...)
+
+		if err != nil {
+			t.Fatal(err)
+		}
 
 		boundsNew := result.Bounds()
 
@@ -104,7 +207,11 @@ func TestResample(t *testing.T) {
 		assert.Equal(t, 750, bounds.Max.X)
 		assert.Equal(t, 500, bounds.Max.Y)
 
-		result := Resample(img, right224.Width, right224.Height, right224.Options...)
+		result, err := Resample(img, right224.Width, right224.Height, right224.Options...)
+
+		if err != nil {
+			t.Fatal(err)
+		}
 
 		boundsNew := result.Bounds()
 
@@ -129,7 +236,11 @@ func TestResample(t *testing.T) {
 		assert.Equal(t, 750, bounds.Max.X)
 		assert.Equal(t, 500, bounds.Max.Y)
 
-		result := Resample(img, fit1280.Width, fit1280.Height, fit1280.Options...)
+		result, err := Resample(img, fit1280.Width, fit1280.Height, fit1280.Options...)
+
+		if err != nil {
+			t.Fatal(err)
+		}
 
 		boundsNew := result.Bounds()
 
@@ -138,12 +249,110 @@ func TestResample(t *testing.T) {
 	})
 }
 
+func TestResample_Aspect(t *testing.T) {
+	img, err := imaging.Open("testdata/example.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("FillConstants", func(t *testing.T) {
+		for option, anchor := range fillAnchors {
+			result, err := Resample(img, 100, 100, option, ResampleDefault)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected := imaging.Fill(img, 100, 100, anchor, Filter.Imaging())
+
+			assert.Equal(t, expected.Pix, imaging.Clone(result).Pix, ResampleMethods[option])
+		}
+	})
+	t.Run("FitSquare", func(t *testing.T) {
+		result, err := Resample(img, 100, 50, ResampleFit, ResampleSquare, ResampleAnchorTopLeft)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 50, result.Bounds().Dx())
+		assert.Equal(t, 50, result.Bounds().Dy())
+
+		expected := imaging.Fit(SquareCrop(img, imaging.TopLeft), 100, 50, imaging.Lanczos)
+
+		assert.Equal(t, expected.Pix, imaging.Clone(result).Pix)
+	})
+}
+
+func TestSquareCrop(t *testing.T) {
+	assert.Equal(t, image.Rect(0, 0, 30, 30), SquareCrop(imaging.New(40, 30, color.White), imaging.Center).Bounds())
+	assert.Equal(t, image.Rect(0, 0, 20, 20), SquareCrop(imaging.New(20, 50, color.White), imaging.BottomRight).Bounds())
+	assert.Equal(t, image.Rect(0, 0, 25, 25), SquareCrop(imaging.New(25, 25, color.White), imaging.TopLeft).Bounds())
+}
+
+func TestResample_SizeLimit(t *testing.T) {
+	img := imaging.New(100, 100, color.NRGBA{R: 200, G: 100, B: 50, A: 255})
+
+	t.Run("ExceedsLimit", func(t *testing.T) {
+		result, err := Resample(img, SizeLimitDefault+1, 100, ResampleFit)
+
+		assert.ErrorIs(t, err, ErrSizeExceedsLimit)
+		assert.Equal(t, img, result)
+	})
+	t.Run("Custom", func(t *testing.T) {
+		limit := SizeLimit
+		SizeLimit = 50
+		defer func() { SizeLimit = limit }()
+
+		// The size limit does not depend on the thumbnail sizes, and vice versa.
+		assert.Equal(t, SizeUncached, MaxSize())
+
+		_, err := Resample(img, 40, 51, ResampleFillCenter)
+
+		if assert.ErrorIs(t, err, ErrSizeExceedsLimit) {
+			assert.Equal(t, "thumb: 40x51 exceeds size limit of 50px", err.Error())
+		}
+
+		result, err := Resample(img, 50, 50, ResampleFillCenter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 50, result.Bounds().Dx())
+	})
+	t.Run("Negative", func(t *testing.T) {
+		_, err := Resample(img, -1, 100, ResampleFit)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCreate_SizeLimit(t *testing.T) {
+	limit := SizeLimit
+	SizeLimit = 500
+	defer func() { SizeLimit = limit }()
+
+	img := imaging.New(100, 100, color.NRGBA{R: 200, G: 100, B: 50, A: 255})
+
+	_, err := Create(img, filepath.Join(t.TempDir(), "large.jpg"), 720, 720, ResampleFit)
+
+	assert.Error(t, err)
+}
+
 func TestSuffix(t *testing.T) {
 	tile50 := Sizes[Tile50]
 
 	result := Suffix(tile50.Width, tile50.Height, tile50.Options...)
 
 	assert.Equal(t, "50x50_center.jpg", result)
+
+	t.Run("Aspect", func(t *testing.T) {
+		for name, size := range Sizes {
+			method, _, format := ResampleOptions(size.Options...)
+			assert.Equal(t, fmt.Sprintf("%dx%d_%s.%s", size.Width, size.Height, ResampleMethods[method], format), Suffix(size.Width, size.Height, size.Options...), name)
+		}
+
+		assert.Equal(t, "224x224_fit_square.jpg", Suffix(224, 224, ResampleFit, ResampleSquare))
+	})
 }
 
 func TestFileName(t *testing.T) {
@@ -321,6 +530,65 @@ func TestFromFile(t *testing.T) {
 		assert.FileExists(t, dst)
 	})
 
+	t.Run("not cached", func(t *testing.T) {
+		tile50 := Sizes[Tile50]
+		src := "testdata/example.jpg"
+		hash := "923456789098765432"
+
+		assert.FileExists(t, src)
+
+		fileName, err := FromCache(src, hash, "testdata", tile50.Width, tile50.Height, tile50.Options...)
+
+		assert.Equal(t, "", fileName)
+		assert.Equal(t, ErrNotCached, err)
+
+		fileName, err = FromFile(src, hash, "testdata", tile50.Width, tile50.Height, OrientationNormal, tile50.Options...)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		assert.FileExists(t, fileName)
+
+		cached, err := FromCache(src, hash, "testdata", tile50.Width, tile50.Height, tile50.Options...)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fs.Abs(fileName), cached)
+	})
+
+	t.Run("concurrent misses", func(t *testing.T) {
+		tile224 := Sizes[Tile224]
+		src := "testdata/example.jpg"
+		hash := "933456789098765432"
+
+		assert.FileExists(t, src)
+
+		var wg sync.WaitGroup
+		results := make([]string, 8)
+		errs := make([]error, 8)
+
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i], errs[i] = FromFile(src, hash, "testdata", tile224.Width, tile224.Height, OrientationNormal, tile224.Options...)
+			}(i)
+		}
+
+		wg.Wait()
+
+		defer os.Remove(results[0])
+
+		for i := range results {
+			assert.NoError(t, errs[i])
+			assert.Equal(t, results[0], results[i])
+		}
+
+		assert.FileExists(t, results[0])
+	})
+
 	t.Run("missing file", func(t *testing.T) {
 		colorThumb := Sizes[Colors]
 		src := "testdata/example.xxx"
@@ -345,6 +613,53 @@ func TestFromFile(t *testing.T) {
 	})
 }
 
+func TestCreateOnce(t *testing.T) {
+	t.Run("Deduplicated", func(t *testing.T) {
+		var calls int32
+		var wg sync.WaitGroup
+
+		start := make(chan struct{})
+		create := func() error {
+			atomic.AddInt32(&calls, 1)
+			time.Sleep(100 * time.Millisecond)
+			return nil
+		}
+
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				_, err := createOnce("testdata/create_once.jpg", create)
+				assert.NoError(t, err)
+			}()
+		}
+
+		close(start)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+	t.Run("Sequential", func(t *testing.T) {
+		var calls int32
+
+		create := func() error {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		}
+
+		shared, err := createOnce("testdata/create_once.jpg", create)
+		assert.NoError(t, err)
+		assert.False(t, shared)
+
+		shared, err = createOnce("testdata/create_once.jpg", create)
+		assert.NoError(t, err)
+		assert.False(t, shared)
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+}
+
 func TestFromCache(t *testing.T) {
 	t.Run("missing thumb", func(t *testing.T) {
 		tile50 := Sizes[Tile50]
@@ -439,6 +754,53 @@ func TestCreate(t *testing.T) {
 		assert.Equal(t, 500, boundsNew.Max.X)
 		assert.Equal(t, 500, boundsNew.Max.Y)
 	})
+	t.Run("Quality", func(t *testing.T) {
+		tile500 := Sizes[Tile500]
+		src := "testdata/example.jpg"
+		dst := "testdata/example.tile_500_quality.jpg"
+
+		img, err := imaging.Open(src, imaging.AutoOrientation(true))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = Create(img, dst, tile500.Width, tile500.Height, tile500.Options...); err != nil {
+			t.Fatal(err)
+		}
+
+		defaultInfo, err := os.Stat(dst)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = CreateQuality(img, dst, tile500.Width, tile500.Height, QualityWorst-30, tile500.Options...); err != nil {
+			t.Fatal(err)
+		}
+
+		lowInfo, err := os.Stat(dst)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Near-lossless, e.g. for printing.
+		if _, err = CreateQuality(img, dst, tile500.Width, tile500.Height, 99, tile500.Options...); err != nil {
+			t.Fatal(err)
+		}
+
+		highInfo, err := os.Stat(dst)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_ = os.Remove(dst)
+
+		assert.Less(t, lowInfo.Size(), defaultInfo.Size())
+		assert.Greater(t, highInfo.Size(), defaultInfo.Size())
+	})
 	t.Run("width & height <= 150", func(t *testing.T) {
 		tile500 := Sizes[Tile500]
 		src := "testdata/example.jpg"
@@ -533,3 +895,44 @@ func TestCreate(t *testing.T) {
 		assert.NotNil(t, resized)
 	})
 }
+
+func TestRemoveCached(t *testing.T) {
+	thumbPath := t.TempDir()
+	hash := "a7d9fb5cbf0a2c7a6bc9a2e4ebc7a1bcbd1b2a6a"
+	img := imaging.New(100, 100, color.NRGBA{R: 200, G: 100, B: 50, A: 255})
+
+	for _, size := range []Size{Sizes[Tile50], Sizes[Tile100]} {
+		fileName, err := size.FileName(hash, thumbPath)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = size.Create(img, fileName); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Thumbnails of other files must not be removed.
+	other, err := Sizes[Tile50].FileName("a7d0000000000000000000000000000000000000", thumbPath)
+
+	if err != nil {
+		t.Fatal(err)
+	} else if _, err = Sizes[Tile50].Create(img, other); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := RemoveCached(hash, thumbPath)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	assert.FileExists(t, other)
+
+	removed, err = RemoveCached(hash, thumbPath)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, removed)
+
+	_, err = RemoveCached("abc", thumbPath)
+	assert.Error(t, err)
+}
@@ -0,0 +1,59 @@
+package thumb
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/disintegration/imaging"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// encodeArgs returns the command arguments of an external encoder based on the input and output file names.
+type encodeArgs func(src, dst string) []string
+
+// saveEncoded encodes an image with an external encoder and saves it. The encoder reads the
+// image from a temporary, uncompressed PNG file in the same directory as the output file.
+func saveEncoded(img image.Image, fileName, encoderBin string, format fs.Type, args encodeArgs) error {
+	// The encoder reads the image from a temporary, uncompressed PNG file.
+	tmp, err := os.CreateTemp(filepath.Dir(fileName), "."+filepath.Base(fileName)+".*.png")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if err = imaging.Encode(tmp, img, imaging.PNG, imaging.PNGCompressionLevel(png.NoCompression)); err != nil {
+		_ = tmp.Close()
+		return err
+	} else if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	var stderr bytes.Buffer
+
+	cmd := exec.Command(encoderBin, args(tmp.Name(), fileName)...)
+	cmd.Stderr = &stderr
+
+	if err = cmd.Run(); err != nil {
+		if s := strings.TrimSpace(stderr.String()); s != "" {
+			return fmt.Errorf("%s: %s", format, s)
+		}
+
+		return fmt.Errorf("%s: %s", format, err)
+	}
+
+	if !fs.FileExists(fileName) {
+		return fmt.Errorf("%s: failed to create %s", format, clean.Log(filepath.Base(fileName)))
+	}
+
+	return nil
+}
@@ -5,5 +5,8 @@ import (
 )
 
 var (
-	ErrNotCached = errors.New("not cached")
+	ErrNotCached           = errors.New("not cached")
+	ErrAvifEncoderNotFound = errors.New("avif encoder not found")
+	ErrWebpEncoderNotFound = errors.New("webp encoder not found")
+	ErrSizeExceedsLimit    = errors.New("exceeds size limit")
 )
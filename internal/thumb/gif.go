@@ -0,0 +1,262 @@
+package thumb
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+	"os"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// AnimatedMaxFrames limits the number of frames of animated thumbnails.
+var AnimatedMaxFrames = 1000
+
+// AnimatedMaxPixels limits the total number of pixels of all frames, i.e. frames × width × height,
+// so that large animations are rejected before they are decoded.
+var AnimatedMaxPixels = 200 * 1000 * 1000
+
+// HasOption tests if the resample options contain the specified option.
+func HasOption(option ResampleOption, opts ...ResampleOption) bool {
+	for _, o := range opts {
+		if o == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// animatedOptions returns the options for creating an animated thumbnail of the image file,
+// or nil if the ResampleAnimated option is not set or the file type does not support it.
+//
+// Animated WebP images are not supported, as they cannot be decoded with the current WebP library.
+func animatedOptions(imageFilename string, opts ...ResampleOption) []ResampleOption {
+	if !HasOption(ResampleAnimated, opts...) || fs.FileType(imageFilename) != fs.ImageGIF {
+		return nil
+	} else if _, _, format := ResampleOptions(opts...); format == fs.ImageGIF {
+		return opts
+	}
+
+	return append(append([]ResampleOption{}, opts...), ResampleGif)
+}
+
+// OpenGif decodes all frames of a GIF image file.
+func OpenGif(fileName string) (*gif.GIF, error) {
+	if fileName == "" {
+		return nil, fmt.Errorf("filename missing")
+	}
+
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	// Check the dimensions and the number of frames before decoding them.
+	config, err := gif.DecodeConfig(f)
+
+	if err != nil {
+		return nil, err
+	} else if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	frames, err := gifFrames(f)
+
+	if err != nil {
+		return nil, err
+	} else if frames > AnimatedMaxFrames {
+		return nil, fmt.Errorf("animation has too many frames (%d)", frames)
+	} else if pixels := frames * config.Width * config.Height; pixels > AnimatedMaxPixels {
+		return nil, fmt.Errorf("animation is too large (%d frames with %dx%d pixels)", frames, config.Width, config.Height)
+	} else if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	return gif.DecodeAll(f)
+}
+
+// gifFrames returns the number of frames in a GIF image without decoding them.
+func gifFrames(r io.Reader) (frames int, err error) {
+	br := bufio.NewReader(r)
+
+	// Skip the header and read the flags of the logical screen descriptor.
+	header := make([]byte, 13)
+
+	if _, err = io.ReadFull(br, header); err != nil {
+		return 0, err
+	}
+
+	// Skip the global color table, if any.
+	if err = gifSkipColorTable(br, header[10]); err != nil {
+		return 0, err
+	}
+
+	for {
+		block, readErr := br.ReadByte()
+
+		if readErr != nil {
+			return frames, readErr
+		}
+
+		switch block {
+		case 0x21: // Extension.
+			if _, err = br.Discard(1); err != nil {
+				return frames, err
+			} else if err = gifSkipSubBlocks(br); err != nil {
+				return frames, err
+			}
+		case 0x2C: // Image descriptor.
+			descriptor := make([]byte, 9)
+
+			if _, err = io.ReadFull(br, descriptor); err != nil {
+				return frames, err
+			} else if err = gifSkipColorTable(br, descriptor[8]); err != nil {
+				return frames, err
+			} else if _, err = br.Discard(1); err != nil { // LZW minimum code size.
+				return frames, err
+			} else if err = gifSkipSubBlocks(br); err != nil {
+				return frames, err
+			}
+
+			frames++
+		case 0x3B: // Trailer.
+			return frames, nil
+		default:
+			return frames, fmt.Errorf("unknown gif block type 0x%02x", block)
+		}
+	}
+}
+
+// gifSkipColorTable skips the color table that follows a descriptor with the specified flags.
+func gifSkipColorTable(br *bufio.Reader, flags byte) error {
+	if flags&0x80 == 0 {
+		return nil
+	}
+
+	_, err := br.Discard(3 * (1 << ((flags & 0x07) + 1)))
+
+	return err
+}
+
+// gifSkipSubBlocks skips a sequence of data sub-blocks, up to and including the block terminator.
+func gifSkipSubBlocks(br *bufio.Reader) error {
+	for {
+		size, err := br.ReadByte()
+
+		if err != nil {
+			return err
+		} else if size == 0 {
+			return nil
+		} else if _, err = br.Discard(int(size)); err != nil {
+			return err
+		}
+	}
+}
+
+// CreateAnimated resamples all frames of an animated GIF and saves the result as animated GIF.
+func CreateAnimated(g *gif.GIF, fileName string, width, height int, opts ...ResampleOption) (result *gif.GIF, err error) {
+	if g == nil || len(g.Image) == 0 {
+		return nil, fmt.Errorf("thumb: animation has no frames")
+	} else if len(g.Image) > AnimatedMaxFrames {
+		return nil, fmt.Errorf("thumb: animation has too many frames (%d)", len(g.Image))
+	} else if InvalidSize(width) {
+		return nil, fmt.Errorf("thumb: width has an invalid value (%d)", width)
+	} else if InvalidSize(height) {
+		return nil, fmt.Errorf("thumb: height has an invalid value (%d)", height)
+	}
+
+	// Frames may only cover a part of the canvas, so they must be composed before resampling.
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+
+	for _, frame := range g.Image {
+		bounds = bounds.Union(frame.Bounds())
+	}
+
+	canvas := image.NewNRGBA(bounds)
+
+	result = &gif.GIF{
+		Image:     make([]*image.Paletted, 0, len(g.Image)),
+		Delay:     make([]int, 0, len(g.Image)),
+		Disposal:  make([]byte, 0, len(g.Image)),
+		LoopCount: g.LoopCount,
+	}
+
+	for i, frame := range g.Image {
+		var disposal byte
+
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+
+		var previous *image.NRGBA
+
+		if disposal == gif.DisposalPrevious {
+			previous = image.NewNRGBA(bounds)
+			copy(previous.Pix, canvas.Pix)
+		}
+
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+
+		resized, resizeErr := Resample(canvas, width, height, opts...)
+
+		if resizeErr != nil {
+			return nil, resizeErr
+		}
+
+		result.Image = append(result.Image, palettedFrame(resized, frame.Palette))
+		result.Disposal = append(result.Disposal, gif.DisposalNone)
+
+		if i < len(g.Delay) {
+			result.Delay = append(result.Delay, g.Delay[i])
+		} else {
+			result.Delay = append(result.Delay, 0)
+		}
+
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			canvas = previous
+		}
+	}
+
+	result.Config = image.Config{
+		Width:  result.Image[0].Bounds().Dx(),
+		Height: result.Image[0].Bounds().Dy(),
+	}
+
+	f, err := os.Create(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = gif.EncodeAll(f, result); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	return result, f.Close()
+}
+
+// palettedFrame converts an image to a paletted GIF frame, using the palette of the source frame.
+func palettedFrame(img image.Image, palette color.Palette) *image.Paletted {
+	if len(palette) == 0 {
+		palette = color.Palette{color.Transparent, color.Black, color.White}
+	}
+
+	b := img.Bounds()
+	result := image.NewPaletted(image.Rect(0, 0, b.Dx(), b.Dy()), palette)
+
+	draw.FloydSteinberg.Draw(result, result.Bounds(), img, b.Min)
+
+	return result
+}
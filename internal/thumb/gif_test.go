@@ -0,0 +1,227 @@
+package thumb
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// saveTestGif creates a GIF image with the specified number of frames and returns the filename.
+func saveTestGif(t *testing.T, frames int) string {
+	palette := color.Palette{color.Transparent, color.Black, color.White, color.NRGBA{R: 255, A: 255}}
+	g := &gif.GIF{LoopCount: 0}
+
+	for i := 0; i < frames; i++ {
+		// The following frames only cover a part of the canvas.
+		bounds := image.Rect(0, 0, 200, 100)
+
+		if i > 0 {
+			bounds = image.Rect(50, 25, 150, 75)
+		}
+
+		frame := image.NewPaletted(bounds, palette)
+
+		for j := range frame.Pix {
+			frame.Pix[j] = uint8(1 + (i % 3))
+		}
+
+		g.Image = append(g.Image, frame)
+		g.Delay = append(g.Delay, 10*(i+1))
+		g.Disposal = append(g.Disposal, gif.DisposalNone)
+	}
+
+	g.Config = image.Config{ColorModel: palette, Width: 200, Height: 100}
+
+	fileName := filepath.Join(t.TempDir(), "animated.gif")
+	f, err := os.Create(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	if err = gif.EncodeAll(f, g); err != nil {
+		t.Fatal(err)
+	}
+
+	return fileName
+}
+
+func TestHasOption(t *testing.T) {
+	assert.True(t, HasOption(ResampleAnimated, ResampleFit, ResampleAnimated))
+	assert.False(t, HasOption(ResampleAnimated, ResampleFit, ResampleDefault))
+	assert.False(t, HasOption(ResampleAnimated))
+}
+
+func TestOpenGif(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		g, err := OpenGif(saveTestGif(t, 5))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, g.Image, 5)
+	})
+	t.Run("TooManyFrames", func(t *testing.T) {
+		maxFrames := AnimatedMaxFrames
+		AnimatedMaxFrames = 4
+		defer func() { AnimatedMaxFrames = maxFrames }()
+
+		_, err := OpenGif(saveTestGif(t, 5))
+		assert.Error(t, err)
+	})
+	t.Run("TooManyPixels", func(t *testing.T) {
+		maxPixels := AnimatedMaxPixels
+		AnimatedMaxPixels = 4 * 200 * 100
+		defer func() { AnimatedMaxPixels = maxPixels }()
+
+		_, err := OpenGif(saveTestGif(t, 5))
+		assert.Error(t, err)
+	})
+	t.Run("NoGif", func(t *testing.T) {
+		_, err := OpenGif("testdata/example.jpg")
+		assert.Error(t, err)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := OpenGif("testdata/foo.gif")
+		assert.Error(t, err)
+	})
+}
+
+func TestGifFrames(t *testing.T) {
+	for _, n := range []int{1, 3, 10} {
+		f, err := os.Open(saveTestGif(t, n))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		frames, err := gifFrames(f)
+		_ = f.Close()
+
+		assert.NoError(t, err)
+		assert.Equal(t, n, frames)
+	}
+}
+
+func TestCreateAnimated(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		g, err := OpenGif(saveTestGif(t, 3))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fileName := filepath.Join(t.TempDir(), "result.gif")
+		result, err := CreateAnimated(g, fileName, 100, 100, ResampleFit)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result.Image, 3)
+		assert.Equal(t, []int{10, 20, 30}, result.Delay)
+		assert.Equal(t, 100, result.Config.Width)
+		assert.Equal(t, 50, result.Config.Height)
+
+		saved, err := OpenGif(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, saved.Image, 3) {
+			for _, frame := range saved.Image {
+				assert.Equal(t, image.Rect(0, 0, 100, 50), frame.Bounds())
+			}
+
+			// The second frame must be composed with the first, so the corners are still black.
+			r, g, b, _ := saved.Image[1].At(0, 0).RGBA()
+			assert.Equal(t, [3]uint32{0, 0, 0}, [3]uint32{r, g, b})
+			r, g, b, _ = saved.Image[1].At(50, 25).RGBA()
+			assert.Equal(t, [3]uint32{0xffff, 0xffff, 0xffff}, [3]uint32{r, g, b})
+		}
+	})
+	t.Run("NoFrames", func(t *testing.T) {
+		_, err := CreateAnimated(&gif.GIF{}, filepath.Join(t.TempDir(), "result.gif"), 100, 100, ResampleFit)
+		assert.Error(t, err)
+	})
+	t.Run("InvalidSize", func(t *testing.T) {
+		g, err := OpenGif(saveTestGif(t, 2))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = CreateAnimated(g, filepath.Join(t.TempDir(), "result.gif"), -1, 100, ResampleFit)
+		assert.Error(t, err)
+	})
+}
+
+func TestFromFile_Animated(t *testing.T) {
+	hash := "a5d9fb5cbf0a2c7a6bc9a2e4ebc7a1bcbd1b2a6a"
+
+	t.Run("Animated", func(t *testing.T) {
+		thumbPath := t.TempDir()
+		src := saveTestGif(t, 3)
+
+		fileName, err := FromFile(src, hash, thumbPath, 100, 100, OrientationNormal, ResampleFit, ResampleAnimated)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, ".gif", filepath.Ext(fileName))
+		assert.Equal(t, fs.ImageGIF, fs.FileType(fileName))
+
+		g, err := OpenGif(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, g.Image, 3)
+
+		// Cached thumbnail is returned.
+		cached, err := FromCache(src, hash, thumbPath, 100, 100, ResampleFit, ResampleAnimated)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fileName, cached)
+	})
+	t.Run("SingleFrame", func(t *testing.T) {
+		fileName, err := FromFile(saveTestGif(t, 1), hash, t.TempDir(), 100, 100, OrientationNormal, ResampleFit, ResampleAnimated)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, ".jpg", filepath.Ext(fileName))
+	})
+	t.Run("NoOption", func(t *testing.T) {
+		fileName, err := FromFile(saveTestGif(t, 3), hash, t.TempDir(), 100, 100, OrientationNormal, ResampleFit)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, ".jpg", filepath.Ext(fileName))
+	})
+	t.Run("Jpeg", func(t *testing.T) {
+		fileName, err := FromFile("testdata/example.jpg", hash, t.TempDir(), 100, 100, OrientationNormal, ResampleFit, ResampleAnimated)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, ".jpg", filepath.Ext(fileName))
+	})
+}
@@ -36,7 +36,7 @@ func Jpeg(srcFile, jpgFile string, orientation int) (img image.Image, err error)
 	quality := JpegQuality.EncodeOption()
 
 	// Save JPEG file.
-	if err = imaging.Save(img, jpgFile, quality); err != nil {
+	if err = SaveJpeg(img, jpgFile, quality); err != nil {
 		log.Errorf("jpeg: failed to save %s", clean.Log(filepath.Base(jpgFile)))
 		return img, err
 	}
@@ -0,0 +1,121 @@
+package thumb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"os"
+
+	"github.com/disintegration/imaging"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// JpegDPIMax is the maximum pixel density that can be stored in a JFIF header.
+const JpegDPIMax = 65535
+
+// JpegDPI specifies the pixel density in dots per inch stored in generated JPEG files,
+// or 0 if it should be omitted, which is recommended for images displayed on the web.
+var JpegDPI = 0
+
+// JFIF density units.
+const (
+	JfifUnitsNone = 0
+	JfifUnitsDPI  = 1
+	JfifUnitsDPCM = 2
+)
+
+// jfifIdentifier is the identifier of the JFIF APP0 segment.
+var jfifIdentifier = []byte("JFIF\x00")
+
+// JpegDensity returns the pixel density units and values stored in the JFIF header of JPEG data, if any.
+func JpegDensity(data []byte) (units, x, y int, found bool) {
+	if len(data) < 2 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 0, 0, 0, false
+	}
+
+	for i := 2; i+4 <= len(data) && data[i] == 0xFF; {
+		marker := data[i+1]
+		size := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+
+		// Stop at the start of scan or an invalid segment size.
+		if marker == 0xDA || size < 2 || i+2+size > len(data) {
+			break
+		}
+
+		segment := data[i+4 : i+2+size]
+
+		if marker == 0xE0 && len(segment) >= 12 && bytes.HasPrefix(segment, jfifIdentifier) {
+			return int(segment[7]), int(binary.BigEndian.Uint16(segment[8:10])), int(binary.BigEndian.Uint16(segment[10:12])), true
+		}
+
+		i += 2 + size
+	}
+
+	return 0, 0, 0, false
+}
+
+// SetJpegDensity returns the JPEG data without JFIF headers, followed by a new JFIF header
+// with the specified pixel density in dots per inch if dpi is greater than 0.
+func SetJpegDensity(data []byte, dpi int) ([]byte, error) {
+	if len(data) < 2 || data[0] != 0xFF || data[1] != 0xD8 {
+		return data, fmt.Errorf("invalid jpeg header")
+	} else if dpi > JpegDPIMax {
+		dpi = JpegDPIMax
+	}
+
+	result := bytes.NewBuffer(make([]byte, 0, len(data)+18))
+	result.Write(data[0:2])
+
+	if dpi > 0 {
+		jfif := make([]byte, 18)
+		jfif[0], jfif[1] = 0xFF, 0xE0
+		binary.BigEndian.PutUint16(jfif[2:4], 16)
+		copy(jfif[4:9], jfifIdentifier)
+		jfif[9], jfif[10] = 1, 2 // Version 1.02.
+		jfif[11] = JfifUnitsDPI
+		binary.BigEndian.PutUint16(jfif[12:14], uint16(dpi))
+		binary.BigEndian.PutUint16(jfif[14:16], uint16(dpi))
+		result.Write(jfif)
+	}
+
+	i := 2
+
+	// Copy all segments except existing JFIF headers.
+	for i+4 <= len(data) && data[i] == 0xFF {
+		marker := data[i+1]
+		size := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+
+		if marker == 0xDA || size < 2 || i+2+size > len(data) {
+			break
+		}
+
+		if marker != 0xE0 || !bytes.HasPrefix(data[i+4:i+2+size], jfifIdentifier) {
+			result.Write(data[i : i+2+size])
+		}
+
+		i += 2 + size
+	}
+
+	result.Write(data[i:])
+
+	return result.Bytes(), nil
+}
+
+// SaveJpeg encodes an image as JPEG and saves it, with the pixel density specified in JpegDPI.
+func SaveJpeg(img image.Image, fileName string, opts ...imaging.EncodeOption) error {
+	var buf bytes.Buffer
+
+	if err := imaging.Encode(&buf, img, imaging.JPEG, opts...); err != nil {
+		return err
+	}
+
+	data, err := SetJpegDensity(buf.Bytes(), JpegDPI)
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(fileName, data, fs.ModeFile)
+}
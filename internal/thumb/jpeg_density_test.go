@@ -0,0 +1,142 @@
+package thumb
+
+import (
+	"bytes"
+	"image"
+	"os"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetJpegDensity(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := imaging.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 8, 8)), imaging.JPEG); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Set", func(t *testing.T) {
+		data, err := SetJpegDensity(buf.Bytes(), 300)
+
+		assert.NoError(t, err)
+
+		units, x, y, found := JpegDensity(data)
+
+		assert.True(t, found)
+		assert.Equal(t, JfifUnitsDPI, units)
+		assert.Equal(t, 300, x)
+		assert.Equal(t, 300, y)
+
+		img, err := imaging.Decode(bytes.NewReader(data))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 8, img.Bounds().Dx())
+	})
+	t.Run("Replace", func(t *testing.T) {
+		data, err := SetJpegDensity(buf.Bytes(), 72)
+
+		assert.NoError(t, err)
+
+		data, err = SetJpegDensity(data, 600)
+
+		assert.NoError(t, err)
+
+		_, x, y, found := JpegDensity(data)
+
+		assert.True(t, found)
+		assert.Equal(t, 600, x)
+		assert.Equal(t, 600, y)
+		assert.Equal(t, 1, bytes.Count(data, jfifIdentifier))
+	})
+	t.Run("Strip", func(t *testing.T) {
+		data, err := SetJpegDensity(buf.Bytes(), 300)
+
+		assert.NoError(t, err)
+
+		data, err = SetJpegDensity(data, 0)
+
+		assert.NoError(t, err)
+
+		_, _, _, found := JpegDensity(data)
+
+		assert.False(t, found)
+		assert.Equal(t, buf.Bytes(), data)
+	})
+	t.Run("Max", func(t *testing.T) {
+		data, err := SetJpegDensity(buf.Bytes(), 100000)
+
+		assert.NoError(t, err)
+
+		_, x, _, _ := JpegDensity(data)
+
+		assert.Equal(t, JpegDPIMax, x)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := SetJpegDensity([]byte("foo"), 300)
+
+		assert.Error(t, err)
+
+		_, _, _, found := JpegDensity([]byte("foo"))
+
+		assert.False(t, found)
+	})
+}
+
+func TestCreate_JpegDPI(t *testing.T) {
+	img, err := imaging.Open("testdata/example.jpg", imaging.AutoOrientation(true))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tile50 := Sizes[Tile50]
+
+	t.Run("Web", func(t *testing.T) {
+		dst := "testdata/example.dpi_web.jpg"
+
+		assert.Equal(t, 0, JpegDPI)
+
+		if _, err = Create(img, dst, tile50.Width, tile50.Height, tile50.Options...); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(dst)
+
+		data, err := os.ReadFile(dst)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, _, found := JpegDensity(data)
+
+		assert.False(t, found)
+	})
+	t.Run("Print", func(t *testing.T) {
+		dst := "testdata/example.dpi_print.jpg"
+
+		JpegDPI = 300
+		defer func() { JpegDPI = 0 }()
+
+		if _, err = Create(img, dst, tile50.Width, tile50.Height, tile50.Options...); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(dst)
+
+		data, err := os.ReadFile(dst)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		units, x, y, found := JpegDensity(data)
+
+		assert.True(t, found)
+		assert.Equal(t, JfifUnitsDPI, units)
+		assert.Equal(t, 300, x)
+		assert.Equal(t, 300, y)
+	})
+}
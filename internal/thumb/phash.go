@@ -0,0 +1,100 @@
+package thumb
+
+import (
+	"image"
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/disintegration/imaging"
+)
+
+const (
+	phashSize   = 32
+	phashBlocks = 8
+	phashBits   = phashBlocks * phashBlocks
+)
+
+// phashCos contains the precomputed cosine values of the discrete cosine transform.
+var phashCos = func() (result [phashBlocks][phashSize]float64) {
+	for u := 0; u < phashBlocks; u++ {
+		for x := 0; x < phashSize; x++ {
+			result[u][x] = math.Cos(float64(2*x+1) * float64(u) * math.Pi / (2 * phashSize))
+		}
+	}
+
+	return result
+}()
+
+// PerceptualHash returns a 64-bit perceptual hash (pHash) of the image based on the discrete cosine transform
+// of its downscaled grayscale version, so that resized or slightly modified copies get a similar hash.
+func PerceptualHash(img image.Image) uint64 {
+	if img == nil {
+		return 0
+	}
+
+	small := imaging.Grayscale(imaging.Resize(img, phashSize, phashSize, imaging.Lanczos))
+
+	var pixels [phashSize][phashSize]float64
+
+	for y := 0; y < phashSize; y++ {
+		for x := 0; x < phashSize; x++ {
+			pixels[y][x] = float64(small.Pix[y*small.Stride+x*4])
+		}
+	}
+
+	// Keep only the low frequencies in the top left corner.
+	coefficients := make([]float64, 0, phashBits)
+
+	for v := 0; v < phashBlocks; v++ {
+		for u := 0; u < phashBlocks; u++ {
+			var sum float64
+
+			for y := 0; y < phashSize; y++ {
+				for x := 0; x < phashSize; x++ {
+					sum += pixels[y][x] * phashCos[u][x] * phashCos[v][y]
+				}
+			}
+
+			coefficients = append(coefficients, sum)
+		}
+	}
+
+	// Compare with the median, ignoring the DC coefficient, which only represents the average brightness.
+	// Since the number of remaining coefficients is odd, the median is the middle element.
+	sorted := make([]float64, phashBits-1)
+	copy(sorted, coefficients[1:])
+	sort.Float64s(sorted)
+	median := sorted[len(sorted)/2]
+
+	var hash uint64
+
+	for i, c := range coefficients {
+		if c > median {
+			hash |= 1 << uint(i)
+		}
+	}
+
+	return hash
+}
+
+// HammingDistance returns the number of bits that differ in two perceptual hashes.
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// Similarity returns the similarity of two perceptual hashes as percentage.
+func Similarity(a, b uint64) float64 {
+	return DistanceSimilarity(HammingDistance(a, b))
+}
+
+// DistanceSimilarity converts the Hamming distance of two perceptual hashes to a similarity percentage.
+func DistanceSimilarity(distance int) float64 {
+	if distance <= 0 {
+		return 100
+	} else if distance >= phashBits {
+		return 0
+	}
+
+	return math.Round(float64(phashBits-distance)*10000/phashBits) / 100
+}
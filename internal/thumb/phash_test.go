@@ -0,0 +1,73 @@
+package thumb
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPerceptualHash(t *testing.T) {
+	img, err := Open("testdata/example.jpg", 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash := PerceptualHash(img)
+
+	t.Run("Identical", func(t *testing.T) {
+		same, err := Open("testdata/example.jpg", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, hash, PerceptualHash(same))
+		assert.Equal(t, 0, HammingDistance(hash, PerceptualHash(same)))
+		assert.Equal(t, float64(100), Similarity(hash, PerceptualHash(same)))
+	})
+	t.Run("Resized", func(t *testing.T) {
+		resized := imaging.Resize(img, img.Bounds().Dx()/3, 0, imaging.Box)
+
+		assert.LessOrEqual(t, HammingDistance(hash, PerceptualHash(resized)), 6)
+		assert.GreaterOrEqual(t, Similarity(hash, PerceptualHash(resized)), float64(90))
+	})
+	t.Run("Unrelated", func(t *testing.T) {
+		other, err := Open("testdata/animated-earth.jpg", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Greater(t, HammingDistance(hash, PerceptualHash(other)), 16)
+		assert.Less(t, Similarity(hash, PerceptualHash(other)), float64(75))
+	})
+	t.Run("Median", func(t *testing.T) {
+		// Of the 63 coefficients other than the DC coefficient, exactly 31 are greater than the median.
+		assert.Equal(t, 31, bits.OnesCount64(hash&^1))
+	})
+	t.Run("Nil", func(t *testing.T) {
+		assert.Equal(t, uint64(0), PerceptualHash(nil))
+	})
+}
+
+func TestHammingDistance(t *testing.T) {
+	assert.Equal(t, 0, HammingDistance(0, 0))
+	assert.Equal(t, 1, HammingDistance(0, 1))
+	assert.Equal(t, 64, HammingDistance(0, ^uint64(0)))
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, float64(100), Similarity(42, 42))
+	assert.Equal(t, float64(50), Similarity(0, 0xFFFFFFFF))
+	assert.Equal(t, float64(0), Similarity(0, ^uint64(0)))
+}
+
+func TestDistanceSimilarity(t *testing.T) {
+	assert.Equal(t, float64(100), DistanceSimilarity(0))
+	assert.Equal(t, 98.44, DistanceSimilarity(1))
+	assert.Equal(t, float64(75), DistanceSimilarity(16))
+	assert.Equal(t, float64(0), DistanceSimilarity(64))
+}
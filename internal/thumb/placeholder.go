@@ -0,0 +1,124 @@
+package thumb
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"time"
+
+	gc "github.com/patrickmn/go-cache"
+)
+
+// PlaceholderStyle represents the style of placeholder images returned if a thumbnail cannot be created.
+type PlaceholderStyle string
+
+// Supported placeholder styles.
+const (
+	PlaceholderIcon  PlaceholderStyle = "icon"
+	PlaceholderGray  PlaceholderStyle = "gray"
+	PlaceholderLight PlaceholderStyle = "light"
+	PlaceholderDark  PlaceholderStyle = "dark"
+)
+
+// PlaceholderDefault is the default placeholder style.
+var PlaceholderDefault = PlaceholderIcon
+
+// placeholderCache contains recently generated placeholder images by style and size.
+var placeholderCache = gc.New(time.Hour, 10*time.Minute)
+
+// placeholderColors maps placeholder styles to their background and foreground colors.
+var placeholderColors = map[PlaceholderStyle][2]color.RGBA{
+	PlaceholderGray:  {{R: 0x9e, G: 0x9e, B: 0x9e, A: 0xff}, {R: 0xe0, G: 0xe0, B: 0xe0, A: 0xff}},
+	PlaceholderLight: {{R: 0xf5, G: 0xf5, B: 0xf5, A: 0xff}, {R: 0xbd, G: 0xbd, B: 0xbd, A: 0xff}},
+	PlaceholderDark:  {{R: 0x21, G: 0x21, B: 0x21, A: 0xff}, {R: 0x61, G: 0x61, B: 0x61, A: 0xff}},
+}
+
+// ParsePlaceholderStyle returns the placeholder style matching the name, or the default style.
+func ParsePlaceholderStyle(name string) PlaceholderStyle {
+	style := PlaceholderStyle(strings.ToLower(strings.TrimSpace(name)))
+
+	if style == PlaceholderIcon {
+		return style
+	} else if _, ok := placeholderColors[style]; ok {
+		return style
+	}
+
+	return PlaceholderDefault
+}
+
+// String returns the style name as string.
+func (s PlaceholderStyle) String() string {
+	return string(s)
+}
+
+// Icon checks if a generic SVG icon should be returned instead of an image with the requested size.
+func (s PlaceholderStyle) Icon() bool {
+	return s == PlaceholderIcon
+}
+
+// Placeholder returns a PNG encoded "unavailable" image with the specified width and height,
+// so that the page layout stays intact when a thumbnail cannot be created. Generated images
+// are cached, so the returned data must not be modified.
+func Placeholder(width, height int, style PlaceholderStyle) ([]byte, error) {
+	if width < 1 || height < 1 {
+		return nil, fmt.Errorf("placeholder: invalid size %dx%d", width, height)
+	}
+
+	colors, ok := placeholderColors[style]
+
+	if !ok {
+		style = PlaceholderGray
+		colors = placeholderColors[style]
+	}
+
+	cacheKey := fmt.Sprintf("%s:%dx%d", style, width, height)
+
+	if cached, found := placeholderCache.Get(cacheKey); found {
+		return cached.([]byte), nil
+	}
+
+	// A two-color palette keeps memory usage and file size low, even for large sizes.
+	img := image.NewPaletted(image.Rect(0, 0, width, height), color.Palette{colors[0], colors[1]})
+
+	// Draw a crossed-out frame in the center to indicate that the image is not available.
+	side := width
+
+	if height < side {
+		side = height
+	}
+
+	side = side / 4
+	stroke := side/24 + 1
+
+	if side > 2*stroke {
+		x0, y0 := (width-side)/2, (height-side)/2
+
+		for i := 0; i < side; i++ {
+			for s := 0; s < stroke; s++ {
+				img.SetColorIndex(x0+i, y0+s, 1)
+				img.SetColorIndex(x0+i, y0+side-1-s, 1)
+				img.SetColorIndex(x0+s, y0+i, 1)
+				img.SetColorIndex(x0+side-1-s, y0+i, 1)
+
+				if i+s < side {
+					img.SetColorIndex(x0+i+s, y0+i, 1)
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+
+	enc := png.Encoder{CompressionLevel: png.BestSpeed}
+
+	if err := enc.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	placeholderCache.SetDefault(cacheKey, buf.Bytes())
+
+	return buf.Bytes(), nil
+}
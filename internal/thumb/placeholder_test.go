@@ -0,0 +1,103 @@
+package thumb
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePlaceholderStyle(t *testing.T) {
+	t.Run("Icon", func(t *testing.T) {
+		assert.Equal(t, PlaceholderIcon, ParsePlaceholderStyle("Icon"))
+		assert.True(t, ParsePlaceholderStyle("icon").Icon())
+	})
+	t.Run("Dark", func(t *testing.T) {
+		assert.Equal(t, PlaceholderDark, ParsePlaceholderStyle(" dark "))
+		assert.False(t, ParsePlaceholderStyle("dark").Icon())
+	})
+	t.Run("Default", func(t *testing.T) {
+		assert.Equal(t, PlaceholderDefault, ParsePlaceholderStyle(""))
+		assert.Equal(t, PlaceholderDefault, ParsePlaceholderStyle("xxx"))
+	})
+}
+
+func TestPlaceholder(t *testing.T) {
+	t.Run("Tile500", func(t *testing.T) {
+		data, err := Placeholder(500, 500, PlaceholderGray)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		img, err := png.Decode(bytes.NewReader(data))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 500, img.Bounds().Dx())
+		assert.Equal(t, 500, img.Bounds().Dy())
+	})
+	t.Run("Landscape", func(t *testing.T) {
+		data, err := Placeholder(1920, 1080, PlaceholderDark)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		img, err := png.Decode(bytes.NewReader(data))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1920, img.Bounds().Dx())
+		assert.Equal(t, 1080, img.Bounds().Dy())
+	})
+	t.Run("Tiny", func(t *testing.T) {
+		data, err := Placeholder(1, 3, PlaceholderLight)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		img, err := png.Decode(bytes.NewReader(data))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, img.Bounds().Dx())
+		assert.Equal(t, 3, img.Bounds().Dy())
+	})
+	t.Run("Cached", func(t *testing.T) {
+		first, err := Placeholder(320, 240, PlaceholderGray)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		second, err := Placeholder(320, 240, PlaceholderGray)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Same(t, &first[0], &second[0])
+
+		other, err := Placeholder(320, 240, PlaceholderDark)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEqual(t, first, other)
+	})
+	t.Run("InvalidSize", func(t *testing.T) {
+		_, err := Placeholder(0, 100, PlaceholderGray)
+
+		assert.Error(t, err)
+	})
+}
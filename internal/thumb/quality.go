@@ -4,12 +4,13 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 
 	"github.com/disintegration/imaging"
 )
 
-// Quality represents a JPEG image quality.
+// Quality represents an image quality, e.g. for encoding JPEG, WebP, or AVIF images.
 type Quality int
 
 // EncodeOption returns the quality as imaging.EncodeOption.
@@ -33,6 +34,14 @@ const (
 	QualityWorst   Quality = 70
 )
 
+// Default Quality settings of the supported output formats. WebP and AVIF achieve
+// a visual quality similar to JPEG with lower values.
+const (
+	JpegQualityDefault         = QualityDefault
+	WebpQualityDefault Quality = 80
+	AvifQualityDefault Quality = 60
+)
+
 // QualityLevels maps human-readable settings to a numeric Quality.
 var QualityLevels = map[string]Quality{
 	"5":         QualityBest,
@@ -60,20 +69,33 @@ var QualityLevels = map[string]Quality{
 
 // Current Quality settings.
 var (
-	JpegQuality      = QualityDefault
+	JpegQuality      = JpegQualityDefault
 	JpegQualitySmall = QualityLow
+	WebpQuality      = WebpQualityDefault
+	AvifQuality      = AvifQualityDefault
 )
 
-// ParseQuality returns the matching quality based on a config value string.
+// ParseQuality returns the matching JPEG quality based on a config value string.
 func ParseQuality(s string) Quality {
+	return ParseFormatQuality(s, fs.ImageJPEG)
+}
+
+// ParseFormatQuality returns the matching quality for the output format based on a config value string,
+// or the default quality of the format if the value is empty or invalid. Human-readable quality levels
+// refer to JPEG images and are therefore only supported for this format.
+func ParseFormatQuality(s string, format fs.Type) Quality {
+	defaultQuality := DefaultQuality(format)
+
 	// Default if empty.
 	if s == "" {
-		return QualityDefault
+		return defaultQuality
 	}
 
 	// Try to parse as positive integer.
 	if i := txt.Int(s); i >= 25 && i <= 100 {
 		return Quality(i)
+	} else if format != fs.ImageJPEG {
+		return defaultQuality
 	}
 
 	// Normalize value.
@@ -84,5 +106,38 @@ func ParseQuality(s string) Quality {
 		return l
 	}
 
-	return QualityDefault
+	return defaultQuality
+}
+
+// DefaultQuality returns the default quality of the output format.
+func DefaultQuality(format fs.Type) Quality {
+	switch format {
+	case fs.ImageWebP:
+		return WebpQualityDefault
+	case fs.ImageAVIF:
+		return AvifQualityDefault
+	default:
+		return JpegQualityDefault
+	}
+}
+
+// FormatQuality returns the current quality setting of the output format.
+func FormatQuality(format fs.Type) Quality {
+	switch format {
+	case fs.ImageWebP:
+		return WebpQuality
+	case fs.ImageAVIF:
+		return AvifQuality
+	default:
+		return JpegQuality
+	}
+}
+
+// EncodeQuality returns the current quality setting for encoding an image of the specified format and size.
+func EncodeQuality(format fs.Type, width, height int) Quality {
+	if format == fs.ImageJPEG && width <= 150 && height <= 150 {
+		return JpegQualitySmall
+	}
+
+	return FormatQuality(format)
 }
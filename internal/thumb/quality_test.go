@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 func TestParseQuality(t *testing.T) {
@@ -81,3 +83,43 @@ func TestParseQuality(t *testing.T) {
 		assert.Equal(t, Quality(100), ParseQuality("100"))
 	})
 }
+
+func TestParseFormatQuality(t *testing.T) {
+	t.Run("Jpeg", func(t *testing.T) {
+		assert.Equal(t, JpegQualityDefault, ParseFormatQuality("", fs.ImageJPEG))
+		assert.Equal(t, QualityHigh, ParseFormatQuality("high", fs.ImageJPEG))
+	})
+	t.Run("WebP", func(t *testing.T) {
+		assert.Equal(t, WebpQualityDefault, ParseFormatQuality("", fs.ImageWebP))
+		assert.Equal(t, WebpQualityDefault, ParseFormatQuality("high", fs.ImageWebP))
+		assert.Equal(t, Quality(70), ParseFormatQuality("70", fs.ImageWebP))
+	})
+	t.Run("AVIF", func(t *testing.T) {
+		assert.Equal(t, AvifQualityDefault, ParseFormatQuality("", fs.ImageAVIF))
+		assert.Equal(t, AvifQualityDefault, ParseFormatQuality("110", fs.ImageAVIF))
+		assert.Equal(t, Quality(50), ParseFormatQuality("50", fs.ImageAVIF))
+	})
+}
+
+func TestDefaultQuality(t *testing.T) {
+	assert.Equal(t, Quality(85), DefaultQuality(fs.ImageJPEG))
+	assert.Equal(t, Quality(80), DefaultQuality(fs.ImageWebP))
+	assert.Equal(t, Quality(60), DefaultQuality(fs.ImageAVIF))
+	assert.Less(t, int(DefaultQuality(fs.ImageAVIF)), int(DefaultQuality(fs.ImageJPEG)))
+}
+
+func TestEncodeQuality(t *testing.T) {
+	t.Run("Jpeg", func(t *testing.T) {
+		assert.Equal(t, JpegQuality, EncodeQuality(fs.ImageJPEG, 720, 720))
+	})
+	t.Run("JpegSmall", func(t *testing.T) {
+		assert.Equal(t, JpegQualitySmall, EncodeQuality(fs.ImageJPEG, 100, 100))
+	})
+	t.Run("WebP", func(t *testing.T) {
+		assert.Equal(t, WebpQuality, EncodeQuality(fs.ImageWebP, 720, 720))
+		assert.Equal(t, WebpQuality, EncodeQuality(fs.ImageWebP, 100, 100))
+	})
+	t.Run("AVIF", func(t *testing.T) {
+		assert.Equal(t, AvifQuality, EncodeQuality(fs.ImageAVIF, 720, 720))
+	})
+}
@@ -6,23 +6,71 @@ import (
 	"github.com/disintegration/imaging"
 )
 
-// Resample downscales an image and returns it.
-func Resample(img image.Image, width, height int, opts ...ResampleOption) image.Image {
+// Resample downscales an image and returns it, or an error if the size exceeds the limit.
+func Resample(img image.Image, width, height int, opts ...ResampleOption) (image.Image, error) {
+	// Reject oversized requests before allocating memory for the result.
+	if err := CheckSize(width, height); err != nil {
+		return img, err
+	}
+
 	var resImg image.Image
 
-	method, filter, _ := ResampleOptions(opts...)
+	method, filter, format := ResampleOptions(opts...)
+	square, anchor := ResampleAspect(opts...)
+
+	// Crop to a square first if requested for a method that does not fill the thumbnail.
+	if _, fill := fillAnchors[method]; square && !fill && method != ResampleFillSmart {
+		img = SquareCrop(img, anchor)
+	}
 
 	if method == ResampleFit {
 		resImg = imaging.Fit(img, width, height, filter)
-	} else if method == ResampleFillCenter {
-		resImg = imaging.Fill(img, width, height, imaging.Center, filter)
-	} else if method == ResampleFillTopLeft {
-		resImg = imaging.Fill(img, width, height, imaging.TopLeft, filter)
-	} else if method == ResampleFillBottomRight {
-		resImg = imaging.Fill(img, width, height, imaging.BottomRight, filter)
+	} else if method == ResampleFillCenter || method == ResampleFillTopLeft || method == ResampleFillBottomRight {
+		resImg = imaging.Fill(img, width, height, anchor, filter)
 	} else if method == ResampleResize {
 		resImg = imaging.Resize(img, width, height, filter)
+	} else if method == ResampleBlurExtend && Transparent(img) && TransparentFormat(format) {
+		// Keep the padding area transparent instead of filling it with the blurred image.
+		resImg = PadTransparent(img, width, height, filter)
+	} else if method == ResampleBlurExtend {
+		resImg = BlurExtend(img, width, height, filter)
+	} else if method == ResampleFillSmart {
+		resImg = SmartFill(img, width, height, filter)
+	}
+
+	if OptionsSharpen(opts...) {
+		resImg = Sharpen(resImg, img, filter)
+	}
+
+	return resImg, nil
+}
+
+// Sharpen applies an unsharp mask with SharpenSigma to an image that has been downscaled from the source,
+// so that it looks less soft. It is returned unchanged if it is not smaller than the source or if the
+// nearest-neighbor filter, which does not blur the image, was used.
+func Sharpen(img, src image.Image, filter imaging.ResampleFilter) image.Image {
+	if img == nil || src == nil || SharpenSigma <= 0 || filter.Support <= 0 {
+		return img
+	}
+
+	b, s := img.Bounds(), src.Bounds()
+
+	if b.Dx()*b.Dy() >= s.Dx()*s.Dy() {
+		return img
+	}
+
+	return imaging.Sharpen(img, SharpenSigma)
+}
+
+// SquareCrop crops the largest possible square from the image at the specified anchor.
+func SquareCrop(img image.Image, anchor imaging.Anchor) image.Image {
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+
+	if w == h {
+		return img
+	} else if w > h {
+		return imaging.CropAnchor(img, h, h, anchor)
 	}
 
-	return resImg
+	return imaging.CropAnchor(img, w, w, anchor)
 }
@@ -16,17 +16,70 @@ const (
 	ResampleNearestNeighbor
 	ResampleDefault
 	ResamplePng
+	ResampleBlurExtend
+	ResampleFillSmart
+	ResampleWebp
+	ResampleFilterLinear  // Fastest filter, but images may look slightly blurry.
+	ResampleFilterCubic   // Sharper than linear at a moderate speed, good for most downscaling.
+	ResampleFilterLanczos // Best quality, especially for large originals, but the slowest filter.
+	ResampleAvif          // Requires the AVIF encoder, see AvifEncoderBin.
+	ResampleGif
+	ResampleAnimated // Keeps all frames of animated GIFs, has no effect on other images.
+	ResampleSquare   // Crops the image to a square before resizing, can be combined with any method.
+	ResampleAnchorCenter
+	ResampleAnchorTopLeft
+	ResampleAnchorBottomRight
+	ResampleSharpen // Applies an unsharp mask after downscaling, see SharpenSigma.
 )
 
+// OptionsSharpen checks if the resample options include sharpening after downscaling.
+func OptionsSharpen(opts ...ResampleOption) bool {
+	for _, option := range opts {
+		if option == ResampleSharpen {
+			return true
+		}
+	}
+
+	return false
+}
+
 var ResampleMethods = map[ResampleOption]string{
 	ResampleFillCenter:      "center",
 	ResampleFillTopLeft:     "left",
 	ResampleFillBottomRight: "right",
 	ResampleFit:             "fit",
 	ResampleResize:          "resize",
+	ResampleBlurExtend:      "blur",
+	ResampleFillSmart:       "smart",
+}
+
+// ResampleAnchors maps crop anchors to the names used in thumbnail file names.
+var ResampleAnchors = map[imaging.Anchor]string{
+	imaging.Center:      "center",
+	imaging.TopLeft:     "left",
+	imaging.BottomRight: "right",
+}
+
+// fillAnchors maps the fill methods to their default crop anchor.
+var fillAnchors = map[ResampleOption]imaging.Anchor{
+	ResampleFillCenter:      imaging.Center,
+	ResampleFillTopLeft:     imaging.TopLeft,
+	ResampleFillBottomRight: imaging.BottomRight,
+}
+
+// FillMethod returns the fill method with the specified name, e.g. "left" for ResampleFillTopLeft.
+func FillMethod(name string) (method ResampleOption, ok bool) {
+	for method = range fillAnchors {
+		if ResampleMethods[method] == name {
+			return method, true
+		}
+	}
+
+	return ResampleFillCenter, false
 }
 
 // ResampleOptions extracts filter, format, and method from resample options.
+// If multiple filters, formats, or methods are specified, the last one wins.
 func ResampleOptions(opts ...ResampleOption) (method ResampleOption, filter imaging.ResampleFilter, format fs.Type) {
 	method = ResampleFit
 	filter = imaging.Lanczos
@@ -36,10 +89,22 @@ func ResampleOptions(opts ...ResampleOption) (method ResampleOption, filter imag
 		switch option {
 		case ResamplePng:
 			format = fs.ImagePNG
+		case ResampleWebp:
+			format = fs.ImageWebP
+		case ResampleAvif:
+			format = fs.ImageAVIF
+		case ResampleGif:
+			format = fs.ImageGIF
 		case ResampleNearestNeighbor:
 			filter = imaging.NearestNeighbor
 		case ResampleDefault:
 			filter = Filter.Imaging()
+		case ResampleFilterLinear:
+			filter = ResampleLinear.Imaging()
+		case ResampleFilterCubic:
+			filter = ResampleCubic.Imaging()
+		case ResampleFilterLanczos:
+			filter = ResampleLanczos.Imaging()
 		case ResampleFillTopLeft:
 			method = ResampleFillTopLeft
 		case ResampleFillCenter:
@@ -50,8 +115,56 @@ func ResampleOptions(opts ...ResampleOption) (method ResampleOption, filter imag
 			method = ResampleFit
 		case ResampleResize:
 			method = ResampleResize
+		case ResampleBlurExtend:
+			method = ResampleBlurExtend
+		case ResampleFillSmart:
+			method = ResampleFillSmart
 		}
 	}
 
 	return method, filter, format
 }
+
+// ResampleAspect extracts the aspect and crop anchor from resample options. The fill methods imply
+// a square aspect and their respective anchor, which can be overridden with an anchor option.
+// If multiple anchors are specified, the last one wins.
+func ResampleAspect(opts ...ResampleOption) (square bool, anchor imaging.Anchor) {
+	anchor = imaging.Center
+
+	for _, option := range opts {
+		if a, ok := fillAnchors[option]; ok {
+			square = true
+			anchor = a
+			continue
+		}
+
+		switch option {
+		case ResampleSquare:
+			square = true
+		case ResampleAnchorCenter:
+			anchor = imaging.Center
+		case ResampleAnchorTopLeft:
+			anchor = imaging.TopLeft
+		case ResampleAnchorBottomRight:
+			anchor = imaging.BottomRight
+		}
+	}
+
+	return square, anchor
+}
+
+// ResampleName returns the name of the resample method and aspect used in thumbnail file names.
+func ResampleName(opts ...ResampleOption) string {
+	method, _, _ := ResampleOptions(opts...)
+	square, anchor := ResampleAspect(opts...)
+
+	if _, fill := fillAnchors[method]; fill {
+		return ResampleAnchors[anchor]
+	} else if !square || method == ResampleFillSmart {
+		return ResampleMethods[method]
+	} else if anchor == imaging.Center {
+		return ResampleMethods[method] + "_square"
+	}
+
+	return ResampleMethods[method] + "_square_" + ResampleAnchors[anchor]
+}
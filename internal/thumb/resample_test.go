@@ -0,0 +1,128 @@
+package thumb
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+// edgeContrast returns the average absolute difference between horizontally adjacent pixels.
+func edgeContrast(img image.Image) float64 {
+	gray := imaging.Grayscale(img)
+	b := gray.Bounds()
+
+	var sum float64
+
+	for y := 0; y < b.Dy(); y++ {
+		for x := 1; x < b.Dx(); x++ {
+			d := int(gray.NRGBAAt(x, y).R) - int(gray.NRGBAAt(x-1, y).R)
+
+			if d < 0 {
+				d = -d
+			}
+
+			sum += float64(d)
+		}
+	}
+
+	return sum / float64((b.Dx()-1)*b.Dy())
+}
+
+// stripes returns an image with black and white vertical stripes of the specified width.
+func stripes(width, height, stripe int) image.Image {
+	img := imaging.New(width, height, color.White)
+
+	for x := 0; x < width; x++ {
+		if (x/stripe)%2 == 0 {
+			for y := 0; y < height; y++ {
+				img.Set(x, y, color.Black)
+			}
+		}
+	}
+
+	return img
+}
+
+func TestResampleSharpen(t *testing.T) {
+	src := stripes(800, 800, 36)
+
+	t.Run("Downscale", func(t *testing.T) {
+		soft, err := Resample(src, 100, 100, ResampleFit, ResampleFilterLanczos)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sharp, err := Resample(src, 100, 100, ResampleFit, ResampleFilterLanczos, ResampleSharpen)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, soft.Bounds(), sharp.Bounds())
+		assert.Greater(t, edgeContrast(sharp), edgeContrast(soft))
+	})
+	t.Run("NearestNeighbor", func(t *testing.T) {
+		plain, err := Resample(src, 100, 100, ResampleFit, ResampleNearestNeighbor)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sharp, err := Resample(src, 100, 100, ResampleFit, ResampleNearestNeighbor, ResampleSharpen)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, edgeContrast(plain), edgeContrast(sharp))
+	})
+	t.Run("NotSmaller", func(t *testing.T) {
+		small := stripes(100, 100, 5)
+
+		plain, err := Resample(small, 200, 200, ResampleFit, ResampleFilterLanczos)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sharp, err := Resample(small, 200, 200, ResampleFit, ResampleFilterLanczos, ResampleSharpen)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, edgeContrast(plain), edgeContrast(sharp))
+	})
+}
+
+func TestOptionsSharpen(t *testing.T) {
+	assert.True(t, OptionsSharpen(ResampleFit, ResampleSharpen))
+	assert.False(t, OptionsSharpen(ResampleFit, ResampleFilterLanczos))
+	assert.False(t, OptionsSharpen())
+}
+
+func TestFillMethod(t *testing.T) {
+	t.Run("Left", func(t *testing.T) {
+		method, ok := FillMethod("left")
+		assert.True(t, ok)
+		assert.Equal(t, ResampleFillTopLeft, method)
+	})
+	t.Run("Right", func(t *testing.T) {
+		method, ok := FillMethod("right")
+		assert.True(t, ok)
+		assert.Equal(t, ResampleFillBottomRight, method)
+	})
+	t.Run("Fit", func(t *testing.T) {
+		method, ok := FillMethod("fit")
+		assert.False(t, ok)
+		assert.Equal(t, ResampleFillCenter, method)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, ok := FillMethod("")
+		assert.False(t, ok)
+	})
+}
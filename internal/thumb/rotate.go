@@ -1,6 +1,7 @@
 package thumb
 
 import (
+	"fmt"
 	"image"
 
 	"github.com/disintegration/imaging"
@@ -45,3 +46,43 @@ func Rotate(img image.Image, o int) image.Image {
 
 	return img
 }
+
+// orientationTransforms maps Exif orientation values to a horizontal flip followed by a clockwise rotation.
+var orientationTransforms = map[int]struct {
+	flip    bool
+	degrees int
+}{
+	OrientationUnspecified: {false, 0},
+	OrientationNormal:      {false, 0},
+	OrientationFlipH:       {true, 0},
+	OrientationRotate180:   {false, 180},
+	OrientationFlipV:       {true, 180},
+	OrientationTranspose:   {true, 270},
+	OrientationRotate270:   {false, 90},
+	OrientationTransverse:  {true, 90},
+	OrientationRotate90:    {false, 270},
+}
+
+// RotateOrientation returns the Exif orientation of an image after rotating it clockwise
+// by 90, 180, or 270 degrees.
+func RotateOrientation(o, degrees int) (int, error) {
+	if degrees != 90 && degrees != 180 && degrees != 270 {
+		return o, fmt.Errorf("thumb: invalid rotation of %d degrees", degrees)
+	}
+
+	t, ok := orientationTransforms[o]
+
+	if !ok {
+		return o, fmt.Errorf("thumb: invalid orientation %d", o)
+	}
+
+	t.degrees = (t.degrees + degrees) % 360
+
+	for result, r := range orientationTransforms {
+		if result != OrientationUnspecified && r.flip == t.flip && r.degrees == t.degrees {
+			return result, nil
+		}
+	}
+
+	return o, fmt.Errorf("thumb: invalid orientation %d", o)
+}
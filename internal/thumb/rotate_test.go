@@ -0,0 +1,59 @@
+package thumb
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotateOrientation(t *testing.T) {
+	// Image with different colors in each corner, so that all transformations can be distinguished.
+	img := imaging.New(3, 2, color.NRGBA{A: 255})
+	img.Set(0, 0, color.NRGBA{R: 255, A: 255})
+	img.Set(2, 0, color.NRGBA{G: 255, A: 255})
+	img.Set(0, 1, color.NRGBA{B: 255, A: 255})
+
+	// Rotates the image clockwise.
+	rotate := map[int]func(image.Image) *image.NRGBA{
+		90:  imaging.Rotate270,
+		180: imaging.Rotate180,
+		270: imaging.Rotate90,
+	}
+
+	for o := OrientationNormal; o <= OrientationRotate90; o++ {
+		for degrees, rotateCW := range rotate {
+			result, err := RotateOrientation(o, degrees)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected := rotateCW(Rotate(img, o))
+			actual := imaging.Clone(Rotate(img, result))
+
+			assert.Equal(t, expected.Pix, actual.Pix, "orientation %d rotated by %d degrees", o, degrees)
+			assert.Equal(t, expected.Rect, actual.Rect, "orientation %d rotated by %d degrees", o, degrees)
+		}
+	}
+
+	t.Run("Unspecified", func(t *testing.T) {
+		result, err := RotateOrientation(OrientationUnspecified, 90)
+
+		assert.NoError(t, err)
+		assert.Equal(t, OrientationRotate270, result)
+	})
+	t.Run("InvalidDegrees", func(t *testing.T) {
+		result, err := RotateOrientation(OrientationNormal, 45)
+
+		assert.Error(t, err)
+		assert.Equal(t, OrientationNormal, result)
+	})
+	t.Run("InvalidOrientation", func(t *testing.T) {
+		_, err := RotateOrientation(9, 90)
+
+		assert.Error(t, err)
+	})
+}
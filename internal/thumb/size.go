@@ -2,6 +2,9 @@ package thumb
 
 import (
 	"image"
+	"os"
+
+	"github.com/disintegration/imaging"
 )
 
 type Size struct {
@@ -40,6 +43,19 @@ func (s Size) FromFile(fileName, fileHash, cachePath string, fileOrientation int
 	return FromFile(fileName, fileHash, cachePath, s.Width, s.Height, fileOrientation, s.Options...)
 }
 
+// Regenerate replaces the cached thumbnail with the matching size, e.g. if it is corrupt, and returns the filename.
+func (s Size) Regenerate(fileName, fileHash, cachePath string, fileOrientation int) (string, error) {
+	thumbName, err := s.FileName(fileHash, cachePath)
+
+	if err != nil {
+		return "", err
+	} else if err = os.Remove(thumbName); err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+
+	return s.FromFile(fileName, fileHash, cachePath, fileOrientation)
+}
+
 // Create creates a thumbnail with the matching size and returns it as image.Image.
 func (s Size) Create(img image.Image, fileName string) (image.Image, error) {
 	return Create(img, fileName, s.Width, s.Height, s.Options...)
@@ -55,6 +71,30 @@ func (s Size) ResolvedName(hash, thumbPath string) (string, error) {
 	return ResolvedName(hash, thumbPath, s.Width, s.Height, s.Options...)
 }
 
+// FillCenter tests if the thumbnail is filled from the image center.
+func (s Size) FillCenter() bool {
+	method, _, _ := ResampleOptions(s.Options...)
+	_, anchor := ResampleAspect(s.Options...)
+	return method == ResampleFillCenter && anchor == imaging.Center
+}
+
+// Reframe returns a copy of the size that is filled using the specified method instead of the image center.
+func (s Size) Reframe(method ResampleOption) Size {
+	opts := make([]ResampleOption, len(s.Options))
+
+	for i, o := range s.Options {
+		if o == ResampleFillCenter {
+			opts[i] = method
+		} else {
+			opts[i] = o
+		}
+	}
+
+	s.Options = opts
+
+	return s
+}
+
 // Skip checks if the thumbnail size is too large for the image and can be skipped.
 func (s Size) Skip(img image.Image) bool {
 	if !s.Fit || !img.Bounds().In(s.Bounds()) {
@@ -1,10 +1,13 @@
 package thumb
 
 import (
+	"os"
 	"testing"
 
 	"github.com/disintegration/imaging"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 func TestSize_Skip(t *testing.T) {
@@ -77,3 +80,57 @@ func TestSize_Skip(t *testing.T) {
 		assert.True(t, size.Skip(img))
 	})
 }
+
+func TestSize_FillCenter(t *testing.T) {
+	assert.True(t, Sizes[Tile224].FillCenter())
+	assert.False(t, Sizes[Left224].FillCenter())
+	assert.False(t, Sizes[Fit720].FillCenter())
+}
+
+func TestSize_Reframe(t *testing.T) {
+	size := Sizes[Tile224].Reframe(ResampleFillTopLeft)
+
+	assert.Equal(t, Tile224, size.Name)
+	assert.Equal(t, []ResampleOption{ResampleFillTopLeft, ResampleDefault}, size.Options)
+	assert.False(t, size.FillCenter())
+
+	// The original size must not be changed.
+	assert.Equal(t, []ResampleOption{ResampleFillCenter, ResampleDefault}, Sizes[Tile224].Options)
+	assert.Equal(t, Sizes[Fit720].Options, Sizes[Fit720].Reframe(ResampleFillTopLeft).Options)
+}
+
+func TestSize_Regenerate(t *testing.T) {
+	size := Sizes[Tile50]
+	src := "testdata/example.jpg"
+	hash := "123456789098765433"
+
+	fileName, err := size.FromFile(src, hash, "testdata", OrientationNormal)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Remove(fileName)
+
+	// Corrupt the cached thumbnail.
+	if err = os.WriteFile(fileName, []byte("corrupt"), fs.ModeFile); err != nil {
+		t.Fatal(err)
+	}
+
+	regenerated, err := size.Regenerate(src, hash, "testdata", OrientationNormal)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, fileName, regenerated)
+
+	img, err := imaging.Open(regenerated)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, size.Width, img.Bounds().Dx())
+	assert.Equal(t, size.Height, img.Bounds().Dy())
+}
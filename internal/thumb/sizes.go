@@ -1,9 +1,19 @@
 package thumb
 
+import "fmt"
+
+// SizeLimitDefault is the default max width and height of resampled images in pixels (8K Ultra HD).
+const SizeLimitDefault = 7680
+
+// SizeLimit is the max width and height of resampled images in pixels, as resampling to larger sizes
+// may exhaust memory. It is independent of the thumbnail size settings and disabled if not positive.
+var SizeLimit = SizeLimitDefault
+
 var (
 	SizePrecached = 2048
 	SizeUncached  = 7680
 	Filter        = ResampleLanczos
+	SharpenSigma  = 0.5 // Strength of the unsharp mask applied with ResampleSharpen, disabled if not positive.
 )
 
 // MaxSize returns the max supported thumb size in pixels.
@@ -20,6 +30,17 @@ func InvalidSize(size int) bool {
 	return size < 0 || size > MaxSize()
 }
 
+// CheckSize returns an error if the width or height in pixels is invalid or exceeds the size limit.
+func CheckSize(width, height int) error {
+	if width < 0 || height < 0 {
+		return fmt.Errorf("thumb: invalid size %dx%d", width, height)
+	} else if SizeLimit > 0 && (width > SizeLimit || height > SizeLimit) {
+		return fmt.Errorf("thumb: %dx%d %w of %dpx", width, height, ErrSizeExceedsLimit, SizeLimit)
+	}
+
+	return nil
+}
+
 // SizeMap maps size names to sizes.
 type SizeMap map[Name]Size
 
@@ -54,3 +54,29 @@ func TestResampleFilter_Imaging(t *testing.T) {
 		assert.Equal(t, float64(1), r.Support)
 	})
 }
+
+func TestCheckSize(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		assert.NoError(t, CheckSize(0, 0))
+		assert.NoError(t, CheckSize(SizeLimit, SizeLimit))
+		assert.ErrorIs(t, CheckSize(SizeLimit+1, 100), ErrSizeExceedsLimit)
+		assert.ErrorIs(t, CheckSize(100, SizeLimitDefault*10), ErrSizeExceedsLimit)
+		assert.Error(t, CheckSize(-1, 100))
+	})
+	t.Run("ThumbSizes", func(t *testing.T) {
+		uncached, precached := SizeUncached, SizePrecached
+		SizeUncached, SizePrecached = 720, 720
+		defer func() { SizeUncached, SizePrecached = uncached, precached }()
+
+		// The size limit does not depend on the thumbnail size settings.
+		assert.NoError(t, CheckSize(1920, 1920))
+		assert.ErrorIs(t, CheckSize(SizeLimit+1, 100), ErrSizeExceedsLimit)
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		limit := SizeLimit
+		SizeLimit = 0
+		defer func() { SizeLimit = limit }()
+
+		assert.NoError(t, CheckSize(SizeLimitDefault*2, 100))
+	})
+}
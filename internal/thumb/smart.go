@@ -0,0 +1,178 @@
+package thumb
+
+import (
+	"image"
+	"math"
+
+	"github.com/disintegration/imaging"
+)
+
+// SmartFillSize is the maximum width and height of the downscaled copy used to detect text and other details.
+const SmartFillSize = 256
+
+// SmartFillEdge is the minimum brightness difference between neighboring pixels to detect an edge.
+const SmartFillEdge = 48
+
+// SmartFillDensity is the minimum ratio of edge pixels in a row or column to be considered as containing text.
+const SmartFillDensity = 0.1
+
+// SmartFill crops an image to fill the specified box like imaging.Fill, but moves the crop window so that
+// regions with a high edge density, such as text and watermarks, are preserved. If these regions do not fit
+// into the box, the image is scaled to fit and the remaining area is filled using BlurExtend instead.
+func SmartFill(img image.Image, width, height int, filter imaging.ResampleFilter) image.Image {
+	b := img.Bounds()
+	srcW, srcH := b.Dx(), b.Dy()
+
+	if width <= 0 || height <= 0 || srcW <= 0 || srcH <= 0 {
+		return imaging.Fill(img, width, height, imaging.Center, filter)
+	}
+
+	srcAspect := float64(srcW) / float64(srcH)
+	dstAspect := float64(width) / float64(height)
+
+	// Nothing to crop if the aspect ratios match.
+	if math.Abs(srcAspect-dstAspect) < 0.01 {
+		return imaging.Resize(img, width, height, filter)
+	}
+
+	// Detect the edge density along the axis that needs to be cropped.
+	vertical := srcAspect < dstAspect
+	density := smartFillDensity(img, vertical)
+
+	// Ratio of the crop axis that fits into the box.
+	keep := srcAspect / dstAspect
+
+	if !vertical {
+		keep = dstAspect / srcAspect
+	}
+
+	start, ok := smartFillStart(density, keep)
+
+	if !ok {
+		return BlurExtend(img, width, height, filter)
+	}
+
+	var rect image.Rectangle
+
+	if vertical {
+		cropH := int(math.Round(float64(srcH) * keep))
+		y := b.Min.Y + int(math.Round(float64(srcH)*start))
+		rect = image.Rect(b.Min.X, y, b.Max.X, y+cropH)
+	} else {
+		cropW := int(math.Round(float64(srcW) * keep))
+		x := b.Min.X + int(math.Round(float64(srcW)*start))
+		rect = image.Rect(x, b.Min.Y, x+cropW, b.Max.Y)
+	}
+
+	return imaging.Resize(imaging.Crop(img, rect.Intersect(b)), width, height, filter)
+}
+
+// smartFillDensity returns the ratio of edge pixels for each row, if vertical is true, or column of
+// a downscaled grayscale copy of the image.
+func smartFillDensity(img image.Image, vertical bool) []float64 {
+	gray := imaging.Grayscale(imaging.Fit(img, SmartFillSize, SmartFillSize, imaging.Box))
+
+	w, h := gray.Bounds().Dx(), gray.Bounds().Dy()
+
+	lum := func(x, y int) int {
+		return int(gray.Pix[y*gray.Stride+x*4])
+	}
+
+	var density []float64
+
+	if vertical {
+		density = make([]float64, h)
+	} else {
+		density = make([]float64, w)
+	}
+
+	for y := 0; y < h-1; y++ {
+		for x := 0; x < w-1; x++ {
+			c := lum(x, y)
+			dx, dy := lum(x+1, y)-c, lum(x, y+1)-c
+
+			if dx < 0 {
+				dx = -dx
+			}
+
+			if dy < 0 {
+				dy = -dy
+			}
+
+			if dx+dy < SmartFillEdge {
+				continue
+			}
+
+			if vertical {
+				density[y]++
+			} else {
+				density[x]++
+			}
+		}
+	}
+
+	n := float64(w)
+
+	if !vertical {
+		n = float64(h)
+	}
+
+	for i := range density {
+		density[i] /= n
+	}
+
+	return density
+}
+
+// smartFillStart returns the start of the crop window as ratio of the axis length, based on the edge density
+// profile and the ratio that fits into the box, or false if all details cannot be included.
+func smartFillStart(density []float64, keep float64) (float64, bool) {
+	n := len(density)
+
+	if n == 0 || keep >= 1 {
+		return 0, true
+	}
+
+	var mean float64
+
+	for _, d := range density {
+		mean += d
+	}
+
+	mean /= float64(n)
+
+	// Lines that contain significantly more edges than average are likely to contain text.
+	threshold := math.Max(SmartFillDensity, 2*mean)
+	first, last := -1, -1
+
+	for i, d := range density {
+		if d < threshold {
+			continue
+		}
+
+		if first < 0 {
+			first = i
+		}
+
+		last = i
+	}
+
+	center := (1 - keep) / 2
+
+	// Use the image center if no details were found.
+	if first < 0 {
+		return center, true
+	}
+
+	from := float64(first) / float64(n)
+	to := float64(last+1) / float64(n)
+
+	if to-from > keep {
+		return 0, false
+	}
+
+	// Move the window as little as possible from the center to include all details.
+	start := math.Min(math.Max(center, to-keep), from)
+
+	return math.Min(math.Max(start, 0), 1-keep), true
+}
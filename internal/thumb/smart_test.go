@@ -0,0 +1,112 @@
+package thumb
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+// smartTestImage returns a light gray image with rows of dark text-like glyphs in the specified areas.
+func smartTestImage(width, height int, text ...image.Rectangle) *image.NRGBA {
+	img := imaging.New(width, height, color.NRGBA{R: 230, G: 230, B: 230, A: 255})
+
+	for _, r := range text {
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			for x := r.Min.X; x < r.Max.X; x++ {
+				if (x-r.Min.X)%8 < 5 && (y-r.Min.Y)%14 < 10 {
+					img.Set(x, y, color.NRGBA{R: 10, G: 10, B: 10, A: 255})
+				}
+			}
+		}
+	}
+
+	return img
+}
+
+// smartTestDark returns the number of dark pixels in the specified area.
+func smartTestDark(img image.Image, r image.Rectangle) (count int) {
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			if c := color.GrayModel.Convert(img.At(x, y)).(color.Gray); c.Y < 100 {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+func TestSmartFill(t *testing.T) {
+	t.Run("BottomText", func(t *testing.T) {
+		img := smartTestImage(200, 400, image.Rect(10, 350, 190, 390))
+
+		// Filling from the center cuts off the text.
+		center := imaging.Fill(img, 100, 100, imaging.Center, imaging.Lanczos)
+		assert.Equal(t, 0, smartTestDark(center, center.Bounds()))
+
+		result := SmartFill(img, 100, 100, imaging.Lanczos)
+
+		assert.Equal(t, 100, result.Bounds().Dx())
+		assert.Equal(t, 100, result.Bounds().Dy())
+
+		// The text region must be preserved at the bottom of the thumbnail.
+		assert.Greater(t, smartTestDark(result, image.Rect(0, 75, 100, 95)), 200)
+		assert.Equal(t, 0, smartTestDark(result, image.Rect(0, 0, 100, 70)))
+	})
+	t.Run("RightText", func(t *testing.T) {
+		img := smartTestImage(400, 200, image.Rect(330, 20, 390, 180))
+
+		result := SmartFill(img, 100, 100, imaging.Lanczos)
+
+		assert.Equal(t, 100, result.Bounds().Dx())
+		assert.Equal(t, 100, result.Bounds().Dy())
+		assert.Greater(t, smartTestDark(result, image.Rect(65, 0, 100, 100)), 500)
+	})
+	t.Run("TopAndBottomText", func(t *testing.T) {
+		img := smartTestImage(200, 400, image.Rect(10, 10, 190, 50), image.Rect(10, 350, 190, 390))
+
+		result := SmartFill(img, 100, 100, imaging.Lanczos)
+
+		assert.Equal(t, 100, result.Bounds().Dx())
+		assert.Equal(t, 100, result.Bounds().Dy())
+
+		// Falls back to fit and pad, so that the text at both edges is preserved.
+		assert.Greater(t, smartTestDark(result, image.Rect(25, 0, 75, 15)), 50)
+		assert.Greater(t, smartTestDark(result, image.Rect(25, 85, 75, 100)), 50)
+	})
+	t.Run("NoText", func(t *testing.T) {
+		img := blurTestImage(400, 200)
+
+		result := SmartFill(img, 100, 100, imaging.Lanczos)
+		center := imaging.Fill(img, 100, 100, imaging.Center, imaging.Lanczos)
+
+		assert.Equal(t, center.Bounds(), result.Bounds())
+		assert.Equal(t, center.At(50, 50), result.At(50, 50))
+	})
+	t.Run("SameAspectRatio", func(t *testing.T) {
+		result := SmartFill(smartTestImage(200, 200), 100, 100, imaging.Lanczos)
+
+		assert.Equal(t, 100, result.Bounds().Dx())
+		assert.Equal(t, 100, result.Bounds().Dy())
+	})
+}
+
+func TestResample_FillSmart(t *testing.T) {
+	img := smartTestImage(200, 400, image.Rect(10, 350, 190, 390))
+	result, err := Resample(img, 100, 100, ResampleFillSmart, ResampleDefault)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 100, result.Bounds().Dx())
+	assert.Equal(t, 100, result.Bounds().Dy())
+	assert.Greater(t, smartTestDark(result, image.Rect(0, 75, 100, 95)), 200)
+
+	method, _, _ := ResampleOptions(ResampleFillSmart, ResampleDefault)
+	assert.Equal(t, ResampleFillSmart, method)
+	assert.Equal(t, "smart", ResampleMethods[method])
+}
@@ -0,0 +1,411 @@
+package thumb
+
+import (
+	"errors"
+	"image"
+	"math"
+
+	"github.com/disintegration/imaging"
+)
+
+// ThumbHashMaxSize is the maximum image width and height used to compute a ThumbHash.
+const ThumbHashMaxSize = 100
+
+// ThumbHashDecodedSize is the maximum width and height of decoded ThumbHash images.
+const ThumbHashDecodedSize = 32
+
+// ErrThumbHashInvalid is returned when a ThumbHash could not be decoded.
+var ErrThumbHashInvalid = errors.New("invalid thumbhash")
+
+// ThumbHash returns the ThumbHash placeholder of an image, see https://evanw.github.io/thumbhash/.
+func ThumbHash(img image.Image) []byte {
+	if img == nil {
+		return nil
+	}
+
+	small := imaging.Fit(img, ThumbHashMaxSize, ThumbHashMaxSize, imaging.Linear)
+	w, h := small.Bounds().Dx(), small.Bounds().Dy()
+
+	if w == 0 || h == 0 {
+		return nil
+	}
+
+	// Determine the average color.
+	var avgR, avgG, avgB, avgA float64
+
+	for i := 0; i < w*h; i++ {
+		alpha := float64(small.Pix[i*4+3]) / 255
+		avgR += alpha / 255 * float64(small.Pix[i*4])
+		avgG += alpha / 255 * float64(small.Pix[i*4+1])
+		avgB += alpha / 255 * float64(small.Pix[i*4+2])
+		avgA += alpha
+	}
+
+	if avgA > 0 {
+		avgR /= avgA
+		avgG /= avgA
+		avgB /= avgA
+	}
+
+	hasAlpha := avgA < float64(w*h)
+
+	// Use fewer luminance bits if there's alpha.
+	lLimit := 7
+
+	if hasAlpha {
+		lLimit = 5
+	}
+
+	maxWH := float64(maxInt(w, h))
+	lx := maxInt(1, int(math.Round(float64(lLimit*w)/maxWH)))
+	ly := maxInt(1, int(math.Round(float64(lLimit*h)/maxWH)))
+
+	l := make([]float64, w*h) // luminance
+	p := make([]float64, w*h) // yellow - blue
+	q := make([]float64, w*h) // red - green
+	a := make([]float64, w*h) // alpha
+
+	// Convert the image from RGBA to LPQA, composited atop the average color.
+	for i := 0; i < w*h; i++ {
+		alpha := float64(small.Pix[i*4+3]) / 255
+		r := avgR*(1-alpha) + alpha/255*float64(small.Pix[i*4])
+		g := avgG*(1-alpha) + alpha/255*float64(small.Pix[i*4+1])
+		b := avgB*(1-alpha) + alpha/255*float64(small.Pix[i*4+2])
+		l[i] = (r + g + b) / 3
+		p[i] = (r+g)/2 - b
+		q[i] = r - g
+		a[i] = alpha
+	}
+
+	// Encode using the DCT into DC (constant) and normalized AC (varying) terms.
+	encodeChannel := func(channel []float64, nx, ny int) (dc float64, ac []float64, scale float64) {
+		fx := make([]float64, w)
+
+		for cy := 0; cy < ny; cy++ {
+			for cx := 0; cx*ny < nx*(ny-cy); cx++ {
+				var f float64
+
+				for x := 0; x < w; x++ {
+					fx[x] = math.Cos(math.Pi / float64(w) * float64(cx) * (float64(x) + 0.5))
+				}
+
+				for y := 0; y < h; y++ {
+					fy := math.Cos(math.Pi / float64(h) * float64(cy) * (float64(y) + 0.5))
+
+					for x := 0; x < w; x++ {
+						f += channel[x+y*w] * fx[x] * fy
+					}
+				}
+
+				f /= float64(w * h)
+
+				if cx > 0 || cy > 0 {
+					ac = append(ac, f)
+					scale = math.Max(scale, math.Abs(f))
+				} else {
+					dc = f
+				}
+			}
+		}
+
+		if scale > 0 {
+			for i := range ac {
+				ac[i] = 0.5 + 0.5/scale*ac[i]
+			}
+		}
+
+		return dc, ac, scale
+	}
+
+	lDC, lAC, lScale := encodeChannel(l, maxInt(3, lx), maxInt(3, ly))
+	pDC, pAC, pScale := encodeChannel(p, 3, 3)
+	qDC, qAC, qScale := encodeChannel(q, 3, 3)
+
+	var aDC, aScale float64
+	var aAC []float64
+
+	if hasAlpha {
+		aDC, aAC, aScale = encodeChannel(a, 5, 5)
+	}
+
+	// Write the constants.
+	isLandscape := w > h
+	header24 := round(63*lDC) | round(31.5+31.5*pDC)<<6 | round(31.5+31.5*qDC)<<12 | round(31*lScale)<<18 | boolBit(hasAlpha)<<23
+	header16 := round(63*pScale)<<3 | round(63*qScale)<<9 | boolBit(isLandscape)<<15
+
+	if isLandscape {
+		header16 |= ly
+	} else {
+		header16 |= lx
+	}
+
+	hash := []byte{byte(header24), byte(header24 >> 8), byte(header24 >> 16), byte(header16), byte(header16 >> 8)}
+	acs := [][]float64{lAC, pAC, qAC}
+
+	if hasAlpha {
+		hash = append(hash, byte(round(15*aDC)|round(15*aScale)<<4))
+		acs = append(acs, aAC)
+	}
+
+	// Write the varying factors.
+	acStart := len(hash)
+	acIndex := 0
+
+	for _, ac := range acs {
+		for _, f := range ac {
+			i := acStart + acIndex>>1
+
+			if i >= len(hash) {
+				hash = append(hash, 0)
+			}
+
+			hash[i] |= byte(round(15*f) << ((acIndex & 1) << 2))
+			acIndex++
+		}
+	}
+
+	return hash
+}
+
+// ThumbHashAspectRatio returns the approximate aspect ratio of the original image.
+func ThumbHashAspectRatio(hash []byte) float64 {
+	if len(hash) < 5 {
+		return 0
+	}
+
+	header := int(hash[3])
+	hasAlpha := hash[2]&0x80 != 0
+	isLandscape := hash[4]&0x80 != 0
+
+	var lx, ly int
+
+	if isLandscape {
+		ly = header & 7
+
+		if hasAlpha {
+			lx = 5
+		} else {
+			lx = 7
+		}
+	} else {
+		lx = header & 7
+
+		if hasAlpha {
+			ly = 5
+		} else {
+			ly = 7
+		}
+	}
+
+	if ly == 0 {
+		return 0
+	}
+
+	return float64(lx) / float64(ly)
+}
+
+// DecodeThumbHash returns the placeholder image encoded in a ThumbHash.
+func DecodeThumbHash(hash []byte) (*image.NRGBA, error) {
+	ratio := ThumbHashAspectRatio(hash)
+
+	if ratio <= 0 {
+		return nil, ErrThumbHashInvalid
+	}
+
+	// Read the constants.
+	header24 := int(hash[0]) | int(hash[1])<<8 | int(hash[2])<<16
+	header16 := int(hash[3]) | int(hash[4])<<8
+	lDC := float64(header24&63) / 63
+	pDC := float64((header24>>6)&63)/31.5 - 1
+	qDC := float64((header24>>12)&63)/31.5 - 1
+	lScale := float64((header24>>18)&31) / 31
+	hasAlpha := header24>>23 != 0
+	pScale := float64((header16>>3)&63) / 63
+	qScale := float64((header16>>9)&63) / 63
+	isLandscape := header16>>15 != 0
+
+	lLimit := 7
+
+	if hasAlpha {
+		lLimit = 5
+	}
+
+	lx, ly := maxInt(3, header16&7), maxInt(3, lLimit)
+
+	if isLandscape {
+		lx, ly = maxInt(3, lLimit), maxInt(3, header16&7)
+	}
+
+	aDC, aScale := 1.0, 0.0
+	acStart := 5
+
+	if hasAlpha {
+		if len(hash) < 6 {
+			return nil, ErrThumbHashInvalid
+		}
+
+		aDC = float64(hash[5]&15) / 15
+		aScale = float64(hash[5]>>4) / 15
+		acStart = 6
+	}
+
+	// Read the varying factors, with saturation boosted by 1.25x to compensate for quantization.
+	acIndex := 0
+	valid := true
+
+	decodeChannel := func(nx, ny int, scale float64) (ac []float64) {
+		for cy := 0; cy < ny; cy++ {
+			cx := 0
+
+			if cy == 0 {
+				cx = 1
+			}
+
+			for ; cx*ny < nx*(ny-cy); cx++ {
+				i := acStart + acIndex>>1
+
+				if i >= len(hash) {
+					valid = false
+					return ac
+				}
+
+				ac = append(ac, (float64((hash[i]>>((acIndex&1)<<2))&15)/7.5-1)*scale)
+				acIndex++
+			}
+		}
+
+		return ac
+	}
+
+	lAC := decodeChannel(lx, ly, lScale)
+	pAC := decodeChannel(3, 3, pScale*1.25)
+	qAC := decodeChannel(3, 3, qScale*1.25)
+
+	var aAC []float64
+
+	if hasAlpha {
+		aAC = decodeChannel(5, 5, aScale)
+	}
+
+	if !valid {
+		return nil, ErrThumbHashInvalid
+	}
+
+	// Decode using the DCT into RGB.
+	w, h := ThumbHashDecodedSize, ThumbHashDecodedSize
+
+	if ratio > 1 {
+		h = round(ThumbHashDecodedSize / ratio)
+	} else {
+		w = round(ThumbHashDecodedSize * ratio)
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+
+	nx, ny := maxInt(lx, 3), maxInt(ly, 3)
+
+	if hasAlpha {
+		nx, ny = maxInt(lx, 5), maxInt(ly, 5)
+	}
+
+	fx := make([]float64, nx)
+	fy := make([]float64, ny)
+
+	for y, i := 0, 0; y < h; y++ {
+		for x := 0; x < w; x, i = x+1, i+4 {
+			l, p, q, a := lDC, pDC, qDC, aDC
+
+			// Precompute the coefficients.
+			for cx := 0; cx < nx; cx++ {
+				fx[cx] = math.Cos(math.Pi / float64(w) * (float64(x) + 0.5) * float64(cx))
+			}
+
+			for cy := 0; cy < ny; cy++ {
+				fy[cy] = math.Cos(math.Pi / float64(h) * (float64(y) + 0.5) * float64(cy))
+			}
+
+			// Decode L.
+			for cy, j := 0, 0; cy < ly; cy++ {
+				cx := 0
+
+				if cy == 0 {
+					cx = 1
+				}
+
+				for fy2 := fy[cy] * 2; cx*ly < lx*(ly-cy); cx, j = cx+1, j+1 {
+					l += lAC[j] * fx[cx] * fy2
+				}
+			}
+
+			// Decode P and Q.
+			for cy, j := 0, 0; cy < 3; cy++ {
+				cx := 0
+
+				if cy == 0 {
+					cx = 1
+				}
+
+				for fy2 := fy[cy] * 2; cx < 3-cy; cx, j = cx+1, j+1 {
+					f := fx[cx] * fy2
+					p += pAC[j] * f
+					q += qAC[j] * f
+				}
+			}
+
+			// Decode A.
+			if hasAlpha {
+				for cy, j := 0, 0; cy < 5; cy++ {
+					cx := 0
+
+					if cy == 0 {
+						cx = 1
+					}
+
+					for fy2 := fy[cy] * 2; cx < 5-cy; cx, j = cx+1, j+1 {
+						a += aAC[j] * fx[cx] * fy2
+					}
+				}
+			}
+
+			// Convert to RGB.
+			b := l - 2.0/3.0*p
+			r := (3*l - b + q) / 2
+			g := r - q
+
+			img.Pix[i] = clampByte(r)
+			img.Pix[i+1] = clampByte(g)
+			img.Pix[i+2] = clampByte(b)
+			img.Pix[i+3] = clampByte(a)
+		}
+	}
+
+	return img, nil
+}
+
+// maxInt returns the larger of two integers.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+// round rounds a float to the nearest integer.
+func round(f float64) int {
+	return int(math.Round(f))
+}
+
+// boolBit returns 1 if b is true, and 0 otherwise.
+func boolBit(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// clampByte converts a color channel value in the range 0 to 1 to a byte.
+func clampByte(f float64) uint8 {
+	return uint8(math.Max(0, 255*math.Min(1, f)))
+}
@@ -0,0 +1,103 @@
+package thumb
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThumbHash(t *testing.T) {
+	t.Run("Deterministic", func(t *testing.T) {
+		img, err := Open("testdata/example.jpg", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hash := ThumbHash(img)
+
+		assert.GreaterOrEqual(t, len(hash), 20)
+		assert.LessOrEqual(t, len(hash), 25)
+		assert.Equal(t, hash, ThumbHash(img))
+	})
+	t.Run("Alpha", func(t *testing.T) {
+		img := image.NewNRGBA(image.Rect(0, 0, 40, 80))
+
+		for y := 0; y < 80; y++ {
+			for x := 0; x < 40; x++ {
+				img.Set(x, y, color.NRGBA{R: 200, G: 50, B: 50, A: uint8(y * 3)})
+			}
+		}
+
+		hash := ThumbHash(img)
+
+		if assert.GreaterOrEqual(t, len(hash), 6) {
+			assert.NotEqual(t, byte(0), hash[2]&0x80)
+		}
+	})
+	t.Run("Nil", func(t *testing.T) {
+		assert.Nil(t, ThumbHash(nil))
+	})
+}
+
+func TestDecodeThumbHash(t *testing.T) {
+	t.Run("Landscape", func(t *testing.T) {
+		img, err := Open("testdata/example.jpg", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hash := ThumbHash(img)
+		ratio := float64(img.Bounds().Dx()) / float64(img.Bounds().Dy())
+
+		assert.InDelta(t, ratio, ThumbHashAspectRatio(hash), 0.5)
+
+		decoded, err := DecodeThumbHash(hash)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, ThumbHashDecodedSize, decoded.Bounds().Dx())
+		assert.Equal(t, round(ThumbHashDecodedSize/ThumbHashAspectRatio(hash)), decoded.Bounds().Dy())
+
+		again, err := DecodeThumbHash(hash)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, decoded.Pix, again.Pix)
+	})
+	t.Run("Portrait", func(t *testing.T) {
+		img := image.NewNRGBA(image.Rect(0, 0, 50, 100))
+
+		for y := 0; y < 100; y++ {
+			for x := 0; x < 50; x++ {
+				img.Set(x, y, color.NRGBA{R: uint8(x * 5), G: uint8(y * 2), B: 100, A: 255})
+			}
+		}
+
+		decoded, err := DecodeThumbHash(ThumbHash(img))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, ThumbHashDecodedSize, decoded.Bounds().Dy())
+		assert.Equal(t, 18, decoded.Bounds().Dx())
+
+		// Decoded images are opaque if the original has no alpha channel.
+		assert.Equal(t, uint8(255), decoded.NRGBAAt(9, 16).A)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := DecodeThumbHash(nil)
+		assert.Equal(t, ErrThumbHashInvalid, err)
+
+		_, err = DecodeThumbHash([]byte{1, 2, 3, 7, 0})
+		assert.Equal(t, ErrThumbHashInvalid, err)
+	})
+}
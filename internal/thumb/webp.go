@@ -0,0 +1,30 @@
+package thumb
+
+import (
+	"image"
+	"os/exec"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// WebpEncoderBin is the name of the WebP encoder executable, see https://developers.google.com/speed/webp/docs/cwebp.
+var WebpEncoderBin = "cwebp"
+
+// WebpEncoderAvailable checks if the WebP encoder executable can be found.
+func WebpEncoderAvailable() bool {
+	_, err := exec.LookPath(WebpEncoderBin)
+	return err == nil
+}
+
+// SaveWebp encodes an image as lossy WebP with the specified quality and saves it.
+func SaveWebp(img image.Image, fileName string, quality Quality) error {
+	encoderBin, err := exec.LookPath(WebpEncoderBin)
+
+	if err != nil {
+		return ErrWebpEncoderNotFound
+	}
+
+	return saveEncoded(img, fileName, encoderBin, fs.ImageWebP, func(src, dst string) []string {
+		return []string{"-quiet", "-q", quality.String(), src, "-o", dst}
+	})
+}
@@ -0,0 +1,137 @@
+package thumb
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestSaveWebp(t *testing.T) {
+	t.Run("EncoderNotFound", func(t *testing.T) {
+		encoderBin := WebpEncoderBin
+		WebpEncoderBin = "cwebp-not-found"
+		defer func() { WebpEncoderBin = encoderBin }()
+
+		img, err := imaging.Open("testdata/example.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fileName := filepath.Join(t.TempDir(), "example.webp")
+
+		assert.False(t, WebpEncoderAvailable())
+		assert.ErrorIs(t, SaveWebp(img, fileName, WebpQuality), ErrWebpEncoderNotFound)
+		assert.False(t, fs.FileExists(fileName))
+	})
+	t.Run("Ok", func(t *testing.T) {
+		if !WebpEncoderAvailable() {
+			t.Skip("webp encoder not found")
+		}
+
+		img, err := imaging.Open("testdata/example.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dir := t.TempDir()
+		fileName := filepath.Join(dir, "example.webp")
+
+		if err = SaveWebp(img, fileName, WebpQuality); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, fs.ImageWebP, fs.FileType(fileName))
+
+		// The temporary PNG file must be removed.
+		files, err := os.ReadDir(dir)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, files, 1)
+	})
+	t.Run("Quality", func(t *testing.T) {
+		if !WebpEncoderAvailable() {
+			t.Skip("webp encoder not found")
+		}
+
+		img, err := imaging.Open("testdata/example.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dir := t.TempDir()
+		lowName := filepath.Join(dir, "low.webp")
+		highName := filepath.Join(dir, "high.webp")
+
+		if err = SaveWebp(img, lowName, QualityWorst); err != nil {
+			t.Fatal(err)
+		} else if err = SaveWebp(img, highName, QualityBest); err != nil {
+			t.Fatal(err)
+		}
+
+		lowInfo, err := os.Stat(lowName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		highInfo, err := os.Stat(highName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Less(t, lowInfo.Size(), highInfo.Size())
+	})
+}
+
+func TestCreate_Webp(t *testing.T) {
+	if !WebpEncoderAvailable() {
+		t.Skip("webp encoder not found")
+	}
+
+	img, err := imaging.Open("testdata/example.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fileName, err := FileName("193456789098765432", t.TempDir(), 100, 100, ResampleFillCenter, ResampleWebp)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, ".webp", filepath.Ext(fileName))
+
+	if _, err = Create(img, fileName, 100, 100, ResampleFillCenter, ResampleWebp); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := imaging.Open(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 100, result.Bounds().Dx())
+	assert.Equal(t, 100, result.Bounds().Dy())
+}
+
+func BenchmarkCreate_Webp(b *testing.B) {
+	if !WebpEncoderAvailable() {
+		b.Skip("webp encoder not found")
+	}
+
+	benchmarkCreate(b, ResampleFit, ResampleWebp)
+}
@@ -31,18 +31,19 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
 )
 
 var log = event.Log
 var stop = make(chan bool, 1)
 
-// Start runs the metadata, share & sync background workers at regular intervals.
+// Start runs the metadata, share, sync & trash background workers at regular intervals.
 func Start(conf *config.Config) {
 	interval := conf.WakeupInterval()
 
 	// Disabled in safe mode?
 	if interval.Seconds() <= 0 {
-		log.Warnf("config: disabled metadata, share & sync background workers")
+		log.Warnf("config: disabled metadata, share, sync & trash background workers")
 		return
 	}
 
@@ -57,11 +58,13 @@ func Start(conf *config.Config) {
 				mutex.MetaWorker.Cancel()
 				mutex.ShareWorker.Cancel()
 				mutex.SyncWorker.Cancel()
+				mutex.TrashWorker.Cancel()
 				return
 			case <-ticker.C:
 				RunMeta(conf)
 				RunShare(conf)
 				RunSync(conf)
+				RunTrash(conf)
 			}
 		}
 	}()
@@ -111,3 +114,15 @@ func RunSync(conf *config.Config) {
 		}()
 	}
 }
+
+// RunTrash runs the trash worker once to permanently delete archived pictures after the retention period.
+func RunTrash(conf *config.Config) {
+	if conf.TrashRetention() > 0 && !mutex.TrashWorker.Running() {
+		go func() {
+			worker := photoprism.NewTrash(conf)
+			if _, err := worker.Purge(); err != nil {
+				log.Warnf("trash: %s", err)
+			}
+		}()
+	}
+}
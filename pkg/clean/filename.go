@@ -1,10 +1,22 @@
 package clean
 
 import (
+	"path"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
+// FileNameMaxLength is the maximum length of user file names in bytes, as supported by most file systems.
+const FileNameMaxLength = 255
+
+// reservedFileNames contains device names that cannot be used as file names on Windows, even with an extension.
+var reservedFileNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
 // FileName removes invalid character from a filename string.
 func FileName(s string) string {
 	if s == "" || reject(s, 512) || strings.Contains(s, "/") || strings.Contains(s, "..") {
@@ -34,3 +46,66 @@ func FileName(s string) string {
 
 	return s
 }
+
+// UserFileName sanitizes and normalizes a user provided file name, e.g. for uploads or when renaming files,
+// so that it cannot be used for path traversal and is valid on all common platforms. Directories are stripped,
+// whitespace is collapsed, reserved names are prefixed with an underscore, and long names are shortened while
+// keeping the extension. An empty string is returned if no valid name remains.
+func UserFileName(s string) string {
+	if s == "" || reject(s, MaxLength) {
+		return ""
+	}
+
+	// Strip directories, including Windows paths.
+	if i := strings.LastIndexAny(s, "/\\"); i >= 0 {
+		s = s[i+1:]
+	}
+
+	// Remove non-printable and other potentially problematic characters.
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return ' '
+		} else if !unicode.IsPrint(r) {
+			return -1
+		}
+
+		switch r {
+		case ':', '|', '"', '?', '*', '<', '>', '{', '}':
+			return -1
+		default:
+			return r
+		}
+	}, s)
+
+	// Collapse whitespace and remove leading dots as well as trailing dots, which are not allowed on Windows.
+	s = strings.Trim(strings.Join(strings.Fields(s), " "), ". ")
+
+	if s == "" {
+		return ""
+	}
+
+	// Prefix reserved device names, e.g. "nul.txt", as they cannot be used on Windows.
+	if base, _, _ := strings.Cut(s, "."); reservedFileNames[strings.ToUpper(strings.TrimSpace(base))] {
+		s = "_" + s
+	}
+
+	if len(s) <= FileNameMaxLength {
+		return s
+	}
+
+	// Shorten the name while keeping the extension, unless the extension itself is too long.
+	ext := path.Ext(s)
+
+	if len(ext) > FileNameMaxLength/2 {
+		ext = ""
+	}
+
+	name := s[:len(s)-len(ext)]
+
+	for len(name) > FileNameMaxLength-len(ext) {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+
+	return strings.TrimRight(name, ". ") + ext
+}
@@ -1,6 +1,7 @@
 package clean
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -35,3 +36,57 @@ func TestFileName(t *testing.T) {
 		assert.Equal(t, "", FileName("${https://<host>:<port>/<path>}"))
 	})
 }
+
+func TestUserFileName(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, "", UserFileName(""))
+	})
+	t.Run("File", func(t *testing.T) {
+		assert.Equal(t, "filename.TXT", UserFileName("filename.TXT"))
+		assert.Equal(t, "Neckarbrücke 2019.jpg", UserFileName("Neckarbrücke 2019.jpg"))
+	})
+	t.Run("Path", func(t *testing.T) {
+		assert.Equal(t, "photoprism.jpg", UserFileName("/go/src/github.com/photoprism/photoprism.jpg"))
+		assert.Equal(t, "passwd", UserFileName("../../etc/passwd"))
+		assert.Equal(t, "photo.jpg", UserFileName(`C:\Users\Jane\photo.jpg`))
+		assert.Equal(t, "", UserFileName("photos/"))
+	})
+	t.Run("Dots", func(t *testing.T) {
+		assert.Equal(t, "", UserFileName("."))
+		assert.Equal(t, "", UserFileName(".."))
+		assert.Equal(t, "", UserFileName("..."))
+		assert.Equal(t, "htaccess", UserFileName(".htaccess"))
+		assert.Equal(t, "photo", UserFileName("photo. . "))
+	})
+	t.Run("ControlCharacters", func(t *testing.T) {
+		assert.Equal(t, "photo.jpg", UserFileName("pho\x00to\x1b.jpg"))
+		assert.Equal(t, "my photo.jpg", UserFileName("my\tphoto.jpg"))
+	})
+	t.Run("ReservedCharacters", func(t *testing.T) {
+		assert.Equal(t, "photo.jpg", UserFileName(`p<h>o:t|o?*".jpg`))
+		assert.Equal(t, "", UserFileName("${https://<host>:<port>/<path>}"))
+	})
+	t.Run("Whitespace", func(t *testing.T) {
+		assert.Equal(t, "my holiday photo.jpg", UserFileName("  my \n holiday   photo.jpg "))
+	})
+	t.Run("ReservedNames", func(t *testing.T) {
+		assert.Equal(t, "_CON", UserFileName("CON"))
+		assert.Equal(t, "_nul.txt", UserFileName("nul.txt"))
+		assert.Equal(t, "_com1.tar.gz", UserFileName("com1.tar.gz"))
+		assert.Equal(t, "console.txt", UserFileName("console.txt"))
+		assert.Equal(t, "COM10", UserFileName("COM10"))
+	})
+	t.Run("MaxLength", func(t *testing.T) {
+		result := UserFileName(strings.Repeat("a", 300) + ".jpeg")
+		assert.Len(t, result, FileNameMaxLength)
+		assert.True(t, strings.HasSuffix(result, "a.jpeg"))
+
+		result = UserFileName(strings.Repeat("ü", 200) + ".jpg")
+		assert.LessOrEqual(t, len(result), FileNameMaxLength)
+		assert.True(t, strings.HasSuffix(result, "ü.jpg"))
+
+		result = UserFileName("photo." + strings.Repeat("x", 300))
+		assert.Len(t, result, FileNameMaxLength)
+		assert.True(t, strings.HasPrefix(result, "photo.x"))
+	})
+}
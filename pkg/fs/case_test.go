@@ -28,5 +28,6 @@ func TestIgnoreCase(t *testing.T) {
 	IgnoreCase()
 	assert.True(t, ignoreCase)
 	ignoreCase = false
+	FileTypes = Extensions.Types(ignoreCase)
 	assert.False(t, ignoreCase)
 }
@@ -122,6 +122,7 @@ var Extensions = FileExtensions{
 	".ept":      VectorEPS,
 	".epsf":     VectorEPS,
 	".epsi":     VectorEPS,
+	".pdf":      DocumentPDF,
 	".xmp":      SidecarXMP,
 	".aae":      SidecarAAE,
 	".xml":      SidecarXML,
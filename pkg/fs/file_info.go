@@ -46,6 +46,7 @@ var TypeInfo = TypeMap{
 	VectorAI:        "Adobe Illustrator",
 	VectorPS:        "Adobe PostScript",
 	VectorEPS:       "Encapsulated PostScript",
+	DocumentPDF:     "Portable Document Format",
 	SidecarXMP:      "Adobe Extensible Metadata Platform",
 	SidecarAAE:      "Apple Image Edits XML",
 	SidecarXML:      "Extensible Markup Language",
@@ -54,6 +54,7 @@ const (
 	VectorAI        Type = "ai"    // Adobe Illustrator
 	VectorPS        Type = "ps"    // Adobe PostScript
 	VectorEPS       Type = "eps"   // Encapsulated PostScript
+	DocumentPDF     Type = "pdf"   // Portable Document Format
 	SidecarXMP      Type = "xmp"   // Adobe XMP sidecar file (XML)
 	SidecarAAE      Type = "aae"   // Apple image edits sidecar file (based on XML)
 	SidecarXML      Type = "xml"   // XML metadata / config / sidecar file
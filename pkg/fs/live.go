@@ -0,0 +1,52 @@
+package fs
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// LivePhotoStill checks if the file type may be the still image of a Live Photo,
+// which consists of a HEIC image and a QuickTime video with the same base name.
+func LivePhotoStill(fileName string) bool {
+	switch FileType(fileName) {
+	case ImageHEIC, ImageHEIF, ImageHEICS:
+		return true
+	default:
+		return false
+	}
+}
+
+// LivePhotoVideo checks if the file type may be the video of a Live Photo.
+func LivePhotoVideo(fileName string) bool {
+	return FileType(fileName) == VideoMOV
+}
+
+// LivePhoto checks if the files are the still image and the video of the same Live Photo,
+// so that they can be stacked instead of being indexed as unrelated items.
+func LivePhoto(stillName, videoName string) bool {
+	if !LivePhotoStill(stillName) || !LivePhotoVideo(videoName) {
+		return false
+	} else if filepath.Dir(stillName) != filepath.Dir(videoName) {
+		return false
+	}
+
+	return strings.EqualFold(BasePrefix(stillName, false), BasePrefix(videoName, false))
+}
+
+// LivePhotoPair returns the name of the other file that belongs to the same Live Photo,
+// i.e. the video for a still image and vice versa, or an empty string if there is none.
+func LivePhotoPair(fileName string) string {
+	if LivePhotoStill(fileName) {
+		return VideoMOV.Find(fileName, false)
+	} else if !LivePhotoVideo(fileName) {
+		return ""
+	}
+
+	for _, t := range []Type{ImageHEIC, ImageHEICS} {
+		if stillName := t.Find(fileName, false); stillName != "" {
+			return stillName
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,62 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLivePhotoStill(t *testing.T) {
+	assert.True(t, LivePhotoStill("IMG_1234.HEIC"))
+	assert.True(t, LivePhotoStill("IMG_1234.heif"))
+	assert.True(t, LivePhotoStill("IMG_1234.heics"))
+	assert.False(t, LivePhotoStill("IMG_1234.jpg"))
+	assert.False(t, LivePhotoStill("IMG_1234.MOV"))
+	assert.False(t, LivePhotoStill(""))
+}
+
+func TestLivePhotoVideo(t *testing.T) {
+	assert.True(t, LivePhotoVideo("IMG_1234.MOV"))
+	assert.True(t, LivePhotoVideo("IMG_1234.qt"))
+	assert.False(t, LivePhotoVideo("IMG_1234.mp4"))
+	assert.False(t, LivePhotoVideo("IMG_1234.HEIC"))
+}
+
+func TestLivePhoto(t *testing.T) {
+	assert.True(t, LivePhoto("2023/IMG_1234.HEIC", "2023/IMG_1234.MOV"))
+	assert.True(t, LivePhoto("2023/img_1234.heic", "2023/IMG_1234.MOV"))
+	assert.False(t, LivePhoto("2023/IMG_1234.MOV", "2023/IMG_1234.HEIC"))
+	assert.False(t, LivePhoto("2023/IMG_1234.HEIC", "2022/IMG_1234.MOV"))
+	assert.False(t, LivePhoto("2023/IMG_1234.HEIC", "2023/IMG_1235.MOV"))
+	assert.False(t, LivePhoto("2023/IMG_1234.JPG", "2023/IMG_1234.MOV"))
+	assert.False(t, LivePhoto("2023/IMG_1234.HEIC", "2023/IMG_1234.MP4"))
+}
+
+func TestLivePhotoPair(t *testing.T) {
+	dir := t.TempDir()
+
+	stillName := filepath.Join(dir, "IMG_1234.HEIC")
+	videoName := filepath.Join(dir, "IMG_1234.MOV")
+	otherName := filepath.Join(dir, "IMG_1235.HEIC")
+
+	for _, fileName := range []string{stillName, videoName, otherName} {
+		if err := os.WriteFile(fileName, []byte("live"), ModeFile); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("Still", func(t *testing.T) {
+		assert.Equal(t, videoName, LivePhotoPair(stillName))
+	})
+	t.Run("Video", func(t *testing.T) {
+		assert.Equal(t, stillName, LivePhotoPair(videoName))
+	})
+	t.Run("NoVideo", func(t *testing.T) {
+		assert.Equal(t, "", LivePhotoPair(otherName))
+	})
+	t.Run("OtherType", func(t *testing.T) {
+		assert.Equal(t, "", LivePhotoPair(filepath.Join(dir, "IMG_1234.jpg")))
+	})
+}
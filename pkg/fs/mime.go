@@ -28,6 +28,7 @@ const (
 	MimeTypeAI      = "application/vnd.adobe.illustrator"
 	MimeTypePS      = "application/ps"
 	MimeTypeEPS     = "image/eps"
+	MimeTypePDF     = "application/pdf"
 	MimeTypeXML     = "text/xml"
 	MimeTypeJSON    = "application/json"
 )
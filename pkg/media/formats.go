@@ -45,6 +45,7 @@ var Formats = map[fs.Type]Type{
 	fs.VectorAI:        Vector,
 	fs.VectorPS:        Vector,
 	fs.VectorEPS:       Vector,
+	fs.DocumentPDF:     Document,
 	fs.SidecarXMP:      Sidecar,
 	fs.SidecarXML:      Sidecar,
 	fs.SidecarAAE:      Sidecar,
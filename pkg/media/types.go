@@ -8,6 +8,7 @@ const (
 	Live     Type = "live"
 	Video    Type = "video"
 	Vector   Type = "vector"
+	Document Type = "document"
 	Sidecar  Type = "sidecar"
 	Text     Type = "text"
 	Other    Type = "other"
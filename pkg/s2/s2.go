@@ -33,6 +33,9 @@ import (
 // DefaultLevel see https://s2geometry.io/resources/s2cell_statistics.html.
 var DefaultLevel = 21
 
+// MaxLevel is the level of the smallest S2 cells.
+const MaxLevel = gs2.MaxLevel
+
 // Token returns the S2 cell token for coordinates using the default level.
 func Token(lat, lng float64) string {
 	return TokenLevel(lat, lng, DefaultLevel)
@@ -21,5 +21,6 @@ const (
 	Category    = "category"
 	Similar     = "similar"
 	Random      = "random"
+	Manual      = "manual"
 	Invalid     = "invalid"
 )
@@ -47,3 +47,14 @@ func New(s string) bool {
 
 	return s == EnNew
 }
+
+// Unnamed tests if a string represents "unnamed".
+func Unnamed(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	return s == EnUnnamed
+}
@@ -149,3 +149,21 @@ func TestNew(t *testing.T) {
 		assert.Equal(t, false, New("non"))
 	})
 }
+
+func TestUnnamed(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, false, Unnamed(""))
+	})
+	t.Run("EnUnnamed", func(t *testing.T) {
+		assert.Equal(t, true, Unnamed(EnUnnamed))
+	})
+	t.Run("Spaces", func(t *testing.T) {
+		assert.Equal(t, true, Unnamed("  unnamed "))
+	})
+	t.Run("Uppercase", func(t *testing.T) {
+		assert.Equal(t, true, Unnamed("UNNAMED"))
+	})
+	t.Run("False", func(t *testing.T) {
+		assert.Equal(t, false, Unnamed("named"))
+	})
+}
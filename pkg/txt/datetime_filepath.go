@@ -6,15 +6,22 @@ import (
 )
 
 // DateFromFilePath returns a string as time or the zero time instant in case it can not be converted.
-func DateFromFilePath(s string) (result time.Time) {
+func DateFromFilePath(s string) time.Time {
+	result, _ := dateFromFilePath(s)
+	return result
+}
+
+// dateFromFilePath returns a string as time along with its precision, or the zero time instant
+// and PrecisionNone in case it can not be converted.
+func dateFromFilePath(s string) (result time.Time, precision TimePrecision) {
 	defer func() {
 		if r := recover(); r != nil {
-			result = time.Time{}
+			result, precision = time.Time{}, PrecisionNone
 		}
 	}()
 
 	if len(s) < 6 {
-		return time.Time{}
+		return time.Time{}, PrecisionNone
 	}
 
 	if !strings.HasPrefix(s, "/") {
@@ -27,7 +34,7 @@ func DateFromFilePath(s string) (result time.Time) {
 		n := DateIntRegexp.FindAll(found, -1)
 
 		if len(n) < 6 {
-			return result
+			return result, PrecisionNone
 		}
 
 		year := ExpandYear(string(n[0]))
@@ -39,12 +46,12 @@ func DateFromFilePath(s string) (result time.Time) {
 
 		// Perform date plausibility check.
 		if year < YearMin || year > YearMax || month < MonthMin || month > MonthMax || day < DayMin || day > DayMax {
-			return result
+			return result, PrecisionNone
 		}
 
 		// Perform time plausibility check.
 		if hour < HourMin || hour > HourMax || min < MinMin || min > MinMax || sec < SecMin || sec > SecMax {
-			return result
+			return result, PrecisionNone
 		}
 
 		result = time.Date(
@@ -57,11 +64,17 @@ func DateFromFilePath(s string) (result time.Time) {
 			0,
 			time.UTC)
 
+		// Reject days that do not exist in the month, e.g. February 30th.
+		if result.Day() != day {
+			return time.Time{}, PrecisionNone
+		}
+
+		precision = PrecisionSecond
 	} else if found = DateRegexp.Find(b); len(found) > 0 { // Is it a date only like "2020-01-30"?
 		n := DateIntRegexp.FindAll(found, -1)
 
 		if len(n) != 3 {
-			return result
+			return result, PrecisionNone
 		}
 
 		year := ExpandYear(string(n[0]))
@@ -70,7 +83,7 @@ func DateFromFilePath(s string) (result time.Time) {
 
 		// Perform date plausibility check.
 		if year < YearMin || year > YearMax || month < MonthMin || month > MonthMax || day < DayMin || day > DayMax {
-			return result
+			return result, PrecisionNone
 		}
 
 		result = time.Date(
@@ -82,18 +95,24 @@ func DateFromFilePath(s string) (result time.Time) {
 			0,
 			0,
 			time.UTC)
+
+		if result.Day() != day {
+			return time.Time{}, PrecisionNone
+		}
+
+		precision = PrecisionDay
 	} else if found = DatePathRegexp.Find(b); len(found) > 0 { // Is it a date path like "2020/01/03"?
 		n := DateIntRegexp.FindAll(found, -1)
 
 		if len(n) < 2 || len(n) > 3 {
-			return result
+			return result, PrecisionNone
 		}
 
 		year := ExpandYear(string(n[0]))
 		month := Int(string(n[1]))
 
 		if year < YearMin || year > YearMax || month < MonthMin || month > MonthMax {
-			return result
+			return result, PrecisionNone
 		}
 
 		if len(n) == 2 {
@@ -106,6 +125,8 @@ func DateFromFilePath(s string) (result time.Time) {
 				0,
 				0,
 				time.UTC)
+
+			precision = PrecisionMonth
 		} else if day := Int(string(n[2])); day >= DayMin && day <= DayMax {
 			result = time.Date(
 				year,
@@ -116,8 +137,10 @@ func DateFromFilePath(s string) (result time.Time) {
 				0,
 				0,
 				time.UTC)
+
+			precision = PrecisionDay
 		}
 	}
 
-	return result.UTC()
+	return result.UTC(), precision
 }
@@ -235,6 +235,10 @@ func TestDateFromFilePath(t *testing.T) {
 		result := DateFromFilePath("2020-01-00.jpg")
 		assert.Equal(t, "0001-01-01 00:00:00 +0000 UTC", result.String())
 	})
+	t.Run("day not in month", func(t *testing.T) {
+		result := DateFromFilePath("2019-02-30.jpg")
+		assert.Equal(t, "0001-01-01 00:00:00 +0000 UTC", result.String())
+	})
 	t.Run("IMG-20191120-WA0001.jpg", func(t *testing.T) {
 		result := DateFromFilePath("IMG-20191120-WA0001.jpg")
 		assert.Equal(t, "0001-01-01 00:00:00 +0000 UTC", result.String())
@@ -0,0 +1,158 @@
+package txt
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// TimePrecision indicates which parts of a parsed time are known.
+type TimePrecision int
+
+// Precisions of parsed times, from the least to the most precise.
+const (
+	PrecisionNone TimePrecision = iota
+	PrecisionYear
+	PrecisionMonth
+	PrecisionDay
+	PrecisionMinute
+	PrecisionSecond
+)
+
+// String returns the precision name, e.g. for logging.
+func (p TimePrecision) String() string {
+	switch p {
+	case PrecisionYear:
+		return "year"
+	case PrecisionMonth:
+		return "month"
+	case PrecisionDay:
+		return "day"
+	case PrecisionMinute:
+		return "minute"
+	case PrecisionSecond:
+		return "second"
+	default:
+		return "none"
+	}
+}
+
+// monthNames maps lowercase English month names and abbreviations to the month number,
+// and monthPattern matches them in regular expressions.
+var monthNames, monthPattern = parseMonthNames()
+
+// parseMonthNames returns the month names map and pattern used by ParseTime.
+func parseMonthNames() (map[string]int, string) {
+	result := map[string]int{"sept": 9}
+	names := []string{"sept"}
+
+	for i := 1; i < len(Months); i++ {
+		name := strings.ToLower(Months[i])
+		result[name] = i
+		result[name[:3]] = i
+		names = append(names, name, name[:3])
+	}
+
+	return result, "(" + strings.Join(names, "|") + ")"
+}
+
+// parseTimeLayout represents a pattern that ParseTime tries to match, with the indexes of the submatches.
+type parseTimeLayout struct {
+	regexp    *regexp.Regexp
+	precision TimePrecision
+	year      int
+	month     int
+	day       int
+}
+
+// parseTimeLayouts contains the patterns that ParseTime tries to match, in order of priority.
+var parseTimeLayouts = []parseTimeLayout{
+	// Date and time, e.g. "2019-07-04 12:00:00", "20190704_120000", or "20190704_1200".
+	{regexp.MustCompile(`(?:^|\D)(\d{4})[\-_.:]?(\d{2})[\-_.:]?(\d{2})[T\s_\-.]{1,3}(\d{2})[\-_.:]?(\d{2})(?:[\-_.:]?(\d{2}))?(?:\D|$)`), PrecisionSecond, 1, 2, 3},
+	// Date, e.g. "2019-07-04", "2019.07.04", or "20190704".
+	{regexp.MustCompile(`(?:^|\D)(\d{4})[\-_./]?(\d{2})[\-_./]?(\d{2})(?:\D|$)`), PrecisionDay, 1, 2, 3},
+	// Day, month name, and year, e.g. "4 July 2019" or "4th Jul. 2019".
+	{regexp.MustCompile(`(?i)(?:^|\D)(\d{1,2})(?:st|nd|rd|th)?\.?[\s_\-]+` + monthPattern + `\.?,?[\s_\-]+(\d{4})(?:\D|$)`), PrecisionDay, 3, 2, 1},
+	// Month name, day, and year, e.g. "July 4, 2019".
+	{regexp.MustCompile(`(?i)(?:^|[^a-z])` + monthPattern + `\.?[\s_\-]+(\d{1,2})(?:st|nd|rd|th)?,?[\s_\-]+(\d{4})(?:\D|$)`), PrecisionDay, 3, 1, 2},
+	// Year and month, e.g. "2019-07" or "2019.07".
+	{regexp.MustCompile(`(?:^|\D)(\d{4})[\-_./](\d{1,2})(?:\D|$)`), PrecisionMonth, 1, 2, 0},
+	// Month name and year, e.g. "July 2019" or "Jul_2019".
+	{regexp.MustCompile(`(?i)(?:^|[^a-z])` + monthPattern + `\.?,?[\s_\-]+(\d{4})(?:\D|$)`), PrecisionMonth, 2, 1, 0},
+	// Year only, e.g. "2019", "Summer 2019.jpg", or "2019/IMG_1234.jpg". The year must be a separate word, so
+	// that camera counters like "IMG_1999.jpg" and numbers like "1990-13" are not mistaken for a year.
+	{regexp.MustCompile(`(?:^|[\s/(\[,])(\d{4})(?:$|[\s/)\],]|\.[a-zA-Z]\w{1,4}$)`), PrecisionYear, 1, 0, 0},
+}
+
+// ParseTime tries to find a date in a string such as a file name or caption, e.g. "2019.07", "July 2019",
+// or "20190704_1200", and returns it as UTC time along with its precision. Parts that are not known, such
+// as the day, are set to their minimum value. PrecisionNone and the zero time are returned if no valid
+// date was found.
+func ParseTime(s string) (t time.Time, precision TimePrecision) {
+	if len(s) < 4 {
+		return time.Time{}, PrecisionNone
+	}
+
+	// Find a date with the same rules as DateFromFilePath.
+	found, foundPrecision := dateFromFilePath(s)
+
+	for _, layout := range parseTimeLayouts {
+		// Prefer the date found by DateFromFilePath unless the layout is more precise.
+		if foundPrecision >= layout.precision {
+			return found, foundPrecision
+		}
+
+		for _, m := range layout.regexp.FindAllStringSubmatch(s, -1) {
+			if t, precision = layout.parse(m); precision != PrecisionNone {
+				return t, precision
+			}
+		}
+	}
+
+	return found, foundPrecision
+}
+
+// parse returns the time represented by the submatches, or PrecisionNone if it is not valid.
+func (l parseTimeLayout) parse(m []string) (time.Time, TimePrecision) {
+	year, month, day := Int(m[l.year]), MonthMin, DayMin
+	hour, min, sec := 0, 0, 0
+	precision := l.precision
+
+	if l.month > 0 {
+		if n, ok := monthNames[strings.ToLower(m[l.month])]; ok {
+			month = n
+		} else {
+			month = Int(m[l.month])
+		}
+	}
+
+	if l.day > 0 {
+		day = Int(m[l.day])
+	}
+
+	if l.precision >= PrecisionMinute {
+		hour, min = Int(m[4]), Int(m[5])
+
+		if m[6] == "" {
+			precision = PrecisionMinute
+		} else {
+			sec = Int(m[6])
+		}
+	}
+
+	// Perform date and time plausibility check.
+	if year < YearMin || year > YearMax || month < MonthMin || month > MonthMax || day < DayMin || day > DayMax {
+		return time.Time{}, PrecisionNone
+	} else if hour < HourMin || hour >= HourMax || min < MinMin || min > MinMax || sec < SecMin || sec > SecMax {
+		return time.Time{}, PrecisionNone
+	}
+
+	t := time.Date(year, time.Month(month), day, hour, min, sec, 0, time.UTC)
+
+	// Reject days that do not exist in the month, e.g. February 30th.
+	if t.Day() != day {
+		return time.Time{}, PrecisionNone
+	}
+
+	return t, precision
+}
@@ -0,0 +1,69 @@
+package txt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		s         string
+		expected  string
+		precision TimePrecision
+	}{
+		{"IMG_20190704_120013.jpg", "2019-07-04 12:00:13", PrecisionSecond},
+		{"2019-07-04 12:00:13", "2019-07-04 12:00:13", PrecisionSecond},
+		{"2019:07:04 12:00:13", "2019-07-04 12:00:13", PrecisionSecond},
+		{"20190704_1200", "2019-07-04 12:00:00", PrecisionMinute},
+		{"2019-07-04T12-30", "2019-07-04 12:30:00", PrecisionMinute},
+		{"20190704_2500", "2019-07-04 00:00:00", PrecisionDay},
+		{"Holiday 2019.07.04", "2019-07-04 00:00:00", PrecisionDay},
+		{"scan_20190704.png", "2019-07-04 00:00:00", PrecisionDay},
+		{"2019/07/04", "2019-07-04 00:00:00", PrecisionDay},
+		{"4 July 2019", "2019-07-04 00:00:00", PrecisionDay},
+		{"Party on the 4th of July 2019", "2019-07-01 00:00:00", PrecisionMonth},
+		{"4th Jul. 2019", "2019-07-04 00:00:00", PrecisionDay},
+		{"July 4, 2019", "2019-07-04 00:00:00", PrecisionDay},
+		{"sept 21 2020", "2020-09-21 00:00:00", PrecisionDay},
+		{"2019.07", "2019-07-01 00:00:00", PrecisionMonth},
+		{"Berlin 2019-7", "2019-07-01 00:00:00", PrecisionMonth},
+		{"July 2019", "2019-07-01 00:00:00", PrecisionMonth},
+		{"Trip_dec_2018_001.jpg", "2018-12-01 00:00:00", PrecisionMonth},
+		{"Summer 2019", "2019-01-01 00:00:00", PrecisionYear},
+		{"Summer 2019.jpg", "2019-01-01 00:00:00", PrecisionYear},
+		{"2019/IMG_1234.jpg", "2019-01-01 00:00:00", PrecisionYear},
+		{"Party (2019)", "2019-01-01 00:00:00", PrecisionYear},
+		{"Screenshot 2019-05-21 at 10.45.52.png", "2019-05-21 10:45:52", PrecisionSecond},
+		{"nextcloud/2022/04/22-04-06 15-21-03 2160.jpg", "2022-04-06 15:21:03", PrecisionSecond},
+		{"2018/04 - April/IMG_1234.jpg", "2018-04-01 00:00:00", PrecisionMonth},
+		{"2019-02-30", "2019-02-01 00:00:00", PrecisionMonth},
+	}
+
+	for _, tt := range tests {
+		result, precision := ParseTime(tt.s)
+
+		assert.Equal(t, tt.precision, precision, tt.s)
+		assert.Equal(t, tt.expected, result.Format("2006-01-02 15:04:05"), tt.s)
+		assert.Equal(t, time.UTC, result.Location(), tt.s)
+	}
+
+	t.Run("None", func(t *testing.T) {
+		for _, s := range []string{"", "foo", "IMG_0001.jpg", "IMG_1999.JPG", "1990-13.jpg", "2019.13", "DSC_2015_0042.jpg", "Julyfest", "1234", "3000", "123456789"} {
+			result, precision := ParseTime(s)
+
+			assert.Equal(t, PrecisionNone, precision, s)
+			assert.True(t, result.IsZero(), s)
+		}
+	})
+}
+
+func TestTimePrecision_String(t *testing.T) {
+	assert.Equal(t, "none", PrecisionNone.String())
+	assert.Equal(t, "year", PrecisionYear.String())
+	assert.Equal(t, "month", PrecisionMonth.String())
+	assert.Equal(t, "day", PrecisionDay.String())
+	assert.Equal(t, "minute", PrecisionMinute.String())
+	assert.Equal(t, "second", PrecisionSecond.String())
+}
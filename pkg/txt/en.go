@@ -1,10 +1,11 @@
 package txt
 
 const (
-	EnOr   = "or"
-	EnAnd  = "and"
-	EnWith = "with"
-	EnIn   = "in"
-	EnAt   = "at"
-	EnNew  = "new"
+	EnOr      = "or"
+	EnAnd     = "and"
+	EnWith    = "with"
+	EnIn      = "in"
+	EnAt      = "at"
+	EnNew     = "new"
+	EnUnnamed = "unnamed"
 )
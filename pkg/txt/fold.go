@@ -0,0 +1,44 @@
+package txt
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Fold returns the string in lowercase and without diacritics, e.g. "Café" becomes "cafe",
+// so that it can be used for case- and diacritic-insensitive matching.
+func Fold(s string) string {
+	if s == "" {
+		return s
+	} else if IsASCII(s) {
+		return strings.ToLower(s)
+	}
+
+	// Transformers are stateful and must not be shared between goroutines.
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+	if result, _, err := transform.String(t, s); err == nil {
+		return strings.ToLower(result)
+	}
+
+	return strings.ToLower(s)
+}
+
+// FoldWords returns the words with diacritic-free variants added for those that contain diacritics.
+func FoldWords(words []string) (results []string) {
+	results = make([]string, 0, len(words))
+
+	for _, w := range words {
+		results = append(results, w)
+
+		if f := Fold(w); f != strings.ToLower(w) {
+			results = append(results, f)
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,38 @@
+package txt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFold(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, "", Fold(""))
+	})
+	t.Run("ASCII", func(t *testing.T) {
+		assert.Equal(t, "cafe", Fold("Cafe"))
+	})
+	t.Run("Accents", func(t *testing.T) {
+		assert.Equal(t, "cafe", Fold("Café"))
+		assert.Equal(t, "creme brulee", Fold("Crème Brûlée"))
+		assert.Equal(t, "sao paulo", Fold("São Paulo"))
+		assert.Equal(t, "zurich", Fold("ZÜRICH"))
+	})
+	t.Run("NonLatin", func(t *testing.T) {
+		assert.Equal(t, "桥", Fold("桥"))
+		assert.Equal(t, "москва", Fold("Москва"))
+	})
+}
+
+func TestFoldWords(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, []string{}, FoldWords(nil))
+	})
+	t.Run("Accents", func(t *testing.T) {
+		assert.Equal(t, []string{"café", "cafe", "paris"}, FoldWords([]string{"café", "paris"}))
+	})
+	t.Run("Uppercase", func(t *testing.T) {
+		assert.Equal(t, []string{"Paris"}, FoldWords([]string{"Paris"}))
+	})
+}
@@ -2,12 +2,16 @@ package txt
 
 import (
 	"unicode"
+	"unicode/utf8"
 )
 
 // UpperFirst returns the string with the first character converted to uppercase.
 func UpperFirst(str string) string {
-	for i, v := range str {
-		return string(unicode.ToUpper(v)) + str[i+1:]
+	r, n := utf8.DecodeRuneInString(str)
+
+	if r == utf8.RuneError {
+		return str
 	}
-	return ""
+
+	return string(unicode.ToUpper(r)) + str[n:]
 }
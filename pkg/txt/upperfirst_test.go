@@ -19,4 +19,7 @@ func TestUcFirst(t *testing.T) {
 	t.Run("empty string", func(t *testing.T) {
 		assert.Equal(t, "", UpperFirst(""))
 	})
+	t.Run("říjen 2020", func(t *testing.T) {
+		assert.Equal(t, "Říjen 2020", UpperFirst("říjen 2020"))
+	})
 }
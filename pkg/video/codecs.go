@@ -1,5 +1,7 @@
 package video
 
+import "strings"
+
 type Codec string
 
 // Check browser support: https://cconcolato.github.io/media-mime-support/
@@ -14,6 +16,7 @@ const (
 	CodecVP9     Codec = "vp9"
 	CodecOGV     Codec = "ogv"
 	CodecWebM    Codec = "webm"
+	CodecProRes  Codec = "prores"
 )
 
 // Codecs maps identifiers to codecs.
@@ -28,6 +31,7 @@ var Codecs = StandardCodecs{
 	"hevc":     CodecHEVC,
 	"hvc":      CodecHEVC,
 	"hvc1":     CodecHEVC,
+	"hev1":     CodecHEVC,
 	"v_hvc":    CodecHEVC,
 	"v_hvc1":   CodecHEVC,
 	"vvc":      CodecVVC,
@@ -44,6 +48,31 @@ var Codecs = StandardCodecs{
 	"v_vp9":    CodecVP9,
 	"ogv":      CodecOGV,
 	"webm":     CodecWebM,
+	"prores":   CodecProRes,
+	"apch":     CodecProRes,
+	"apcn":     CodecProRes,
+	"apcs":     CodecProRes,
+	"apco":     CodecProRes,
+	"ap4h":     CodecProRes,
+	"ap4x":     CodecProRes,
+}
+
+// CompatibleCodecs lists the codecs that common browsers can play without transcoding.
+var CompatibleCodecs = map[Codec]bool{
+	CodecAVC: true,
+	CodecVP8: true,
+	CodecVP9: true,
+	CodecAV1: true,
+}
+
+// Compatible checks if common browsers can play videos with the specified codec identifier,
+// e.g. as stored in the index. Unknown codecs are considered incompatible.
+func Compatible(codec string) bool {
+	if c, ok := Codecs[strings.ToLower(strings.TrimSpace(codec))]; ok {
+		return CompatibleCodecs[c]
+	}
+
+	return false
 }
 
 // StandardCodecs maps names to known codecs.
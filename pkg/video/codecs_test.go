@@ -15,3 +15,17 @@ func TestCodecs(t *testing.T) {
 		t.Fatal("codec should be CodecAV1")
 	}
 }
+
+func TestCompatible(t *testing.T) {
+	for _, codec := range []string{"avc1", "AVC", "vp9", "av01"} {
+		if !Compatible(codec) {
+			t.Errorf("%s should be compatible", codec)
+		}
+	}
+
+	for _, codec := range []string{"", "hvc1", "hev1", "apcn", "prores", "vvc", "xyz"} {
+		if Compatible(codec) {
+			t.Errorf("%s should not be compatible", codec)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package video
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// SamsungMotionPhotoMarker precedes the video data in Samsung motion photos.
+var SamsungMotionPhotoMarker = []byte("MotionPhoto_Data")
+
+// XmpSearchLimit is the number of bytes to search for Google motion photo metadata.
+var XmpSearchLimit = 256 * 1024
+
+var (
+	// Google motion photos (legacy format), e.g. MVIMG_20190101_123456.jpg.
+	googleMicroVideoOffset = regexp.MustCompile(`GCamera:MicroVideoOffset(?:="|>)(\d+)`)
+	// Google motion photos (version 1 format) with a container directory.
+	googleContainerItem = regexp.MustCompile(`<Container:Item\b[^>]*>`)
+	googleItemLength    = regexp.MustCompile(`Item:Length="(\d+)"`)
+	mp4FileTypeBox      = []byte("ftyp")
+)
+
+// EmbeddedOffset returns the offset of an MP4 video embedded in a motion photo, or -1 if there is none.
+func EmbeddedOffset(data []byte) int {
+	if len(data) < 16 {
+		return -1
+	}
+
+	// Samsung stores the video after a marker at the end of the image data.
+	if i := bytes.LastIndex(data, SamsungMotionPhotoMarker); i > 0 {
+		if offset := i + len(SamsungMotionPhotoMarker); isMp4(data, offset) {
+			return offset
+		}
+	}
+
+	xmp := data
+
+	if len(xmp) > XmpSearchLimit {
+		xmp = xmp[:XmpSearchLimit]
+	}
+
+	// Google stores the video length, counted from the end of the file, in the XMP metadata.
+	if m := googleMicroVideoOffset.FindSubmatch(xmp); len(m) > 1 {
+		if n, err := strconv.Atoi(string(m[1])); err == nil && n > 0 {
+			if offset := len(data) - n; isMp4(data, offset) {
+				return offset
+			}
+		}
+	}
+
+	for _, item := range googleContainerItem.FindAll(xmp, -1) {
+		if !bytes.Contains(item, []byte(`Item:Semantic="MotionPhoto"`)) {
+			continue
+		}
+
+		if m := googleItemLength.FindSubmatch(item); len(m) > 1 {
+			if n, err := strconv.Atoi(string(m[1])); err == nil && n > 0 {
+				if offset := len(data) - n; isMp4(data, offset) {
+					return offset
+				}
+			}
+		}
+	}
+
+	return -1
+}
+
+// ExtractEmbedded saves the video embedded in a motion photo and returns true if it was found.
+func ExtractEmbedded(fileName, videoName string) (bool, error) {
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return false, err
+	}
+
+	offset := EmbeddedOffset(data)
+
+	if offset < 0 {
+		return false, nil
+	}
+
+	if err = os.WriteFile(videoName, data[offset:], fs.ModeFile); err != nil {
+		return false, fmt.Errorf("video: failed to save embedded video (%s)", err)
+	}
+
+	return true, nil
+}
+
+// isMp4 checks if an MP4 file type box starts at the specified offset.
+func isMp4(data []byte, offset int) bool {
+	if offset <= 0 || offset+8 > len(data) {
+		return false
+	}
+
+	return bytes.Equal(data[offset+4:offset+8], mp4FileTypeBox)
+}
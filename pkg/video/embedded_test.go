@@ -0,0 +1,85 @@
+package video
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testJpeg = []byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x10, 'J', 'F', 'I', 'F', 0x00, 0xFF, 0xD9}
+var testMp4 = []byte{0x00, 0x00, 0x00, 0x18, 'f', 't', 'y', 'p', 'm', 'p', '4', '2', 0x00, 0x00, 0x00, 0x00, 'm', 'p', '4', '2', 'i', 's', 'o', 'm'}
+
+func TestEmbeddedOffset(t *testing.T) {
+	t.Run("Samsung", func(t *testing.T) {
+		data := bytes.Join([][]byte{testJpeg, SamsungMotionPhotoMarker, testMp4}, nil)
+		assert.Equal(t, len(testJpeg)+len(SamsungMotionPhotoMarker), EmbeddedOffset(data))
+	})
+	t.Run("GoogleMicroVideo", func(t *testing.T) {
+		xmp := []byte(fmt.Sprintf(`<rdf:Description GCamera:MicroVideo="1" GCamera:MicroVideoOffset="%d"/>`, len(testMp4)))
+		data := bytes.Join([][]byte{testJpeg[:11], xmp, testJpeg[11:], testMp4}, nil)
+		assert.Equal(t, len(data)-len(testMp4), EmbeddedOffset(data))
+	})
+	t.Run("GoogleContainer", func(t *testing.T) {
+		xmp := []byte(fmt.Sprintf(`<Container:Item Item:Mime="image/jpeg" Item:Semantic="Primary" Item:Length="0"/>`+
+			`<Container:Item Item:Mime="video/mp4" Item:Semantic="MotionPhoto" Item:Length="%d"/>`, len(testMp4)))
+		data := bytes.Join([][]byte{testJpeg[:11], xmp, testJpeg[11:], testMp4}, nil)
+		assert.Equal(t, len(data)-len(testMp4), EmbeddedOffset(data))
+	})
+	t.Run("WrongOffset", func(t *testing.T) {
+		xmp := []byte(`<rdf:Description GCamera:MicroVideoOffset="5"/>`)
+		data := bytes.Join([][]byte{testJpeg[:11], xmp, testJpeg[11:], testMp4}, nil)
+		assert.Equal(t, -1, EmbeddedOffset(data))
+	})
+	t.Run("None", func(t *testing.T) {
+		assert.Equal(t, -1, EmbeddedOffset(testJpeg))
+		assert.Equal(t, -1, EmbeddedOffset(nil))
+	})
+}
+
+func TestExtractEmbedded(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("MotionPhoto", func(t *testing.T) {
+		fileName := filepath.Join(dir, "motion.jpg")
+		videoName := filepath.Join(dir, "motion.jpg.mp4")
+
+		if err := os.WriteFile(fileName, bytes.Join([][]byte{testJpeg, SamsungMotionPhotoMarker, testMp4}, nil), 0o666); err != nil {
+			t.Fatal(err)
+		}
+
+		found, err := ExtractEmbedded(fileName, videoName)
+
+		assert.NoError(t, err)
+		assert.True(t, found)
+
+		if data, err := os.ReadFile(videoName); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, testMp4, data)
+		}
+	})
+	t.Run("NoVideo", func(t *testing.T) {
+		fileName := filepath.Join(dir, "still.jpg")
+		videoName := filepath.Join(dir, "still.jpg.mp4")
+
+		if err := os.WriteFile(fileName, testJpeg, 0o666); err != nil {
+			t.Fatal(err)
+		}
+
+		found, err := ExtractEmbedded(fileName, videoName)
+
+		assert.NoError(t, err)
+		assert.False(t, found)
+		assert.NoFileExists(t, videoName)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		found, err := ExtractEmbedded(filepath.Join(dir, "missing.jpg"), filepath.Join(dir, "missing.jpg.mp4"))
+
+		assert.Error(t, err)
+		assert.False(t, found)
+	})
+}